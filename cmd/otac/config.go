@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/otac"
+	otgo "github.com/open-trust/ot-go-lib"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// config otac 命令行工具的配置文件
+type config struct {
+	Endpoint string    `yaml:"endpoint"` // ot-ac 服务地址，如 https://ot.example.com/ac
+	Audience otgo.OTID `yaml:"audience"` // ot-ac 服务的 OTID，即签发的 OTVID 的 aud
+	Timeout  int       `yaml:"timeout"`  // 请求超时时间，单位秒，默认为 30
+	Admin    identity  `yaml:"admin"`    // 管理员身份，用于 tenant、export、import 和 object tree 命令
+	Tenant   identity  `yaml:"tenant"`   // 租户身份，用于其它命令
+}
+
+// identity 访问 ot-ac 的身份，通过 private_keys 向信任域的 OT-Auth 服务申请 OTVID，或直接使用已签发的 otvid
+type identity struct {
+	OTID        otgo.OTID `yaml:"otid"`
+	PrivateKeys []string  `yaml:"private_keys"`
+	OTVID       string    `yaml:"otvid"`
+}
+
+// configPath 依次使用 -config 参数、OTAC_CONFIG 环境变量和 ~/.otac.yaml
+func configPath(flagPath string) string {
+	if flagPath != "" {
+		return flagPath
+	}
+	if p := os.Getenv("OTAC_CONFIG"); p != "" {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".otac.yaml"
+	}
+	return filepath.Join(home, ".otac.yaml")
+}
+
+func loadConfig(path string) (*config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("%s: endpoint required", path)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30
+	}
+	return cfg, nil
+}
+
+// client 创建使用 id 身份的 ot-ac 客户端
+func (c *config) client(name string, id identity) (*otac.Client, error) {
+	var ts otac.TokenSource
+	switch {
+	case id.OTVID != "":
+		ts = otac.StaticToken(id.OTVID)
+	case len(id.PrivateKeys) > 0:
+		if err := id.OTID.Validate(); err != nil {
+			return nil, fmt.Errorf("%s.otid: %v", name, err)
+		}
+		if err := c.Audience.Validate(); err != nil {
+			return nil, fmt.Errorf("audience: %v", err)
+		}
+		keys, err := otgo.ParseSet(id.PrivateKeys...)
+		if err != nil {
+			return nil, fmt.Errorf("%s.private_keys: %v", name, err)
+		}
+		oc := otgo.NewOTClient(ctx, id.OTID)
+		oc.SetPrivateKeys(*keys)
+		ts = otac.OTClientToken(oc, c.Audience)
+	default:
+		return nil, errors.New(name + ": private_keys or otvid required")
+	}
+
+	return otac.New(otac.Options{
+		Endpoint: c.Endpoint,
+		Token:    ts,
+		// 超时由 ctx 控制，以便导出和导入较大的快照
+		HTTPClient: &http.Client{},
+		RetryWait:  time.Millisecond * 200,
+	})
+}
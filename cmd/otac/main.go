@@ -0,0 +1,230 @@
+// otac 是 ot-ac 的命令行管理工具，使用配置文件中的 OT 密钥签发 OTVID 调用 ot-ac 的 HTTP API，
+// 用于查看和修复租户、管理单元、资源对象及其权限。
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/otac"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/teambition/gear"
+)
+
+// errUsage 参数错误，输出命令的用法
+var errUsage = errors.New("invalid arguments")
+
+var ctx = gear.ContextWithSignal(context.Background())
+
+// command 子命令，name 为空格分隔的命令路径，如 "tenant add"
+type command struct {
+	name     string
+	args     string
+	synopsis string
+	admin    bool // 使用管理员身份
+	run      func(cli *otac.Client, fs *flag.FlagSet, args []string) error
+	flags    func(fs *flag.FlagSet)
+}
+
+var commands []*command
+
+func register(cmds ...*command) {
+	commands = append(commands, cmds...)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: otac [-config file] <command> [flags] [args]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	sort.Slice(commands, func(i, j int) bool { return commands[i].name < commands[j].name })
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", c.name, c.synopsis)
+	}
+	fmt.Fprintln(os.Stderr, "\nRun 'otac <command> -h' for the flags of a command.")
+}
+
+func main() {
+	configFlag := flag.String("config", "", "config file, defaults to $OTAC_CONFIG or ~/.otac.yaml")
+	flag.Usage = usage
+	flag.Parse()
+
+	cmd, args := lookup(flag.Args())
+	if cmd == nil {
+		usage()
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("otac "+cmd.name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: otac %s [flags] %s\n\n%s\n\n", cmd.name, cmd.args, cmd.synopsis)
+		fs.PrintDefaults()
+	}
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	fs.Parse(args)
+
+	err := run(*configFlag, cmd, fs)
+	if err == errUsage {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "otac: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// lookup 按最长匹配查找子命令
+func lookup(args []string) (*command, []string) {
+	for n := len(args); n > 0; n-- {
+		name := strings.Join(args[:n], " ")
+		for _, c := range commands {
+			if c.name == name {
+				return c, args[n:]
+			}
+		}
+	}
+	return nil, nil
+}
+
+func run(configFlag string, cmd *command, fs *flag.FlagSet) error {
+	cfg, err := loadConfig(configPath(configFlag))
+	if err != nil {
+		return err
+	}
+	var cli *otac.Client
+	if cmd.admin {
+		cli, err = cfg.client("admin", cfg.Admin)
+	} else {
+		cli, err = cfg.client("tenant", cfg.Tenant)
+	}
+	if err != nil {
+		return err
+	}
+
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.Timeout)*time.Second)
+	defer cancel()
+	return cmd.run(cli, fs, fs.Args())
+}
+
+// printJSON 以 JSON 格式输出结果，便于通过 jq 等工具处理
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// parseTarget 解析 Type:ID 格式的目标，ID 中可以包含冒号
+func parseTarget(s string) (tpl.Target, error) {
+	i := strings.IndexByte(s, ':')
+	if i <= 0 {
+		return tpl.Target{}, fmt.Errorf("invalid target %q, should be Type:ID", s)
+	}
+	t := tpl.Target{Type: s[:i], ID: s[i+1:]}
+	if err := t.Validate(); err != nil {
+		return t, fmt.Errorf("invalid target %q: %v", s, err)
+	}
+	return t, nil
+}
+
+func parseTargets(ss []string) ([]tpl.Target, error) {
+	targets := make([]tpl.Target, 0, len(ss))
+	for _, s := range ss {
+		t, err := parseTarget(s)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// targetFlag 可选的 Type:ID 参数
+type targetFlag struct {
+	target *tpl.Target
+}
+
+func (f *targetFlag) String() string {
+	if f.target == nil {
+		return ""
+	}
+	return f.target.Type + ":" + f.target.ID
+}
+
+func (f *targetFlag) Set(s string) error {
+	t, err := parseTarget(s)
+	if err != nil {
+		return err
+	}
+	f.target = &t
+	return nil
+}
+
+// stringsFlag 可以重复指定的参数
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
+// timeFlag RFC3339 格式的时间参数
+type timeFlag struct {
+	t *time.Time
+}
+
+func (f *timeFlag) String() string {
+	if f.t == nil {
+		return ""
+	}
+	return f.t.Format(time.RFC3339)
+}
+
+func (f *timeFlag) Set(s string) error {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return err
+	}
+	f.t = &t
+	return nil
+}
+
+// pageFlags 分页参数，-all 时获取所有页
+type pageFlags struct {
+	pageSize  int
+	pageToken string
+	all       bool
+}
+
+func (p *pageFlags) set(fs *flag.FlagSet) {
+	fs.IntVar(&p.pageSize, "page-size", 100, "page size")
+	fs.StringVar(&p.pageToken, "page-token", "", "page token returned by the previous page")
+	fs.BoolVar(&p.all, "all", false, "list all pages")
+}
+
+// list 获取一页或所有页，输出 nextToken 到 stderr
+func (p *pageFlags) list(fn func(pg tpl.Pagination) (string, error)) error {
+	pg := tpl.Pagination{PageSize: p.pageSize, PageToken: p.pageToken}
+	if !p.all {
+		next, err := fn(pg)
+		if err == nil && next != "" {
+			fmt.Fprintf(os.Stderr, "next page token: %s\n", next)
+		}
+		return err
+	}
+	return otac.EachPage(ctx, pg, func(_ context.Context, pg tpl.Pagination) (string, error) {
+		return fn(pg)
+	})
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/open-trust/ot-ac/src/otac"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+func init() {
+	register(
+		&command{
+			name:     "export",
+			args:     "<tenant OTID>",
+			synopsis: "export a tenant snapshot as NDJSON.",
+			admin:    true,
+			run:      export,
+			flags:    exportFlags.set,
+		},
+		&command{
+			name:     "import",
+			args:     "[file]",
+			synopsis: "import a tenant snapshot from a file or stdin.",
+			admin:    true,
+			run:      importSnapshot,
+		},
+		&command{
+			name:     "object tree",
+			args:     "<tenant OTID> [Type:ID]",
+			synopsis: "print the object hierarchy of a tenant, or the descendants of an object.",
+			admin:    true,
+			run:      objectTree,
+			flags:    objectTreeFlags.set,
+		},
+	)
+}
+
+type outFlags struct {
+	out string
+}
+
+func (f *outFlags) set(fs *flag.FlagSet) {
+	fs.StringVar(&f.out, "out", "", "write the snapshot to `file` instead of stdout")
+}
+
+var exportFlags outFlags
+
+func export(cli *otac.Client, _ *flag.FlagSet, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	tenant, err := parseTenant(args[0])
+	if err != nil {
+		return err
+	}
+	r, err := cli.Admin.ExportTenant(ctx, tenant)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if exportFlags.out == "" {
+		_, err = io.Copy(os.Stdout, r)
+		return err
+	}
+	file, err := os.Create(exportFlags.out)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(file, r); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func importSnapshot(cli *otac.Client, _ *flag.FlagSet, args []string) error {
+	var r io.Reader = os.Stdin
+	switch len(args) {
+	case 0:
+	case 1:
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	default:
+		return errUsage
+	}
+	res, err := cli.Admin.ImportTenant(ctx, r)
+	if err != nil {
+		return err
+	}
+	return printJSON(res)
+}
+
+type snapshotFlags struct {
+	snapshot string
+}
+
+func (f *snapshotFlags) set(fs *flag.FlagSet) {
+	fs.StringVar(&f.snapshot, "snapshot", "", "read the hierarchy from an exported snapshot `file` instead of exporting the tenant")
+}
+
+var objectTreeFlags snapshotFlags
+
+// objectTree 从租户快照的 object 和 objectParent 记录构建资源对象的层级关系，
+// 资源对象可以有多个父级，因此同一个资源对象可能出现在多个分支下
+func objectTree(cli *otac.Client, _ *flag.FlagSet, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errUsage
+	}
+	tenant, err := parseTenant(args[0])
+	if err != nil {
+		return err
+	}
+	var root *tpl.Target
+	if len(args) == 2 {
+		t, err := parseTarget(args[1])
+		if err != nil {
+			return err
+		}
+		root = &t
+	}
+
+	var r io.ReadCloser
+	if objectTreeFlags.snapshot != "" {
+		r, err = os.Open(objectTreeFlags.snapshot)
+	} else {
+		r, err = cli.Admin.ExportTenant(ctx, tenant)
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	tree, err := readObjectTree(r)
+	if err != nil {
+		return err
+	}
+	if root != nil {
+		key := targetKey(*root)
+		if !tree.objects[key] {
+			return fmt.Errorf("object %s not found in %s", key, tenant.String())
+		}
+		tree.print(key, 0, map[string]bool{})
+		return nil
+	}
+	for _, key := range tree.order {
+		if !tree.hasParent[key] {
+			tree.print(key, 0, map[string]bool{})
+		}
+	}
+	return nil
+}
+
+type objectTreeData struct {
+	order     []string // 快照中资源对象的顺序
+	objects   map[string]bool
+	hasParent map[string]bool
+	children  map[string][]string
+}
+
+func targetKey(t tpl.Target) string {
+	return t.Type + ":" + t.ID
+}
+
+func readObjectTree(r io.Reader) (*objectTreeData, error) {
+	tree := &objectTreeData{
+		objects:   make(map[string]bool),
+		hasParent: make(map[string]bool),
+		children:  make(map[string][]string),
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	ended := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		rec := &tpl.SnapshotRecord{}
+		if err := json.Unmarshal([]byte(line), rec); err != nil {
+			return nil, fmt.Errorf("invalid snapshot record: %v", err)
+		}
+		switch rec.Kind {
+		case tpl.SnapshotObject:
+			key := targetKey(tpl.Target{Type: rec.TargetType, ID: rec.TargetID})
+			tree.objects[key] = true
+			tree.order = append(tree.order, key)
+		case tpl.SnapshotObjectParent:
+			if rec.Object == nil || rec.Parent == nil {
+				continue
+			}
+			key := targetKey(*rec.Object)
+			parent := targetKey(*rec.Parent)
+			tree.hasParent[key] = true
+			tree.children[parent] = append(tree.children[parent], key)
+		case tpl.SnapshotEnd:
+			ended = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !ended {
+		return nil, errors.New("incomplete snapshot: end record not found")
+	}
+	return tree, nil
+}
+
+// print 输出以 key 为根的子树，path 用于在数据异常出现环时停止递归
+func (t *objectTreeData) print(key string, depth int, path map[string]bool) {
+	if path[key] {
+		fmt.Printf("%s%s (cycle)\n", strings.Repeat("  ", depth), key)
+		return
+	}
+	fmt.Printf("%s%s\n", strings.Repeat("  ", depth), key)
+	path[key] = true
+	for _, child := range t.children[key] {
+		t.print(child, depth+1, path)
+	}
+	delete(path, key)
+}
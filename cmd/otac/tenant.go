@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/open-trust/ot-ac/src/otac"
+	"github.com/open-trust/ot-ac/src/tpl"
+	otgo "github.com/open-trust/ot-go-lib"
+)
+
+func init() {
+	register(
+		&command{
+			name:     "tenant add",
+			args:     "<tenant OTID>",
+			synopsis: "add a tenant.",
+			admin:    true,
+			run:      tenantAdd,
+		},
+		&command{
+			name:     "tenant list",
+			synopsis: "list tenants.",
+			admin:    true,
+			run:      tenantList,
+			flags:    tenantListFlags.set,
+		},
+		&command{
+			name:     "tenant status",
+			args:     "<tenant OTID> <status>",
+			synopsis: "update the status of a tenant, a status less than 0 forbids the tenant.",
+			admin:    true,
+			run:      tenantStatus,
+		},
+	)
+}
+
+func parseTenant(s string) (otgo.OTID, error) {
+	tenant, err := otgo.ParseOTID(s)
+	if err != nil {
+		return tenant, fmt.Errorf("invalid tenant %q: %v", s, err)
+	}
+	return tenant, nil
+}
+
+func tenantAdd(cli *otac.Client, _ *flag.FlagSet, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	tenant, err := parseTenant(args[0])
+	if err != nil {
+		return err
+	}
+	created, err := cli.Admin.AddTenant(ctx, tenant)
+	if err != nil {
+		return err
+	}
+	return printJSON(created)
+}
+
+var tenantListFlags pageFlags
+
+func tenantList(cli *otac.Client, _ *flag.FlagSet, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+	return tenantListFlags.list(func(pg tpl.Pagination) (string, error) {
+		tenants, next, err := cli.Admin.ListTenants(ctx, pg)
+		if err != nil {
+			return "", err
+		}
+		return next, printJSON(tenants)
+	})
+}
+
+func tenantStatus(cli *otac.Client, _ *flag.FlagSet, args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+	tenant, err := parseTenant(args[0])
+	if err != nil {
+		return err
+	}
+	status, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid status %q", args[1])
+	}
+	res, err := cli.Admin.UpdateTenantStatus(ctx, tpl.TenantAddInput{Tenant: tenant, Status: status})
+	if err != nil {
+		return err
+	}
+	return printJSON(res)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/open-trust/ot-ac/src/otac"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+func init() {
+	register(
+		&command{
+			name:     "unit add",
+			args:     "<Type:ID>...",
+			synopsis: "add units, optionally under a parent unit or scope.",
+			run:      unitAdd,
+			flags:    unitAddFlags.set,
+		},
+		&command{
+			name:     "unit assign",
+			args:     "<Type:ID>",
+			synopsis: "assign a parent unit, a scope or an object to a unit.",
+			run:      unitAssign,
+			flags:    unitAssignFlags.set,
+		},
+		&command{
+			name:     "unit grant",
+			args:     "<Type:ID>",
+			synopsis: "grant permissions, roles or subjects to a unit.",
+			run:      unitGrant,
+			flags:    unitGrantFlags.set,
+		},
+		&command{
+			name:     "object add",
+			args:     "<Type:ID>...",
+			synopsis: "add objects, optionally under a parent object or scope.",
+			run:      objectAdd,
+			flags:    objectAddFlags.set,
+		},
+	)
+}
+
+// batchAddFlags unit add 和 object add 的参数
+type batchAddFlags struct {
+	parent targetFlag
+	scope  targetFlag
+}
+
+func (f *batchAddFlags) set(fs *flag.FlagSet) {
+	fs.Var(&f.parent, "parent", "parent `Type:ID`")
+	fs.Var(&f.scope, "scope", "scope `Type:ID`")
+}
+
+func (f *batchAddFlags) input(args []string) (*tpl.TargetBatchAddInput, error) {
+	if len(args) == 0 {
+		return nil, errUsage
+	}
+	targets, err := parseTargets(args)
+	if err != nil {
+		return nil, err
+	}
+	return &tpl.TargetBatchAddInput{Targets: targets, Parent: f.parent.target, Scope: f.scope.target}, nil
+}
+
+var unitAddFlags batchAddFlags
+
+func unitAdd(cli *otac.Client, _ *flag.FlagSet, args []string) error {
+	input, err := unitAddFlags.input(args)
+	if err != nil {
+		return err
+	}
+	return cli.Unit.BatchAdd(ctx, *input)
+}
+
+var objectAddFlags batchAddFlags
+
+func objectAdd(cli *otac.Client, _ *flag.FlagSet, args []string) error {
+	input, err := objectAddFlags.input(args)
+	if err != nil {
+		return err
+	}
+	return cli.Object.BatchAdd(ctx, *input)
+}
+
+var unitAssignFlags assignFlags
+
+type assignFlags struct {
+	parent targetFlag
+	scope  targetFlag
+	object targetFlag
+}
+
+func (f *assignFlags) set(fs *flag.FlagSet) {
+	fs.Var(&f.parent, "parent", "parent unit `Type:ID`")
+	fs.Var(&f.scope, "scope", "scope `Type:ID`")
+	fs.Var(&f.object, "object", "object `Type:ID`")
+}
+
+func unitAssign(cli *otac.Client, _ *flag.FlagSet, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	unit, err := parseTarget(args[0])
+	if err != nil {
+		return err
+	}
+	f := &unitAssignFlags
+	if f.parent.target == nil && f.scope.target == nil && f.object.target == nil {
+		return errors.New("one of -parent, -scope or -object required")
+	}
+	if f.parent.target != nil {
+		if err := cli.Unit.AssignParent(ctx, tpl.UnitAssignParentInput{Target: unit, Parent: *f.parent.target}); err != nil {
+			return err
+		}
+	}
+	if f.scope.target != nil {
+		if err := cli.Unit.AssignScope(ctx, tpl.UnitAssignScopeInput{Target: unit, Scope: *f.scope.target}); err != nil {
+			return err
+		}
+	}
+	if f.object.target != nil {
+		if err := cli.Unit.AssignObject(ctx, tpl.UnitAssignObjectInput{Target: unit, Object: *f.object.target}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var unitGrantFlags grantFlags
+
+type grantFlags struct {
+	permissions stringsFlag
+	roles       stringsFlag
+	subjects    stringsFlag
+	notBefore   timeFlag
+	notAfter    timeFlag
+}
+
+func (f *grantFlags) set(fs *flag.FlagSet) {
+	fs.Var(&f.permissions, "permission", "`permission` to grant, such as Doc.read, can be repeated")
+	fs.Var(&f.roles, "role", "`role` to grant, can be repeated")
+	fs.Var(&f.subjects, "subject", "`subject` to add to the unit, such as user:u1, can be repeated")
+	fs.Var(&f.notBefore, "not-before", "grant valid from `RFC3339 time`, for permissions and subjects")
+	fs.Var(&f.notAfter, "not-after", "grant valid until `RFC3339 time`, for permissions and subjects")
+}
+
+func unitGrant(cli *otac.Client, _ *flag.FlagSet, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	unit, err := parseTarget(args[0])
+	if err != nil {
+		return err
+	}
+	f := &unitGrantFlags
+	if len(f.permissions) == 0 && len(f.roles) == 0 && len(f.subjects) == 0 {
+		return errors.New("one of -permission, -role or -subject required")
+	}
+	validity := tpl.Validity{NotBefore: f.notBefore.t, NotAfter: f.notAfter.t}
+
+	if len(f.permissions) > 0 {
+		input := tpl.UnitAddPermissionsInput{Target: unit}
+		for _, p := range f.permissions {
+			input.Permissions = append(input.Permissions, tpl.PermissionEx{Permission: p, Validity: validity})
+		}
+		if err := cli.Unit.AddPermissions(ctx, input); err != nil {
+			return err
+		}
+	}
+	if len(f.roles) > 0 {
+		if err := cli.Unit.AddRoles(ctx, tpl.UnitRolesInput{Target: unit, Roles: f.roles}); err != nil {
+			return err
+		}
+	}
+	if len(f.subjects) > 0 {
+		input := tpl.UnitAddSubjectsInput{
+			Target:        unit,
+			SubjectsInput: tpl.SubjectsInput{Subjects: f.subjects},
+			Validity:      validity,
+		}
+		if err := cli.Unit.AddSubjects(ctx, input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,12 +4,16 @@ go 1.15
 
 require (
 	github.com/dgraph-io/dgo/v200 v200.0.0-20201023081658-a9ad93fe6ebd
+	github.com/golang/protobuf v1.5.0
 	github.com/open-trust/dag-go v0.3.0
 	github.com/open-trust/ot-go-lib v0.10.0
+	github.com/prometheus/client_golang v1.11.0
+	github.com/prometheus/common v0.26.0
 	github.com/teambition/compressible-go v1.0.1
 	github.com/teambition/gear v1.22.0
 	go.uber.org/dig v1.10.0
 	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 	google.golang.org/grpc v1.33.2
+	google.golang.org/protobuf v1.26.0
 	gopkg.in/yaml.v2 v2.3.0
 )
@@ -8,7 +8,12 @@ import (
 
 	"github.com/open-trust/ot-ac/src/app"
 	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/extauthz"
 	"github.com/open-trust/ot-ac/src/logging"
+	"github.com/open-trust/ot-ac/src/rpc"
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/util"
+	"google.golang.org/grpc"
 )
 
 var help = flag.Bool("help", false, "show help info")
@@ -23,6 +28,18 @@ func main() {
 		os.Exit(0)
 	}
 
+	// ot-ac migrate: 应用 Dgraph schema 并执行数据迁移
+	if flag.Arg(0) == "migrate" {
+		err := util.DigInvoke(func(dg *dgraph.Dgraph) error {
+			return dg.Migrate(conf.GlobalContext)
+		})
+		if err != nil {
+			logging.Errf("migrate failed: %v", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	if len(conf.Config.SrvAddr) == 0 {
 		conf.Config.SrvAddr = ":8080"
 	}
@@ -33,6 +50,45 @@ func main() {
 		prefix = "https://"
 	}
 
+	// gRPC 服务与 HTTP 服务共用 bll.Blls 和 OTVID 认证，监听在另一个端口
+	if conf.Config.GRPCAddr != "" {
+		err := util.DigInvoke(func(srv *grpc.Server) {
+			go func() {
+				logging.Errf("%s grpc closed %v", conf.AppName, rpc.ListenWithContext(
+					conf.GlobalContext, srv, conf.Config.GRPCAddr))
+			}()
+		})
+		if err != nil {
+			logging.Errf("start grpc failed: %v", err)
+			os.Exit(1)
+		}
+		logging.Logger.Info(logging.SrvLog("grpc start on %s", conf.Config.GRPCAddr))
+	}
+
+	// Envoy ext_authz 适配器，不验证调用方身份，监听地址只应该对 Envoy 开放
+	if cfg := conf.Config.ExtAuthz; cfg.GRPCAddr != "" || cfg.HTTPAddr != "" {
+		err := util.DigInvoke(func(a *extauthz.Authorizer) {
+			if cfg.GRPCAddr != "" {
+				go func() {
+					logging.Errf("%s ext_authz grpc closed %v", conf.AppName, rpc.ListenWithContext(
+						conf.GlobalContext, extauthz.NewGRPCServer(a), cfg.GRPCAddr))
+				}()
+				logging.Logger.Info(logging.SrvLog("ext_authz grpc start on %s", cfg.GRPCAddr))
+			}
+			if cfg.HTTPAddr != "" {
+				go func() {
+					logging.Errf("%s ext_authz http closed %v", conf.AppName, extauthz.NewHTTPApp(a).ListenWithContext(
+						conf.GlobalContext, cfg.HTTPAddr))
+				}()
+				logging.Logger.Info(logging.SrvLog("ext_authz http start on %s", cfg.HTTPAddr))
+			}
+		})
+		if err != nil {
+			logging.Errf("start ext_authz failed: %v", err)
+			os.Exit(1)
+		}
+	}
+
 	logging.Logger.Info(logging.SrvLog("start on %s", prefix+conf.Config.SrvAddr).With(appInfo))
 	logging.Errf("%s closed %v", conf.AppName, app.ListenWithContext(
 		conf.GlobalContext, conf.Config.SrvAddr, conf.Config.CertFile, conf.Config.KeyFile))
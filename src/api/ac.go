@@ -70,16 +70,67 @@ func (a *AC) CheckObject(ctx *gear.Context) error {
 	return ctx.OkJSON(res)
 }
 
+// ListPermissionsByUnit 列出请求主体到指定管理单元的权限，未指定管理单元时列出请求主体能触达的所有管理单元的权限
 func (a *AC) ListPermissionsByUnit(ctx *gear.Context) error {
-	return nil
+	input := tpl.ACListPermissionsInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.AC.ListPermissionsByUnit(ctx, *tenant, input.Subject, input.Target, input.Resources, input.WithOrganization)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
 }
 
+// ListPermissionsByScope 列出请求主体到指定范围约束的权限
 func (a *AC) ListPermissionsByScope(ctx *gear.Context) error {
-	return nil
+	input := tpl.ACListPermissionsInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+	if err := input.Target.Validate(); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.AC.ListPermissionsByScope(ctx, *tenant, input.Subject, input.Target, input.Resources, input.WithOrganization)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
 }
 
+// ListPermissionsByObject 列出请求主体通过 Scope 或 Unit-Object 的连接关系到指定资源对象的权限
 func (a *AC) ListPermissionsByObject(ctx *gear.Context) error {
-	return nil
+	input := tpl.ACListPermissionsInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+	if err := input.Target.Validate(); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.AC.ListPermissionsByObject(ctx, *tenant, input.Subject, input.Target, input.Resources, input.WithOrganization, input.IgnoreScope)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
 }
 
 func (a *AC) ListObject(ctx *gear.Context) error {
@@ -1,6 +1,9 @@
 package api
 
 import (
+	"io"
+	"net/http"
+
 	"github.com/open-trust/ot-ac/src/bll"
 	"github.com/open-trust/ot-ac/src/model"
 	"github.com/open-trust/ot-ac/src/tpl"
@@ -54,6 +57,80 @@ func (a *Admin) DeleteTenant(ctx *gear.Context) error {
 	return ctx.OkJSON(res)
 }
 
+// GetDeleteTenantJob 获取删除租户任务的状态
+func (a *Admin) GetDeleteTenantJob(ctx *gear.Context) error {
+	input := tpl.TenantAddInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	res, err := a.blls.Admin.GetDeleteTenantJob(ctx, input.Tenant)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// CloneTenant 启动复制租户的后台任务
+func (a *Admin) CloneTenant(ctx *gear.Context) error {
+	input := tpl.TenantCloneInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	var mapper bll.SubjectMapper
+	if len(input.SubjectMapping) > 0 {
+		mapper = bll.MapSubjects(input.SubjectMapping)
+	}
+	res, err := a.blls.Admin.CloneTenant(model.ContextWithPrefer(ctx), input.Source, input.Target, input.WithSubjects, mapper)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// GetCloneTenantJob 获取复制租户任务的状态
+func (a *Admin) GetCloneTenantJob(ctx *gear.Context) error {
+	input := tpl.TenantAddInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	res, err := a.blls.Admin.GetCloneTenantJob(ctx, input.Tenant)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// ExportTenant 以 NDJSON 流的形式导出租户快照
+func (a *Admin) ExportTenant(ctx *gear.Context) error {
+	input := tpl.TenantAddInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	export, err := a.blls.Admin.ExportTenant(ctx, input.Tenant)
+	if err != nil {
+		return err
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(export(pw))
+	}()
+	defer pr.Close()
+	return ctx.Stream(http.StatusOK, "application/x-ndjson", pr)
+}
+
+// ImportTenant 导入 NDJSON 格式的租户快照，请求体为 ExportTenant 的输出
+func (a *Admin) ImportTenant(ctx *gear.Context) error {
+	res, err := a.blls.Admin.ImportTenant(ctx, ctx.Req.Body)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
 // ListTenants ...
 func (a *Admin) ListTenants(ctx *gear.Context) error {
 	input := tpl.Pagination{}
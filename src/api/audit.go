@@ -0,0 +1,32 @@
+package api
+
+import (
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/middleware"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/teambition/gear"
+)
+
+// Audit ..
+type Audit struct {
+	blls *bll.Blls
+}
+
+// List 列出租户的审计记录，可以按涉及的节点、写操作、操作者和时间过滤
+func (a *Audit) List(ctx *gear.Context) error {
+	input := tpl.AuditListInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Audit.List(ctx, *tenant, input)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
@@ -14,12 +14,17 @@ type APIs struct {
 	Blls         *bll.Blls
 	AC           *AC
 	Admin        *Admin
+	Audit        *Audit
+	GraphQL      *GraphQL
 	Healthz      *Healthz
 	Object       *Object
 	Organization *Organization
 	Permission   *Permission
+	Role         *Role
 	Scope        *Scope
 	Unit         *Unit
+	Watch        *Watch
+	Webhook      *Webhook
 }
 
 // NewAPIs ...
@@ -28,11 +33,16 @@ func NewAPIs(blls *bll.Blls) *APIs {
 		Blls:         blls,
 		AC:           &AC{blls: blls},
 		Admin:        &Admin{blls: blls},
+		Audit:        &Audit{blls: blls},
+		GraphQL:      &GraphQL{blls: blls},
 		Healthz:      &Healthz{blls: blls},
 		Object:       &Object{blls: blls},
 		Organization: &Organization{blls: blls},
 		Permission:   &Permission{blls: blls},
+		Role:         &Role{blls: blls},
 		Scope:        &Scope{blls: blls},
 		Unit:         &Unit{blls: blls},
+		Watch:        &Watch{blls: blls},
+		Webhook:      &Webhook{blls: blls},
 	}
 }
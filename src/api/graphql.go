@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/middleware"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/teambition/gear"
+)
+
+// GraphQL ..
+type GraphQL struct {
+	blls *bll.Blls
+}
+
+// Query 租户范围的只读 GraphQL 查询，查询无效时返回 400 和 GraphQL 格式的 errors
+func (a *GraphQL) Query(ctx *gear.Context) error {
+	input := tpl.GraphQLInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.GraphQL.Query(ctx, *tenant, input)
+	if err != nil {
+		return err
+	}
+	if res.Data == nil {
+		return ctx.JSON(http.StatusBadRequest, res)
+	}
+	return ctx.OkJSON(res)
+}
@@ -12,7 +12,7 @@ type Healthz struct {
 
 // Check ..
 func (a *Healthz) Check(ctx *gear.Context) error {
-	h, err := a.blls.Models.Model.CheckHealth(ctx)
+	h, err := a.blls.Models.Model.Storage.CheckHealth(ctx)
 	if err != nil {
 		return err
 	}
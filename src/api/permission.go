@@ -44,7 +44,26 @@ func (a *Permission) Delete(ctx *gear.Context) error {
 		return err
 	}
 
-	res, err := a.blls.Permission.Delete(model.ContextWithPrefer(ctx), *tenant, input.Permission)
+	res, err := a.blls.Permission.Delete(model.ContextWithPrefer(ctx), *tenant, input.Permission, input.Force)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// Usage 统计引用权限的管理单元、资源对象和角色数量
+func (a *Permission) Usage(ctx *gear.Context) error {
+	input := tpl.PermissionInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Permission.Usage(model.ContextWithPrefer(ctx), *tenant, input.Permission)
 	if err != nil {
 		return err
 	}
@@ -69,3 +88,41 @@ func (a *Permission) List(ctx *gear.Context) error {
 	}
 	return ctx.OkJSON(res)
 }
+
+// Rename 重命名权限，所有引用关系及其 facets 保持不变
+func (a *Permission) Rename(ctx *gear.Context) error {
+	input := tpl.PermissionMigrateInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Permission.Rename(model.ContextWithPrefer(ctx), *tenant, input.From, input.To)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// Merge 将权限 from 合并到已存在的权限 to，并删除 from
+func (a *Permission) Merge(ctx *gear.Context) error {
+	input := tpl.PermissionMigrateInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Permission.Merge(model.ContextWithPrefer(ctx), *tenant, input.From, input.To)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/middleware"
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/teambition/gear"
+)
+
+// Role ..
+type Role struct {
+	blls *bll.Blls
+}
+
+// Add 创建角色，权限必须预先存在
+func (a *Role) Add(ctx *gear.Context) error {
+	input := tpl.RoleAddInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Role.Add(model.ContextWithPrefer(ctx), *tenant, input.Role, input.Permissions)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// Get 获取角色及其权限
+func (a *Role) Get(ctx *gear.Context) error {
+	input := tpl.RoleInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Role.Get(model.ContextWithPrefer(ctx), *tenant, input.Role)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// Update 覆盖角色的权限，权限必须预先存在，当 permissions 为空时会清空权限
+func (a *Role) Update(ctx *gear.Context) error {
+	input := tpl.RoleAddInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Role.Update(model.ContextWithPrefer(ctx), *tenant, input.Role, input.Permissions)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// Delete 删除角色，并解除所有管理单元与该角色的关系
+func (a *Role) Delete(ctx *gear.Context) error {
+	input := tpl.RoleInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Role.Delete(model.ContextWithPrefer(ctx), *tenant, input.Role)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// List 列出该系统当前所有角色
+func (a *Role) List(ctx *gear.Context) error {
+	input := tpl.RoleListInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Role.List(model.ContextWithPrefer(ctx), *tenant, input.Pagination)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
@@ -171,7 +171,7 @@ func (a *Unit) UpdateStatus(ctx *gear.Context) error {
 	return nil
 }
 
-// AddSubjects 管理单元批量添加请求主体，当请求主体不存在时会自动创建
+// AddSubjects 管理单元批量添加请求主体，当请求主体不存在时会自动创建，可通过 notBefore/notAfter 限定成员关系的有效期
 func (a *Unit) AddSubjects(ctx *gear.Context) error {
 	input := tpl.UnitAddSubjectsInput{}
 	if err := ctx.ParseBody(&input); err != nil {
@@ -183,7 +183,7 @@ func (a *Unit) AddSubjects(ctx *gear.Context) error {
 		return err
 	}
 
-	res, err := a.blls.Unit.AddSubjects(model.ContextWithPrefer(ctx), *tenant, input.Target, input.Subjects)
+	res, err := a.blls.Unit.AddSubjects(model.ContextWithPrefer(ctx), *tenant, input.Target, input.Subjects, input.Validity)
 	if err != nil {
 		return err
 	}
@@ -214,6 +214,44 @@ func (a *Unit) AddPermissions(ctx *gear.Context) error {
 	return ctx.OkJSON(res)
 }
 
+// AddRoles 给管理单元添加角色，角色必须预先存在
+func (a *Unit) AddRoles(ctx *gear.Context) error {
+	input := tpl.UnitRolesInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Unit.AddRoles(model.ContextWithPrefer(ctx), *tenant, input.Target, input.Roles)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// RemoveRoles 移除管理单元的角色
+func (a *Unit) RemoveRoles(ctx *gear.Context) error {
+	input := tpl.UnitRolesInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Unit.RemoveRoles(model.ContextWithPrefer(ctx), *tenant, input.Target, input.Roles)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
 // UpdatePermissions 覆盖管理单元的权限，权限必须预先存在，当 permissions 为空时会清空权限
 func (a *Unit) UpdatePermissions(ctx *gear.Context) error {
 	return nil
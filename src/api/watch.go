@@ -0,0 +1,32 @@
+package api
+
+import (
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/middleware"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/teambition/gear"
+)
+
+// Watch ..
+type Watch struct {
+	blls *bll.Blls
+}
+
+// Watch 长轮询租户的变更事件，从 revision 之后的事件开始返回，revision 过期时返回 410 错误，需要重新全量同步
+func (a *Watch) Watch(ctx *gear.Context) error {
+	input := tpl.WatchInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Watch.Watch(ctx, *tenant, input)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/middleware"
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/teambition/gear"
+)
+
+// Webhook ..
+type Webhook struct {
+	blls *bll.Blls
+}
+
+// Add 注册 webhook，secret 为空时自动生成，只在本次返回
+func (a *Webhook) Add(ctx *gear.Context) error {
+	input := tpl.WebhookAddInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Webhook.Add(model.ContextWithPrefer(ctx), *tenant, input)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// Update 更新 webhook 的事件类型、签名密钥或状态
+func (a *Webhook) Update(ctx *gear.Context) error {
+	input := tpl.WebhookUpdateInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Webhook.Update(ctx, *tenant, input)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// Delete 删除 webhook 及其死信
+func (a *Webhook) Delete(ctx *gear.Context) error {
+	input := tpl.WebhookInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Webhook.Delete(ctx, *tenant, input.URL)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// List 列出租户的 webhook，不返回签名密钥
+func (a *Webhook) List(ctx *gear.Context) error {
+	input := tpl.WebhookListInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Webhook.List(ctx, *tenant, input.Pagination)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
+
+// ListDeadLetters 列出重试后仍投递失败的事件
+func (a *Webhook) ListDeadLetters(ctx *gear.Context) error {
+	input := tpl.WebhookDeadLetterListInput{}
+	if err := ctx.ParseBody(&input); err != nil {
+		return err
+	}
+
+	tenant, err := middleware.TenantFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.blls.Webhook.ListDeadLetters(ctx, *tenant, input)
+	if err != nil {
+		return err
+	}
+	return ctx.OkJSON(res)
+}
@@ -8,8 +8,11 @@ import (
 	"github.com/teambition/compressible-go"
 	"github.com/teambition/gear"
 
+	"github.com/open-trust/ot-ac/src/bll"
 	"github.com/open-trust/ot-ac/src/conf"
 	"github.com/open-trust/ot-ac/src/logging"
+	"github.com/open-trust/ot-ac/src/middleware"
+	"github.com/open-trust/ot-ac/src/service/dgraph"
 	"github.com/open-trust/ot-ac/src/util"
 )
 
@@ -35,6 +38,16 @@ func New() *gear.App {
 	if app.Env() != "testing" {
 		app.Use(logging.WithAccessLogger)
 	}
+	app.Use(middleware.Metrics)
+
+	if conf.Config.Dgraph.AutoMigrate {
+		err := util.DigInvoke(func(dg *dgraph.Dgraph) error {
+			return dg.Migrate(conf.GlobalContext)
+		})
+		if err != nil {
+			logging.Panicf("migrate error: %v", err)
+		}
+	}
 
 	err := util.DigInvoke(func(routers []*gear.Router) error {
 		for _, router := range routers {
@@ -47,5 +60,39 @@ func New() *gear.App {
 		logging.Panicf("DigInvoke error: %v", err)
 	}
 
+	err = util.DigInvoke(func(blls *bll.Blls) error {
+		return blls.Admin.ResumeJobs(conf.GlobalContext)
+	})
+	if err != nil {
+		logging.Errf("resume jobs error: %v", err)
+	}
+
+	if interval := conf.Config.GrantSweeper.Interval; interval > 0 {
+		err = util.DigInvoke(func(blls *bll.Blls) {
+			go blls.Sweeper.Run(conf.GlobalContext, time.Duration(interval)*time.Second)
+		})
+		if err != nil {
+			logging.Panicf("DigInvoke error: %v", err)
+		}
+	}
+
+	if conf.Config.DecisionLog.Sink != "" {
+		err = util.DigInvoke(func(blls *bll.Blls) {
+			go blls.AC.RunDecisionLog(conf.GlobalContext)
+		})
+		if err != nil {
+			logging.Panicf("DigInvoke error: %v", err)
+		}
+	}
+
+	if conf.Config.Webhook.Workers > 0 {
+		err = util.DigInvoke(func(blls *bll.Blls) {
+			go blls.Webhook.Run(conf.GlobalContext, conf.Config.Webhook)
+		})
+		if err != nil {
+			logging.Panicf("DigInvoke error: %v", err)
+		}
+	}
+
 	return app
 }
@@ -1,12 +1,17 @@
 package app
 
 import (
+	"bytes"
+	"net/http"
+
 	"github.com/teambition/gear"
 
 	"github.com/open-trust/ot-ac/src/api"
 	"github.com/open-trust/ot-ac/src/bll"
 	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/metrics"
 	"github.com/open-trust/ot-ac/src/middleware"
+	"github.com/open-trust/ot-ac/src/openapi"
 	"github.com/open-trust/ot-ac/src/util"
 )
 
@@ -18,6 +23,20 @@ func getVersion(ctx *gear.Context) error {
 	return ctx.OkJSON(conf.AppInfo())
 }
 
+func getOpenAPI(ctx *gear.Context) error {
+	ctx.Type(gear.MIMEApplicationJSONCharsetUTF8)
+	return ctx.End(http.StatusOK, openapi.JSON(conf.Config.ServiceEndpoints))
+}
+
+// getMetrics 以 Prometheus 文本格式输出指标
+func getMetrics(ctx *gear.Context) error {
+	buf := &bytes.Buffer{}
+	if err := metrics.WriteTo(buf); err != nil {
+		return err
+	}
+	return ctx.Stream(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", buf)
+}
+
 // NewRouters ...
 func NewRouters(apis *api.APIs) []*gear.Router {
 
@@ -29,6 +48,8 @@ func NewRouters(apis *api.APIs) []*gear.Router {
 	router.Get("/livez", apis.Healthz.Check)
 	router.Get("/readyz", apis.Healthz.Check)
 	router.Get("/healthz", apis.Healthz.Check)
+	router.Get("/openapi.json", getOpenAPI)
+	router.Get("/metrics", getMetrics)
 
 	router.Post("/AC/CheckUnit", middleware.VerifyTenant, apis.AC.CheckUnit)
 	router.Post("/AC/CheckScope", middleware.VerifyTenant, apis.AC.CheckScope)
@@ -72,6 +93,8 @@ func NewRouters(apis *api.APIs) []*gear.Router {
 	router.Post("/Unit/AddPermissions", middleware.VerifyTenant, apis.Unit.AddPermissions)
 	router.Post("/Unit/UpdatePermissions", middleware.VerifyTenant, apis.Unit.UpdatePermissions)
 	router.Post("/Unit/RemovePermissions", middleware.VerifyTenant, apis.Unit.RemovePermissions)
+	router.Post("/Unit/AddRoles", middleware.VerifyTenant, apis.Unit.AddRoles)
+	router.Post("/Unit/RemoveRoles", middleware.VerifyTenant, apis.Unit.RemoveRoles)
 	router.Post("/Unit/ListChildren", middleware.VerifyTenant, apis.Unit.ListChildren)
 	router.Post("/Unit/ListDescendant", middleware.VerifyTenant, apis.Unit.ListDescendant)
 	router.Post("/Unit/ListPermissions", middleware.VerifyTenant, apis.Unit.ListPermissions)
@@ -90,12 +113,40 @@ func NewRouters(apis *api.APIs) []*gear.Router {
 	router.Post("/Permission/BatchAdd", middleware.VerifyTenant, apis.Permission.BatchAdd)
 	router.Post("/Permission/List", middleware.VerifyTenant, apis.Permission.List)
 	router.Post("/Permission/Delete", middleware.VerifyTenant, apis.Permission.Delete)
+	router.Post("/Permission/Usage", middleware.VerifyTenant, apis.Permission.Usage)
+	router.Post("/Permission/Rename", middleware.VerifyTenant, apis.Permission.Rename)
+	router.Post("/Permission/Merge", middleware.VerifyTenant, apis.Permission.Merge)
+
+	router.Post("/Role/Add", middleware.VerifyTenant, apis.Role.Add)
+	router.Post("/Role/Get", middleware.VerifyTenant, apis.Role.Get)
+	router.Post("/Role/Update", middleware.VerifyTenant, apis.Role.Update)
+	router.Post("/Role/Delete", middleware.VerifyTenant, apis.Role.Delete)
+	router.Post("/Role/List", middleware.VerifyTenant, apis.Role.List)
+
+	// 只读 GraphQL 接口，schema 见 graphql/schema.graphql
+	router.Post("/graphql", middleware.VerifyTenant, apis.GraphQL.Query)
+
+	// 长轮询租户的变更事件
+	router.Post("/Watch", middleware.VerifyTenant, apis.Watch.Watch)
+
+	router.Post("/Webhook/Add", middleware.VerifyTenant, apis.Webhook.Add)
+	router.Post("/Webhook/Update", middleware.VerifyTenant, apis.Webhook.Update)
+	router.Post("/Webhook/Delete", middleware.VerifyTenant, apis.Webhook.Delete)
+	router.Post("/Webhook/List", middleware.VerifyTenant, apis.Webhook.List)
+	router.Post("/Webhook/ListDeadLetters", middleware.VerifyTenant, apis.Webhook.ListDeadLetters)
+
+	router.Post("/Audit/List", middleware.VerifyTenant, apis.Audit.List)
 
 	// Admin
 	router.Post("/Admin/AddTenant", middleware.VerifyAdmin, apis.Admin.AddTenant)
 	router.Post("/Admin/UpdateTenantStatus", middleware.VerifyAdmin, apis.Admin.UpdateTenantStatus)
 	router.Post("/Admin/DeleteTenant", middleware.VerifyAdmin, apis.Admin.DeleteTenant)
+	router.Post("/Admin/GetDeleteTenantJob", middleware.VerifyAdmin, apis.Admin.GetDeleteTenantJob)
+	router.Post("/Admin/CloneTenant", middleware.VerifyAdmin, apis.Admin.CloneTenant)
+	router.Post("/Admin/GetCloneTenantJob", middleware.VerifyAdmin, apis.Admin.GetCloneTenantJob)
 	router.Post("/Admin/ListTenants", middleware.VerifyAdmin, apis.Admin.ListTenants)
+	router.Post("/Admin/ExportTenant", middleware.VerifyAdmin, apis.Admin.ExportTenant)
+	router.Post("/Admin/ImportTenant", middleware.VerifyAdmin, apis.Admin.ImportTenant)
 	router.Post("/Admin/BatchAddSubjects", middleware.VerifyAdmin, apis.Admin.BatchAddSubjects)
 	router.Post("/Admin/UpdateSubjectStatus", middleware.VerifyAdmin, apis.Admin.UpdateSubjectStatus)
 	router.Post("/Admin/ListSubjects", middleware.VerifyAdmin, apis.Admin.ListSubjects)
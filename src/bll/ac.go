@@ -2,7 +2,9 @@ package bll
 
 import (
 	"context"
+	"time"
 
+	"github.com/open-trust/ot-ac/src/metrics"
 	"github.com/open-trust/ot-ac/src/model"
 	"github.com/open-trust/ot-ac/src/tpl"
 )
@@ -10,45 +12,106 @@ import (
 // AC ...
 type AC struct {
 	ms *model.Models
+	// decisions 为 nil 时不记录决策日志
+	decisions *decisionLog
+}
+
+// RunDecisionLog 在后台写出权限检查的决策日志，直到 ctx 结束
+func (b *AC) RunDecisionLog(ctx context.Context) {
+	if b.decisions != nil {
+		b.decisions.run(ctx)
+	}
+}
+
+// check 执行权限检查，记录检查结果的指标和决策日志
+func (b *AC) check(ctx context.Context, tenant tpl.Tenant, check, subject string, target tpl.Target,
+	permissions []string, run func(context.Context) (interface{}, error)) (res interface{}, err error) {
+	start := time.Now()
+	if b.decisions == nil {
+		res, err = run(ctx)
+	} else {
+		res, err = b.decisions.check(ctx, tenant, check, subject, target, permissions, run)
+	}
+
+	tier := metrics.TenantTier(tenant.Tenant)
+	result := "deny"
+	switch {
+	case err != nil:
+		result = "error"
+	case checkAllowed(res):
+		result = "allow"
+	}
+	metrics.ACChecks.WithLabelValues(check, result, tier).Inc()
+	metrics.ACCheckDuration.WithLabelValues(check, tier).Observe(time.Since(start).Seconds())
+	return res, err
+}
+
+// checkAllowed 返回权限检查的结果是否为允许，res 为 bool 或 respond-detail 时的 []tpl.ACPermissionPayload
+func checkAllowed(res interface{}) bool {
+	switch v := res.(type) {
+	case bool:
+		return v
+	case []tpl.ACPermissionPayload:
+		return len(v) > 0
+	}
+	return false
 }
 
 // CheckUnit 检查请求主体到指定管理单元有没有指定权限
 func (b *AC) CheckUnit(ctx context.Context, tenant tpl.Tenant, subject string,
 	unit tpl.Target, permissions []string, withOrganization bool) (*tpl.SuccessResponseType, error) {
-	res, err := b.ms.AC.CheckUnit(ctx, tenant, subject, unit, permissions, withOrganization)
+	res, err := b.check(ctx, tenant, "CheckUnit", subject, unit, permissions, func(ctx context.Context) (interface{}, error) {
+		return b.ms.AC.CheckUnit(ctx, tenant, subject, unit, permissions, withOrganization)
+	})
 	return &tpl.SuccessResponseType{Result: res}, err
 }
 
 // CheckScope 检查请求主体到指定范围约束有没有指定权限
 func (b *AC) CheckScope(ctx context.Context, tenant tpl.Tenant, subject string,
 	scope tpl.Target, permissions []string, withOrganization bool) (*tpl.SuccessResponseType, error) {
-	res, err := b.ms.AC.CheckScope(ctx, tenant, subject, scope, permissions, withOrganization)
+	res, err := b.check(ctx, tenant, "CheckScope", subject, scope, permissions, func(ctx context.Context) (interface{}, error) {
+		return b.ms.AC.CheckScope(ctx, tenant, subject, scope, permissions, withOrganization)
+	})
 	return &tpl.SuccessResponseType{Result: res}, err
 }
 
 // CheckObject 检查请求主体通过 Scope 或 Unit-Object 的连接关系到指定资源对象有没有指定权限，如果 ignoreScope 为 true，则要求必须有 Unit-Object 的连接关系
 func (b *AC) CheckObject(ctx context.Context, tenant tpl.Tenant, subject string,
 	object tpl.Target, permissions []string, withOrganization, ignoreScope bool) (*tpl.SuccessResponseType, error) {
-	res, err := b.ms.AC.CheckObject(ctx, tenant, subject, object, permissions, withOrganization, ignoreScope)
+	res, err := b.check(ctx, tenant, "CheckObject", subject, object, permissions, func(ctx context.Context) (interface{}, error) {
+		return b.ms.AC.CheckObject(ctx, tenant, subject, object, permissions, withOrganization, ignoreScope)
+	})
 	return &tpl.SuccessResponseType{Result: res}, err
 }
 
 // ListPermissionsByUnit 列出请求主体到指定管理单元的符合 resource 的权限，如果未指定管理单元，则会查询请求主体能触达的所有管理单元，如果 resources 为空，则会列出所有触达的有效权限
 func (b *AC) ListPermissionsByUnit(ctx context.Context, tenant tpl.Tenant, subject string,
-	unit tpl.Target, resources []string, withOrganization bool, pg tpl.Pagination) (*tpl.SuccessResponseType, error) {
-	return nil, nil
+	unit tpl.Target, resources []string, withOrganization bool) (*tpl.SuccessResponseType, error) {
+	res, err := b.ms.AC.ListPermissionsByUnit(ctx, tenant, subject, unit, resources, withOrganization)
+	if err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: res}, nil
 }
 
 // ListPermissionsByScope 列出请求主体到指定范围约束的符合 resource 的权限，如果 resources 为空，则会列出所有触达的有效权限
 func (b *AC) ListPermissionsByScope(ctx context.Context, tenant tpl.Tenant, subject string,
-	scope tpl.Target, resources []string, withOrganization bool, pg tpl.Pagination) (*tpl.SuccessResponseType, error) {
-	return nil, nil
+	scope tpl.Target, resources []string, withOrganization bool) (*tpl.SuccessResponseType, error) {
+	res, err := b.ms.AC.ListPermissionsByScope(ctx, tenant, subject, scope, resources, withOrganization)
+	if err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: res}, nil
 }
 
 // ListPermissionsByObject 列出请求主体到指定资源对象的符合 resource 的权限，如果 resources 为空，则会列出所有触达的有效权限
 func (b *AC) ListPermissionsByObject(ctx context.Context, tenant tpl.Tenant, subject string,
-	object tpl.Target, resources []string, withOrganization bool, ignoreScope bool, pg tpl.Pagination) (*tpl.SuccessResponseType, error) {
-	return nil, nil
+	object tpl.Target, resources []string, withOrganization, ignoreScope bool) (*tpl.SuccessResponseType, error) {
+	res, err := b.ms.AC.ListPermissionsByObject(ctx, tenant, subject, object, resources, withOrganization, ignoreScope)
+	if err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: res}, nil
 }
 
 // ListObject 列出请求主体在指定资源对象中能触达的所有指定类型的子孙资源对象
@@ -2,7 +2,14 @@ package bll
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
 
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/logging"
 	"github.com/open-trust/ot-ac/src/model"
 	"github.com/open-trust/ot-ac/src/tpl"
 	otgo "github.com/open-trust/ot-go-lib"
@@ -11,7 +18,8 @@ import (
 
 // Admin ...
 type Admin struct {
-	ms *model.Models
+	ms   *model.Models
+	jobs sync.Map // 当前进程中执行中的后台任务
 }
 
 // AddTenant 添加租户
@@ -45,20 +53,327 @@ func (b *Admin) UpdateTenantStatus(ctx context.Context, tenant otgo.OTID, status
 	return &tpl.SuccessResponseType{Result: data}, nil
 }
 
-// DeleteTenant 删除租户及其名下所有数据，status 必须为 -1 才能删除
+// tenantDeleteBatchSize 删除租户时每个事务删除的节点数，管理单元可能有大量的边，不宜过大
+const tenantDeleteBatchSize = 100
+
+func isNotFound(err error) bool {
+	e, ok := err.(*gear.Error)
+	return ok && e.Code == http.StatusNotFound
+}
+
+// DeleteTenant 启动删除租户及其名下所有数据的后台任务，status 必须为 -1 才能删除，返回任务状态。
+// 租户名下的资源对象、管理单元、角色、范围约束和权限按批删除，每批是一个独立的事务，最后删除租户节点；
+// 任务状态持久化在 Dgraph 中，服务重启后会继续执行，任务失败后再次调用会从中断处继续
 func (b *Admin) DeleteTenant(ctx context.Context, tenant otgo.OTID) (*tpl.SuccessResponseType, error) {
+	job, err := b.ms.Job.Get(ctx, tpl.JobDeleteTenant, tenant.String())
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
 	doc, err := b.ms.Tenant.Get(ctx, tenant)
 	if err != nil {
+		if !isNotFound(err) {
+			return nil, err
+		}
+		if job != nil {
+			return &tpl.SuccessResponseType{Result: job}, nil
+		}
 		return &tpl.SuccessResponseType{Result: true}, nil
 	}
 	if doc.Status >= 0 {
 		return nil, gear.ErrPreconditionRequired.WithMsgf("tenant %s should be disabled before deleting", tenant.String())
 	}
 
-	if err := b.ms.Tenant.Delete(ctx, tenant); err != nil {
+	if job == nil {
+		job = &tpl.Job{Kind: tpl.JobDeleteTenant, Target: tenant.String()}
+	}
+	if job.Status != tpl.JobRunning {
+		job.Status = tpl.JobRunning
+		job.Error = ""
+		if err := b.ms.Job.Save(ctx, job); err != nil {
+			return nil, err
+		}
+	}
+	b.startJob(model.ActorFromContext(ctx), *job, b.runDeleteTenant)
+	return &tpl.SuccessResponseType{Result: job}, nil
+}
+
+// GetDeleteTenantJob 获取删除租户任务的状态
+func (b *Admin) GetDeleteTenantJob(ctx context.Context, tenant otgo.OTID) (*tpl.SuccessResponseType, error) {
+	job, err := b.ms.Job.Get(ctx, tpl.JobDeleteTenant, tenant.String())
+	if err != nil {
 		return nil, err
 	}
-	return &tpl.SuccessResponseType{Result: true}, nil
+	return &tpl.SuccessResponseType{Result: job}, nil
+}
+
+// ResumeJobs 继续执行服务重启前未完成的删除租户任务，未完成的复制租户任务缺少复制参数，标记为失败
+func (b *Admin) ResumeJobs(ctx context.Context) error {
+	jobs, err := b.ms.Job.ListRunning(ctx, tpl.JobDeleteTenant)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		b.startJob("", job, b.runDeleteTenant)
+	}
+
+	jobs, err = b.ms.Job.ListRunning(ctx, tpl.JobCloneTenant)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		job := job
+		job.Status = tpl.JobFailed
+		job.Error = "interrupted by restart, call CloneTenant again to retry"
+		if err := b.ms.Job.Save(ctx, &job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startJob 在后台执行任务，同一任务在当前进程中只会有一个在执行，任务出错时标记为失败。
+// actor 为发起任务的请求者，任务中的写操作以其身份记录审计日志
+func (b *Admin) startJob(actor string, job tpl.Job, run func(context.Context, *tpl.Job) error) {
+	key := job.Kind + " " + job.Target
+	if _, loaded := b.jobs.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+	go func() {
+		defer b.jobs.Delete(key)
+		ctx := model.ContextWithActor(conf.GlobalContext, actor)
+		if err := run(ctx, &job); err != nil {
+			logging.Errf("job %s %s error: %v", job.Kind, job.Target, err)
+			job.Status = tpl.JobFailed
+			job.Error = err.Error()
+			if err := b.ms.Job.Save(ctx, &job); err != nil {
+				logging.Errf("save job %s %s error: %v", job.Kind, job.Target, err)
+			}
+		}
+	}()
+}
+
+func (b *Admin) runDeleteTenant(ctx context.Context, job *tpl.Job) error {
+	tenant, err := otgo.ParseOTID(job.Target)
+	if err != nil {
+		return err
+	}
+	doc, err := b.ms.Tenant.Get(ctx, tenant)
+	switch {
+	case isNotFound(err):
+		// 租户节点已删除，说明上次执行已完成删除
+	case err != nil:
+		return err
+	case doc.Status >= 0:
+		return fmt.Errorf("tenant %s was enabled during deleting", job.Target)
+	default:
+		for _, kind := range model.TenantNodeKinds {
+			job.Stage = kind
+			for {
+				n, err := b.ms.Tenant.DeleteNodes(ctx, doc.UID, kind, tenantDeleteBatchSize)
+				if err != nil {
+					return err
+				}
+				if n == 0 {
+					break
+				}
+				job.Deleted += n
+				if err := b.ms.Job.Save(ctx, job); err != nil {
+					return err
+				}
+			}
+		}
+		// 租户的审计记录随租户删除，删除租户节点的审计记录在之后写入并保留
+		job.Stage = "Audit"
+		for {
+			n, err := b.ms.Audit.Purge(ctx, job.Target, tenantDeleteBatchSize)
+			if err != nil {
+				return err
+			}
+			if n == 0 {
+				break
+			}
+			job.Deleted += n
+			if err := b.ms.Job.Save(ctx, job); err != nil {
+				return err
+			}
+		}
+		if err := b.ms.Tenant.Delete(ctx, tenant); err != nil {
+			return err
+		}
+	}
+
+	job.Status = tpl.JobDone
+	job.Stage = ""
+	logging.Infof("tenant %s deleted, %d nodes removed", job.Target, job.Deleted)
+	return b.ms.Job.Save(ctx, job)
+}
+
+// cloneProgressInterval 复制租户时每复制多少条记录保存一次任务进度
+const cloneProgressInterval = 1000
+
+// SubjectMapper 复制租户时改写请求主体的钩子，返回空字符串表示不复制该请求主体的关系
+type SubjectMapper func(subject string) string
+
+// MapSubjects 返回按 mapping 改写请求主体的 SubjectMapper，不在 mapping 中的请求主体保持不变
+func MapSubjects(mapping map[string]string) SubjectMapper {
+	return func(subject string) string {
+		if s, ok := mapping[subject]; ok {
+			return s
+		}
+		return subject
+	}
+}
+
+// CloneTenant 启动将 source 租户复制为新租户 target 的后台任务，返回任务状态。
+// 复制的内容与 ExportTenant 的快照相同，target 租户的状态为 0；withSubjects 为 false 时不复制管理单元与请求主体、
+// 组织、OU 和组织成员的关系，mapper 不为 nil 时用于改写请求主体。target 租户不能已存在，
+// 除非是此前未完成的复制任务：复制是幂等的，任务失败后再次调用会重新复制
+func (b *Admin) CloneTenant(ctx context.Context, source, target otgo.OTID, withSubjects bool, mapper SubjectMapper) (*tpl.SuccessResponseType, error) {
+	src, err := b.ms.Tenant.Get(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	job, err := b.ms.Job.Get(ctx, tpl.JobCloneTenant, target.String())
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	if job != nil && job.Status == tpl.JobRunning {
+		return &tpl.SuccessResponseType{Result: job}, nil
+	}
+	_, err = b.ms.Tenant.Get(ctx, target)
+	switch {
+	case err == nil && (job == nil || job.Status == tpl.JobDone):
+		return nil, gear.ErrConflict.WithMsgf("tenant %s already exists", target.String())
+	case err != nil && !isNotFound(err):
+		return nil, err
+	}
+
+	if job == nil {
+		job = &tpl.Job{Kind: tpl.JobCloneTenant, Target: target.String()}
+	}
+	job.Status = tpl.JobRunning
+	job.Stage = ""
+	job.Copied = 0
+	job.Error = ""
+	if err := b.ms.Job.Save(ctx, job); err != nil {
+		return nil, err
+	}
+	b.startJob(model.ActorFromContext(ctx), *job, func(ctx context.Context, job *tpl.Job) error {
+		return b.runCloneTenant(ctx, job, *src, withSubjects, mapper)
+	})
+	return &tpl.SuccessResponseType{Result: job}, nil
+}
+
+// GetCloneTenantJob 获取复制租户任务的状态，tenant 为目标租户
+func (b *Admin) GetCloneTenantJob(ctx context.Context, tenant otgo.OTID) (*tpl.SuccessResponseType, error) {
+	job, err := b.ms.Job.Get(ctx, tpl.JobCloneTenant, tenant.String())
+	if err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: job}, nil
+}
+
+// cloneRecord 按复制参数改写快照记录，返回 false 表示不复制该记录
+func cloneRecord(r *tpl.SnapshotRecord, withSubjects bool, mapper SubjectMapper) bool {
+	switch r.Kind {
+	case tpl.SnapshotUnitOrg, tpl.SnapshotUnitOU:
+		return withSubjects
+	case tpl.SnapshotUnitSubject, tpl.SnapshotUnitMember:
+		if !withSubjects {
+			return false
+		}
+		if mapper != nil {
+			r.Subject = mapper(r.Subject)
+		}
+		return r.Subject != ""
+	}
+	return true
+}
+
+// runCloneTenant 将源租户的快照记录逐条应用到目标租户，与导入快照使用相同的逻辑
+func (b *Admin) runCloneTenant(ctx context.Context, job *tpl.Job, source tpl.Tenant, withSubjects bool, mapper SubjectMapper) error {
+	im := newSnapshotImporter(b.ms)
+	apply := func(r *tpl.SnapshotRecord) error {
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		if err := im.apply(ctx, data); err != nil {
+			return fmt.Errorf("%s record: %v", r.Kind, err)
+		}
+		job.Stage = r.Kind
+		job.Copied++
+		if job.Copied%cloneProgressInterval == 0 {
+			return b.ms.Job.Save(ctx, job)
+		}
+		return nil
+	}
+
+	if err := apply(&tpl.SnapshotRecord{
+		Kind:    tpl.SnapshotTenant,
+		Version: tpl.SnapshotVersion,
+		Tenant:  job.Target,
+	}); err != nil {
+		return err
+	}
+	err := b.ms.Snapshot.Export(ctx, source, func(r *tpl.SnapshotRecord) error {
+		if !cloneRecord(r, withSubjects, mapper) {
+			return nil
+		}
+		return apply(r)
+	})
+	if err != nil {
+		return err
+	}
+	if err := im.flush(ctx); err != nil {
+		return err
+	}
+	if err := im.flushImplies(ctx); err != nil {
+		return err
+	}
+
+	job.Status = tpl.JobDone
+	job.Stage = ""
+	logging.Infof("tenant %s cloned to %s, %d records copied", source.Tenant, job.Target, job.Copied)
+	return b.ms.Job.Save(ctx, job)
+}
+
+// ExportTenant 导出租户快照，租户不存在时返回 404 错误，否则返回将 NDJSON 格式的快照写入 w 的函数。
+// 快照以 tenant 记录开始、end 记录结束，缺少 end 记录说明导出被中断
+func (b *Admin) ExportTenant(ctx context.Context, tenant otgo.OTID) (func(w io.Writer) error, error) {
+	doc, err := b.ms.Tenant.Get(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	return func(w io.Writer) error {
+		count := 0
+		enc := json.NewEncoder(w)
+		emit := func(r *tpl.SnapshotRecord) error {
+			count++
+			return enc.Encode(r)
+		}
+		if err := emit(&tpl.SnapshotRecord{
+			Kind:    tpl.SnapshotTenant,
+			Version: tpl.SnapshotVersion,
+			Tenant:  doc.Tenant,
+			Status:  doc.Status,
+		}); err != nil {
+			return err
+		}
+		if err := b.ms.Snapshot.Export(ctx, *doc, emit); err != nil {
+			return err
+		}
+		return enc.Encode(&tpl.SnapshotRecord{Kind: tpl.SnapshotEnd, Count: count})
+	}, nil
+}
+
+// ImportTenant 导入 NDJSON 格式的租户快照，租户不存在时会被创建，重复导入是幂等的
+func (b *Admin) ImportTenant(ctx context.Context, r io.Reader) (*tpl.SuccessResponseType, error) {
+	res, err := importSnapshot(ctx, b.ms, r)
+	if err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: res}, nil
 }
 
 // ListTenants 列出系统中的租户
@@ -0,0 +1,26 @@
+package bll
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Audit ...
+type Audit struct {
+	ms *model.Models
+}
+
+// List 列出租户的审计记录，按记录时间先后排序
+func (b *Audit) List(ctx context.Context, tenant tpl.Tenant, input tpl.AuditListInput) (*tpl.SuccessResponseType, error) {
+	data, err := b.ms.Audit.List(ctx, tenant, input)
+	if err != nil {
+		return nil, err
+	}
+	res := &tpl.SuccessResponseType{Result: data, NextToken: ""}
+	if len(data) >= input.PageSize {
+		res.NextToken = data[len(data)-1].UID
+	}
+	return res, nil
+}
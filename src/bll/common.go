@@ -1,6 +1,7 @@
 package bll
 
 import (
+	"github.com/open-trust/ot-ac/src/conf"
 	"github.com/open-trust/ot-ac/src/model"
 	"github.com/open-trust/ot-ac/src/util"
 	"github.com/teambition/gear"
@@ -15,25 +16,41 @@ type Blls struct {
 	Models       *model.Models
 	AC           *AC
 	Admin        *Admin
+	Audit        *Audit
+	GraphQL      *GraphQL
 	Object       *Object
 	Organization *Organization
 	Permission   *Permission
+	Role         *Role
 	Scope        *Scope
+	Sweeper      *Sweeper
 	Unit         *Unit
+	Watch        *Watch
+	Webhook      *Webhook
 }
 
 // NewBlls ...
-func NewBlls(models *model.Models) *Blls {
+func NewBlls(models *model.Models) (*Blls, error) {
+	decisions, err := newDecisionLog(conf.Config.DecisionLog)
+	if err != nil {
+		return nil, err
+	}
 	return &Blls{
 		Models:       models,
-		AC:           &AC{models},
-		Admin:        &Admin{models},
+		AC:           &AC{ms: models, decisions: decisions},
+		Admin:        &Admin{ms: models},
+		Audit:        &Audit{models},
+		GraphQL:      &GraphQL{models},
 		Object:       &Object{models},
 		Organization: &Organization{models},
 		Permission:   &Permission{models},
+		Role:         &Role{models},
 		Scope:        &Scope{models},
+		Sweeper:      &Sweeper{models},
 		Unit:         &Unit{models},
-	}
+		Watch:        &Watch{models},
+		Webhook:      &Webhook{ms: models},
+	}, nil
 }
 
 type contextKey int
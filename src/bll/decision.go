@@ -0,0 +1,170 @@
+package bll
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/logging"
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// decisionLog 以 NDJSON 记录权限检查的决策。记录先放入内存缓冲，由 run 在后台写出；缓冲满时丢弃新的允许记录并定期记录警告日志，
+// 拒绝和出错的记录直接写入 sink
+type decisionLog struct {
+	cfg     conf.DecisionLog
+	entries chan *tpl.Decision
+	dropped int64
+	// mu 保护 bw，run 和缓冲满时的 add 都会写入
+	mu  sync.Mutex
+	bw  *bufio.Writer
+	enc *json.Encoder
+}
+
+type decisionLine struct {
+	Kind string `json:"kind"`
+	*tpl.Decision
+}
+
+// newDecisionLog 根据配置创建决策日志，decision_log.sink 为空时返回 nil
+func newDecisionLog(cfg conf.DecisionLog) (*decisionLog, error) {
+	var w io.Writer
+	switch cfg.Sink {
+	case "":
+		return nil, nil
+	case "stdout":
+		w = os.Stdout
+	case "file":
+		f, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		w = f
+	default:
+		return nil, fmt.Errorf("invalid decision_log.sink %q", cfg.Sink)
+	}
+	return newDecisionLogWriter(cfg, w), nil
+}
+
+func newDecisionLogWriter(cfg conf.DecisionLog, w io.Writer) *decisionLog {
+	bw := bufio.NewWriterSize(w, 64*1024)
+	return &decisionLog{cfg: cfg, entries: make(chan *tpl.Decision, cfg.Buffer), bw: bw, enc: json.NewEncoder(bw)}
+}
+
+// sampleRate 返回租户允许结果的采样率
+func (l *decisionLog) sampleRate(tenant string) float64 {
+	if rate, ok := l.cfg.Tenants[tenant]; ok {
+		return rate
+	}
+	return *l.cfg.SampleRate
+}
+
+// add 将记录放入缓冲，缓冲已满时丢弃允许的记录，拒绝和出错的记录直接写入 sink
+func (l *decisionLog) add(d *tpl.Decision) {
+	select {
+	case l.entries <- d:
+	default:
+		if d.Allowed {
+			atomic.AddInt64(&l.dropped, 1)
+			return
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.write(d)
+		l.flush()
+	}
+}
+
+// write 和 flush 需持有 mu
+func (l *decisionLog) write(d *tpl.Decision) {
+	if err := l.enc.Encode(decisionLine{Kind: "decision", Decision: d}); err != nil {
+		logging.Errf("write decision log error: %v", err)
+	}
+}
+
+func (l *decisionLog) flush() {
+	if err := l.bw.Flush(); err != nil {
+		logging.Errf("flush decision log error: %v", err)
+	}
+}
+
+// run 写出缓冲的记录，缓冲为空时刷新到 sink，ctx 结束后写完剩余的记录再返回
+func (l *decisionLog) run(ctx context.Context) {
+	write := func(d *tpl.Decision, flush bool) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.write(d)
+		if flush {
+			l.flush()
+		}
+	}
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case d := <-l.entries:
+			write(d, len(l.entries) == 0)
+		case <-ticker.C:
+			if n := atomic.SwapInt64(&l.dropped, 0); n > 0 {
+				logging.Warningf("decision log buffer is full, %d allowed decisions dropped", n)
+			}
+		case <-ctx.Done():
+			for {
+				select {
+				case d := <-l.entries:
+					write(d, len(l.entries) == 0)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// check 执行权限检查并记录决策，权限检查的结果按调用方的要求返回。允许的结果按租户的采样率记录，采样在检查之前决定，
+// 采样到的检查同时查询授予权限的管理单元作为 grantedBy；拒绝和出错的结果总是记录
+func (l *decisionLog) check(ctx context.Context, tenant tpl.Tenant, check, subject string, target tpl.Target,
+	permissions []string, run func(context.Context) (interface{}, error)) (interface{}, error) {
+	rate := l.sampleRate(tenant.Tenant)
+	sampled := rand.Float64() < rate
+	var granted *model.GrantedBy
+	if sampled {
+		ctx, granted = model.ContextWithGrantedBy(ctx)
+	}
+	start := time.Now()
+	res, err := run(ctx)
+	d := &tpl.Decision{
+		Tenant:      tenant.Tenant,
+		Check:       check,
+		Subject:     subject,
+		Target:      target,
+		Permissions: permissions,
+		LatencyMs:   float64(time.Since(start).Microseconds()) / 1000,
+		SampleRate:  1,
+		CreatedAt:   start.UTC(),
+	}
+	d.Allowed = err == nil && checkAllowed(res)
+	if err != nil {
+		d.Error = err.Error()
+	}
+
+	if d.Allowed {
+		if !sampled {
+			return res, err
+		}
+		d.SampleRate = rate
+		d.GrantedBy = granted.Permissions
+	}
+	l.add(d)
+	return res, err
+}
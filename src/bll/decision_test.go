@@ -0,0 +1,87 @@
+package bll
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+func TestDecisionLogCheck(t *testing.T) {
+	rate := 1.0
+	w := &bytes.Buffer{}
+	l := newDecisionLogWriter(conf.DecisionLog{SampleRate: &rate, Tenants: map[string]float64{"unsampled": 0}, Buffer: 10}, w)
+	granted := []tpl.ACPermissionPayload{{Target: tpl.Target{Type: "team", ID: "t1"}, Role: "reader"}}
+	calls := make([]bool, 0)
+	// allow 与 model 的权限检查一样，要求 respond-detail 时返回授予权限的管理单元，并记录到 GrantedBy
+	allow := func(ctx context.Context) (interface{}, error) {
+		g := model.GrantedByFromContext(ctx)
+		calls = append(calls, g != nil)
+		if g != nil {
+			g.Permissions = granted
+		}
+		if model.IsRespondDetail(ctx) {
+			return granted, nil
+		}
+		return true, nil
+	}
+	deny := func(ctx context.Context) (interface{}, error) {
+		return false, nil
+	}
+	ctx := context.Background()
+	target := tpl.Target{Type: "doc", ID: "a"}
+
+	// 采样到的检查按调用方的要求返回，grantedBy 来自这次检查
+	res, err := l.check(ctx, tpl.Tenant{Tenant: "sampled"}, "CheckObject", "user:1", target, []string{"read"}, allow)
+	if err != nil || res != true || len(calls) != 1 || !calls[0] {
+		t.Fatalf("check got %v, %v, calls %v", res, err, calls)
+	}
+	res, err = l.check(model.ContextWithRespondDetail(ctx), tpl.Tenant{Tenant: "sampled"}, "CheckUnit", "user:1", target, []string{"read"}, allow)
+	if ps, ok := res.([]tpl.ACPermissionPayload); err != nil || !ok || len(ps) != 1 || len(calls) != 2 {
+		t.Fatalf("check with respond-detail got %v, %v, calls %v", res, err, calls)
+	}
+	// 未采样的检查不查询 grantedBy，允许结果不记录，拒绝和出错的结果总是记录
+	if res, _ = l.check(ctx, tpl.Tenant{Tenant: "unsampled"}, "CheckUnit", "user:1", target, []string{"read"}, allow); res != true || calls[2] {
+		t.Fatalf("unsampled check got %v, calls %v", res, calls)
+	}
+	l.check(ctx, tpl.Tenant{Tenant: "unsampled"}, "CheckScope", "user:1", target, []string{"read"}, func(ctx context.Context) (interface{}, error) {
+		return nil, errors.New("dgraph unavailable")
+	})
+	if len(l.entries) != 3 || w.Len() != 0 {
+		t.Fatalf("entries got %d, written %q", len(l.entries), w.String())
+	}
+
+	// 缓冲已满时丢弃允许的记录，拒绝的记录直接写入
+	for i := 0; i < 8; i++ {
+		l.check(ctx, tpl.Tenant{Tenant: "sampled"}, "CheckUnit", "user:1", target, []string{"read"}, allow)
+	}
+	l.check(ctx, tpl.Tenant{Tenant: "sampled"}, "CheckUnit", "user:2", target, []string{"read"}, deny)
+	if len(l.entries) != 10 || l.dropped != 1 || !strings.Contains(w.String(), `"subject":"user:2"`) {
+		t.Fatalf("full buffer got %d entries, %d dropped, written %q", len(l.entries), l.dropped, w.String())
+	}
+
+	// ctx 已结束时 run 写完缓冲中的记录后返回
+	rctx, cancel := context.WithCancel(ctx)
+	cancel()
+	l.run(rctx)
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	if len(lines) != 11 {
+		t.Fatalf("decision log got %q", w.String())
+	}
+	for i, check := range []string{"CheckUnit", "CheckObject", "CheckUnit", "CheckScope"} {
+		d := tpl.Decision{}
+		if err := json.Unmarshal([]byte(lines[i]), &d); err != nil {
+			t.Fatal(err)
+		}
+		allowed := i == 1 || i == 2
+		if d.Check != check || d.Allowed != allowed || len(d.GrantedBy) != map[bool]int{true: 1}[allowed] {
+			t.Fatalf("decision %d got %s", i, lines[i])
+		}
+	}
+}
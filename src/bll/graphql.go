@@ -0,0 +1,38 @@
+package bll
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/gql"
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// GraphQL ...
+type GraphQL struct {
+	ms *model.Models
+}
+
+// Query 执行租户范围的只读 GraphQL 查询，查询无效时返回的响应只包含 errors
+func (b *GraphQL) Query(ctx context.Context, tenant tpl.Tenant, input tpl.GraphQLInput) (*gql.Response, error) {
+	limits := gql.Limits{
+		MaxDepth:      conf.Config.GraphQL.MaxDepth,
+		MaxComplexity: conf.Config.GraphQL.MaxComplexity,
+	}
+	plan, e := gql.Compile(input, tenant, limits)
+	if e != nil {
+		return &gql.Response{Errors: []*gql.Error{e}}, nil
+	}
+
+	query, vars := plan.DQL()
+	res, err := b.ms.GraphQL.Query(ctx, query, vars)
+	if err != nil {
+		return nil, err
+	}
+	data, err := plan.Result(res)
+	if err != nil {
+		return nil, err
+	}
+	return &gql.Response{Data: data}, nil
+}
@@ -13,7 +13,7 @@ type Permission struct {
 }
 
 // BatchAdd 批量添加权限
-func (b *Permission) BatchAdd(ctx context.Context, tenant tpl.Tenant, permissions []string) (
+func (b *Permission) BatchAdd(ctx context.Context, tenant tpl.Tenant, permissions []tpl.Permission) (
 	*tpl.SuccessResponseType, error) {
 	if err := b.ms.Permission.BatchAdd(ctx, tenant, permissions); err != nil {
 		return nil, err
@@ -21,13 +21,44 @@ func (b *Permission) BatchAdd(ctx context.Context, tenant tpl.Tenant, permission
 	return &tpl.SuccessResponseType{Result: true}, nil
 }
 
-// Delete 删除权限
-func (b *Permission) Delete(ctx context.Context, tenant tpl.Tenant, permission string) (
+// Delete 删除权限，权限仍被引用时需要 force 为 true，返回被解除引用关系的管理单元、资源对象和角色
+func (b *Permission) Delete(ctx context.Context, tenant tpl.Tenant, permission string, force bool) (
 	*tpl.SuccessResponseType, error) {
-	if err := b.ms.Permission.Delete(ctx, tenant, permission); err != nil {
+	res, err := b.ms.Permission.Delete(ctx, tenant, permission, force)
+	if err != nil {
 		return nil, err
 	}
-	return &tpl.SuccessResponseType{Result: true}, nil
+	return &tpl.SuccessResponseType{Result: res}, nil
+}
+
+// Rename 重命名权限，所有引用关系及其 facets 保持不变
+func (b *Permission) Rename(ctx context.Context, tenant tpl.Tenant, from, to string) (
+	*tpl.SuccessResponseType, error) {
+	res, err := b.ms.Permission.Rename(ctx, tenant, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: res}, nil
+}
+
+// Merge 将权限 from 合并到已存在的权限 to，并删除 from
+func (b *Permission) Merge(ctx context.Context, tenant tpl.Tenant, from, to string) (
+	*tpl.SuccessResponseType, error) {
+	res, err := b.ms.Permission.Merge(ctx, tenant, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: res}, nil
+}
+
+// Usage 统计引用权限的管理单元、资源对象和角色数量
+func (b *Permission) Usage(ctx context.Context, tenant tpl.Tenant, permission string) (
+	*tpl.SuccessResponseType, error) {
+	res, err := b.ms.Permission.Usage(ctx, tenant, permission)
+	if err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: res}, nil
 }
 
 // List 列出该系统当前指定资源类型的权限，当 resource 为空时列出所有权限
@@ -0,0 +1,62 @@
+package bll
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Role ...
+type Role struct {
+	ms *model.Models
+}
+
+// Add 创建角色，权限必须预先存在
+func (b *Role) Add(ctx context.Context, tenant tpl.Tenant, role string, permissions []string) (
+	*tpl.SuccessResponseType, error) {
+	ok, err := b.ms.Role.Add(ctx, tenant, role, permissions)
+	if err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: ok}, nil
+}
+
+// Get 获取角色及其权限
+func (b *Role) Get(ctx context.Context, tenant tpl.Tenant, role string) (*tpl.SuccessResponseType, error) {
+	res, err := b.ms.Role.Get(ctx, tenant, role)
+	if err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: res}, nil
+}
+
+// Update 覆盖角色的权限，权限必须预先存在，当 permissions 为空时会清空权限
+func (b *Role) Update(ctx context.Context, tenant tpl.Tenant, role string, permissions []string) (
+	*tpl.SuccessResponseType, error) {
+	if err := b.ms.Role.Update(ctx, tenant, role, permissions); err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: true}, nil
+}
+
+// Delete 删除角色，并解除所有管理单元与该角色的关系
+func (b *Role) Delete(ctx context.Context, tenant tpl.Tenant, role string) (*tpl.SuccessResponseType, error) {
+	if err := b.ms.Role.Delete(ctx, tenant, role); err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: true}, nil
+}
+
+// List 列出该系统当前所有角色
+func (b *Role) List(ctx context.Context, tenant tpl.Tenant, pg tpl.Pagination) (*tpl.SuccessResponseType, error) {
+	data, err := b.ms.Role.List(ctx, tenant, pg.PageSize, pg.Skip, pg.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	res := &tpl.SuccessResponseType{Result: data, NextToken: ""}
+	if len(data) >= pg.PageSize {
+		res.NextToken = data[len(data)-1].UID
+	}
+	return res, nil
+}
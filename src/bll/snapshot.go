@@ -0,0 +1,381 @@
+package bll
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/tpl"
+	otgo "github.com/open-trust/ot-go-lib"
+	"github.com/teambition/gear"
+)
+
+// snapshotBatchSize 导入时连续的同类记录合并为一次批量操作的最大记录数
+const snapshotBatchSize = 100
+
+// snapshotMaxLine 导入时单行记录的最大长度
+const snapshotMaxLine = 1 << 20
+
+// snapshotImporter 按顺序应用租户快照的记录，所有操作都基于唯一键（UK），重复导入同一快照是幂等的
+type snapshotImporter struct {
+	ms          *model.Models
+	tenant      *tpl.Tenant
+	output      *tpl.SnapshotImportOutput
+	batchKey    string
+	batch       []*tpl.SnapshotRecord
+	permissions []tpl.Permission
+	// imported 已导入的权限，deferred 蕴含了尚未导入的权限，在全部权限导入后再次写入完整的蕴含关系
+	imported map[string]struct{}
+	deferred []tpl.Permission
+	ended    bool
+}
+
+func newSnapshotImporter(ms *model.Models) *snapshotImporter {
+	return &snapshotImporter{
+		ms:       ms,
+		output:   &tpl.SnapshotImportOutput{Kinds: make(map[string]int)},
+		imported: make(map[string]struct{}),
+	}
+}
+
+func formatTimeKey(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// snapshotBatchKey 返回记录所属批次的 key，key 相同的连续记录会合并处理
+func snapshotBatchKey(r *tpl.SnapshotRecord) string {
+	switch r.Kind {
+	case tpl.SnapshotUnitParent, tpl.SnapshotObjectParent:
+		return fmt.Sprintf("%s %s %s", r.Kind, r.Parent.Type, r.Parent.ID)
+	case tpl.SnapshotUnitScope, tpl.SnapshotObjectScope:
+		return fmt.Sprintf("%s %s %s", r.Kind, r.Scope.Type, r.Scope.ID)
+	case tpl.SnapshotUnitPermission, tpl.SnapshotUnitRole:
+		return fmt.Sprintf("%s %s %s", r.Kind, r.Unit.Type, r.Unit.ID)
+	case tpl.SnapshotUnitSubject:
+		return fmt.Sprintf("%s %s %s %s %s", r.Kind, r.Unit.Type, r.Unit.ID,
+			formatTimeKey(r.NotBefore), formatTimeKey(r.NotAfter))
+	case tpl.SnapshotUnitMember:
+		return fmt.Sprintf("%s %s %s %s", r.Kind, r.Unit.Type, r.Unit.ID, r.Org)
+	case tpl.SnapshotObjectPermission:
+		return fmt.Sprintf("%s %s %s", r.Kind, r.Object.Type, r.Object.ID)
+	default:
+		return r.Kind
+	}
+}
+
+func (im *snapshotImporter) apply(ctx context.Context, line []byte) error {
+	if im.ended {
+		return gear.ErrBadRequest.WithMsg("unexpected record after end")
+	}
+	r := &tpl.SnapshotRecord{}
+	if err := json.Unmarshal(line, r); err != nil {
+		return gear.ErrBadRequest.WithMsgf("invalid record: %v", err)
+	}
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	switch {
+	case r.Kind == tpl.SnapshotTenant:
+		if im.tenant != nil {
+			return gear.ErrBadRequest.WithMsg("duplicate tenant record")
+		}
+		return im.applyTenant(ctx, r)
+	case im.tenant == nil:
+		return gear.ErrBadRequest.WithMsg("tenant record should be the first record")
+	case r.Kind == tpl.SnapshotEnd:
+		if err := im.flush(ctx); err != nil {
+			return err
+		}
+		if err := im.flushImplies(ctx); err != nil {
+			return err
+		}
+		if r.Count != im.output.Records {
+			return gear.ErrBadRequest.WithMsgf("snapshot truncated: %d records expected, %d received", r.Count, im.output.Records)
+		}
+		im.ended = true
+		return nil
+	}
+
+	if key := snapshotBatchKey(r); key != im.batchKey || len(im.batch) >= snapshotBatchSize {
+		if err := im.flush(ctx); err != nil {
+			return err
+		}
+		if key != im.batchKey {
+			if err := im.flushImplies(ctx); err != nil {
+				return err
+			}
+		}
+		im.batchKey = key
+	}
+	if r.Kind == tpl.SnapshotPermission {
+		// 以对象形式解析，导入的权限会覆盖已存在权限的元数据和蕴含关系
+		p := tpl.Permission{}
+		if err := json.Unmarshal(line, &p); err != nil {
+			return gear.ErrBadRequest.WithMsgf("invalid record: %v", err)
+		}
+		im.permissions = append(im.permissions, p)
+	}
+	im.batch = append(im.batch, r)
+	im.output.Records++
+	im.output.Kinds[r.Kind]++
+	return nil
+}
+
+func (im *snapshotImporter) applyTenant(ctx context.Context, r *tpl.SnapshotRecord) error {
+	otid, err := otgo.ParseOTID(r.Tenant)
+	if err != nil {
+		return gear.ErrBadRequest.WithMsgf("invalid tenant %s: %v", r.Tenant, err)
+	}
+	if !otid.MemberOf(conf.OT.TrustDomain) {
+		return gear.ErrBadRequest.WithMsgf("tenant %s is not a member of %s", otid.String(), conf.OT.TrustDomain.String())
+	}
+	if _, err := im.ms.Tenant.Add(ctx, tpl.Tenant{Tenant: otid.String(), Status: r.Status}); err != nil {
+		return err
+	}
+	tenant, err := im.ms.Tenant.Get(ctx, otid)
+	if err != nil {
+		return err
+	}
+	if tenant.Status != r.Status {
+		tenant.Status = r.Status
+		if err := im.ms.Tenant.Update(ctx, *tenant); err != nil {
+			return err
+		}
+	}
+	im.tenant = tenant
+	im.output.Tenant = tenant.Tenant
+	im.output.Records++
+	im.output.Kinds[r.Kind]++
+	return nil
+}
+
+func snapshotTargets(rs []*tpl.SnapshotRecord, get func(*tpl.SnapshotRecord) tpl.Target) []tpl.Target {
+	ts := make([]tpl.Target, len(rs))
+	for i, r := range rs {
+		ts[i] = get(r)
+	}
+	return ts
+}
+
+func snapshotUnit(r *tpl.SnapshotRecord) tpl.Target {
+	return *r.Unit
+}
+
+func snapshotObject(r *tpl.SnapshotRecord) tpl.Target {
+	return *r.Object
+}
+
+// flush 应用当前批次的记录
+func (im *snapshotImporter) flush(ctx context.Context) error {
+	rs := im.batch
+	if len(rs) == 0 {
+		return nil
+	}
+	im.batch = nil
+	tenant := *im.tenant
+	first := rs[0]
+
+	switch first.Kind {
+	case tpl.SnapshotPermission:
+		ps := im.permissions
+		im.permissions = nil
+		for _, p := range ps {
+			im.imported[p.Permission] = struct{}{}
+		}
+		// 蕴含的权限可能在后续批次中，先写入已导入的部分
+		for i, p := range ps {
+			implies := make([]string, 0, len(p.Implies))
+			for _, v := range p.Implies {
+				if _, ok := im.imported[v]; ok {
+					implies = append(implies, v)
+				}
+			}
+			if len(implies) < len(p.Implies) {
+				im.deferred = append(im.deferred, p)
+				ps[i].Implies = implies
+			}
+		}
+		return im.ms.Permission.BatchAdd(ctx, tenant, ps)
+
+	case tpl.SnapshotRole:
+		for _, r := range rs {
+			ok, err := im.ms.Role.Add(ctx, tenant, r.Role, r.Permissions)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				if err := im.ms.Role.Update(ctx, tenant, r.Role, r.Permissions); err != nil {
+					return err
+				}
+			}
+		}
+
+	case tpl.SnapshotScope:
+		for _, r := range rs {
+			ok, err := im.ms.Scope.Add(ctx, tenant, tpl.Scope{Status: r.Status, TargetID: r.TargetID, TargetType: r.TargetType})
+			if err != nil {
+				return err
+			}
+			if !ok {
+				if err := im.ms.Scope.UpdateStatus(ctx, tenant, r.Target(), r.Status); err != nil {
+					return err
+				}
+			}
+		}
+
+	case tpl.SnapshotUnit:
+		if err := im.ms.Unit.BatchAdd(ctx, tenant, snapshotTargets(rs, (*tpl.SnapshotRecord).Target), nil, nil); err != nil {
+			return err
+		}
+		for _, r := range rs {
+			if r.Status != 0 {
+				if err := im.ms.Snapshot.UpdateUnitStatus(ctx, tenant, r.Target(), r.Status); err != nil {
+					return err
+				}
+			}
+		}
+
+	case tpl.SnapshotObject:
+		if err := im.ms.Object.BatchAdd(ctx, tenant, snapshotTargets(rs, (*tpl.SnapshotRecord).Target), nil, nil); err != nil {
+			return err
+		}
+		for _, r := range rs {
+			if r.Terms != "" {
+				if err := im.ms.Snapshot.UpdateObjectTerms(ctx, tenant, r.Target(), r.Terms); err != nil {
+					return err
+				}
+			}
+		}
+
+	case tpl.SnapshotUnitParent:
+		return im.ms.Unit.BatchAdd(ctx, tenant, snapshotTargets(rs, snapshotUnit), first.Parent, nil)
+
+	case tpl.SnapshotUnitScope:
+		return im.ms.Unit.BatchAdd(ctx, tenant, snapshotTargets(rs, snapshotUnit), nil, first.Scope)
+
+	case tpl.SnapshotUnitPermission:
+		ps := make([]tpl.PermissionEx, len(rs))
+		for i, r := range rs {
+			ps[i] = tpl.PermissionEx{Permission: r.Permission, Extensions: r.Extensions, Validity: r.Validity}
+		}
+		return im.ms.Unit.AddPermissions(ctx, tenant, *first.Unit, ps)
+
+	case tpl.SnapshotUnitRole:
+		roles := make([]string, len(rs))
+		for i, r := range rs {
+			roles[i] = r.Role
+		}
+		return im.ms.Unit.AddRoles(ctx, tenant, *first.Unit, roles)
+
+	case tpl.SnapshotUnitSubject:
+		subs := make([]string, len(rs))
+		for i, r := range rs {
+			subs[i] = r.Subject
+		}
+		subjects, err := im.ms.Subject.AcquireUIDsOrAdd(ctx, subs)
+		if err != nil {
+			return err
+		}
+		return im.ms.Unit.AddSubjects(ctx, tenant, *first.Unit, subjects, first.Validity)
+
+	case tpl.SnapshotUnitOrg:
+		for _, r := range rs {
+			if err := im.ms.Unit.AddFromOrg(ctx, tenant, *r.Unit, r.Org, nil, nil); err != nil {
+				return err
+			}
+		}
+
+	case tpl.SnapshotUnitOU:
+		for _, r := range rs {
+			if err := im.ms.Unit.AddFromOU(ctx, tenant, *r.Unit, r.Org, r.OU, nil, nil); err != nil {
+				return err
+			}
+		}
+
+	case tpl.SnapshotUnitMember:
+		subs := make([]string, len(rs))
+		for i, r := range rs {
+			subs[i] = r.Subject
+		}
+		return im.ms.Unit.AddFromMembers(ctx, tenant, *first.Unit, first.Org, subs, nil, nil)
+
+	case tpl.SnapshotObjectParent:
+		return im.ms.Object.BatchAdd(ctx, tenant, snapshotTargets(rs, snapshotObject), first.Parent, nil)
+
+	case tpl.SnapshotObjectScope:
+		return im.ms.Object.BatchAdd(ctx, tenant, snapshotTargets(rs, snapshotObject), nil, first.Scope)
+
+	case tpl.SnapshotObjectPermission:
+		ps := make([]string, len(rs))
+		for i, r := range rs {
+			ps[i] = r.Permission
+		}
+		return im.ms.Object.AddPermissions(ctx, tenant, *first.Object, ps)
+
+	case tpl.SnapshotObjectUnit:
+		for _, r := range rs {
+			if err := im.ms.Unit.AssignObject(ctx, tenant, *r.Unit, *r.Object); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// flushImplies 在连续的权限记录之后写入被推迟的蕴含关系
+func (im *snapshotImporter) flushImplies(ctx context.Context) error {
+	for len(im.deferred) > 0 {
+		n := len(im.deferred)
+		if n > snapshotBatchSize {
+			n = snapshotBatchSize
+		}
+		if err := im.ms.Permission.BatchAdd(ctx, *im.tenant, im.deferred[:n]); err != nil {
+			return err
+		}
+		im.deferred = im.deferred[n:]
+	}
+	return nil
+}
+
+// importSnapshot 逐行读取并应用 NDJSON 格式的租户快照，出错时返回出错的行号，此前的记录已生效
+func importSnapshot(ctx context.Context, ms *model.Models, r io.Reader) (*tpl.SnapshotImportOutput, error) {
+	im := newSnapshotImporter(ms)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), snapshotMaxLine)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := im.apply(ctx, scanner.Bytes()); err != nil {
+			return nil, wrapSnapshotError(line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, gear.ErrBadRequest.WithMsgf("line %d: %v", line+1, err)
+	}
+	if im.tenant == nil {
+		return nil, gear.ErrBadRequest.WithMsg("empty snapshot")
+	}
+	if !im.ended {
+		return nil, gear.ErrBadRequest.WithMsgf("snapshot truncated: end record missing after %d records", im.output.Records)
+	}
+	return im.output, nil
+}
+
+func wrapSnapshotError(line int, err error) error {
+	if e, ok := err.(*gear.Error); ok {
+		return e.WithMsgf("line %d: %s", line, e.Msg)
+	}
+	return gear.ErrInternalServerError.WithMsgf("line %d: %v", line, err)
+}
@@ -0,0 +1,55 @@
+package bll
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/logging"
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Sweeper 后台清理已过期的授权关系
+type Sweeper struct {
+	ms *model.Models
+}
+
+// Run 每隔 interval 清理一次过期授权，直到 ctx 结束
+func (b *Sweeper) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := b.SweepExpiredGrants(ctx, time.Now()); err != nil {
+				logging.Errf("sweep expired grants error: %v", err)
+			}
+		}
+	}
+}
+
+// SweepExpiredGrants 物理删除 notAfter 早于 now 的 OTAC.U-Ps 和 OTAC.U-Ss 边，并记录被删除的授权关系
+func (b *Sweeper) SweepExpiredGrants(ctx context.Context, now time.Time) ([]tpl.ExpiredGrant, error) {
+	res := make([]tpl.ExpiredGrant, 0)
+	uidToken := "0x0"
+	for uidToken != "" {
+		grants, nextToken, err := b.ms.Unit.DeleteExpiredGrants(ctx, now, 1000, uidToken)
+		if err != nil {
+			return res, err
+		}
+		for _, g := range grants {
+			logging.Logger.Info(logging.SrvLog("expired grant removed").With(map[string]interface{}{
+				"unit":       g.Unit,
+				"permission": g.Permission,
+				"subject":    g.Subject,
+				"notAfter":   g.NotAfter,
+			}))
+		}
+		res = append(res, grants...)
+		uidToken = nextToken
+	}
+	return res, nil
+}
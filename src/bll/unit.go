@@ -100,18 +100,14 @@ func (b *Unit) UpdateStatus(ctx context.Context, tenant tpl.Tenant, unit tpl.Tar
 	return nil, nil
 }
 
-// AddSubjects 管理单元批量添加请求主体，当请求主体不存在时会自动创建
-func (b *Unit) AddSubjects(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, input []string) (
+// AddSubjects 管理单元批量添加请求主体，当请求主体不存在时会自动创建，validity 定义成员关系的有效时间窗口
+func (b *Unit) AddSubjects(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, input []string, validity tpl.Validity) (
 	*tpl.SuccessResponseType, error) {
 	subjects, err := b.ms.Subject.AcquireUIDsOrAdd(ctx, input)
 	if err != nil {
 		return nil, err
 	}
-	subjectUIDs := make([]string, 0, len(subjects))
-	for _, subject := range subjects {
-		subjectUIDs = append(subjectUIDs, subject.UID)
-	}
-	if err := b.ms.Unit.AddSubjects(ctx, tenant, unit, subjectUIDs); err != nil {
+	if err := b.ms.Unit.AddSubjects(ctx, tenant, unit, subjects, validity); err != nil {
 		return nil, err
 	}
 	return &tpl.SuccessResponseType{Result: true}, nil
@@ -132,6 +128,24 @@ func (b *Unit) AddPermissions(ctx context.Context, tenant tpl.Tenant, unit tpl.T
 	return &tpl.SuccessResponseType{Result: true}, nil
 }
 
+// AddRoles 给管理单元添加角色，角色必须预先存在
+func (b *Unit) AddRoles(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, roles []string) (
+	*tpl.SuccessResponseType, error) {
+	if err := b.ms.Unit.AddRoles(ctx, tenant, unit, roles); err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: true}, nil
+}
+
+// RemoveRoles 移除管理单元的角色
+func (b *Unit) RemoveRoles(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, roles []string) (
+	*tpl.SuccessResponseType, error) {
+	if err := b.ms.Unit.RemoveRoles(ctx, tenant, unit, roles); err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: true}, nil
+}
+
 // UpdatePermissions 覆盖管理单元的权限，权限必须预先存在，当 permissions 为空时会清空权限
 func (b *Unit) UpdatePermissions(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, permissions []string) (
 	*tpl.SuccessResponseType, error) {
@@ -0,0 +1,62 @@
+package bll
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Watch ...
+type Watch struct {
+	ms *model.Models
+}
+
+// watchMargin 在请求超时之前预留的响应时间
+const watchMargin = time.Millisecond * 500
+
+// watchPoll 等待新事件时重新读取变更日志的间隔，其它实例的写操作不会唤醒本实例的等待
+const watchPoll = time.Second
+
+// Watch 长轮询租户的变更事件：revision 之后已有事件时立即返回，否则等待新事件直到 timeout 或请求超时。
+// revision 为 0 时直接返回当前的 revision，消费方应在全量同步之前获取它
+func (b *Watch) Watch(ctx context.Context, tenant tpl.Tenant, input tpl.WatchInput) (*tpl.SuccessResponseType, error) {
+	if input.Revision == 0 {
+		rev, err := b.ms.Watch.Revision(ctx, tenant)
+		if err != nil {
+			return nil, err
+		}
+		res := &tpl.WatchOutput{Revision: rev, Events: make([]tpl.WatchEvent, 0)}
+		return &tpl.SuccessResponseType{Result: res}, nil
+	}
+
+	wait := time.Duration(input.Timeout) * time.Second
+	if deadline, ok := ctx.Deadline(); ok {
+		if d := time.Until(deadline) - watchMargin; d < wait {
+			wait = d
+		}
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	poll := time.NewTicker(watchPoll)
+	defer poll.Stop()
+
+	for {
+		res, changed, err := b.ms.Watch.Since(ctx, tenant, input.Revision, input.Limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(res.Events) > 0 {
+			return &tpl.SuccessResponseType{Result: res}, nil
+		}
+		select {
+		case <-changed:
+		case <-poll.C:
+		case <-timer.C:
+			return &tpl.SuccessResponseType{Result: res}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,423 @@
+package bll
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/logging"
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/open-trust/ot-ac/src/util"
+)
+
+// Webhook 管理租户的 webhook，并将变更事件投递到订阅了该事件类型的 webhook
+type Webhook struct {
+	ms *model.Models
+	// checkIP 建立连接前检查 webhook 的地址，为 nil 时使用 tpl.CheckWebhookIP
+	checkIP func(ip net.IP) error
+}
+
+// Add 注册 webhook，secret 为空时自动生成，只在本次返回
+func (b *Webhook) Add(ctx context.Context, tenant tpl.Tenant, input tpl.WebhookAddInput) (*tpl.SuccessResponseType, error) {
+	webhook := tpl.Webhook{
+		URL:       input.URL,
+		Events:    input.Events,
+		Secret:    input.Secret,
+		CreatedAt: time.Now().UTC(),
+	}
+	if webhook.Secret == "" {
+		webhook.Secret = randomHex(32)
+	}
+	ok, err := b.ms.Webhook.Add(ctx, tenant, webhook)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// 幂等请求重放时 webhook 已存在，不返回本次生成的 secret
+		webhook.Secret = ""
+	}
+	return &tpl.SuccessResponseType{Result: webhook}, nil
+}
+
+// Update 更新 webhook 的事件类型、签名密钥或状态
+func (b *Webhook) Update(ctx context.Context, tenant tpl.Tenant, input tpl.WebhookUpdateInput) (*tpl.SuccessResponseType, error) {
+	if err := b.ms.Webhook.Update(ctx, tenant, input); err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: true}, nil
+}
+
+// Delete 删除 webhook 及其死信和等待重试的投递
+func (b *Webhook) Delete(ctx context.Context, tenant tpl.Tenant, url string) (*tpl.SuccessResponseType, error) {
+	if err := b.ms.Webhook.Delete(ctx, tenant, url); err != nil {
+		return nil, err
+	}
+	return &tpl.SuccessResponseType{Result: true}, nil
+}
+
+// List 列出租户的 webhook
+func (b *Webhook) List(ctx context.Context, tenant tpl.Tenant, pg tpl.Pagination) (*tpl.SuccessResponseType, error) {
+	data, err := b.ms.Webhook.List(ctx, tenant, pg.PageSize, pg.Skip, pg.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	res := &tpl.SuccessResponseType{Result: data, NextToken: ""}
+	if len(data) >= pg.PageSize {
+		res.NextToken = data[len(data)-1].UID
+	}
+	return res, nil
+}
+
+// ListDeadLetters 列出租户的死信，url 不为空时只列出该 webhook 的死信
+func (b *Webhook) ListDeadLetters(ctx context.Context, tenant tpl.Tenant, input tpl.WebhookDeadLetterListInput) (
+	*tpl.SuccessResponseType, error) {
+	pg := input.Pagination
+	data, err := b.ms.Webhook.ListDeadLetters(ctx, tenant, input.URL, pg.PageSize, pg.Skip, pg.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	res := &tpl.SuccessResponseType{Result: data, NextToken: ""}
+	if len(data) >= pg.PageSize {
+		res.NextToken = data[len(data)-1].UID
+	}
+	return res, nil
+}
+
+// webhookDelivery 一次待投递的事件，重试时复用同一个请求体
+type webhookDelivery struct {
+	uid      string // 保存在 Dgraph 中的等待重试的投递，首次投递时为空
+	tenant   string
+	webhook  tpl.Webhook
+	id       string
+	typ      string
+	body     []byte
+	attempts int
+	err      string // 最近一次投递的错误
+}
+
+func (d *webhookDelivery) saved(next time.Time) tpl.WebhookDelivery {
+	return tpl.WebhookDelivery{
+		UID:           d.uid,
+		Tenant:        d.tenant,
+		Webhook:       d.webhook,
+		ID:            d.id,
+		Type:          d.typ,
+		Payload:       d.body,
+		Error:         d.err,
+		Attempts:      d.attempts,
+		NextAttemptAt: next,
+	}
+}
+
+const (
+	// webhookFlushTimeout 服务关闭时保存未完成的投递的最长时间
+	webhookFlushTimeout = 10 * time.Second
+	// webhookLease 取出等待重试的投递后其它实例不会再取出它的时间，需大于投递在队列中等待和投递的时间，
+	// 超过时投递可能重复，接收方可以按 X-OTAC-Delivery 去重
+	webhookLease = 5 * time.Minute
+)
+
+// Run 订阅变更事件并投递到 webhook，直到 ctx 结束。投递失败时保存到 Dgraph，按 retry_wait 指数退避重试，
+// 超过 max_attempts 后记为死信；订阅的事件缓冲已满而没有推送的事件直接记为死信。
+// 每隔 retry_wait 秒取出到期的重试，包括其它实例或重启前保存的；ctx 结束时未完成的投递保存为立即重试
+func (b *Webhook) Run(ctx context.Context, cfg conf.Webhook) {
+	b.run(ctx, cfg, b.ms.Watch.Subscribe(1000))
+}
+
+func (b *Webhook) run(ctx context.Context, cfg conf.Webhook, changes <-chan model.Change) {
+	deliveries := make(chan *webhookDelivery, 1000)
+	client := newWebhookClient(cfg, b.checkIP)
+
+	mu := new(sync.Mutex)
+	stranded := make([]*webhookDelivery, 0)
+	strand := func(ds ...*webhookDelivery) {
+		mu.Lock()
+		defer mu.Unlock()
+		stranded = append(stranded, ds...)
+	}
+	workers := new(sync.WaitGroup)
+	for i := 0; i < cfg.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			b.work(ctx, client, cfg, deliveries, strand)
+		}()
+	}
+
+	ticker := time.NewTicker(time.Duration(cfg.RetryWait) * time.Second)
+	defer ticker.Stop()
+	b.claimRetries(ctx, deliveries)
+	for {
+		select {
+		case <-ctx.Done():
+			workers.Wait()
+			for len(deliveries) > 0 {
+				stranded = append(stranded, <-deliveries)
+			}
+			b.flush(stranded)
+			return
+		case change := <-changes:
+			b.deadLetterDropped(ctx)
+			strand(b.dispatch(ctx, change, deliveries)...)
+		case <-ticker.C:
+			b.claimRetries(ctx, deliveries)
+		}
+	}
+}
+
+// deliveriesOf 为订阅了该事件类型的每个 webhook 生成投递
+func (b *Webhook) deliveriesOf(ctx context.Context, change model.Change) []*webhookDelivery {
+	types := tpl.WebhookEventsOf(change.Event.Op)
+	if len(types) == 0 {
+		return nil
+	}
+	webhooks, err := b.ms.Webhook.ListByEvents(ctx, change.Tenant, types)
+	if err != nil {
+		logging.Errf("list webhooks of %s error: %v", change.Tenant, err)
+		return nil
+	}
+	res := make([]*webhookDelivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		for _, typ := range types {
+			typ := typ
+			if !util.StringsHas(webhook.Events, func(s string) bool { return s == typ }) {
+				continue
+			}
+			id := randomHex(16)
+			body, err := json.Marshal(tpl.WebhookPayload{
+				ID:        id,
+				Type:      typ,
+				Tenant:    change.Tenant,
+				Revision:  change.Event.Revision,
+				CreatedAt: change.Event.CreatedAt,
+				Data:      change.Event,
+			})
+			if err != nil {
+				logging.Errf("marshal webhook payload error: %v", err)
+				continue
+			}
+			res = append(res, &webhookDelivery{tenant: change.Tenant, webhook: webhook, id: id, typ: typ, body: body})
+		}
+	}
+	return res
+}
+
+// dispatch 将事件的投递放入投递队列，返回因 ctx 结束而没有放入的投递
+func (b *Webhook) dispatch(ctx context.Context, change model.Change, deliveries chan<- *webhookDelivery) []*webhookDelivery {
+	ds := b.deliveriesOf(ctx, change)
+	for i, d := range ds {
+		select {
+		case deliveries <- d:
+		case <-ctx.Done():
+			return ds[i:]
+		}
+	}
+	return nil
+}
+
+// claimRetries 按投递队列的空余取出到期的重试放入队列，webhook 已停用的投递记为死信，已删除的直接删除
+func (b *Webhook) claimRetries(ctx context.Context, deliveries chan<- *webhookDelivery) {
+	n := cap(deliveries) - len(deliveries)
+	if n <= 0 {
+		return
+	}
+	ds, err := b.ms.Webhook.ClaimDeliveries(ctx, time.Now().UTC(), webhookLease, n)
+	if err != nil {
+		if ctx.Err() == nil {
+			logging.Errf("claim webhook deliveries error: %v", err)
+		}
+		return
+	}
+	for _, wd := range ds {
+		d := &webhookDelivery{uid: wd.UID, tenant: wd.Tenant, webhook: wd.Webhook, id: wd.ID, typ: wd.Type,
+			body: wd.Payload, attempts: wd.Attempts, err: wd.Error}
+		switch {
+		case d.webhook.URL == "":
+			b.deleteDelivery(ctx, d)
+		case d.webhook.Status < 0:
+			d.err = "webhook disabled, last error: " + d.err
+			b.deadLetter(ctx, d)
+		default:
+			// 只有 Run 向队列中放入投递，不会超过取出时的空余
+			deliveries <- d
+		}
+	}
+}
+
+// deadLetterDropped 将订阅的事件缓冲已满而没有推送的事件记为死信
+func (b *Webhook) deadLetterDropped(ctx context.Context) {
+	dropped := b.ms.Watch.Dropped()
+	if len(dropped) == 0 {
+		return
+	}
+	logging.Warningf("webhook dispatcher is too slow, %d events dropped", len(dropped))
+	for _, change := range dropped {
+		for _, d := range b.deliveriesOf(ctx, change) {
+			d.err = "event dropped: webhook dispatcher is too slow"
+			b.deadLetter(ctx, d)
+		}
+	}
+}
+
+// flush 在服务关闭时将没有完成的投递保存为立即重试，由其它实例或重启后继续投递，并将没有推送的事件记为死信
+func (b *Webhook) flush(pending []*webhookDelivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookFlushTimeout)
+	defer cancel()
+	if len(pending) > 0 {
+		logging.Warningf("webhook dispatcher is shutting down, %d pending deliveries saved for retry", len(pending))
+	}
+	now := time.Now().UTC()
+	for _, d := range pending {
+		b.retry(ctx, d, now)
+	}
+	b.deadLetterDropped(ctx)
+}
+
+// retry 保存投递，在 next 之后重试
+func (b *Webhook) retry(ctx context.Context, d *webhookDelivery, next time.Time) {
+	if err := b.ms.Webhook.SaveDelivery(ctx, d.saved(next)); err != nil {
+		logging.Errf("save webhook delivery %s error: %v", d.id, err)
+	}
+}
+
+// deadLetter 记录死信，并删除保存的投递
+func (b *Webhook) deadLetter(ctx context.Context, d *webhookDelivery) {
+	err := b.ms.Webhook.AddDeadLetter(ctx, d.tenant, d.webhook.UID, tpl.WebhookDeadLetter{
+		Type:      d.typ,
+		Payload:   d.body,
+		Error:     d.err,
+		Attempts:  d.attempts,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		logging.Errf("add webhook dead letter error: %v", err)
+		return
+	}
+	b.deleteDelivery(ctx, d)
+}
+
+func (b *Webhook) deleteDelivery(ctx context.Context, d *webhookDelivery) {
+	if d.uid == "" {
+		return
+	}
+	if err := b.ms.Webhook.DeleteDelivery(ctx, d.uid); err != nil {
+		logging.Errf("delete webhook delivery %s error: %v", d.id, err)
+	}
+}
+
+func (b *Webhook) work(ctx context.Context, client *http.Client, cfg conf.Webhook, deliveries <-chan *webhookDelivery,
+	strand func(ds ...*webhookDelivery)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-deliveries:
+			if ctx.Err() != nil {
+				strand(d)
+				return
+			}
+			d.attempts++
+			err := deliverWebhook(ctx, client, d)
+			if err == nil {
+				b.deleteDelivery(ctx, d)
+				continue
+			}
+			d.err = err.Error()
+			switch {
+			case ctx.Err() != nil:
+				// 服务关闭时取消的投递
+				strand(d)
+			case d.attempts < cfg.MaxAttempts:
+				b.retry(ctx, d, time.Now().UTC().Add(time.Duration(cfg.RetryWait)*time.Second<<uint(d.attempts-1)))
+			default:
+				logging.Warningf("webhook %s %s failed after %d attempts: %v", d.webhook.URL, d.typ, d.attempts, err)
+				b.deadLetter(ctx, d)
+			}
+		}
+	}
+}
+
+// newWebhookClient 创建投递 webhook 的 HTTP 客户端。建立连接前按解析后的地址检查 checkIP（为 nil 时为 tpl.CheckWebhookIP），
+// 重定向和 DNS 解析结果变化也无法连接到内部网络；不使用 HTTP 代理，否则检查的会是代理的地址
+func newWebhookClient(cfg conf.Webhook, checkIP func(ip net.IP) error) *http.Client {
+	if checkIP == nil {
+		checkIP = tpl.CheckWebhookIP
+	}
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("invalid webhook address %s", address)
+			}
+			return checkIP(ip)
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Transport: transport, Timeout: time.Duration(cfg.Timeout) * time.Second}
+}
+
+// deliverWebhook 投递一次事件，响应状态码不是 2xx 时返回错误。
+// X-OTAC-Signature 为 sha256= 加上以 secret 为密钥对 "{X-OTAC-Timestamp}.{请求体}" 的 HMAC-SHA256 签名的十六进制编码
+func deliverWebhook(ctx context.Context, client *http.Client, d *webhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.webhook.URL, bytes.NewReader(d.body))
+	if err != nil {
+		return err
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("User-Agent", "ot-ac-webhook")
+	req.Header.Set("X-OTAC-Event", d.typ)
+	req.Header.Set("X-OTAC-Delivery", d.id)
+	req.Header.Set("X-OTAC-Timestamp", ts)
+	req.Header.Set("X-OTAC-Signature", "sha256="+SignWebhook(d.webhook.Secret, ts, d.body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SignWebhook 返回 webhook 请求的签名，接收方应使用同样的方法验证 X-OTAC-Signature
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
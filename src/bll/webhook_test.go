@@ -0,0 +1,221 @@
+package bll
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/service/storage"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/open-trust/ot-ac/src/util"
+	otgo "github.com/open-trust/ot-go-lib"
+)
+
+func TestWebhookClient(t *testing.T) {
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer s.Close()
+
+	// httptest 监听在回环地址上，投递在建立连接前被拒绝
+	client := newWebhookClient(conf.Webhook{Timeout: 1}, nil)
+	d := &webhookDelivery{webhook: tpl.Webhook{URL: s.URL, Secret: "secret"}, id: "1", typ: "unit.added", body: []byte("{}")}
+	err := deliverWebhook(context.Background(), client, d)
+	if err == nil || !strings.Contains(err.Error(), "is not allowed") || requests != 0 {
+		t.Fatalf("deliver to %s got %v after %d requests", s.URL, err, requests)
+	}
+	if err := deliverWebhook(context.Background(), s.Client(), d); err != nil || requests != 1 {
+		t.Fatalf("deliver with the default client got %v after %d requests", err, requests)
+	}
+	// 替换地址检查后可以连接到回环地址
+	client = newWebhookClient(conf.Webhook{Timeout: 1}, func(ip net.IP) error { return nil })
+	if err := deliverWebhook(context.Background(), client, d); err != nil || requests != 2 {
+		t.Fatalf("deliver with an allow-all check got %v after %d requests", err, requests)
+	}
+
+	for _, u := range []string{"http://127.0.0.1/hook", "http://[::1]/hook", "http://10.1.2.3/hook", "http://172.16.0.1/hook",
+		"http://192.168.1.1/hook",
+		"http://100.64.0.1/hook", "http://192.0.0.1/hook", "http://169.254.169.254/latest", "http://0.0.0.0/hook", "http://[fd00::1]/hook",
+		"http://[::ffff:127.0.0.1]/hook", "http://224.0.0.1/hook", "http://localhost:8080/hook", "http://api.localhost./hook"} {
+		input := tpl.WebhookAddInput{Events: []string{"unit.added"}}
+		input.URL = u
+		if err := input.Validate(); err == nil || !strings.Contains(err.Error(), "not allowed") {
+			t.Fatalf("register %s got %v", u, err)
+		}
+		// 已注册的 webhook 仍然可以更新和删除
+		if err := (&tpl.WebhookInput{URL: u}).Validate(); err != nil {
+			t.Fatalf("delete %s got %v", u, err)
+		}
+	}
+	for _, u := range []string{"https://example.com/hook", "http://8.8.8.8/hook", "http://[2001:db8::1]/hook"} {
+		input := tpl.WebhookAddInput{Events: []string{"unit.added"}}
+		input.URL = u
+		if err := input.Validate(); err != nil {
+			t.Fatalf("register %s got %v", u, err)
+		}
+	}
+}
+
+// newTestTenant 连接 testing 配置中的 Dgraph 并添加测试租户，测试结束时删除租户及其名下的节点，Dgraph 不可用时跳过测试
+func newTestTenant(t *testing.T) (*model.Models, tpl.Tenant) {
+	dg, err := dgraph.NewDgraph()
+	if err != nil {
+		t.Skipf("dgraph is unavailable: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if _, err := dg.CheckHealth(ctx); err != nil {
+		t.Skipf("dgraph is unavailable: %v", err)
+	}
+	ctx = context.Background()
+	if err := dg.Migrate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	ms, err := model.NewModels(dg, storage.NewDgraph(dg))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := otgo.ParseOTID(fmt.Sprintf("otid:ot.example.com:app:test%d", time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ms.Tenant.Add(ctx, tpl.Tenant{Tenant: id.String()}); err != nil {
+		t.Fatal(err)
+	}
+	tenant, err := ms.Tenant.Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		tenant.Status = -1
+		if err := ms.Tenant.Update(ctx, *tenant); err != nil {
+			t.Error(err)
+			return
+		}
+		for _, kind := range model.TenantNodeKinds {
+			for {
+				n, err := ms.Tenant.DeleteNodes(ctx, tenant.UID, kind, 1000)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if n == 0 {
+					break
+				}
+			}
+		}
+		if err := ms.Tenant.Delete(ctx, id); err != nil {
+			t.Error(err)
+		}
+	})
+	return ms, *tenant
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	ms, tenant := newTestTenant(t)
+	ctx := context.Background()
+
+	// /flaky 前两次返回 500，/broken 总是返回 500，记录每次请求的 X-OTAC-Delivery
+	mu := new(sync.Mutex)
+	requests := make(map[string][]string)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		payload := tpl.WebhookPayload{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Error(err)
+		}
+		sig := "sha256=" + SignWebhook("secret", r.Header.Get("X-OTAC-Timestamp"), body)
+		if r.Header.Get("X-OTAC-Signature") != sig || r.Header.Get("X-OTAC-Event") != "unit.added" ||
+			payload.ID != r.Header.Get("X-OTAC-Delivery") || payload.Tenant != tenant.Tenant {
+			t.Errorf("unexpected request %v: %s", r.Header, body)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		requests[r.URL.Path] = append(requests[r.URL.Path], payload.ID)
+		if r.URL.Path == "/broken" || len(requests[r.URL.Path]) <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer s.Close()
+	for _, path := range []string{"/flaky", "/broken"} {
+		webhook := tpl.Webhook{URL: s.URL + path, Events: []string{"unit.added"}, Secret: "secret", CreatedAt: time.Now().UTC()}
+		if _, err := ms.Webhook.Add(ctx, tenant, webhook); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b := &Webhook{ms: ms, checkIP: func(ip net.IP) error { return nil }}
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	changes := ms.Watch.Subscribe(1000)
+	go func() {
+		defer close(done)
+		b.run(runCtx, conf.Webhook{Workers: 2, MaxAttempts: 3, RetryWait: 1, Timeout: 1}, changes)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	if err := ms.Unit.BatchAdd(ctx, tenant, []tpl.Target{{Type: "team", ID: "t1"}}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// 等待 /flaky 在第三次投递成功、/broken 重试后记为死信，并且不再有保存的投递
+	var dls []tpl.WebhookDeadLetter
+	for deadline := time.Now().Add(20 * time.Second); ; time.Sleep(100 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("requests %v, dead letters %v", requests, dls)
+		}
+		var err error
+		if dls, err = ms.Webhook.ListDeadLetters(ctx, tenant, "", 10, 0, ""); err != nil {
+			t.Fatal(err)
+		}
+		mu.Lock()
+		flaky := len(requests["/flaky"])
+		mu.Unlock()
+		if len(dls) == 0 || flaky < 3 {
+			continue
+		}
+		// 只取出本租户到期的投递，其它租户的投递被推迟一分钟
+		pending, err := ms.Webhook.ClaimDeliveries(ctx, time.Now().Add(time.Hour), time.Minute, 1000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !util.StringsHas(tenantsOf(pending), func(s string) bool { return s == tenant.Tenant }) {
+			break
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for path, ids := range requests {
+		if len(ids) != 3 || ids[0] != ids[1] || ids[1] != ids[2] {
+			t.Fatalf("%s got deliveries %v", path, ids)
+		}
+	}
+	if len(dls) != 1 || dls[0].URL != s.URL+"/broken" || dls[0].Attempts != 3 || dls[0].Error != "unexpected status 500" {
+		t.Fatalf("dead letters got %+v", dls)
+	}
+}
+
+func tenantsOf(ds []tpl.WebhookDelivery) []string {
+	res := make([]string, len(ds))
+	for i, d := range ds {
+		res[i] = d.Tenant
+	}
+	return res
+}
@@ -2,10 +2,12 @@ package conf
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
 
+	"github.com/open-trust/ot-ac/src/tpl"
 	"github.com/open-trust/ot-ac/src/util"
 	otgo "github.com/open-trust/ot-go-lib"
 	"github.com/teambition/gear"
@@ -57,6 +59,7 @@ func initOT(cfg *ConfigTpl) error {
 		TrustDomain: cfg.OpenTrust.OTID.TrustDomain(),
 		OTClient:    otgo.NewOTClient(GlobalContext, cfg.OpenTrust.OTID),
 	}
+	tpl.TrustDomain = OT.TrustDomain
 
 	if len(cfg.OpenTrust.PrivateKeys) > 0 {
 		privateKeys, err := otgo.ParseSet(cfg.OpenTrust.PrivateKeys...)
@@ -88,8 +91,82 @@ type Logger struct {
 
 // Dgraph ...
 type Dgraph struct {
-	Insecure     bool   `json:"insecure" yaml:"insecure"`
-	GRPCEndpoint string `json:"grpc_endpoint" yaml:"grpc_endpoint"`
+	Insecure     bool    `json:"insecure" yaml:"insecure"`
+	GRPCEndpoint string  `json:"grpc_endpoint" yaml:"grpc_endpoint"`
+	AutoMigrate  bool    `json:"auto_migrate" yaml:"auto_migrate"` // 启动时自动应用 schema 并执行数据迁移
+	Storage      Storage `json:"storage" yaml:"storage"`
+}
+
+// Storage 存储配置
+type Storage struct {
+	Driver string `json:"driver" yaml:"driver"` // 目前只支持 dgraph（默认）
+}
+
+// GrantSweeper 过期授权清理配置
+type GrantSweeper struct {
+	Interval int `json:"interval" yaml:"interval"` // 清理间隔，单位秒，0 表示不启用
+}
+
+// GraphQL GraphQL 读接口配置
+type GraphQL struct {
+	MaxDepth      int `json:"max_depth" yaml:"max_depth"`           // 最大查询深度，默认 8
+	MaxComplexity int `json:"max_complexity" yaml:"max_complexity"` // 最大查询复杂度，默认 10000
+}
+
+// Watch 变更日志配置
+type Watch struct {
+	History int `json:"history" yaml:"history"` // 每个租户至少保留的最近事件数，默认 1000
+}
+
+// Webhook webhook 投递配置
+type Webhook struct {
+	Workers     int `json:"workers" yaml:"workers"`           // 并发投递数，0 表示不投递
+	MaxAttempts int `json:"max_attempts" yaml:"max_attempts"` // 最多尝试次数，超过后记为死信，默认 5
+	RetryWait   int `json:"retry_wait" yaml:"retry_wait"`     // 首次重试的等待时间，单位秒，之后每次翻倍，默认 5
+	Timeout     int `json:"timeout" yaml:"timeout"`           // 单次投递的超时时间，单位秒，默认 10
+}
+
+// Audit 审计日志配置
+type Audit struct {
+	Sink string `json:"sink" yaml:"sink"` // graph（存储在 Dgraph 中）、file（JSON lines 文件）或 stdout，为空时不记录
+	Path string `json:"path" yaml:"path"` // file 的路径
+}
+
+// DecisionLog 权限检查决策日志配置
+type DecisionLog struct {
+	Sink       string             `json:"sink" yaml:"sink"`               // file（NDJSON 文件）或 stdout，为空时不记录
+	Path       string             `json:"path" yaml:"path"`               // file 的路径
+	Buffer     int                `json:"buffer" yaml:"buffer"`           // 内存中缓冲的记录数，写出跟不上时丢弃新的允许记录，拒绝和出错的记录直接写入，默认 10000
+	SampleRate *float64           `json:"sample_rate" yaml:"sample_rate"` // 允许结果的采样率，0 到 1，默认 1；拒绝和出错的结果总是记录
+	Tenants    map[string]float64 `json:"tenants" yaml:"tenants"`         // 租户 OTID 到允许结果采样率的映射，覆盖 sample_rate
+}
+
+// Metrics /metrics 指标配置
+type Metrics struct {
+	TenantTiers map[string]string `json:"tenant_tiers" yaml:"tenant_tiers"` // 租户 OTID 到指标 tier 标签的映射，未配置的租户为 default
+}
+
+// ExtAuthz Envoy ext_authz 适配器配置
+type ExtAuthz struct {
+	GRPCAddr         string         `json:"grpc_addr" yaml:"grpc_addr"`                   // ext_authz gRPC 服务地址，为空时不启动
+	HTTPAddr         string         `json:"http_addr" yaml:"http_addr"`                   // ext_authz HTTP 服务地址，为空时不启动
+	PathPrefix       string         `json:"path_prefix" yaml:"path_prefix"`               // 与 Envoy HTTP ext_authz 的 path_prefix 一致，匹配规则前去除
+	Tenant           otgo.OTID      `json:"tenant" yaml:"tenant"`                         // 权限检查所属的租户
+	SubjectHeader    string         `json:"subject_header" yaml:"subject_header"`         // 由 Envoy 验证后设置的请求主体 header
+	JWTPayloadHeader string         `json:"jwt_payload_header" yaml:"jwt_payload_header"` // Envoy jwt_authn 的 forward_payload_header
+	SubjectClaim     string         `json:"subject_claim" yaml:"subject_claim"`           // 请求主体所在的 JWT claim，默认为 sub
+	WithOrganization bool           `json:"with_organization" yaml:"with_organization"`
+	Rules            []ExtAuthzRule `json:"rules" yaml:"rules"`
+}
+
+// ExtAuthzRule 将请求映射为权限检查的规则，按顺序匹配第一条
+type ExtAuthzRule struct {
+	Path        string            `json:"path" yaml:"path"`               // 路径模板，如 /projects/{project}/docs/{doc}，{name} 匹配一段路径，* 匹配一段路径但不捕获
+	Check       string            `json:"check" yaml:"check"`             // object（默认）、scope 或 unit
+	TargetType  string            `json:"target_type" yaml:"target_type"` // 目标类型
+	TargetID    string            `json:"target_id" yaml:"target_id"`     // 目标 ID 模板，如 {doc}
+	Permissions map[string]string `json:"permissions" yaml:"permissions"` // HTTP 方法 -> 权限，* 匹配其它方法
+	IgnoreScope bool              `json:"ignore_scope" yaml:"ignore_scope"`
 }
 
 // OpenTrust ...
@@ -101,14 +178,23 @@ type OpenTrust struct {
 
 // ConfigTpl ...
 type ConfigTpl struct {
-	SrvAddr          string    `json:"addr" yaml:"addr"`
-	CertFile         string    `json:"cert_file" yaml:"cert_file"`
-	KeyFile          string    `json:"key_file" yaml:"key_file"`
-	TrustedProxy     bool      `json:"trusted_proxy" yaml:"trusted_proxy"`
-	ServiceEndpoints []string  `json:"service_endpoints" yaml:"service_endpoints"`
-	Logger           Logger    `json:"logger" yaml:"logger"`
-	Dgraph           Dgraph    `json:"dgraph" yaml:"dgraph"`
-	OpenTrust        OpenTrust `json:"open_trust" yaml:"open_trust"`
+	SrvAddr          string       `json:"addr" yaml:"addr"`
+	GRPCAddr         string       `json:"grpc_addr" yaml:"grpc_addr"` // gRPC 服务地址，为空时不启动 gRPC 服务
+	CertFile         string       `json:"cert_file" yaml:"cert_file"`
+	KeyFile          string       `json:"key_file" yaml:"key_file"`
+	TrustedProxy     bool         `json:"trusted_proxy" yaml:"trusted_proxy"`
+	ServiceEndpoints []string     `json:"service_endpoints" yaml:"service_endpoints"`
+	Logger           Logger       `json:"logger" yaml:"logger"`
+	Dgraph           Dgraph       `json:"dgraph" yaml:"dgraph"`
+	GrantSweeper     GrantSweeper `json:"grant_sweeper" yaml:"grant_sweeper"`
+	GraphQL          GraphQL      `json:"graphql" yaml:"graphql"`
+	Watch            Watch        `json:"watch" yaml:"watch"`
+	Webhook          Webhook      `json:"webhook" yaml:"webhook"`
+	Audit            Audit        `json:"audit" yaml:"audit"`
+	DecisionLog      DecisionLog  `json:"decision_log" yaml:"decision_log"`
+	Metrics          Metrics      `json:"metrics" yaml:"metrics"`
+	ExtAuthz         ExtAuthz     `json:"ext_authz" yaml:"ext_authz"`
+	OpenTrust        OpenTrust    `json:"open_trust" yaml:"open_trust"`
 }
 
 // Validate 用于完成基本的配置验证和初始化工作。业务相关的配置验证建议放到相关代码中实现，如 mysql 的配置。
@@ -117,6 +203,57 @@ func (c *ConfigTpl) Validate() error {
 	if err != nil {
 		return err
 	}
+	switch c.Audit.Sink {
+	case "", "graph", "stdout":
+	case "file":
+		if c.Audit.Path == "" {
+			return errors.New("audit.path required for file sink")
+		}
+	default:
+		return fmt.Errorf("invalid audit.sink %q", c.Audit.Sink)
+	}
+	switch c.DecisionLog.Sink {
+	case "", "stdout":
+	case "file":
+		if c.DecisionLog.Path == "" {
+			return errors.New("decision_log.path required for file sink")
+		}
+	default:
+		return fmt.Errorf("invalid decision_log.sink %q", c.DecisionLog.Sink)
+	}
+	if c.DecisionLog.Buffer <= 0 {
+		c.DecisionLog.Buffer = 10000
+	}
+	if c.DecisionLog.SampleRate == nil {
+		rate := 1.0
+		c.DecisionLog.SampleRate = &rate
+	}
+	if rate := *c.DecisionLog.SampleRate; rate < 0 || rate > 1 {
+		return fmt.Errorf("invalid decision_log.sample_rate %v", rate)
+	}
+	for tenant, rate := range c.DecisionLog.Tenants {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("invalid decision_log.tenants sample rate %v for %s", rate, tenant)
+		}
+	}
+	if c.GraphQL.MaxDepth <= 0 {
+		c.GraphQL.MaxDepth = 8
+	}
+	if c.GraphQL.MaxComplexity <= 0 {
+		c.GraphQL.MaxComplexity = 10000
+	}
+	if c.Watch.History <= 0 {
+		c.Watch.History = 1000
+	}
+	if c.Webhook.MaxAttempts <= 0 {
+		c.Webhook.MaxAttempts = 5
+	}
+	if c.Webhook.RetryWait <= 0 {
+		c.Webhook.RetryWait = 5
+	}
+	if c.Webhook.Timeout <= 0 {
+		c.Webhook.Timeout = 10
+	}
 	return nil
 }
 
@@ -0,0 +1,204 @@
+package extauthz
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/open-trust/ot-ac/src/util"
+	"github.com/teambition/gear"
+)
+
+func init() {
+	util.DigProvide(NewAuthorizer)
+}
+
+// 携带检查结果的 header，允许时添加到转发给上游服务的请求中，拒绝时添加到返回给客户端的响应中
+const (
+	HeaderSubject    = "X-OTAC-Subject"
+	HeaderPermission = "X-OTAC-Permission"
+	HeaderDetail     = "X-OTAC-Detail" // 允许时为 JSON 格式的 []tpl.ACPermissionPayload
+	HeaderReason     = "X-OTAC-Reason" // 拒绝的原因
+)
+
+// Request Envoy 转发的请求属性
+type Request struct {
+	Method string
+	Path   string // 可以包含查询字符串
+	Header http.Header
+}
+
+// Decision 权限检查的结果
+type Decision struct {
+	Allowed    bool
+	Status     int // 拒绝时返回给客户端的 HTTP 状态码
+	Reason     string
+	Subject    string
+	Permission string
+	Target     tpl.Target
+	Detail     []tpl.ACPermissionPayload
+}
+
+func deny(status int, format string, args ...interface{}) *Decision {
+	return &Decision{Status: status, Reason: fmt.Sprintf(format, args...)}
+}
+
+// Headers 返回携带检查结果的 header
+func (d *Decision) Headers() map[string]string {
+	if !d.Allowed {
+		return map[string]string{HeaderReason: d.Reason}
+	}
+	h := map[string]string{
+		HeaderSubject:    d.Subject,
+		HeaderPermission: d.Permission,
+	}
+	if d.Detail != nil {
+		data, _ := json.Marshal(d.Detail)
+		h[HeaderDetail] = string(data)
+	}
+	return h
+}
+
+// Authorizer 将 Envoy ext_authz 转发的请求属性按配置的规则映射为 AC.CheckObject、CheckScope 或 CheckUnit
+type Authorizer struct {
+	blls  *bll.Blls
+	cfg   conf.ExtAuthz
+	rules []*rule
+}
+
+// NewAuthorizer 根据 ext_authz 配置创建 Authorizer，规则无效时返回错误
+func NewAuthorizer(blls *bll.Blls) (*Authorizer, error) {
+	cfg := conf.Config.ExtAuthz
+	if err := cfg.Tenant.Validate(); err != nil {
+		return nil, fmt.Errorf("ext_authz.tenant: %v", err)
+	}
+	if cfg.SubjectHeader == "" && cfg.JWTPayloadHeader == "" {
+		return nil, errors.New("ext_authz: subject_header or jwt_payload_header required")
+	}
+	if cfg.SubjectClaim == "" {
+		cfg.SubjectClaim = "sub"
+	}
+
+	a := &Authorizer{blls: blls, cfg: cfg}
+	for i, r := range cfg.Rules {
+		cr, err := compileRule(r)
+		if err != nil {
+			return nil, fmt.Errorf("ext_authz.rules[%d]: %v", i, err)
+		}
+		a.rules = append(a.rules, cr)
+	}
+	return a, nil
+}
+
+// Check 检查请求，返回的错误表示无法完成检查（如存储不可用），而不是拒绝
+func (a *Authorizer) Check(ctx context.Context, req Request) (*Decision, error) {
+	path := req.Path
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	if err := checkPath(path); err != nil {
+		return deny(http.StatusBadRequest, "invalid path %s: %v", path, err), nil
+	}
+	path = strings.TrimPrefix(path, a.cfg.PathPrefix)
+
+	var r *rule
+	var vars map[string]string
+	for _, v := range a.rules {
+		if vars = v.match(path); vars != nil {
+			r = v
+			break
+		}
+	}
+	if r == nil {
+		return deny(http.StatusForbidden, "no rule matched %s", path), nil
+	}
+	permission := r.permission(req.Method)
+	if permission == "" {
+		return deny(http.StatusForbidden, "no permission for %s %s", req.Method, path), nil
+	}
+
+	subject, err := a.subject(req.Header)
+	if err != nil {
+		return deny(http.StatusUnauthorized, "invalid subject: %v", err), nil
+	}
+	target := r.target(vars)
+	if err := target.Validate(); err != nil {
+		return deny(http.StatusBadRequest, "invalid target: %v", err), nil
+	}
+
+	tenant, err := a.blls.Models.Tenant.Get(ctx, a.cfg.Tenant)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.Status < 0 {
+		return deny(http.StatusForbidden, "%s is forbidden", a.cfg.Tenant.String()), nil
+	}
+
+	// respond-detail 让检查返回授予权限的管理单元或资源对象，用于 X-OTAC-Detail
+	ctx = model.PreferContext(ctx, []string{"respond-detail"})
+	permissions := []string{permission}
+	var res *tpl.SuccessResponseType
+	switch r.Check {
+	case checkScope:
+		res, err = a.blls.AC.CheckScope(ctx, *tenant, subject, target, permissions, a.cfg.WithOrganization)
+	case checkUnit:
+		res, err = a.blls.AC.CheckUnit(ctx, *tenant, subject, target, permissions, a.cfg.WithOrganization)
+	default:
+		res, err = a.blls.AC.CheckObject(ctx, *tenant, subject, target, permissions, a.cfg.WithOrganization, r.IgnoreScope)
+	}
+	if err != nil {
+		// 目标不存在时拒绝请求，不暴露目标是否存在
+		if e := gear.ParseError(err); e.Status() == http.StatusNotFound {
+			return deny(http.StatusForbidden, "%s not allowed", permission), nil
+		}
+		return nil, err
+	}
+
+	d := &Decision{Subject: subject, Permission: permission, Target: target}
+	switch v := res.Result.(type) {
+	case bool:
+		d.Allowed = v
+	case []tpl.ACPermissionPayload:
+		d.Allowed = len(v) > 0
+		d.Detail = v
+	}
+	if !d.Allowed {
+		d.Status = http.StatusForbidden
+		d.Reason = permission + " not allowed"
+	}
+	return d, nil
+}
+
+// subject 从 subject_header 或 jwt_payload_header 的 JWT payload 中获取请求主体，
+// 这些 header 需要由 Envoy 验证后设置（如 jwt_authn 的 forward_payload_header），并在 Envoy 中移除客户端传入的同名 header
+func (a *Authorizer) subject(h http.Header) (string, error) {
+	sub := ""
+	if a.cfg.SubjectHeader != "" {
+		sub = h.Get(a.cfg.SubjectHeader)
+	}
+	if sub == "" && a.cfg.JWTPayloadHeader != "" {
+		if payload := h.Get(a.cfg.JWTPayloadHeader); payload != "" {
+			data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(payload, "="))
+			if err != nil {
+				return "", err
+			}
+			claims := make(map[string]interface{})
+			if err := json.Unmarshal(data, &claims); err != nil {
+				return "", err
+			}
+			sub, _ = claims[a.cfg.SubjectClaim].(string)
+		}
+	}
+	if err := tpl.CheckSubject(sub); err != nil {
+		return "", err
+	}
+	return sub, nil
+}
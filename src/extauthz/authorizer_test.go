@@ -0,0 +1,159 @@
+package extauthz
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/pb"
+	otgo "github.com/open-trust/ot-go-lib"
+	"google.golang.org/grpc/codes"
+)
+
+// testAuthorizer 创建不依赖存储的 Authorizer，只用于路径和请求主体被拒绝的请求
+func testAuthorizer(t *testing.T) *Authorizer {
+	t.Helper()
+	tenant, err := otgo.ParseOTID("otid:ot.example.com:app:tenant1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &Authorizer{cfg: conf.ExtAuthz{Tenant: tenant, SubjectHeader: "x-subject", PathPrefix: "/api"}}
+	for _, r := range []conf.ExtAuthzRule{
+		{Path: "/docs/{doc}", TargetType: "doc", TargetID: "{doc}", Permissions: map[string]string{"GET": "read", "*": "write"}},
+		{Path: "/admin/*", TargetType: "system", TargetID: "admin", Permissions: map[string]string{"*": "admin"}},
+	} {
+		cr, err := compileRule(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.rules = append(a.rules, cr)
+	}
+	return a
+}
+
+func TestRuleMatch(t *testing.T) {
+	r, err := compileRule(conf.ExtAuthzRule{
+		Path: "/projects/{project}/docs/*/{doc}", TargetType: "doc", TargetID: "{project}-{doc}",
+		Permissions: map[string]string{"get": "read"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vars := r.match("/projects/p1/docs/v1/d1")
+	if vars == nil || r.target(vars).ID != "p1-d1" || r.permission("GET") != "read" || r.permission("POST") != "" {
+		t.Fatalf("match got %v", vars)
+	}
+	for _, path := range []string{"/projects/p1/docs/v1", "/projects/p1/docs//d1", "/projects//docs/v1/d1", "/projects/p1/files/v1/d1"} {
+		if vars := r.match(path); vars != nil {
+			t.Fatalf("match %s got %v", path, vars)
+		}
+	}
+
+	if _, err := compileRule(conf.ExtAuthzRule{Path: "/docs/{doc}", TargetType: "doc", TargetID: "{id}",
+		Permissions: map[string]string{"*": "read"}}); err == nil {
+		t.Fatal("unknown variable in target_id should fail")
+	}
+}
+
+func TestCheckPath(t *testing.T) {
+	cases := []struct {
+		path  string
+		valid bool
+	}{
+		{"/docs/a", true},
+		{"/docs/a.b", true},
+		{"/docs/..a", true},
+		{"/docs/a%20b", true},
+		{"/docs/", true},
+		{"/docs/../admin/x", false},
+		{"/docs/./a", false},
+		{"/docs/..", false},
+		{"/docs//a", false},
+		{"/docs/a\\..\\b", false},
+		{"/docs/a%2Fb", false},
+		{"/docs/a%2fb", false},
+		{"/docs/%2e%2e/admin", false},
+		{"/docs/a%5Cb", false},
+		{"docs/a", false},
+	}
+	for _, c := range cases {
+		if err := checkPath(c.path); (err == nil) != c.valid {
+			t.Fatalf("checkPath(%q) got %v, want valid %v", c.path, err, c.valid)
+		}
+	}
+}
+
+// envoyRequest 构造 Envoy ext_authz 转发的 CheckRequest
+func envoyRequest(method, path string, headers map[string]string) *pb.ExtAuthzCheckRequest {
+	return &pb.ExtAuthzCheckRequest{
+		Attributes: &pb.ExtAuthzAttributeContext{
+			Request: &pb.ExtAuthzRequest{
+				Http: &pb.ExtAuthzHttpRequest{Method: method, Path: path, Headers: headers},
+			},
+		},
+	}
+}
+
+func TestGRPCCheck(t *testing.T) {
+	s := &grpcServer{a: testAuthorizer(t)}
+	cases := []struct {
+		method, path string
+		headers      map[string]string
+		status       int
+		reason       string
+	}{
+		// 匹配规则后因为缺少请求主体而拒绝，说明路径已匹配
+		{"GET", "/api/docs/a", nil, http.StatusUnauthorized, "invalid subject"},
+		{"GET", "/api/docs/a?next=/../admin/x", nil, http.StatusUnauthorized, "invalid subject"},
+		{"GET", "/api/docs/a", map[string]string{"x-subject": "invalid subject"}, http.StatusUnauthorized, "invalid subject"},
+		{"GET", "/api/files/a", nil, http.StatusForbidden, "no rule matched"},
+		{"GET", "/api/docs/../admin/x", nil, http.StatusBadRequest, "dot segment"},
+		{"GET", "/api/docs/%2e%2e/admin/x", nil, http.StatusBadRequest, "encoded separator"},
+		{"POST", "/api/docs/a%2F..%2Fb", nil, http.StatusBadRequest, "encoded separator"},
+		{"GET", "/api/docs//a", nil, http.StatusBadRequest, "empty segment"},
+	}
+	for _, c := range cases {
+		res, err := s.Check(context.Background(), envoyRequest(c.method, c.path, c.headers))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Status.Code != int32(codes.PermissionDenied) || res.DeniedResponse == nil || res.OkResponse != nil {
+			t.Fatalf("%s %s got %v", c.method, c.path, res)
+		}
+		if code := res.DeniedResponse.Status.Code; code != int32(c.status) || !strings.Contains(res.Status.Message, c.reason) {
+			t.Fatalf("%s %s got %d %s, want %d %s", c.method, c.path, code, res.Status.Message, c.status, c.reason)
+		}
+		reason := ""
+		for _, h := range res.DeniedResponse.Headers {
+			if h.Header.Key == HeaderReason {
+				reason = h.Header.Value
+			}
+		}
+		if reason != res.Status.Message {
+			t.Fatalf("%s %s got reason header %q", c.method, c.path, reason)
+		}
+	}
+}
+
+func TestHTTPCheck(t *testing.T) {
+	app := NewHTTPApp(testAuthorizer(t))
+	cases := []struct {
+		target string
+		status int
+	}{
+		{"/api/docs/a", http.StatusUnauthorized},
+		{"/api/docs/..%2Fadmin%2Fx", http.StatusBadRequest},
+		{"/api/docs/%2E%2E/admin/x", http.StatusBadRequest},
+		{"/api/admin/x", http.StatusUnauthorized},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest("GET", c.target, nil))
+		if w.Code != c.status || w.Header().Get(HeaderReason) == "" {
+			t.Fatalf("GET %s got %d %v", c.target, w.Code, w.Header())
+		}
+	}
+}
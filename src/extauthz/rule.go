@@ -0,0 +1,130 @@
+package extauthz
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+const (
+	checkObject = "object"
+	checkScope  = "scope"
+	checkUnit   = "unit"
+)
+
+var varReg = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+type rule struct {
+	conf.ExtAuthzRule
+	segments []string
+}
+
+func compileRule(r conf.ExtAuthzRule) (*rule, error) {
+	switch r.Check {
+	case "":
+		r.Check = checkObject
+	case checkObject, checkScope, checkUnit:
+	default:
+		return nil, fmt.Errorf("invalid check %q", r.Check)
+	}
+	if !strings.HasPrefix(r.Path, "/") {
+		return nil, fmt.Errorf("invalid path %q", r.Path)
+	}
+	if err := tpl.CheckResource(r.TargetType); err != nil {
+		return nil, err
+	}
+	if len(r.Permissions) == 0 {
+		return nil, errors.New("empty permissions")
+	}
+	permissions := make(map[string]string, len(r.Permissions))
+	for method, p := range r.Permissions {
+		if err := tpl.CheckPermission(p); err != nil {
+			return nil, err
+		}
+		permissions[strings.ToUpper(method)] = p
+	}
+	r.Permissions = permissions
+
+	cr := &rule{ExtAuthzRule: r, segments: strings.Split(r.Path, "/")[1:]}
+	vars := make(map[string]bool)
+	for _, s := range cr.segments {
+		if m := varReg.FindStringSubmatch(s); m != nil && m[0] == s {
+			vars[m[1]] = true
+		}
+	}
+	for _, m := range varReg.FindAllStringSubmatch(r.TargetID, -1) {
+		if !vars[m[1]] {
+			return nil, fmt.Errorf("unknown variable %q in target_id", m[1])
+		}
+	}
+	return cr, nil
+}
+
+// encodedSeparators 编码后的 /、\ 与 .，上游服务解码后可能改变路径的分段
+var encodedSeparators = []string{"%2f", "%5c", "%2e"}
+
+// checkPath 拒绝含点段（. 或 ..）、连续的 /、\ 以及编码的 /、\、. 的路径。
+// 上游服务可能规范化或解码路径，这类路径在上游看来是另一条路径，与匹配的规则不一致，因此不规范化而是直接拒绝
+func checkPath(path string) error {
+	if !strings.HasPrefix(path, "/") {
+		return errors.New("path must start with /")
+	}
+	if strings.Contains(path, "//") || strings.ContainsRune(path, '\\') {
+		return errors.New("empty segment or backslash")
+	}
+	lower := strings.ToLower(path)
+	for _, s := range encodedSeparators {
+		if strings.Contains(lower, s) {
+			return fmt.Errorf("encoded separator %s", strings.ToUpper(s))
+		}
+	}
+	for _, s := range strings.Split(path, "/")[1:] {
+		if s == "." || s == ".." {
+			return fmt.Errorf("dot segment %s", s)
+		}
+	}
+	return nil
+}
+
+// match 匹配路径，匹配时返回路径模板中的变量，路径需要先经过 checkPath 检查
+func (r *rule) match(path string) map[string]string {
+	segments := strings.Split(path, "/")[1:]
+	if len(segments) != len(r.segments) {
+		return nil
+	}
+	vars := make(map[string]string)
+	for i, s := range r.segments {
+		switch {
+		case s == "*":
+			if segments[i] == "" {
+				return nil
+			}
+		case strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}"):
+			if segments[i] == "" {
+				return nil
+			}
+			vars[s[1:len(s)-1]] = segments[i]
+		case s != segments[i]:
+			return nil
+		}
+	}
+	return vars
+}
+
+func (r *rule) permission(method string) string {
+	if p, ok := r.Permissions[strings.ToUpper(method)]; ok {
+		return p
+	}
+	return r.Permissions["*"]
+}
+
+func (r *rule) target(vars map[string]string) tpl.Target {
+	id := varReg.ReplaceAllStringFunc(r.TargetID, func(s string) string {
+		return vars[s[1:len(s)-1]]
+	})
+	return tpl.Target{Type: r.TargetType, ID: id}
+}
@@ -0,0 +1,147 @@
+package extauthz
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/logging"
+	"github.com/open-trust/ot-ac/src/pb"
+	"github.com/teambition/gear"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// timeout 单次检查的超时时间，与 HTTP 接口一致
+const timeout = time.Second * 5
+
+// authorizationServer envoy.service.auth.v3.Authorization 服务
+type authorizationServer interface {
+	Check(context.Context, *pb.ExtAuthzCheckRequest) (*pb.ExtAuthzCheckResponse, error)
+}
+
+func checkHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(pb.ExtAuthzCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(authorizationServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/envoy.service.auth.v3.Authorization/Check",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(authorizationServer).Check(ctx, req.(*pb.ExtAuthzCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// authorizationServiceDesc 以 Envoy 的服务名注册，pb.ExtAuthz* 消息与 Envoy 的消息在线路上兼容
+var authorizationServiceDesc = grpc.ServiceDesc{
+	ServiceName: "envoy.service.auth.v3.Authorization",
+	HandlerType: (*authorizationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Check",
+			Handler:    checkHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ext_authz.proto",
+}
+
+type grpcServer struct {
+	a *Authorizer
+}
+
+// Check 实现 Envoy ext_authz gRPC 接口，无法完成检查时返回错误，由 Envoy 的 failure_mode_allow 决定是否放行
+func (s *grpcServer) Check(ctx context.Context, req *pb.ExtAuthzCheckRequest) (*pb.ExtAuthzCheckResponse, error) {
+	r := Request{Header: http.Header{}}
+	if hr := req.GetAttributes().GetRequest().GetHttp(); hr != nil {
+		r.Method = hr.Method
+		r.Path = hr.Path
+		for k, v := range hr.Headers {
+			r.Header.Set(k, v)
+		}
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	d, err := s.a.Check(ctx, r)
+	if err != nil {
+		logging.Errf("ext_authz check error: %v", err)
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	var headers []*pb.ExtAuthzHeaderValueOption
+	for k, v := range d.Headers() {
+		headers = append(headers, &pb.ExtAuthzHeaderValueOption{
+			Header: &pb.ExtAuthzHeaderValue{Key: k, Value: v},
+		})
+	}
+	meta, err := structpb.NewStruct(map[string]interface{}{
+		"allowed":    d.Allowed,
+		"subject":    d.Subject,
+		"permission": d.Permission,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &pb.ExtAuthzCheckResponse{DynamicMetadata: meta}
+	if d.Allowed {
+		res.Status = &pb.ExtAuthzStatus{Code: int32(codes.OK)}
+		res.OkResponse = &pb.ExtAuthzOkHttpResponse{Headers: headers}
+	} else {
+		res.Status = &pb.ExtAuthzStatus{Code: int32(codes.PermissionDenied), Message: d.Reason}
+		res.DeniedResponse = &pb.ExtAuthzDeniedHttpResponse{
+			Status:  &pb.ExtAuthzHttpStatus{Code: int32(d.Status)},
+			Headers: headers,
+		}
+	}
+	return res, nil
+}
+
+// NewGRPCServer 创建 Envoy ext_authz gRPC 服务，该服务不验证调用方身份，只应该对 Envoy 开放
+func NewGRPCServer(a *Authorizer) *grpc.Server {
+	srv := grpc.NewServer()
+	srv.RegisterService(&authorizationServiceDesc, &grpcServer{a: a})
+	return srv
+}
+
+// NewHTTPApp 创建 Envoy ext_authz HTTP 服务：允许时响应 200，拒绝时响应 4xx，
+// 检查结果通过 X-OTAC-* header 返回，需要在 Envoy 的 allowed_upstream_headers/allowed_client_headers 中配置
+func NewHTTPApp(a *Authorizer) *gear.App {
+	app := gear.New()
+	app.Set(gear.SetEnv, conf.AppEnv)
+	app.Set(gear.SetLogger, log.New(gear.DefaultFilterWriter(), "", 0))
+	app.Set(gear.SetTimeout, timeout)
+	app.Set(gear.SetRenderError, gear.RenderErrorResponse)
+	if app.Env() != "testing" {
+		app.Use(logging.WithAccessLogger)
+	}
+	app.Use(func(ctx *gear.Context) error {
+		d, err := a.Check(ctx, Request{Method: ctx.Method, Path: ctx.Req.URL.RequestURI(), Header: ctx.Req.Header})
+		if err != nil {
+			logging.Errf("ext_authz check error: %v", err)
+			return gear.ErrServiceUnavailable.From(err)
+		}
+		for k, v := range d.Headers() {
+			ctx.SetHeader(k, v)
+		}
+		if d.Allowed {
+			return ctx.End(http.StatusOK)
+		}
+		return ctx.JSON(d.Status, gear.Err.WithCode(d.Status).WithMsg(d.Reason))
+	})
+	return app
+}
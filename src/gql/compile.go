@@ -0,0 +1,617 @@
+package gql
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/open-trust/ot-ac/src/util"
+)
+
+const (
+	defaultFirst = 10   // 列表字段未指定 first 时返回的数量，与分页接口的默认 pageSize 一致
+	maxFirst     = 1000 // 列表字段 first 的最大值
+)
+
+// Limits 查询的深度和复杂度限制。深度为嵌套的对象字段层数，根字段为第 1 层；
+// 复杂度为每个字段计 1，列表字段的子字段按 first 倍计算，用于估算查询可能返回的节点数
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// Error GraphQL 错误
+type Error struct {
+	Message   string     `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+}
+
+// Response GraphQL 响应，编译失败时没有 data
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Plan 编译后的查询，包含 DQL 和整理查询结果所需的信息
+type Plan struct {
+	query string
+	vars  map[string]string
+	roots []*plan
+}
+
+// DQL 返回编译生成的 DQL 查询和查询变量
+func (p *Plan) DQL() (string, map[string]string) {
+	return p.query, p.vars
+}
+
+// plan 一个响应字段，alias 为 DQL 中的别名，由编译器生成，不包含用户输入
+type plan struct {
+	key      string
+	alias    string
+	typename string // __typename 字段的值
+	facet    bool
+	object   bool
+	list     bool
+	children []*plan
+}
+
+type compiler struct {
+	doc        *document
+	op         *operation
+	tenant     tpl.Tenant
+	limits     Limits
+	vars       map[string]interface{}
+	q          *dgraph.Query
+	tenantUID  dgraph.DQL
+	n          int
+	fragments  map[string]bool // 正在展开的片段，用于检测循环引用
+	complexity int
+}
+
+// Compile 解析并校验 GraphQL 查询，编译为在 tenant 范围内执行的 DQL
+func Compile(input tpl.GraphQLInput, tenant tpl.Tenant, limits Limits) (p *Plan, err *Error) {
+	doc, e := parseDocument(input.Query)
+	if e != nil {
+		return nil, toError(input.Query, e)
+	}
+	c := &compiler{
+		doc:       doc,
+		tenant:    tenant,
+		limits:    limits,
+		q:         dgraph.NewQuery(),
+		fragments: make(map[string]bool),
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			pe, ok := r.(*parseError)
+			if !ok {
+				panic(r)
+			}
+			p, err = nil, toError(input.Query, pe)
+		}
+	}()
+	return c.compile(input.OperationName, input.Variables), nil
+}
+
+func toError(src string, e error) *Error {
+	err := &Error{Message: e.Error()}
+	if pe, ok := e.(*parseError); ok {
+		err.Locations = []Location{location(src, pe.pos)}
+	}
+	return err
+}
+
+func (c *compiler) fail(pos int, format string, args ...interface{}) {
+	panic(errorf(pos, format, args...))
+}
+
+// alias 生成查询内唯一的别名或变量名，如 f1、r2，prefix 只能来自代码
+func (c *compiler) alias(prefix string) dgraph.DQL {
+	c.n++
+	return dgraph.Sprintf("%s%s", dgraph.Predicate(prefix), dgraph.Index(c.n))
+}
+
+func (c *compiler) compile(operationName string, variables map[string]interface{}) *Plan {
+	var op *operation
+	for _, o := range c.doc.operations {
+		if operationName == "" || o.name == operationName {
+			if op != nil {
+				c.fail(o.pos, "operationName required when the query contains multiple operations")
+			}
+			op = o
+		}
+	}
+	if op == nil {
+		c.fail(0, "unknown operation %q", operationName)
+	}
+	if op.kind != "query" {
+		c.fail(op.pos, "%s is not supported, the GraphQL API is read-only", op.kind)
+	}
+	if len(op.directives) > 0 {
+		c.fail(op.directives[0].pos, "unknown directive @%s", op.directives[0].name)
+	}
+	c.op = op
+	c.coerceVariables(op, variables)
+	c.tenantUID = c.q.UID(c.tenant.UID)
+
+	var blocks []dgraph.DQL
+	var roots []*plan
+	for _, g := range c.collect(rootTypeName, op.selections, nil) {
+		block, p := c.compileRoot(g)
+		if !block.IsZero() {
+			blocks = append(blocks, block)
+		}
+		roots = append(roots, p)
+	}
+	if c.limits.MaxComplexity > 0 && c.complexity > c.limits.MaxComplexity {
+		c.fail(op.pos, "query complexity exceeds %d", c.limits.MaxComplexity)
+	}
+
+	query, vars := c.q.Build(dgraph.Join(blocks, "\n"))
+	return &Plan{query: query, vars: vars, roots: roots}
+}
+
+// coerceVariables 按变量定义校验并转换请求中的变量，只支持标量类型
+func (c *compiler) coerceVariables(op *operation, variables map[string]interface{}) {
+	c.vars = make(map[string]interface{}, len(op.vars))
+	for _, v := range op.vars {
+		if _, ok := c.vars[v.name]; ok {
+			c.fail(v.pos, "there can be only one variable named $%s", v.name)
+		}
+		if v.typ.elem != nil || !inputTypes[v.typ.name] {
+			c.fail(v.pos, "variable $%s: unsupported type %s", v.name, v.typ.String())
+		}
+		raw, ok := variables[v.name]
+		var val interface{}
+		switch {
+		case ok && raw != nil:
+			val = c.coerceJSON(v, raw)
+		case !ok && v.def != nil:
+			val = c.literal(v.def, v.typ.name, "$"+v.name)
+		}
+		if val == nil && v.typ.nonNull {
+			c.fail(v.pos, "variable $%s of required type %s was not provided", v.name, v.typ.String())
+		}
+		c.vars[v.name] = val
+	}
+}
+
+var inputTypes = map[string]bool{"Int": true, "String": true, "Boolean": true, "ID": true}
+
+func (c *compiler) coerceJSON(v *varDef, raw interface{}) interface{} {
+	switch v.typ.name {
+	case "Int":
+		if f, ok := raw.(float64); ok && f == math.Trunc(f) && math.Abs(f) <= math.MaxInt32 {
+			return int(f)
+		}
+	case "Boolean":
+		if b, ok := raw.(bool); ok {
+			return b
+		}
+	case "String":
+		if s, ok := raw.(string); ok {
+			return s
+		}
+	case "ID":
+		switch x := raw.(type) {
+		case string:
+			return x
+		case float64:
+			if x == math.Trunc(x) {
+				return strconv.FormatInt(int64(x), 10)
+			}
+		}
+	}
+	c.fail(v.pos, "variable $%s: invalid value for type %s", v.name, v.typ.String())
+	return nil
+}
+
+// literal 转换查询中的字面量
+func (c *compiler) literal(v *value, typ, name string) interface{} {
+	if v.kind == valNull {
+		return nil
+	}
+	switch typ {
+	case "Int":
+		if v.kind == valInt {
+			i, err := strconv.ParseInt(v.raw, 10, 32)
+			if err == nil {
+				return int(i)
+			}
+		}
+	case "Boolean":
+		if v.kind == valBoolean {
+			return v.raw == "true"
+		}
+	case "String":
+		if v.kind == valString {
+			return v.raw
+		}
+	case "ID":
+		if v.kind == valString || v.kind == valInt {
+			return v.raw
+		}
+	}
+	c.fail(v.pos, "%s: expected type %s", name, typ)
+	return nil
+}
+
+// args 校验字段参数并返回参数值，defs 为参数名到类型的映射，类型以 ! 结尾表示必填
+func (c *compiler) args(f *fieldNode, defs map[string]string) map[string]interface{} {
+	res := make(map[string]interface{}, len(f.args))
+	for _, a := range f.args {
+		typ, ok := defs[a.name]
+		if !ok {
+			c.fail(a.pos, "unknown argument %q on field %q", a.name, f.name)
+		}
+		if _, ok := res[a.name]; ok {
+			c.fail(a.pos, "there can be only one argument named %q", a.name)
+		}
+		res[a.name] = c.argValue(a, trimNonNull(typ))
+	}
+	for name, typ := range defs {
+		if typ[len(typ)-1] == '!' && res[name] == nil {
+			c.fail(f.pos, "argument %q of type %s is required on field %q", name, typ, f.name)
+		}
+	}
+	return res
+}
+
+func trimNonNull(typ string) string {
+	if typ[len(typ)-1] == '!' {
+		return typ[:len(typ)-1]
+	}
+	return typ
+}
+
+func (c *compiler) argValue(a *argument, typ string) interface{} {
+	if a.val.kind != valVariable {
+		return c.literal(a.val, typ, "argument "+strconv.Quote(a.name))
+	}
+	val, ok := c.vars[a.val.raw]
+	if !ok {
+		c.fail(a.val.pos, "variable $%s is not defined", a.val.raw)
+	}
+	for _, d := range c.op.vars {
+		if d.name == a.val.raw && d.typ.name != typ && !(typ == "String" && d.typ.name == "ID") {
+			c.fail(a.val.pos, "variable $%s of type %s used in position expecting %s", d.name, d.typ.String(), typ)
+		}
+	}
+	return val
+}
+
+// included 处理 @skip 和 @include
+func (c *compiler) included(ds []*directive) bool {
+	for _, d := range ds {
+		var cond bool
+		switch d.name {
+		case "skip", "include":
+			if len(d.args) != 1 || d.args[0].name != "if" {
+				c.fail(d.pos, "directive @%s requires the argument \"if\"", d.name)
+			}
+			v := c.argValue(d.args[0], "Boolean")
+			b, ok := v.(bool)
+			if !ok {
+				c.fail(d.pos, "directive @%s: \"if\" must be a Boolean", d.name)
+			}
+			cond = b
+		default:
+			c.fail(d.pos, "unknown directive @%s", d.name)
+		}
+		if (d.name == "skip") == cond {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldGroup 响应中同一个 key 的字段，子选择集会被合并
+type fieldGroup struct {
+	key   string
+	nodes []*fieldNode
+}
+
+// collect 展开片段并按响应 key 合并字段
+func (c *compiler) collect(typeName string, ss []selection, groups []*fieldGroup) []*fieldGroup {
+	for _, s := range ss {
+		switch s := s.(type) {
+		case *fieldNode:
+			if !c.included(s.directives) {
+				continue
+			}
+			var g *fieldGroup
+			for _, v := range groups {
+				if v.key == s.key() {
+					g = v
+					break
+				}
+			}
+			if g == nil {
+				g = &fieldGroup{key: s.key()}
+				groups = append(groups, g)
+			} else if g.nodes[0].name != s.name {
+				c.fail(s.pos, "fields %q conflict because %s and %s are different fields", s.key(), g.nodes[0].name, s.name)
+			}
+			g.nodes = append(g.nodes, s)
+		case *inlineFragment:
+			if !c.included(s.directives) {
+				continue
+			}
+			if s.on != "" && s.on != typeName {
+				c.fail(s.pos, "fragment cannot be spread here as objects of type %q can never be of type %q", typeName, s.on)
+			}
+			groups = c.collect(typeName, s.selections, groups)
+		case *fragmentSpread:
+			if !c.included(s.directives) {
+				continue
+			}
+			f, ok := c.doc.fragments[s.name]
+			if !ok {
+				c.fail(s.pos, "unknown fragment %q", s.name)
+			}
+			if c.fragments[s.name] {
+				c.fail(s.pos, "cannot spread fragment %q within itself", s.name)
+			}
+			if f.on != typeName {
+				c.fail(s.pos, "fragment %q cannot be spread here as objects of type %q can never be of type %q", s.name, typeName, f.on)
+			}
+			if !c.included(f.directives) {
+				continue
+			}
+			c.fragments[s.name] = true
+			groups = c.collect(typeName, f.selections, groups)
+			delete(c.fragments, s.name)
+		}
+	}
+	return groups
+}
+
+func (c *compiler) subSelections(g *fieldGroup) []selection {
+	var ss []selection
+	for _, n := range g.nodes {
+		ss = append(ss, n.selections...)
+	}
+	return ss
+}
+
+// page 读取列表字段的 first 和 offset 参数
+func (c *compiler) page(f *fieldNode, args map[string]interface{}) (int, int) {
+	first, offset := defaultFirst, 0
+	if v, ok := args["first"].(int); ok {
+		if v < 1 || v > maxFirst {
+			c.fail(f.pos, "argument \"first\" must be between 1 and %d", maxFirst)
+		}
+		first = v
+	}
+	if v, ok := args["offset"].(int); ok {
+		if v < 0 {
+			c.fail(f.pos, "argument \"offset\" must not be negative")
+		}
+		offset = v
+	}
+	return first, offset
+}
+
+// addCost 累加复杂度，超出限制后不再增长以避免溢出
+func addCost(a, b, limit int) int {
+	if limit > 0 && (a > limit || b > limit || a+b > limit) {
+		return limit + 1
+	}
+	return a + b
+}
+
+func mulCost(n, cost, limit int) int {
+	if limit > 0 && cost > 0 && n > (limit+1)/cost {
+		return limit + 1
+	}
+	return n * cost
+}
+
+// compileFields 编译对象类型的选择集，facets 不为 nil 时表示上级边带有 facets，notBefore/notAfter 字段从中读取
+func (c *compiler) compileFields(t *objectType, groups []*fieldGroup, depth int, facets *[]dgraph.DQL) ([]dgraph.DQL, []*plan, int) {
+	// 总是查询 uid，使只选择了 __typename 的对象也能出现在结果中
+	parts := []dgraph.DQL{dgraph.Sprintf("uid")}
+	var plans []*plan
+	cost := 0
+	limit := c.limits.MaxComplexity
+	for _, g := range groups {
+		n := g.nodes[0]
+		p := &plan{key: g.key}
+		plans = append(plans, p)
+		if n.name == "__typename" {
+			c.args(n, nil)
+			p.typename = t.Name
+			continue
+		}
+		f := t.field(n.name)
+		if f == nil || hiddenFields[t.Name+"."+n.name] {
+			c.fail(n.pos, "cannot query field %q on type %q", n.name, t.Name)
+		}
+
+		if scalarTypes[f.Type] {
+			c.args(n, nil)
+			if len(n.selections) > 0 {
+				c.fail(n.pos, "field %q must not have a selection since type %q has no subfields", n.name, f.Type)
+			}
+			cost = addCost(cost, 1, limit)
+			switch {
+			case f.Facet != "":
+				p.facet = true
+				if facets != nil {
+					alias := c.alias("f")
+					p.alias = alias.String()
+					*facets = append(*facets, dgraph.Sprintf("%s: %s", alias, dgraph.Predicate(f.Facet)))
+				}
+			case f.Name == "id":
+				alias := c.alias("f")
+				p.alias = alias.String()
+				parts = append(parts, dgraph.Sprintf("%s : uid", alias))
+			default:
+				alias := c.alias("f")
+				p.alias = alias.String()
+				parts = append(parts, dgraph.Sprintf("%s : %s", alias, dgraph.Predicate(f.Pred)))
+			}
+			continue
+		}
+
+		target := types[f.Type]
+		if target == nil {
+			c.fail(n.pos, "cannot query field %q on type %q", n.name, t.Name)
+		}
+		if len(n.selections) == 0 {
+			c.fail(n.pos, "field %q of type %q must have a selection of subfields", n.name, f.Type)
+		}
+		if c.limits.MaxDepth > 0 && depth+1 > c.limits.MaxDepth {
+			c.fail(n.pos, "query depth exceeds %d", c.limits.MaxDepth)
+		}
+
+		alias := c.alias("f")
+		p.alias = alias.String()
+		p.object = true
+		p.list = f.List
+		edge := dgraph.Sprintf("%s : %s", alias, dgraph.Predicate(f.Pred))
+		times := 1
+		if f.List {
+			first, offset := c.page(n, c.args(n, map[string]string{"first": "Int", "offset": "Int"}))
+			times = first
+			edge = dgraph.Sprintf("%s (first: %s, offset: %s)", edge, c.q.Int(first), c.q.Int(offset))
+		} else {
+			c.args(n, nil)
+		}
+		// 租户数据通过 OTAC.*-T 边限定在请求者的租户内
+		if target.TenantPred != "" {
+			edge = dgraph.Sprintf("%s @filter(uid_in(%s, %s))", edge, dgraph.Predicate(target.TenantPred), c.tenantUID)
+		}
+
+		var edgeFacets []dgraph.DQL
+		var childFacets *[]dgraph.DQL
+		if f.Facets {
+			childFacets = &edgeFacets
+		}
+		children, childPlans, childCost := c.compileFields(target, c.collect(target.Name, c.subSelections(g), nil), depth+1, childFacets)
+		if len(edgeFacets) > 0 {
+			edge = dgraph.Sprintf("%s @facets(%s)", edge, dgraph.Join(edgeFacets, ", "))
+		}
+		p.children = childPlans
+		parts = append(parts, dgraph.Sprintf("%s {\n%s\n}", edge, dgraph.Join(children, "\n")))
+		cost = addCost(cost, addCost(1, mulCost(times, childCost, limit), limit), limit)
+	}
+	return parts, plans, cost
+}
+
+// rootTypeName 查询根类型
+const rootTypeName = "Query"
+
+// rootField 查询根字段
+type rootField struct {
+	typ  string
+	list bool
+	args map[string]string
+}
+
+// rootFields 查询的入口，列表字段支持 first 和 offset 参数，root 为 true 时只返回没有上级的管理单元或资源对象
+var rootFields = map[string]*rootField{
+	"tenant":      {typ: "OTACTenant"},
+	"unit":        {typ: "OTACUnit", args: map[string]string{"targetType": "String!", "targetId": "String!"}},
+	"units":       {typ: "OTACUnit", list: true, args: map[string]string{"targetType": "String", "root": "Boolean", "first": "Int", "offset": "Int"}},
+	"object":      {typ: "OTACObject", args: map[string]string{"targetType": "String!", "targetId": "String!"}},
+	"objects":     {typ: "OTACObject", list: true, args: map[string]string{"targetType": "String", "root": "Boolean", "first": "Int", "offset": "Int"}},
+	"scope":       {typ: "OTACScope", args: map[string]string{"targetType": "String!", "targetId": "String!"}},
+	"scopes":      {typ: "OTACScope", list: true, args: map[string]string{"targetType": "String", "first": "Int", "offset": "Int"}},
+	"permission":  {typ: "OTACPermission", args: map[string]string{"permission": "String!"}},
+	"permissions": {typ: "OTACPermission", list: true, args: map[string]string{"first": "Int", "offset": "Int"}},
+	"role":        {typ: "OTACRole", args: map[string]string{"role": "String!"}},
+	"roles":       {typ: "OTACRole", list: true, args: map[string]string{"first": "Int", "offset": "Int"}},
+	"subject":     {typ: "OTACSubject", args: map[string]string{"subject": "String!"}},
+}
+
+// 各类型的唯一键、目标类型与上级谓词，用于根字段查询
+var rootPreds = map[string]struct{ uk, targetType, parent string }{
+	"OTACUnit":       {"OTAC.U.UK", "OTAC.UType", "OTAC.U-Us"},
+	"OTACObject":     {"OTAC.O.UK", "OTAC.OType", "OTAC.O-Os"},
+	"OTACScope":      {"OTAC.Sc.UK", "OTAC.ScType", ""},
+	"OTACPermission": {"OTAC.P.UK", "", ""},
+	"OTACRole":       {"OTAC.R.UK", "", ""},
+}
+
+func (c *compiler) compileRoot(g *fieldGroup) (dgraph.DQL, *plan) {
+	n := g.nodes[0]
+	p := &plan{key: g.key}
+	if n.name == "__typename" {
+		c.args(n, nil)
+		p.typename = rootTypeName
+		return dgraph.DQL{}, p
+	}
+	if n.name == "__schema" || n.name == "__type" {
+		c.fail(n.pos, "introspection is not supported, see graphql/schema.graphql")
+	}
+	rf := rootFields[n.name]
+	if rf == nil {
+		c.fail(n.pos, "cannot query field %q on type %q", n.name, rootTypeName)
+	}
+	if len(n.selections) == 0 {
+		c.fail(n.pos, "field %q of type %q must have a selection of subfields", n.name, rf.typ)
+	}
+	args := c.args(n, rf.args)
+	t := types[rf.typ]
+	alias := c.alias("f")
+	p.alias = alias.String()
+	p.object = true
+	p.list = rf.list
+
+	children, childPlans, childCost := c.compileFields(t, c.collect(t.Name, c.subSelections(g), nil), 1, nil)
+	p.children = childPlans
+	body := dgraph.Join(children, "\n")
+	limit := c.limits.MaxComplexity
+	preds := rootPreds[rf.typ]
+
+	var block dgraph.DQL
+	switch {
+	case n.name == "tenant":
+		block = dgraph.Sprintf("%s(func: uid(%s)) {\n%s\n}", alias, c.tenantUID, body)
+		c.complexity = addCost(c.complexity, addCost(1, childCost, limit), limit)
+
+	case n.name == "subject":
+		// 请求主体不属于租户，只返回租户内管理单元关联的请求主体
+		sub := c.q.Str(args["subject"].(string))
+		units, subjects := c.alias("r"), c.alias("r")
+		block = dgraph.Sprintf("var(func: eq(OTAC.Sub, %s)) {\n%s as ~OTAC.U-Ss @filter(uid_in(OTAC.U-T, %s))\n}\nvar(func: uid(%s)) {\n%s as OTAC.U-Ss @filter(eq(OTAC.Sub, %s))\n}\n%s(func: uid(%s), first: 1) {\n%s\n}",
+			sub, units, c.tenantUID, units, subjects, sub, alias, subjects, body)
+		c.complexity = addCost(c.complexity, addCost(1, childCost, limit), limit)
+
+	case rf.list:
+		first, offset := c.page(n, args)
+		// 从租户节点沿反向的 OTAC.*-T 边获取租户的节点
+		nodes := c.alias("r")
+		var filters []dgraph.DQL
+		if tt, ok := args["targetType"].(string); ok && preds.targetType != "" {
+			filters = append(filters, dgraph.Sprintf("eq(%s, %s)", dgraph.Predicate(preds.targetType), c.q.Str(tt)))
+		}
+		if root, ok := args["root"].(bool); ok && root && preds.parent != "" {
+			filters = append(filters, dgraph.Sprintf("NOT has(%s)", dgraph.Predicate(preds.parent)))
+		}
+		filter := dgraph.DQL{}
+		if len(filters) > 0 {
+			filter = dgraph.Sprintf(" @filter(%s)", dgraph.Join(filters, " AND "))
+		}
+		block = dgraph.Sprintf("var(func: uid(%s)) {\n%s as ~%s\n}\n%s(func: uid(%s), first: %s, offset: %s)%s {\n%s\n}",
+			c.tenantUID, nodes, dgraph.Predicate(t.TenantPred), alias, nodes, c.q.Int(first), c.q.Int(offset), filter, body)
+		c.complexity = addCost(c.complexity, addCost(1, mulCost(first, childCost, limit), limit), limit)
+
+	default:
+		var uk string
+		switch n.name {
+		case "permission":
+			uk = util.HashBase64(c.tenant.Tenant, args["permission"].(string))
+		case "role":
+			uk = util.HashBase64(c.tenant.Tenant, args["role"].(string))
+		default:
+			uk = util.HashBase64(c.tenant.Tenant, args["targetType"].(string), args["targetId"].(string))
+		}
+		block = dgraph.Sprintf("%s(func: eq(%s, %s), first: 1) @filter(uid_in(%s, %s)) {\n%s\n}",
+			alias, dgraph.Predicate(preds.uk), c.q.Str(uk), dgraph.Predicate(t.TenantPred), c.tenantUID, body)
+		c.complexity = addCost(c.complexity, addCost(1, childCost, limit), limit)
+	}
+	return block, p
+}
@@ -0,0 +1,265 @@
+package gql
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/open-trust/ot-ac/src/util"
+)
+
+var (
+	testTenant = tpl.Tenant{UID: "0x1", Tenant: "otid:ot.example.com:app:t1"}
+	testLimits = Limits{MaxDepth: 8, MaxComplexity: 10000}
+)
+
+func compile(t *testing.T, query string, variables map[string]interface{}) (string, map[string]string) {
+	t.Helper()
+	p, err := Compile(tpl.GraphQLInput{Query: query, Variables: variables}, testTenant, testLimits)
+	if err != nil {
+		t.Fatalf("Compile(%q) got %s", query, err.Message)
+	}
+	return p.DQL()
+}
+
+func TestCompileDQL(t *testing.T) {
+	cases := []struct {
+		query string
+		vars  map[string]interface{}
+		dql   string
+		want  map[string]string
+	}{
+		{
+			query: `{ unit(targetType: "team", targetId: "t1") { targetId hasSubjects(first: 2) { subject notAfter } } }`,
+			dql: `query q($v0: string, $v1: int, $v2: int, $v3: string) {
+f1(func: eq(OTAC.U.UK, $v3), first: 1) @filter(uid_in(OTAC.U-T, $v0)) {
+uid
+f2 : OTAC.UId
+f3 : OTAC.U-Ss (first: $v1, offset: $v2) @facets(f5: notAfter) {
+uid
+f4 : OTAC.Sub
+}
+}
+}`,
+			want: map[string]string{"$v0": "0x1", "$v1": "2", "$v2": "0", "$v3": util.HashBase64(testTenant.Tenant, "team", "t1")},
+		},
+		{
+			query: `query($n: Int = 5, $root: Boolean!) { units(first: $n, root: $root, targetType: "team") { id __typename } tenant { tenant } }`,
+			vars:  map[string]interface{}{"root": true},
+			dql: `query q($v0: string, $v1: string, $v2: int, $v3: int) {
+var(func: uid($v0)) {
+r3 as ~OTAC.U-T
+}
+f1(func: uid(r3), first: $v2, offset: $v3) @filter(eq(OTAC.UType, $v1) AND NOT has(OTAC.U-Us)) {
+uid
+f2 : uid
+}
+f4(func: uid($v0)) {
+uid
+f5 : OTAC.T
+}
+}`,
+			want: map[string]string{"$v0": "0x1", "$v1": "team", "$v2": "5", "$v3": "0"},
+		},
+		{
+			query: `{ subject(subject: "user:1") { subject joinedUnits(offset: 10) { targetId } } }`,
+			dql: `query q($v0: string, $v1: int, $v2: int, $v3: string) {
+var(func: eq(OTAC.Sub, $v3)) {
+r5 as ~OTAC.U-Ss @filter(uid_in(OTAC.U-T, $v0))
+}
+var(func: uid(r5)) {
+r6 as OTAC.U-Ss @filter(eq(OTAC.Sub, $v3))
+}
+f1(func: uid(r6), first: 1) {
+uid
+f2 : OTAC.Sub
+f3 : ~OTAC.U-Ss (first: $v1, offset: $v2) @filter(uid_in(OTAC.U-T, $v0)) {
+uid
+f4 : OTAC.UId
+}
+}
+}`,
+			want: map[string]string{"$v0": "0x1", "$v1": "10", "$v2": "10", "$v3": "user:1"},
+		},
+	}
+	for _, c := range cases {
+		dql, vars := compile(t, c.query, c.vars)
+		if dql != c.dql {
+			t.Fatalf("Compile(%q) got:\n%s\nwant:\n%s", c.query, dql, c.dql)
+		}
+		if fmt.Sprint(vars) != fmt.Sprint(c.want) {
+			t.Fatalf("Compile(%q) vars got %v, want %v", c.query, vars, c.want)
+		}
+	}
+}
+
+var edgeReg = regexp.MustCompile(`(?m)^f\d+ : (~?OTAC\.[A-Za-z]+-[A-Za-z]+)( \(first: \$v\d+, offset: \$v\d+\))?(.*)\{$`)
+
+// TestCompileTenantIsolation 每条到租户数据的边都带有 OTAC.*-T 过滤，租户参数与唯一键都来自请求者的租户
+func TestCompileTenantIsolation(t *testing.T) {
+	query := `{
+		units(first: 1) { hasUnits(first: 1) { objects(first: 1) {
+			joinedScopes { targetId }
+			units(first: 1) { roles(first: 1) { permissions(first: 1) { implies { id } } } }
+		} } }
+		objects(targetType: "doc") { permissions { permission notBefore } hasObjects { tenant { tenant } } }
+		scope(targetType: "project", targetId: "p1") { targetId tenant { tenant } }
+		role(role: "admin") { permissions { permission } }
+		permission(permission: "doc.read") { impliedBy { permission } }
+		subject(subject: "user:1") { joinedUnits { targetId } }
+	}`
+	dql, vars := compile(t, query, nil)
+	if vars["$v0"] != testTenant.UID {
+		t.Fatalf("tenant variable got %v", vars)
+	}
+	edges := edgeReg.FindAllStringSubmatch(dql, -1)
+	if len(edges) < 10 {
+		t.Fatalf("edges got %d in:\n%s", len(edges), dql)
+	}
+	for _, e := range edges {
+		// 边指向租户节点本身时不需要过滤
+		if strings.HasSuffix(e[1], "-T") {
+			continue
+		}
+		if !regexp.MustCompile(`@filter\(uid_in\(OTAC\.[A-Za-z]+-T, \$v0\)\)`).MatchString(e[3]) {
+			t.Fatalf("edge %s without tenant filter in:\n%s", e[0], dql)
+		}
+	}
+	for _, uk := range []string{
+		util.HashBase64(testTenant.Tenant, "project", "p1"),
+		util.HashBase64(testTenant.Tenant, "admin"),
+		util.HashBase64(testTenant.Tenant, "doc.read"),
+	} {
+		found := false
+		for _, v := range vars {
+			found = found || v == uk
+		}
+		if !found {
+			t.Fatalf("unique key %s not in vars %v", uk, vars)
+		}
+	}
+
+	// 用户输入只出现在变量中
+	dql, vars = compile(t, `{ unit(targetType: "x\") { uid } evil(func: has(OTAC.T)", targetId: "1") { id } }`, nil)
+	if strings.Contains(dql, "evil") || strings.Count(dql, "OTAC.T") != 0 {
+		t.Fatalf("input leaked into query:\n%s", dql)
+	}
+}
+
+func TestCompileLimits(t *testing.T) {
+	cases := []struct {
+		query  string
+		limits Limits
+		msg    string
+	}{
+		{`{ unit(targetType: "a", targetId: "b") { hasUnits { hasUnits { id } } } }`, Limits{MaxDepth: 2}, "query depth exceeds 2"},
+		{`{ unit(targetType: "a", targetId: "b") { hasUnits { id } } }`, Limits{MaxDepth: 2}, ""},
+		{`{ units(first: 100) { hasUnits(first: 100) { id } } }`, Limits{MaxComplexity: 10000}, "query complexity exceeds 10000"},
+		{`{ units(first: 99) { hasUnits(first: 100) { id } } }`, Limits{MaxComplexity: 10000}, ""},
+		// 复杂度按 first 相乘，很大的乘积不会溢出
+		{`{ units(first: 1000) { hasUnits(first: 1000) { hasUnits(first: 1000) { hasUnits(first: 1000) { hasUnits(first: 1000) { id } } } } } }`,
+			Limits{MaxComplexity: 10000}, "query complexity exceeds 10000"},
+		{`{ a: tenant { id } b: tenant { id } c: tenant { id } }`, Limits{MaxComplexity: 5}, "query complexity exceeds 5"},
+		{`{ a: tenant { id } b: tenant { id } }`, Limits{MaxComplexity: 5}, ""},
+		// 片段中的字段同样计入深度
+		{`{ unit(targetType: "a", targetId: "b") { ...F } } fragment F on OTACUnit { hasUnits { hasUnits { id } } }`, Limits{MaxDepth: 2}, "query depth exceeds 2"},
+		{`{ units(first: 0) { id } }`, Limits{}, "must be between 1 and 1000"},
+		{`{ units(first: 1001) { id } }`, Limits{}, "must be between 1 and 1000"},
+		{`{ units(offset: -1) { id } }`, Limits{}, "must not be negative"},
+	}
+	for _, c := range cases {
+		_, err := Compile(tpl.GraphQLInput{Query: c.query}, testTenant, c.limits)
+		switch {
+		case c.msg == "" && err != nil:
+			t.Fatalf("Compile(%q) got %s", c.query, err.Message)
+		case c.msg != "" && (err == nil || !strings.Contains(err.Message, c.msg)):
+			t.Fatalf("Compile(%q) got %v, want %q", c.query, err, c.msg)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	cases := []struct {
+		query string
+		vars  map[string]interface{}
+		op    string
+		msg   string
+	}{
+		{`mutation { unit { id } }`, nil, "", "mutation is not supported"},
+		{`{ __schema { types { name } } }`, nil, "", "introspection is not supported"},
+		{`{ webhooks { id } }`, nil, "", `cannot query field "webhooks" on type "Query"`},
+		{`{ subject(subject: "user:1") { joinedOrg { id } } }`, nil, "", `cannot query field "joinedOrg"`},
+		{`{ unit(targetType: "a") { id } }`, nil, "", `argument "targetId" of type String! is required`},
+		{`{ unit(targetType: "a", targetId: "b", x: 1) { id } }`, nil, "", `unknown argument "x"`},
+		{`{ unit(targetType: 1, targetId: "b") { id } }`, nil, "", "expected type String"},
+		{`{ unit(targetType: "a", targetId: "b") }`, nil, "", "must have a selection of subfields"},
+		{`{ tenant { tenant { id } } }`, nil, "", "must not have a selection"},
+		{`query($n: String) { units(first: $n) { id } }`, nil, "", "variable $n of type String used in position expecting Int"},
+		{`query($n: Int!) { units(first: $n) { id } }`, nil, "", "variable $n of required type Int! was not provided"},
+		{`query($n: Int) { units(first: $n) { id } }`, map[string]interface{}{"n": 1.5}, "", "invalid value for type Int"},
+		{`query($n: [Int]) { units { id } }`, nil, "", "unsupported type [Int]"},
+		{`{ units(first: $n) { id } }`, nil, "", "variable $n is not defined"},
+		{`{ tenant { ...F } } fragment F on OTACTenant { ...F }`, nil, "", `cannot spread fragment "F" within itself`},
+		{`{ tenant { ...F } } fragment F on OTACUnit { id }`, nil, "", `fragment "F" cannot be spread here`},
+		{`{ tenant { ...G } }`, nil, "", `unknown fragment "G"`},
+		{`{ tenant { a: id a: status } }`, nil, "", `fields "a" conflict`},
+		{`{ tenant @cached { id } }`, nil, "", "unknown directive @cached"},
+		{`{ tenant @skip { id } }`, nil, "", `directive @skip requires the argument "if"`},
+		{`query A { tenant { id } } query B { tenant { id } }`, nil, "", "operationName required"},
+		{`query A { tenant { id } }`, nil, "B", `unknown operation "B"`},
+	}
+	for _, c := range cases {
+		_, err := Compile(tpl.GraphQLInput{Query: c.query, OperationName: c.op, Variables: c.vars}, testTenant, testLimits)
+		if err == nil || !strings.Contains(err.Message, c.msg) {
+			t.Fatalf("Compile(%q) got %v, want %q", c.query, err, c.msg)
+		}
+		if len(err.Locations) != 1 {
+			t.Fatalf("Compile(%q) locations got %v", c.query, err.Locations)
+		}
+	}
+}
+
+func TestPlanResult(t *testing.T) {
+	query := `query($skip: Boolean = true) {
+		u: unit(targetType: "team", targetId: "t1") {
+			__typename
+			targetId
+			status @skip(if: $skip)
+			hasSubjects { subject notAfter }
+			tenant { tenant }
+		}
+		units { id }
+	}`
+	p, err := Compile(tpl.GraphQLInput{Query: query}, testTenant, testLimits)
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	dql, _ := p.DQL()
+	if strings.Contains(dql, "OTAC.status") {
+		t.Fatalf("skipped field in query:\n%s", dql)
+	}
+	// f1 unit，f2 targetId，f3 hasSubjects，f4 subject，f5 notAfter，f6 tenant，f7 tenant.tenant，f9 units
+	data := `{
+		"f1": [{"uid": "0x2", "f2": "t1", "f3": [{"uid": "0x3", "f4": "user:1", "f5": "2030-01-01T00:00:00Z"}, {"uid": "0x4", "f4": "user:2"}], "f6": [{"uid": "0x1", "f7": "t"}]}],
+		"f9": []
+	}`
+	res, e := p.Result([]byte(data))
+	if e != nil {
+		t.Fatal(e)
+	}
+	out, _ := json.Marshal(res)
+	want := `{"u":{"__typename":"OTACUnit","targetId":"t1","hasSubjects":[{"subject":"user:1","notAfter":"2030-01-01T00:00:00Z"},{"subject":"user:2","notAfter":null}],"tenant":{"tenant":"t"}},"units":[]}`
+	if string(out) != want {
+		t.Fatalf("Result got %s\nwant %s\n%s", out, want, dql)
+	}
+
+	if res, e = p.Result(nil); e != nil {
+		t.Fatal(e)
+	}
+	if out, _ = json.Marshal(res); string(out) != `{"u":null,"units":[]}` {
+		t.Fatalf("empty Result got %s", out)
+	}
+}
@@ -0,0 +1,179 @@
+// gen 根据 graphql/schema.graphql 生成 GraphQL 读接口的类型定义 src/gql/schema_gen.go：
+// 每个字段对应 @dgraph(pred: ...) 指定的谓词，注释中标有 @facets 的边在目标类型上生成 notBefore/notAfter 字段。
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	in  = flag.String("in", "graphql/schema.graphql", "GraphQL schema file")
+	out = flag.String("out", "src/gql/schema_gen.go", "output Go file")
+)
+
+var (
+	typeReg  = regexp.MustCompile(`^type\s+([A-Za-z_][0-9A-Za-z_]*)\s*\{`)
+	fieldReg = regexp.MustCompile(`^([A-Za-z_][0-9A-Za-z_]*)\s*:\s*(\[?)\s*([A-Za-z_][0-9A-Za-z_]*)\s*(!?)\s*(\]?)\s*(!?)`)
+	predReg  = regexp.MustCompile(`@dgraph\(pred:\s*"([^"]+)"\)`)
+)
+
+// facetFields 带 facets 的边的目标类型上生成的字段
+var facetFields = []string{"notBefore", "notAfter"}
+
+type field struct {
+	name    string
+	typ     string
+	list    bool
+	nonNull bool
+	pred    string
+	facets  bool
+	facet   string
+}
+
+type objectType struct {
+	name   string
+	fields []*field
+}
+
+func (t *objectType) has(name string) bool {
+	for _, f := range t.fields {
+		if f.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func main() {
+	flag.Parse()
+	types, err := parse(*in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	src, err := generate(types)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func parse(file string) ([]*objectType, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var types []*objectType
+	var cur *objectType
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case cur == nil:
+			m := typeReg.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("%s:%d: type definition expected", file, n)
+			}
+			cur = &objectType{name: m[1]}
+			types = append(types, cur)
+		case strings.HasPrefix(line, "}"):
+			cur = nil
+		default:
+			m := fieldReg.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("%s:%d: field definition expected", file, n)
+			}
+			// uk 为内部使用的唯一键哈希，不对外暴露
+			if m[1] == "uk" {
+				continue
+			}
+			fd := &field{name: m[1], typ: m[3], list: m[2] == "["}
+			if fd.list {
+				fd.nonNull = m[6] == "!"
+			} else {
+				fd.nonNull = m[4] == "!"
+			}
+			if p := predReg.FindStringSubmatch(line); p != nil {
+				fd.pred = p[1]
+			} else if fd.name != "id" {
+				return nil, fmt.Errorf("%s:%d: @dgraph(pred: ...) required", file, n)
+			}
+			if i := strings.Index(line, "#"); i >= 0 && strings.Contains(line[i:], "@facets") {
+				fd.facets = true
+			}
+			cur.fields = append(cur.fields, fd)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*objectType)
+	for _, t := range types {
+		byName[t.name] = t
+	}
+	for _, t := range types {
+		for _, fd := range t.fields {
+			if !fd.facets {
+				continue
+			}
+			target := byName[fd.typ]
+			if target == nil {
+				return nil, fmt.Errorf("%s.%s: unknown type %s", t.name, fd.name, fd.typ)
+			}
+			for _, name := range facetFields {
+				if !target.has(name) {
+					target.fields = append(target.fields, &field{name: name, typ: "DateTime", facet: name})
+				}
+			}
+		}
+	}
+	return types, nil
+}
+
+func generate(types []*objectType) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by src/gql/gen from graphql/schema.graphql. DO NOT EDIT.\n\n")
+	b.WriteString("package gql\n\n")
+	b.WriteString("var schemaTypes = []*objectType{\n")
+	for _, t := range types {
+		fmt.Fprintf(&b, "{\nName: %q,\nFields: []*field{\n", t.name)
+		for _, fd := range t.fields {
+			fmt.Fprintf(&b, "{Name: %q, Type: %q", fd.name, fd.typ)
+			if fd.list {
+				b.WriteString(", List: true")
+			}
+			if fd.nonNull {
+				b.WriteString(", NonNull: true")
+			}
+			if fd.pred != "" {
+				fmt.Fprintf(&b, ", Pred: %q", fd.pred)
+			}
+			if fd.facets {
+				b.WriteString(", Facets: true")
+			}
+			if fd.facet != "" {
+				fmt.Fprintf(&b, ", Facet: %q", fd.facet)
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("},\n},\n")
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
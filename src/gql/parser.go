@@ -0,0 +1,595 @@
+package gql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// 解析 GraphQL 可执行文档（查询与片段），不支持 schema 定义语言
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type token struct {
+	kind tokenKind
+	val  string
+	pos  int
+}
+
+// Location 错误在查询中的位置，从 1 开始
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind       string // query、mutation 或 subscription
+	name       string
+	vars       []*varDef
+	directives []*directive
+	selections []selection
+	pos        int
+}
+
+type varDef struct {
+	name string
+	typ  *typeRef
+	def  *value
+	pos  int
+}
+
+type typeRef struct {
+	name    string
+	elem    *typeRef // 列表类型的元素类型
+	nonNull bool
+}
+
+func (t *typeRef) String() string {
+	s := t.name
+	if t.elem != nil {
+		s = "[" + t.elem.String() + "]"
+	}
+	if t.nonNull {
+		s += "!"
+	}
+	return s
+}
+
+type fragment struct {
+	name       string
+	on         string
+	directives []*directive
+	selections []selection
+	pos        int
+}
+
+type selection interface {
+	position() int
+}
+
+type fieldNode struct {
+	alias      string
+	name       string
+	args       []*argument
+	directives []*directive
+	selections []selection
+	pos        int
+}
+
+func (f *fieldNode) position() int { return f.pos }
+
+func (f *fieldNode) key() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+	pos        int
+}
+
+func (f *fragmentSpread) position() int { return f.pos }
+
+type inlineFragment struct {
+	on         string
+	directives []*directive
+	selections []selection
+	pos        int
+}
+
+func (f *inlineFragment) position() int { return f.pos }
+
+type argument struct {
+	name string
+	val  *value
+	pos  int
+}
+
+type directive struct {
+	name string
+	args []*argument
+	pos  int
+}
+
+type valueKind int
+
+const (
+	valVariable valueKind = iota
+	valInt
+	valFloat
+	valString
+	valBoolean
+	valNull
+	valEnum
+	valList
+	valObject
+)
+
+type value struct {
+	kind   valueKind
+	raw    string // 变量名、数字、字符串（已解码）或枚举值
+	list   []*value
+	fields []*argument
+	pos    int
+}
+
+// maxNesting 选择集和值的最大嵌套层数，避免恶意查询耗尽栈空间，查询深度由 Limits.MaxDepth 另行限制
+const maxNesting = 64
+
+type parser struct {
+	src   string
+	i     int
+	tok   token
+	depth int
+}
+
+func (p *parser) enter() {
+	p.depth++
+	if p.depth > maxNesting {
+		p.fail(p.tok.pos, "syntax error: nesting too deep")
+	}
+}
+
+// parseError 语法或校验错误，pos 为查询中的字节偏移
+type parseError struct {
+	msg string
+	pos int
+}
+
+func (e *parseError) Error() string {
+	return e.msg
+}
+
+func errorf(pos int, format string, args ...interface{}) *parseError {
+	return &parseError{msg: fmt.Sprintf(format, args...), pos: pos}
+}
+
+// location 将字节偏移转换为行列
+func location(src string, pos int) Location {
+	if pos > len(src) {
+		pos = len(src)
+	}
+	line := 1 + strings.Count(src[:pos], "\n")
+	start := strings.LastIndexByte(src[:pos], '\n') + 1
+	return Location{Line: line, Column: utf8.RuneCountInString(src[start:pos]) + 1}
+}
+
+func parseDocument(src string) (doc *document, err error) {
+	p := &parser{src: src}
+	defer func() {
+		if r := recover(); r != nil {
+			pe, ok := r.(*parseError)
+			if !ok {
+				panic(r)
+			}
+			err = pe
+		}
+	}()
+
+	p.next()
+	doc = &document{fragments: make(map[string]*fragment)}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.peek(tokPunct, "{"):
+			op := &operation{kind: "query", pos: p.tok.pos}
+			op.selections = p.selectionSet()
+			doc.operations = append(doc.operations, op)
+		case p.peek(tokName, "query"), p.peek(tokName, "mutation"), p.peek(tokName, "subscription"):
+			doc.operations = append(doc.operations, p.operation())
+		case p.peek(tokName, "fragment"):
+			f := p.fragment()
+			if _, ok := doc.fragments[f.name]; ok {
+				return nil, errorf(f.pos, "there can be only one fragment named %q", f.name)
+			}
+			doc.fragments[f.name] = f
+		default:
+			p.unexpected()
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, errorf(0, "no operation in query")
+	}
+	return doc, nil
+}
+
+func (p *parser) fail(pos int, format string, args ...interface{}) {
+	panic(errorf(pos, format, args...))
+}
+
+func (p *parser) unexpected() {
+	if p.tok.kind == tokEOF {
+		p.fail(p.tok.pos, "syntax error: unexpected end of query")
+	}
+	p.fail(p.tok.pos, "syntax error: unexpected %q", p.tok.val)
+}
+
+func (p *parser) peek(kind tokenKind, val string) bool {
+	return p.tok.kind == kind && p.tok.val == val
+}
+
+func (p *parser) skip(kind tokenKind, val string) bool {
+	if p.peek(kind, val) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, val string) {
+	if !p.skip(kind, val) {
+		p.unexpected()
+	}
+}
+
+func (p *parser) name() string {
+	if p.tok.kind != tokName {
+		p.unexpected()
+	}
+	s := p.tok.val
+	p.next()
+	return s
+}
+
+func (p *parser) operation() *operation {
+	op := &operation{kind: p.tok.val, pos: p.tok.pos}
+	p.next()
+	if p.tok.kind == tokName {
+		op.name = p.name()
+	}
+	if p.skip(tokPunct, "(") {
+		for !p.skip(tokPunct, ")") {
+			v := &varDef{pos: p.tok.pos}
+			p.expect(tokPunct, "$")
+			v.name = p.name()
+			p.expect(tokPunct, ":")
+			v.typ = p.typeRef()
+			if p.skip(tokPunct, "=") {
+				v.def = p.value(true)
+			}
+			op.vars = append(op.vars, v)
+		}
+	}
+	op.directives = p.directives()
+	op.selections = p.selectionSet()
+	return op
+}
+
+func (p *parser) typeRef() *typeRef {
+	t := &typeRef{}
+	if p.skip(tokPunct, "[") {
+		t.elem = p.typeRef()
+		p.expect(tokPunct, "]")
+	} else {
+		t.name = p.name()
+	}
+	t.nonNull = p.skip(tokPunct, "!")
+	return t
+}
+
+func (p *parser) fragment() *fragment {
+	f := &fragment{pos: p.tok.pos}
+	p.next()
+	f.name = p.name()
+	if f.name == "on" {
+		p.fail(f.pos, "syntax error: fragment cannot be named \"on\"")
+	}
+	p.expect(tokName, "on")
+	f.on = p.name()
+	f.directives = p.directives()
+	f.selections = p.selectionSet()
+	return f
+}
+
+func (p *parser) selectionSet() []selection {
+	p.enter()
+	defer func() { p.depth-- }()
+	p.expect(tokPunct, "{")
+	// 选择集不能为空
+	if p.peek(tokPunct, "}") {
+		p.unexpected()
+	}
+	var ss []selection
+	for !p.skip(tokPunct, "}") {
+		ss = append(ss, p.selection())
+	}
+	return ss
+}
+
+func (p *parser) selection() selection {
+	pos := p.tok.pos
+	if p.skip(tokPunct, "...") {
+		if p.tok.kind == tokName && p.tok.val != "on" {
+			return &fragmentSpread{name: p.name(), directives: p.directives(), pos: pos}
+		}
+		f := &inlineFragment{pos: pos}
+		if p.skip(tokName, "on") {
+			f.on = p.name()
+		}
+		f.directives = p.directives()
+		f.selections = p.selectionSet()
+		return f
+	}
+
+	f := &fieldNode{pos: pos, name: p.name()}
+	if p.skip(tokPunct, ":") {
+		f.alias = f.name
+		f.name = p.name()
+	}
+	f.args = p.arguments(false)
+	f.directives = p.directives()
+	if p.peek(tokPunct, "{") {
+		f.selections = p.selectionSet()
+	}
+	return f
+}
+
+func (p *parser) arguments(constant bool) []*argument {
+	if !p.skip(tokPunct, "(") {
+		return nil
+	}
+	var args []*argument
+	for !p.skip(tokPunct, ")") {
+		a := &argument{pos: p.tok.pos}
+		a.name = p.name()
+		p.expect(tokPunct, ":")
+		a.val = p.value(constant)
+		args = append(args, a)
+	}
+	return args
+}
+
+func (p *parser) directives() []*directive {
+	var ds []*directive
+	for p.peek(tokPunct, "@") {
+		d := &directive{pos: p.tok.pos}
+		p.next()
+		d.name = p.name()
+		d.args = p.arguments(false)
+		ds = append(ds, d)
+	}
+	return ds
+}
+
+func (p *parser) value(constant bool) *value {
+	v := &value{pos: p.tok.pos, raw: p.tok.val}
+	switch p.tok.kind {
+	case tokPunct:
+		switch p.tok.val {
+		case "$":
+			if constant {
+				p.unexpected()
+			}
+			p.next()
+			v.kind = valVariable
+			v.raw = p.name()
+			return v
+		case "[":
+			p.enter()
+			defer func() { p.depth-- }()
+			p.next()
+			v.kind = valList
+			for !p.skip(tokPunct, "]") {
+				v.list = append(v.list, p.value(constant))
+			}
+			return v
+		case "{":
+			p.enter()
+			defer func() { p.depth-- }()
+			p.next()
+			v.kind = valObject
+			for !p.skip(tokPunct, "}") {
+				a := &argument{pos: p.tok.pos}
+				a.name = p.name()
+				p.expect(tokPunct, ":")
+				a.val = p.value(constant)
+				v.fields = append(v.fields, a)
+			}
+			return v
+		}
+	case tokInt:
+		v.kind = valInt
+	case tokFloat:
+		v.kind = valFloat
+	case tokString:
+		v.kind = valString
+	case tokName:
+		switch p.tok.val {
+		case "true", "false":
+			v.kind = valBoolean
+		case "null":
+			v.kind = valNull
+		default:
+			v.kind = valEnum
+		}
+	default:
+		p.unexpected()
+	}
+	p.next()
+	return v
+}
+
+// next 读取下一个词法单元，跳过空白、逗号和注释
+func (p *parser) next() {
+	src := p.src
+	for p.i < len(src) {
+		c := src[p.i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			p.i++
+		case c == '#':
+			for p.i < len(src) && src[p.i] != '\n' && src[p.i] != '\r' {
+				p.i++
+			}
+		case strings.HasPrefix(src[p.i:], "\ufeff"):
+			p.i += len("\ufeff")
+		default:
+			goto scan
+		}
+	}
+	p.tok = token{kind: tokEOF, pos: p.i}
+	return
+
+scan:
+	start := p.i
+	c := src[p.i]
+	switch {
+	case strings.HasPrefix(src[p.i:], "..."):
+		p.i += 3
+		p.tok = token{kind: tokPunct, val: "...", pos: start}
+	case strings.IndexByte("!$():=@[]{|}&", c) >= 0:
+		p.i++
+		p.tok = token{kind: tokPunct, val: string(c), pos: start}
+	case c == '_' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z':
+		for p.i < len(src) && isNameChar(src[p.i]) {
+			p.i++
+		}
+		p.tok = token{kind: tokName, val: src[start:p.i], pos: start}
+	case c == '-' || c >= '0' && c <= '9':
+		p.number()
+	case c == '"':
+		p.string()
+	default:
+		r, _ := utf8.DecodeRuneInString(src[p.i:])
+		p.fail(start, "syntax error: unexpected character %q", r)
+	}
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
+
+func (p *parser) number() {
+	src, start := p.src, p.i
+	digits := func() {
+		n := p.i
+		for p.i < len(src) && src[p.i] >= '0' && src[p.i] <= '9' {
+			p.i++
+		}
+		if p.i == n {
+			p.fail(p.i, "syntax error: invalid number")
+		}
+	}
+	if src[p.i] == '-' {
+		p.i++
+	}
+	digits()
+	kind := tokInt
+	if p.i < len(src) && src[p.i] == '.' {
+		p.i++
+		digits()
+		kind = tokFloat
+	}
+	if p.i < len(src) && (src[p.i] == 'e' || src[p.i] == 'E') {
+		p.i++
+		if p.i < len(src) && (src[p.i] == '+' || src[p.i] == '-') {
+			p.i++
+		}
+		digits()
+		kind = tokFloat
+	}
+	if p.i < len(src) && (isNameChar(src[p.i]) || src[p.i] == '.') {
+		p.fail(p.i, "syntax error: invalid number")
+	}
+	p.tok = token{kind: kind, val: src[start:p.i], pos: start}
+}
+
+func (p *parser) string() {
+	src, start := p.src, p.i
+	if strings.HasPrefix(src[p.i:], `"""`) {
+		end := strings.Index(src[p.i+3:], `"""`)
+		if end < 0 {
+			p.fail(start, "syntax error: unterminated string")
+		}
+		raw := src[p.i+3 : p.i+3+end]
+		p.i += 6 + end
+		p.tok = token{kind: tokString, val: strings.TrimSpace(raw), pos: start}
+		return
+	}
+
+	p.i++
+	var b strings.Builder
+	for {
+		if p.i >= len(src) || src[p.i] == '\n' || src[p.i] == '\r' {
+			p.fail(start, "syntax error: unterminated string")
+		}
+		c := src[p.i]
+		if c == '"' {
+			p.i++
+			break
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			p.i++
+			continue
+		}
+		if p.i+1 >= len(src) {
+			p.fail(start, "syntax error: unterminated string")
+		}
+		switch e := src[p.i+1]; e {
+		case '"', '\\', '/':
+			b.WriteByte(e)
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			if p.i+6 > len(src) {
+				p.fail(p.i, "syntax error: invalid unicode escape")
+			}
+			r, err := strconv.ParseUint(src[p.i+2:p.i+6], 16, 32)
+			if err != nil {
+				p.fail(p.i, "syntax error: invalid unicode escape")
+			}
+			b.WriteRune(rune(r))
+			p.i += 4
+		default:
+			p.fail(p.i, "syntax error: invalid escape \\%c", e)
+		}
+		p.i += 2
+	}
+	p.tok = token{kind: tokString, val: b.String(), pos: start}
+}
@@ -0,0 +1,128 @@
+package gql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDocument(t *testing.T) {
+	src := `# 注释
+query Q($id: ID!, $n: Int = 10, $types: [String!]) @cached {
+	a: unit(targetType: "team", targetId: $id) {
+		...F
+		... on OTACUnit @include(if: true) { status }
+	}
+}
+fragment F on OTACUnit { targetId, targetType }
+{ tenant { tenant } }`
+	doc, err := parseDocument(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.operations) != 2 || len(doc.fragments) != 1 {
+		t.Fatalf("got %d operations, %d fragments", len(doc.operations), len(doc.fragments))
+	}
+
+	op := doc.operations[0]
+	if op.kind != "query" || op.name != "Q" || len(op.vars) != 3 || len(op.directives) != 1 {
+		t.Fatalf("operation got %+v", op)
+	}
+	types := make([]string, 0, len(op.vars))
+	for _, v := range op.vars {
+		types = append(types, v.typ.String())
+	}
+	if got := strings.Join(types, ","); got != "ID!,Int,[String!]" {
+		t.Fatalf("variable types got %s", got)
+	}
+	if def := op.vars[1].def; def == nil || def.kind != valInt || def.raw != "10" {
+		t.Fatalf("default value got %+v", def)
+	}
+
+	f := op.selections[0].(*fieldNode)
+	if f.key() != "a" || f.name != "unit" || len(f.args) != 2 || f.args[1].val.kind != valVariable || f.args[1].val.raw != "id" {
+		t.Fatalf("field got %+v", f)
+	}
+	if _, ok := f.selections[0].(*fragmentSpread); !ok {
+		t.Fatalf("fragment spread got %T", f.selections[0])
+	}
+	if inline, ok := f.selections[1].(*inlineFragment); !ok || inline.on != "OTACUnit" || len(inline.directives) != 1 {
+		t.Fatalf("inline fragment got %+v", f.selections[1])
+	}
+	if fr := doc.fragments["F"]; fr.on != "OTACUnit" || len(fr.selections) != 2 {
+		t.Fatalf("fragment got %+v", fr)
+	}
+	if anon := doc.operations[1]; anon.kind != "query" || anon.name != "" {
+		t.Fatalf("anonymous operation got %+v", anon)
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	doc, err := parseDocument(`{ f(a: -1, b: 1.5e3, c: "x\"é\n", d: """ block "quoted" """, e: true, g: null, h: ENUM, i: [1, [2]], j: {k: "v"}) }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := doc.operations[0].selections[0].(*fieldNode).args
+	cases := []struct {
+		kind valueKind
+		raw  string
+	}{
+		{valInt, "-1"},
+		{valFloat, "1.5e3"},
+		{valString, "x\"é\n"},
+		{valString, `block "quoted"`},
+		{valBoolean, "true"},
+		{valNull, "null"},
+		{valEnum, "ENUM"},
+		{valList, "["},
+		{valObject, "{"},
+	}
+	for i, c := range cases {
+		if v := args[i].val; v.kind != c.kind || v.raw != c.raw {
+			t.Fatalf("argument %s got %d %q, want %d %q", args[i].name, v.kind, v.raw, c.kind, c.raw)
+		}
+	}
+	if l := args[7].val.list; len(l) != 2 || l[1].kind != valList || len(l[1].list) != 1 {
+		t.Fatalf("list value got %+v", l)
+	}
+	if o := args[8].val.fields; len(o) != 1 || o[0].name != "k" || o[0].val.raw != "v" {
+		t.Fatalf("object value got %+v", o)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		src, msg string
+		line     int
+		column   int
+	}{
+		{``, "no operation in query", 1, 1},
+		{`# only comment`, "no operation in query", 1, 1},
+		{`{ unit }}`, `unexpected "}"`, 1, 9},
+		{`{ unit`, "unexpected end of query", 1, 7},
+		{`{ }`, `unexpected "}"`, 1, 3},
+		{"{\n  unit(a: 1.) }", "invalid number", 2, 13},
+		{`{ unit(a: 12ab) }`, "invalid number", 1, 13},
+		{`{ unit(a: "x) }`, "unterminated string", 1, 11},
+		{`{ unit(a: """x) }`, "unterminated string", 1, 11},
+		{`{ unit(a: "\q") }`, `invalid escape \q`, 1, 12},
+		{`{ unit(a: "\u12") }`, "invalid unicode escape", 1, 12},
+		{"{ unit ? }", `unexpected character '?'`, 1, 8},
+		{"{\n\tunit(\n\ta: ☃) }", `unexpected character '☃'`, 3, 5},
+		{`query ($a: Int = $b) { unit }`, `unexpected "$"`, 1, 18},
+		{`fragment on on T { a }`, `fragment cannot be named "on"`, 1, 1},
+		{`fragment F on T { a } fragment F on T { b } { a }`, `only one fragment named "F"`, 1, 23},
+		{`foo { a }`, `unexpected "foo"`, 1, 1},
+		{`{ a` + strings.Repeat(" { a", maxNesting) + strings.Repeat(" }", maxNesting+1), "nesting too deep", 1, 4*maxNesting + 1},
+		{`{ a(b: ` + strings.Repeat("[", maxNesting+1) + strings.Repeat("]", maxNesting+1) + `) }`, "nesting too deep", 1, 7 + maxNesting},
+	}
+	for _, c := range cases {
+		_, err := parseDocument(c.src)
+		pe, ok := err.(*parseError)
+		if !ok || !strings.Contains(pe.msg, c.msg) {
+			t.Fatalf("parseDocument(%q) got %v, want %q", c.src, err, c.msg)
+		}
+		if loc := location(c.src, pe.pos); loc.Line != c.line || loc.Column != c.column {
+			t.Fatalf("parseDocument(%q) error %q at %d:%d, want %d:%d", c.src, pe.msg, loc.Line, loc.Column, c.line, c.column)
+		}
+	}
+}
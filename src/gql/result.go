@@ -0,0 +1,96 @@
+package gql
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// object 保持字段顺序的 JSON 对象，GraphQL 响应中字段的顺序与查询一致
+type object []member
+
+type member struct {
+	key string
+	val interface{}
+}
+
+// MarshalJSON 实现 json.Marshaler
+func (o object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(m.val)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(val)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// Result 将 Dgraph 的查询结果整理为 GraphQL 响应的 data
+func (p *Plan) Result(data []byte) (interface{}, error) {
+	res := make(map[string]interface{})
+	if len(data) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&res); err != nil {
+			return nil, err
+		}
+	}
+	return shape(res, p.roots), nil
+}
+
+func shape(node map[string]interface{}, plans []*plan) object {
+	o := make(object, 0, len(plans))
+	for _, p := range plans {
+		var val interface{}
+		switch {
+		case p.typename != "":
+			val = p.typename
+		case p.alias == "":
+			// 没有 facets 的边上的 notBefore/notAfter 字段
+		case p.object:
+			val = shapeObjects(node[p.alias], p)
+		default:
+			val = node[p.alias]
+		}
+		o = append(o, member{key: p.key, val: val})
+	}
+	return o
+}
+
+// shapeObjects Dgraph 对 uid 边和根查询都可能返回数组，列表字段返回数组，非列表字段取第一个元素
+func shapeObjects(v interface{}, p *plan) interface{} {
+	var nodes []interface{}
+	switch v := v.(type) {
+	case []interface{}:
+		nodes = v
+	case map[string]interface{}:
+		nodes = []interface{}{v}
+	}
+	if !p.list {
+		for _, n := range nodes {
+			if m, ok := n.(map[string]interface{}); ok {
+				return shape(m, p.children)
+			}
+		}
+		return nil
+	}
+	list := make([]interface{}, 0, len(nodes))
+	for _, n := range nodes {
+		if m, ok := n.(map[string]interface{}); ok {
+			list = append(list, shape(m, p.children))
+		}
+	}
+	return list
+}
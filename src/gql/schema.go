@@ -0,0 +1,76 @@
+// Package gql 实现租户范围的只读 GraphQL 接口：解析 GraphQL 查询，按 graphql/schema.graphql 中字段对应的谓词编译为 DQL，
+// 并将 Dgraph 的查询结果整理为 GraphQL 响应。所有租户数据都通过 OTAC.*-T 边限定在请求者的租户内。
+package gql
+
+//go:generate go run ./gen -in ../../graphql/schema.graphql -out schema_gen.go
+
+// field 对象类型的字段，Pred 为对应的 Dgraph 谓词（以 ~ 开头表示反向边），id 字段对应 uid
+type field struct {
+	Name    string
+	Type    string
+	List    bool
+	NonNull bool
+	Pred    string
+	Facets  bool   // 边上带有 notBefore/notAfter facets
+	Facet   string // 不为空时字段取自上级边的 facet
+}
+
+// objectType 对象类型，TenantPred 为关联租户的谓词（如 OTAC.U-T），为空表示不属于租户，如请求主体和组织
+type objectType struct {
+	Name       string
+	Fields     []*field
+	TenantPred string
+	fields     map[string]*field
+}
+
+func (t *objectType) field(name string) *field {
+	return t.fields[name]
+}
+
+// tenantType 租户类型，其它类型通过 tenant 字段关联到该类型
+const tenantType = "OTACTenant"
+
+// hiddenTypes 不通过 GraphQL 接口暴露的类型
+var hiddenTypes = map[string]bool{
+	"OTACSchema": true,
+	"OTACJob":    true,
+	// webhook 包含签名密钥，通过 Webhook 接口管理
+	"OTACWebhook":           true,
+	"OTACWebhookDeadLetter": true,
+	"OTACWebhookDelivery":   true,
+	// 变更事件通过 Watch 接口读取
+	"OTACChange": true,
+	// 审计记录通过 Audit 接口查询
+	"OTACAudit": true,
+}
+
+// hiddenFields 不通过 GraphQL 接口暴露的字段，请求主体的组织成员身份可能属于与租户无关的组织
+var hiddenFields = map[string]bool{
+	"OTACSubject.joinedOrg": true,
+}
+
+var scalarTypes = map[string]bool{
+	"ID":       true,
+	"String":   true,
+	"Int":      true,
+	"Boolean":  true,
+	"DateTime": true,
+}
+
+var types = make(map[string]*objectType)
+
+func init() {
+	for _, t := range schemaTypes {
+		if hiddenTypes[t.Name] {
+			continue
+		}
+		t.fields = make(map[string]*field, len(t.Fields))
+		for _, f := range t.Fields {
+			t.fields[f.Name] = f
+			if f.Name == "tenant" && f.Type == tenantType {
+				t.TenantPred = f.Pred
+			}
+		}
+		types[t.Name] = t
+	}
+}
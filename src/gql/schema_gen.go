@@ -0,0 +1,223 @@
+// Code generated by src/gql/gen from graphql/schema.graphql. DO NOT EDIT.
+
+package gql
+
+var schemaTypes = []*objectType{
+	{
+		Name: "OTACSubject",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "status", Type: "Int", NonNull: true, Pred: "OTAC.status"},
+			{Name: "subject", Type: "String", NonNull: true, Pred: "OTAC.Sub"},
+			{Name: "joinedOrg", Type: "OTACMember", List: true, NonNull: true, Pred: "~OTAC.M-S"},
+			{Name: "joinedUnits", Type: "OTACUnit", List: true, NonNull: true, Pred: "~OTAC.U-Ss"},
+			{Name: "notBefore", Type: "DateTime", Facet: "notBefore"},
+			{Name: "notAfter", Type: "DateTime", Facet: "notAfter"},
+		},
+	},
+	{
+		Name: "OTACOrg",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "status", Type: "Int", NonNull: true, Pred: "OTAC.status"},
+			{Name: "org", Type: "String", NonNull: true, Pred: "OTAC.Org"},
+			{Name: "members", Type: "OTACMember", List: true, NonNull: true, Pred: "~OTAC.M-Org"},
+			{Name: "joinedUnits", Type: "OTACUnit", List: true, NonNull: true, Pred: "~OTAC.U-Orgs"},
+		},
+	},
+	{
+		Name: "OTACOU",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "status", Type: "Int", NonNull: true, Pred: "OTAC.status"},
+			{Name: "ou", Type: "String", NonNull: true, Pred: "OTAC.OU"},
+			{Name: "org", Type: "OTACOrg", NonNull: true, Pred: "OTAC.OU-Org"},
+			{Name: "parent", Type: "OTACOU", Pred: "OTAC.OU-OU"},
+			{Name: "children", Type: "OTACOU", List: true, Pred: "~OTAC.OU-OU"},
+			{Name: "members", Type: "OTACMember", List: true, NonNull: true, Pred: "OTAC.OU-Ms"},
+			{Name: "joinedUnits", Type: "OTACUnit", List: true, NonNull: true, Pred: "~OTAC.U-OUs"},
+			{Name: "terms", Type: "String", NonNull: true, Pred: "OTAC.OU.terms"},
+		},
+	},
+	{
+		Name: "OTACMember",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "status", Type: "Int", NonNull: true, Pred: "OTAC.status"},
+			{Name: "subject", Type: "OTACSubject", NonNull: true, Pred: "OTAC.M-S"},
+			{Name: "org", Type: "OTACOrg", NonNull: true, Pred: "OTAC.M-Org"},
+			{Name: "joinedOU", Type: "OTACOU", List: true, NonNull: true, Pred: "~OTAC.OU-Ms"},
+			{Name: "joinedUnits", Type: "OTACUnit", List: true, NonNull: true, Pred: "~OTAC.U-Ms"},
+			{Name: "terms", Type: "String", NonNull: true, Pred: "OTAC.M.terms"},
+		},
+	},
+	{
+		Name: "OTACTenant",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "status", Type: "Int", NonNull: true, Pred: "OTAC.status"},
+			{Name: "tenant", Type: "String", NonNull: true, Pred: "OTAC.T"},
+			{Name: "revision", Type: "Int", Pred: "OTAC.T.rev"},
+			{Name: "compactedRevision", Type: "Int", Pred: "OTAC.T.revCompacted"},
+		},
+	},
+	{
+		Name: "OTACChange",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "tenant", Type: "OTACTenant", NonNull: true, Pred: "OTAC.C-T"},
+			{Name: "revision", Type: "Int", NonNull: true, Pred: "OTAC.C.rev"},
+			{Name: "event", Type: "String", NonNull: true, Pred: "OTAC.C.event"},
+		},
+	},
+	{
+		Name: "OTACUnit",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "status", Type: "Int", NonNull: true, Pred: "OTAC.status"},
+			{Name: "tenant", Type: "OTACTenant", NonNull: true, Pred: "OTAC.U-T"},
+			{Name: "targetId", Type: "String", NonNull: true, Pred: "OTAC.UId"},
+			{Name: "targetType", Type: "String", NonNull: true, Pred: "OTAC.UType"},
+			{Name: "objects", Type: "OTACObject", List: true, NonNull: true, Pred: "~OTAC.O-Us"},
+			{Name: "joinedUnits", Type: "OTACUnit", List: true, NonNull: true, Pred: "OTAC.U-Us"},
+			{Name: "joinedScopes", Type: "OTACScope", List: true, NonNull: true, Pred: "OTAC.U-Scs"},
+			{Name: "permissions", Type: "OTACPermission", List: true, NonNull: true, Pred: "OTAC.U-Ps", Facets: true},
+			{Name: "roles", Type: "OTACRole", List: true, NonNull: true, Pred: "OTAC.U-Rs"},
+			{Name: "hasUnits", Type: "OTACUnit", List: true, NonNull: true, Pred: "~OTAC.U-Us"},
+			{Name: "hasSubjects", Type: "OTACSubject", List: true, NonNull: true, Pred: "OTAC.U-Ss", Facets: true},
+			{Name: "hasMembers", Type: "OTACMember", List: true, NonNull: true, Pred: "OTAC.U-Ms"},
+			{Name: "hasOUs", Type: "OTACOU", List: true, NonNull: true, Pred: "OTAC.U-OUs"},
+			{Name: "hasOrgs", Type: "OTACOrg", List: true, NonNull: true, Pred: "OTAC.U-Orgs"},
+		},
+	},
+	{
+		Name: "OTACObject",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "tenant", Type: "OTACTenant", NonNull: true, Pred: "OTAC.O-T"},
+			{Name: "targetId", Type: "String", NonNull: true, Pred: "OTAC.OId"},
+			{Name: "targetType", Type: "String", NonNull: true, Pred: "OTAC.OType"},
+			{Name: "permissions", Type: "OTACPermission", List: true, NonNull: true, Pred: "OTAC.O-Ps", Facets: true},
+			{Name: "joinedObjects", Type: "OTACObject", List: true, NonNull: true, Pred: "OTAC.O-Os"},
+			{Name: "joinedScopes", Type: "OTACScope", List: true, NonNull: true, Pred: "OTAC.O-Scs"},
+			{Name: "hasObjects", Type: "OTACObject", List: true, NonNull: true, Pred: "~OTAC.O-Os"},
+			{Name: "units", Type: "OTACUnit", List: true, NonNull: true, Pred: "OTAC.O-Us"},
+			{Name: "terms", Type: "String", NonNull: true, Pred: "OTAC.terms"},
+		},
+	},
+	{
+		Name: "OTACPermission",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "tenant", Type: "OTACTenant", NonNull: true, Pred: "OTAC.P-T"},
+			{Name: "permission", Type: "String", NonNull: true, Pred: "OTAC.P"},
+			{Name: "name", Type: "String", Pred: "OTAC.name"},
+			{Name: "description", Type: "String", Pred: "OTAC.description"},
+			{Name: "deprecated", Type: "Boolean", Pred: "OTAC.deprecated"},
+			{Name: "implies", Type: "OTACPermission", List: true, NonNull: true, Pred: "OTAC.P-Ps"},
+			{Name: "impliedBy", Type: "OTACPermission", List: true, NonNull: true, Pred: "~OTAC.P-Ps"},
+			{Name: "notBefore", Type: "DateTime", Facet: "notBefore"},
+			{Name: "notAfter", Type: "DateTime", Facet: "notAfter"},
+		},
+	},
+	{
+		Name: "OTACRole",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "tenant", Type: "OTACTenant", NonNull: true, Pred: "OTAC.R-T"},
+			{Name: "role", Type: "String", NonNull: true, Pred: "OTAC.R"},
+			{Name: "permissions", Type: "OTACPermission", List: true, NonNull: true, Pred: "OTAC.R-Ps"},
+			{Name: "units", Type: "OTACUnit", List: true, NonNull: true, Pred: "~OTAC.U-Rs"},
+		},
+	},
+	{
+		Name: "OTACScope",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "status", Type: "Int", NonNull: true, Pred: "OTAC.status"},
+			{Name: "tenant", Type: "OTACTenant", NonNull: true, Pred: "OTAC.Sc-T"},
+			{Name: "targetId", Type: "String", NonNull: true, Pred: "OTAC.ScId"},
+			{Name: "targetType", Type: "String", NonNull: true, Pred: "OTAC.ScType"},
+		},
+	},
+	{
+		Name: "OTACSchema",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "version", Type: "Int", NonNull: true, Pred: "OTAC.Schema.version"},
+			{Name: "updatedAt", Type: "DateTime", NonNull: true, Pred: "OTAC.Schema.updatedAt"},
+		},
+	},
+	{
+		Name: "OTACJob",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "kind", Type: "String", NonNull: true, Pred: "OTAC.Job.kind"},
+			{Name: "target", Type: "String", NonNull: true, Pred: "OTAC.Job.target"},
+			{Name: "status", Type: "String", NonNull: true, Pred: "OTAC.Job.status"},
+			{Name: "stage", Type: "String", Pred: "OTAC.Job.stage"},
+			{Name: "deleted", Type: "Int", NonNull: true, Pred: "OTAC.Job.deleted"},
+			{Name: "copied", Type: "Int", Pred: "OTAC.Job.copied"},
+			{Name: "error", Type: "String", Pred: "OTAC.Job.error"},
+			{Name: "createdAt", Type: "DateTime", NonNull: true, Pred: "OTAC.Job.createdAt"},
+			{Name: "updatedAt", Type: "DateTime", NonNull: true, Pred: "OTAC.Job.updatedAt"},
+		},
+	},
+	{
+		Name: "OTACWebhook",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "status", Type: "Int", NonNull: true, Pred: "OTAC.status"},
+			{Name: "tenant", Type: "OTACTenant", NonNull: true, Pred: "OTAC.WH-T"},
+			{Name: "url", Type: "String", NonNull: true, Pred: "OTAC.WH.url"},
+			{Name: "secret", Type: "String", NonNull: true, Pred: "OTAC.WH.secret"},
+			{Name: "events", Type: "String", List: true, NonNull: true, Pred: "OTAC.WH.events"},
+			{Name: "createdAt", Type: "DateTime", NonNull: true, Pred: "OTAC.WH.createdAt"},
+			{Name: "deadLetters", Type: "OTACWebhookDeadLetter", List: true, NonNull: true, Pred: "~OTAC.DL-WH"},
+			{Name: "pendingDeliveries", Type: "OTACWebhookDelivery", List: true, NonNull: true, Pred: "~OTAC.WD-WH"},
+		},
+	},
+	{
+		Name: "OTACWebhookDeadLetter",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "tenant", Type: "OTACTenant", NonNull: true, Pred: "OTAC.DL-T"},
+			{Name: "webhook", Type: "OTACWebhook", NonNull: true, Pred: "OTAC.DL-WH"},
+			{Name: "type", Type: "String", NonNull: true, Pred: "OTAC.DL.type"},
+			{Name: "payload", Type: "String", NonNull: true, Pred: "OTAC.DL.payload"},
+			{Name: "error", Type: "String", NonNull: true, Pred: "OTAC.DL.error"},
+			{Name: "attempts", Type: "Int", NonNull: true, Pred: "OTAC.DL.attempts"},
+			{Name: "createdAt", Type: "DateTime", NonNull: true, Pred: "OTAC.DL.createdAt"},
+		},
+	},
+	{
+		Name: "OTACWebhookDelivery",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "tenant", Type: "OTACTenant", NonNull: true, Pred: "OTAC.WD-T"},
+			{Name: "webhook", Type: "OTACWebhook", NonNull: true, Pred: "OTAC.WD-WH"},
+			{Name: "deliveryId", Type: "String", NonNull: true, Pred: "OTAC.WD.id"},
+			{Name: "type", Type: "String", NonNull: true, Pred: "OTAC.WD.type"},
+			{Name: "payload", Type: "String", NonNull: true, Pred: "OTAC.WD.payload"},
+			{Name: "error", Type: "String", NonNull: true, Pred: "OTAC.WD.error"},
+			{Name: "attempts", Type: "Int", NonNull: true, Pred: "OTAC.WD.attempts"},
+			{Name: "nextAttemptAt", Type: "DateTime", NonNull: true, Pred: "OTAC.WD.nextAttemptAt"},
+		},
+	},
+	{
+		Name: "OTACAudit",
+		Fields: []*field{
+			{Name: "id", Type: "ID", NonNull: true},
+			{Name: "tenant", Type: "String", NonNull: true, Pred: "OTAC.AU.tenant"},
+			{Name: "tenantUid", Type: "String", Pred: "OTAC.AU.tenantUid"},
+			{Name: "actor", Type: "String", NonNull: true, Pred: "OTAC.AU.actor"},
+			{Name: "op", Type: "String", NonNull: true, Pred: "OTAC.AU.op"},
+			{Name: "targets", Type: "String", List: true, NonNull: true, Pred: "OTAC.AU.targets"},
+			{Name: "added", Type: "String", NonNull: true, Pred: "OTAC.AU.added"},
+			{Name: "removed", Type: "String", NonNull: true, Pred: "OTAC.AU.removed"},
+			{Name: "before", Type: "String", NonNull: true, Pred: "OTAC.AU.before"},
+			{Name: "after", Type: "String", NonNull: true, Pred: "OTAC.AU.after"},
+			{Name: "createdAt", Type: "DateTime", NonNull: true, Pred: "OTAC.AU.createdAt"},
+		},
+	},
+}
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"io"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/open-trust/ot-ac/src/conf"
+)
+
+// DefBuckets 延迟直方图的默认桶，单位秒，低延迟部分较密以满足权限检查的 SLO 统计
+var DefBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5}
+
+// registry 服务的指标，包括 Go 运行时和进程的指标，不使用 prometheus 的全局 registry
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+}
+
+// WriteTo 以 Prometheus 文本格式（0.0.4）输出所有指标
+func WriteTo(w io.Writer) error {
+	mfs, err := registry.Gather()
+	if err != nil {
+		return err
+	}
+	enc := expfmt.NewEncoder(w, expfmt.FmtText)
+	for _, mf := range mfs {
+		if err := enc.Encode(mf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TenantTier 返回租户在指标中的等级标签，由 metrics.tenant_tiers 配置，未配置的租户为 default，
+// 不属于租户的请求为 none。指标不以租户 OTID 为标签，避免序列数量随租户增长
+func TenantTier(tenant string) string {
+	if tenant == "" {
+		return "none"
+	}
+	if tier, ok := conf.Config.Metrics.TenantTiers[tenant]; ok {
+		return tier
+	}
+	return "default"
+}
+
+func newCounterVec(name, help string, labels ...string) *prometheus.CounterVec {
+	c := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)
+	registry.MustRegister(c)
+	return c
+}
+
+func newHistogramVec(name, help string, labels ...string) *prometheus.HistogramVec {
+	h := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: DefBuckets}, labels)
+	registry.MustRegister(h)
+	return h
+}
+
+// StateGauge 在每次采集时读取当前状态的 Gauge，当前状态为 1，其它状态为 0
+type StateGauge struct {
+	desc    *prometheus.Desc
+	mu      sync.Mutex
+	states  []string
+	current func() string
+}
+
+func newStateGauge(name, help, label string) *StateGauge {
+	g := &StateGauge{desc: prometheus.NewDesc(name, help, []string{label}, nil)}
+	registry.MustRegister(g)
+	return g
+}
+
+// Watch 设置所有可能的状态和读取当前状态的函数，替换此前设置的函数
+func (g *StateGauge) Watch(states []string, current func() string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.states = states
+	g.current = current
+}
+
+// Describe 实现 prometheus.Collector
+func (g *StateGauge) Describe(ch chan<- *prometheus.Desc) {
+	ch <- g.desc
+}
+
+// Collect 实现 prometheus.Collector
+func (g *StateGauge) Collect(ch chan<- prometheus.Metric) {
+	g.mu.Lock()
+	states, current := g.states, g.current
+	g.mu.Unlock()
+	if current == nil {
+		return
+	}
+	cur := current()
+	for _, s := range states {
+		v := 0.0
+		if s == cur {
+			v = 1
+		}
+		ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, v, s)
+	}
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteTo(t *testing.T) {
+	HTTPRequests.WithLabelValues("/version", "200", "none").Inc()
+	ACCheckDuration.WithLabelValues("CheckUnit", "default").Observe(0.002)
+	state := "READY"
+	DgraphConnectionState.Watch([]string{"IDLE", "READY"}, func() string { return state })
+
+	buf := &bytes.Buffer{}
+	if err := WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+	body := buf.String()
+	for _, s := range []string{
+		`otac_http_requests_total{code="200",route="/version",tier="none"} 1`,
+		`otac_ac_check_duration_seconds_bucket{check="CheckUnit",tier="default",le="0.0025"} 1`,
+		`otac_dgraph_connection_state{state="IDLE"} 0`,
+		`otac_dgraph_connection_state{state="READY"} 1`,
+		"go_goroutines ",
+		"process_cpu_seconds_total ",
+	} {
+		if !strings.Contains(body, s) {
+			t.Fatalf("metrics should contain %q, got:\n%s", s, body)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package metrics
+
+// 服务的指标，标签中的 tier 为 TenantTier 返回的租户等级
+var (
+	// HTTPRequests HTTP 请求数，route 为匹配的路由，未匹配时为 unmatched
+	HTTPRequests = newCounterVec("otac_http_requests_total",
+		"Total number of HTTP requests.", "route", "code", "tier")
+	// HTTPRequestDuration HTTP 请求的处理时间
+	HTTPRequestDuration = newHistogramVec("otac_http_request_duration_seconds",
+		"HTTP request latencies in seconds.", "route", "tier")
+
+	// GRPCRequests gRPC 请求数，method 为完整的方法名，如 /otac.v1.AC/CheckObject
+	GRPCRequests = newCounterVec("otac_grpc_requests_total",
+		"Total number of gRPC requests.", "method", "code", "tier")
+	// GRPCRequestDuration gRPC 请求的处理时间
+	GRPCRequestDuration = newHistogramVec("otac_grpc_request_duration_seconds",
+		"gRPC request latencies in seconds.", "method", "tier")
+
+	// ACChecks 权限检查次数，result 为 allow、deny 或 error
+	ACChecks = newCounterVec("otac_ac_checks_total",
+		"Total number of access checks by result.", "check", "result", "tier")
+	// ACCheckDuration 权限检查的处理时间，包括 HTTP、gRPC 和 ext_authz 的检查
+	ACCheckDuration = newHistogramVec("otac_ac_check_duration_seconds",
+		"Access check latencies in seconds.", "check", "tier")
+
+	// DgraphRequestDuration Dgraph 请求的往返时间，method 为发起请求的 model 方法，如 Unit.AddPermissions
+	DgraphRequestDuration = newHistogramVec("otac_dgraph_request_duration_seconds",
+		"Dgraph round-trip latencies in seconds by the model method that issued them.", "method")
+	// DgraphRequestErrors Dgraph 请求出错的次数
+	DgraphRequestErrors = newCounterVec("otac_dgraph_request_errors_total",
+		"Total number of failed Dgraph requests by the model method that issued them.", "method")
+	// DgraphConnectionState 到 Dgraph 的 gRPC 连接状态，当前状态为 1，其它状态为 0
+	DgraphConnectionState = newStateGauge("otac_dgraph_connection_state",
+		"State of the gRPC connection to Dgraph, 1 for the current state.", "state")
+)
@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"context"
+
 	"github.com/open-trust/ot-ac/src/bll"
 	"github.com/open-trust/ot-ac/src/conf"
 	"github.com/open-trust/ot-ac/src/logging"
+	"github.com/open-trust/ot-ac/src/model"
 	"github.com/open-trust/ot-ac/src/tpl"
 	otgo "github.com/open-trust/ot-go-lib"
 	"github.com/teambition/gear"
@@ -16,54 +19,79 @@ const (
 	tenantKey
 )
 
-// VerifyAdmin 验证请求者管理员身份，如果验证失败，则返回 401 的 gear.HTTPError
-func VerifyAdmin(ctx *gear.Context) error {
-	token := otgo.ExtractTokenFromHeader(ctx.Req.Header)
+// AuthenticateAdmin 验证 token 是否为管理员的 OTVID，供 HTTP 和 gRPC 接口共用
+func AuthenticateAdmin(ctx context.Context, token string) (*otgo.OTVID, error) {
 	if token == "" {
-		return gear.ErrUnauthorized.WithMsg("invalid authorization token")
+		return nil, gear.ErrUnauthorized.WithMsg("invalid authorization token")
 	}
 
 	vid, err := conf.OT.ParseOTVID(ctx, token)
 	if err != nil {
-		return gear.ErrUnauthorized.From(err)
+		return nil, gear.ErrUnauthorized.From(err)
 	}
 	if !vid.ID.Equal(conf.OT.OTID) {
-		return gear.ErrForbidden.WithMsgf("%s is not admin", vid.ID.String())
+		return vid, gear.ErrForbidden.WithMsgf("%s is not admin", vid.ID.String())
 	}
-
-	ctx.SetAny(authKey, vid)
-	logging.AccessLogger.SetTo(ctx, "subject", vid.ID.String())
-	return nil
+	return vid, nil
 }
 
-// VerifyTenant 验证请求者租户身份，如果验证失败，则返回 401 的 gear.HTTPError
-func VerifyTenant(ctx *gear.Context) error {
-	token := otgo.ExtractTokenFromHeader(ctx.Req.Header)
+// AuthenticateTenant 验证 token 是否为有效租户的 OTVID，返回 OTVID 和租户，供 HTTP 和 gRPC 接口共用
+func AuthenticateTenant(ctx context.Context, blls *bll.Blls, token string) (*otgo.OTVID, *tpl.Tenant, error) {
 	if token == "" {
-		return gear.ErrUnauthorized.WithMsg("invalid authorization token")
+		return nil, nil, gear.ErrUnauthorized.WithMsg("invalid authorization token")
 	}
 
 	vid, err := conf.OT.ParseOTVID(ctx, token)
 	if err != nil {
-		return gear.ErrUnauthorized.From(err)
+		return nil, nil, gear.ErrUnauthorized.From(err)
+	}
+
+	tenant, err := blls.Models.Tenant.Get(ctx, vid.ID)
+	if err != nil {
+		return vid, nil, gear.ErrUnauthorized.WithMsgf("invalid tenant: %s", err.Error())
+	}
+	if tenant.Status < 0 {
+		return vid, tenant, gear.ErrForbidden.WithMsgf("%s is forbidden", vid.ID.String())
+	}
+	return vid, tenant, nil
+}
+
+// VerifyAdmin 验证请求者管理员身份，如果验证失败，则返回 401 的 gear.HTTPError
+func VerifyAdmin(ctx *gear.Context) error {
+	vid, err := AuthenticateAdmin(ctx, otgo.ExtractTokenFromHeader(ctx.Req.Header))
+	if vid != nil {
+		logging.AccessLogger.SetTo(ctx, "subject", vid.ID.String())
+	}
+	if err != nil {
+		return err
 	}
 
 	ctx.SetAny(authKey, vid)
-	logging.AccessLogger.SetTo(ctx, "subject", vid.ID.String())
+	ctx.WithContext(model.ContextWithActor(ctx.Context(), vid.ID.String()))
+	return nil
+}
 
+// VerifyTenant 验证请求者租户身份，如果验证失败，则返回 401 的 gear.HTTPError
+func VerifyTenant(ctx *gear.Context) error {
 	blls, err := bll.FromCtx(ctx)
 	if err != nil {
 		return err
 	}
-	tenant, err := blls.Models.Tenant.Get(ctx, vid.ID)
-	if err != nil {
-		return gear.ErrUnauthorized.WithMsgf("invalid tenant: %s", err.Error())
+
+	vid, tenant, err := AuthenticateTenant(ctx, blls, otgo.ExtractTokenFromHeader(ctx.Req.Header))
+	if vid != nil {
+		logging.AccessLogger.SetTo(ctx, "subject", vid.ID.String())
 	}
-	logging.AccessLogger.SetTo(ctx, "tenant", tenant.Tenant)
-	if tenant.Status < 0 {
-		return gear.ErrForbidden.WithMsgf("%s is forbidden", vid.ID.String())
+	if tenant != nil {
+		logging.AccessLogger.SetTo(ctx, "tenant", tenant.Tenant)
 	}
+	if err != nil {
+		return err
+	}
+
+	ctx.SetAny(authKey, vid)
 	ctx.SetAny(tenantKey, tenant)
+	ctx.WithContext(model.ContextWithActor(ctx.Context(), vid.ID.String()))
 	return nil
 }
 
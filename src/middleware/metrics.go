@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/metrics"
+	"github.com/teambition/gear"
+)
+
+// Metrics 按路由和租户等级记录 HTTP 请求数和处理时间，未匹配路由的请求记为 unmatched
+func Metrics(ctx *gear.Context) error {
+	start := time.Now()
+	ctx.OnEnd(func() {
+		route := gear.GetRouterPatternFromCtx(ctx)
+		if route == "" {
+			route = "unmatched"
+		}
+		tier := metrics.TenantTier("")
+		if tenant, err := TenantFromCtx(ctx); err == nil {
+			tier = metrics.TenantTier(tenant.Tenant)
+		}
+		metrics.HTTPRequests.WithLabelValues(route, strconv.Itoa(ctx.Res.Status()), tier).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(route, tier).Observe(time.Since(start).Seconds())
+	})
+	return nil
+}
@@ -2,11 +2,11 @@ package model
 
 import (
 	"context"
-	"fmt"
 	"strings"
+	"time"
 
+	"github.com/open-trust/ot-ac/src/service/dgraph"
 	"github.com/open-trust/ot-ac/src/tpl"
-	"github.com/open-trust/ot-ac/src/util"
 
 	daggo "github.com/open-trust/dag-go"
 )
@@ -49,6 +49,12 @@ type jsonRawPermissionsOutput struct {
 	ID          string                   `json:"targetId"`
 	Type        string                   `json:"targetType"`
 	Permissions []map[string]interface{} `json:"permissions"`
+	Roles       []jsonRawRoleOutput      `json:"roles"`
+}
+
+type jsonRawRoleOutput struct {
+	Role        string           `json:"role"`
+	Permissions []tpl.Permission `json:"permissions"`
 }
 
 func rawToPermissions(input jsonRawPermissionsOutput) []tpl.ACPermissionPayload {
@@ -60,15 +66,47 @@ func rawToPermissions(input jsonRawPermissionsOutput) []tpl.ACPermissionPayload
 		p.Permission = raw["permission"].(string)
 		p.Extensions = make(map[string]interface{})
 		for k, v := range raw {
-			if strings.HasPrefix(k, "permissions|") {
-				p.Extensions[k[12:]] = v
+			if !strings.HasPrefix(k, "permissions|") {
+				continue
+			}
+			switch k = k[12:]; k {
+			case "notBefore":
+				p.NotBefore = parseFacetTime(v)
+			case "notAfter":
+				p.NotAfter = parseFacetTime(v)
+			default:
+				p.Extensions[k] = v
 			}
 		}
 		data = append(data, p)
 	}
+	// 通过角色获得的权限
+	for _, role := range input.Roles {
+		for _, rp := range role.Permissions {
+			p := tpl.ACPermissionPayload{
+				Target: tpl.Target{Type: input.Type, ID: input.ID},
+				Role:   role.Role,
+			}
+			p.Permission = rp.Permission
+			p.Extensions = make(map[string]interface{})
+			data = append(data, p)
+		}
+	}
 	return data
 }
 
+func parseFacetTime(v interface{}) *time.Time {
+	s, ok := v.(string)
+	if !ok {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
 func rawsToPermissions(input []jsonRawPermissionsOutput) []tpl.ACPermissionPayload {
 	data := make([]tpl.ACPermissionPayload, 0, len(input))
 	for _, target := range input {
@@ -80,6 +118,7 @@ func rawsToPermissions(input []jsonRawPermissionsOutput) []tpl.ACPermissionPaylo
 // CheckUnit 检查请求主体到指定管理单元有没有指定权限
 func (m *AC) CheckUnit(ctx context.Context, tenant tpl.Tenant, subject string,
 	unit tpl.Target, permissions []string, withOrganization bool) (interface{}, error) {
+	ctx = dgraph.WithMethod(ctx, "AC.CheckUnit")
 	unitUID, _, _, err := m.acquireUnitObjectScope(ctx, tenant, &unit, nil, nil, 0)
 	if err != nil {
 		return nil, err
@@ -92,21 +131,24 @@ func (m *AC) CheckUnit(ctx context.Context, tenant tpl.Tenant, subject string,
 
 	dag = dag.CloseDAG(&V{UID: subject, Typ: "Subject"}, &V{UID: unitUID, Typ: "Unit"})
 	unitUIDs := getIDsFromDAG(dag, "Unit")
-	if respondDetail(ctx) {
+	return checkResult(ctx, func() (bool, error) {
+		return m.checkUnitPermissions(ctx, tenant.UID, unitUIDs, permissions)
+	}, func() ([]tpl.ACPermissionPayload, error) {
 		return m.checkUnitPermissionsWithDetail(ctx, tenant.UID, unitUIDs, permissions)
-	}
-	return m.checkUnitPermissions(ctx, tenant.UID, unitUIDs, permissions)
+	})
 }
 
 func (m *AC) getUnitsDAG(ctx context.Context, subject, tenantUID string, withOrganization bool) (*daggo.DAG, error) {
-	sub := util.FormatStr(subject)
-	tt := util.FormatUID(tenantUID)
-	q := ""
+	q := dgraph.NewQuery()
+	sub := q.Str(subject)
+	tt := q.UID(tenantUID)
+	vf := validityFacets(q, time.Now())
+	body := dgraph.DQL{}
 	switch {
 	case withOrganization:
-		q = fmt.Sprintf(`query {
+		body = dgraph.Sprintf(`
 			var(func: eq(OTAC.Sub, %s), first: 1) @filter(ge(OTAC.status, 0)) {
-				~OTAC.U-Ss @filter(uid_in(OTAC.U-T, %s) AND ge(OTAC.status, 0))  {
+				~OTAC.U-Ss %s @filter(uid_in(OTAC.U-T, %s) AND ge(OTAC.status, 0))  {
 					unitUIDs0 as uid
 				}
 				~OTAC.M-S @filter(ge(OTAC.status, 0)) {
@@ -133,24 +175,23 @@ func (m *AC) getUnitsDAG(ctx context.Context, subject, tenantUID string, withOrg
 			result(func: uid(unitUIDs0, unitUIDs1, unitUIDs2, unitUIDs3)) @recurse(loop: false) {
 				uid
 				OTAC.U-Us @filter(ge(OTAC.status, 0))
-			}
-		}`, sub, tt, tt, tt, tt)
+			}`, sub, vf, tt, tt, tt, tt)
 	default:
-		q = fmt.Sprintf(`query {
+		body = dgraph.Sprintf(`
 			var(func: eq(OTAC.Sub, %s), first: 1) @filter(ge(OTAC.status, 0)) {
-				~OTAC.U-Ss @filter(uid_in(OTAC.U-T, %s) AND ge(OTAC.status, 0))  {
+				~OTAC.U-Ss %s @filter(uid_in(OTAC.U-T, %s) AND ge(OTAC.status, 0))  {
 					unitUIDs as uid
 				}
 			}
 			result(func: uid(unitUIDs)) @recurse(loop: false) {
 				uid
 				OTAC.U-Us @filter(ge(OTAC.status, 0))
-			}
-		}`, sub, tt)
+			}`, sub, vf, tt)
 	}
 
+	query, vars := q.Build(body)
 	data := make([]jsonCheckUnitOutput, 0, 10)
-	if err := m.Model.List(ctx, q, nil, &data); err != nil {
+	if err := m.Model.List(ctx, query, vars, &data); err != nil {
 		return nil, err
 	}
 	dag := daggo.New()
@@ -177,18 +218,129 @@ func (m *AC) getUnitsDAG(ctx context.Context, subject, tenantUID string, withOrg
 	return dag, nil
 }
 
+// maxImpliesDepth 蕴含方带来新通配符权限时的最大查询轮数
+const maxImpliesDepth = 10
+
+// permissionCover 记录每个被检查的权限能被哪些授予的权限覆盖，
+// 包括其自身、上级通配符权限，以及递归蕴含它的权限及其上级通配符权限
+type permissionCover map[string]map[string]struct{}
+
+func (pc permissionCover) candidates() []string {
+	res := make([]string, 0)
+	cr := map[string]struct{}{}
+	for _, set := range pc {
+		for p := range set {
+			if _, ok := cr[p]; !ok {
+				cr[p] = struct{}{}
+				res = append(res, p)
+			}
+		}
+	}
+	return res
+}
+
+func (pc permissionCover) covers(granted, permission string) bool {
+	_, ok := pc[permission][granted]
+	return ok
+}
+
+// jsonImpliedBy @recurse 查询结果不支持别名，使用谓词原名
+type jsonImpliedBy struct {
+	Permission string          `json:"OTAC.P"`
+	ImpliedBy  []jsonImpliedBy `json:"~OTAC.P-Ps"`
+}
+
+func (v jsonImpliedBy) walk(impliedBy map[string][]string, seen map[[2]string]struct{}, fn func(string)) {
+	for _, ib := range v.ImpliedBy {
+		edge := [2]string{v.Permission, ib.Permission}
+		if _, ok := seen[edge]; !ok {
+			seen[edge] = struct{}{}
+			impliedBy[v.Permission] = append(impliedBy[v.Permission], ib.Permission)
+			fn(ib.Permission)
+		}
+		ib.walk(impliedBy, seen, fn)
+	}
+}
+
+// expandPermissions 展开被检查权限的通配符权限与蕴含关系，如 "Doc.edit" 蕴含 "Doc.read"，则检查 "Doc.read" 时
+// "Doc.edit"、"Doc.*" 都能覆盖。蕴含链由一次 @recurse 查询取回，只有蕴含方带来新的通配符权限（如 "Folder.edit"
+// 蕴含 "Doc.read" 时的 "Folder.*"）才需要再查询一轮
+func (m *AC) expandPermissions(ctx context.Context, tenantUID string, permissions []string) (permissionCover, error) {
+	impliedBy := make(map[string][]string)
+	seen := make(map[[2]string]struct{})
+	queried := make(map[string]struct{})
+	frontier := tpl.WildcardPermissions(permissions)
+	for i := 0; i < maxImpliesDepth && len(frontier) > 0; i++ {
+		for _, p := range frontier {
+			queried[p] = struct{}{}
+		}
+		q := dgraph.NewQuery()
+		query, vars := q.Build(dgraph.Sprintf(`
+			result(func: eq(OTAC.P, %s)) @filter(uid_in(OTAC.P-T, %s)) @recurse(loop: false) {
+				OTAC.P
+				~OTAC.P-Ps @filter(uid_in(OTAC.P-T, %s))
+			}`, q.Strs(frontier), q.UID(tenantUID), q.UID(tenantUID)))
+		data := make([]jsonImpliedBy, 0, len(frontier))
+		if err := m.Model.List(ctx, query, vars, &data); err != nil {
+			return nil, err
+		}
+
+		next := make([]string, 0)
+		for _, v := range data {
+			v.walk(impliedBy, seen, func(p string) {
+				queried[p] = struct{}{}
+				for _, w := range tpl.WildcardPermissions([]string{p}) {
+					if _, ok := queried[w]; !ok {
+						queried[w] = struct{}{}
+						next = append(next, w)
+					}
+				}
+			})
+		}
+		frontier = next
+	}
+
+	pc := make(permissionCover, len(permissions))
+	for _, p := range permissions {
+		set := make(map[string]struct{})
+		queue := tpl.WildcardPermissions([]string{p})
+		for len(queue) > 0 {
+			x := queue[0]
+			queue = queue[1:]
+			if _, ok := set[x]; ok {
+				continue
+			}
+			set[x] = struct{}{}
+			for _, y := range impliedBy[x] {
+				queue = append(queue, tpl.WildcardPermissions([]string{y})...)
+			}
+		}
+		pc[p] = set
+	}
+	return pc, nil
+}
+
 func (m *AC) checkUnitPermissions(ctx context.Context, tenantUID string, unitUIDs, permissions []string) (bool, error) {
 	if len(unitUIDs) == 0 {
 		return false, nil
 	}
-	q := fmt.Sprintf(`query {
+	pc, err := m.expandPermissions(ctx, tenantUID, permissions)
+	if err != nil {
+		return false, err
+	}
+	q := dgraph.NewQuery()
+	fTenantUID := q.UID(tenantUID)
+	fPermissions := q.Strs(pc.candidates())
+	query, vars := q.Build(dgraph.Sprintf(`
 		var(func: uid(%s)) @filter(uid_in(OTAC.U-T, %s) AND ge(OTAC.status, 0)) {
-			uids as OTAC.U-Ps @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, [%s]))
+			uids as OTAC.U-Ps %s @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, %s))
+			OTAC.U-Rs @filter(uid_in(OTAC.R-T, %s)) {
+				roleUIDs as OTAC.R-Ps @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, %s))
+			}
 		}
-		result(func: uid(uids), first: 1) { uid }
-	}`, strings.Join(util.FormatUIDs(unitUIDs), ", "), util.FormatUID(tenantUID), util.FormatUID(tenantUID), strings.Join(util.FormatStrs(permissions), ", "))
+		result(func: uid(uids, roleUIDs), first: 1) { uid }`, q.UIDs(unitUIDs), fTenantUID, validityFacets(q, time.Now()), fTenantUID, fPermissions, fTenantUID, fTenantUID, fPermissions))
 	data := make([]jsonUID, 0)
-	if err := m.Model.List(ctx, q, nil, &data); err != nil {
+	if err := m.Model.List(ctx, query, vars, &data); err != nil {
 		return false, err
 	}
 	return (len(data) > 0), nil
@@ -198,17 +350,29 @@ func (m *AC) checkUnitPermissionsWithDetail(ctx context.Context, tenantUID strin
 	if len(unitUIDs) == 0 {
 		return make([]tpl.ACPermissionPayload, 0), nil
 	}
-	q := fmt.Sprintf(`query {
+	pc, err := m.expandPermissions(ctx, tenantUID, permissions)
+	if err != nil {
+		return nil, err
+	}
+	q := dgraph.NewQuery()
+	fTenantUID := q.UID(tenantUID)
+	fPermissions := q.Strs(pc.candidates())
+	query, vars := q.Build(dgraph.Sprintf(`
 		result(func: uid(%s)) @filter(uid_in(OTAC.U-T, %s) AND ge(OTAC.status, 0)) {
 			targetType: OTAC.UType
 			targetId: OTAC.UId
-			permissions: OTAC.U-Ps @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, [%s])) @facets {
+			permissions: OTAC.U-Ps %s @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, %s)) @facets {
 				permission: OTAC.P
 			}
-		}
-	}`, strings.Join(util.FormatUIDs(unitUIDs), ", "), util.FormatUID(tenantUID), util.FormatUID(tenantUID), strings.Join(util.FormatStrs(permissions), ", "))
+			roles: OTAC.U-Rs @filter(uid_in(OTAC.R-T, %s)) {
+				role: OTAC.R
+				permissions: OTAC.R-Ps @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, %s)) {
+					permission: OTAC.P
+				}
+			}
+		}`, q.UIDs(unitUIDs), fTenantUID, validityFacets(q, time.Now()), fTenantUID, fPermissions, fTenantUID, fTenantUID, fPermissions))
 	data := make([]jsonRawPermissionsOutput, 0)
-	if err := m.Model.List(ctx, q, nil, &data); err != nil {
+	if err := m.Model.List(ctx, query, vars, &data); err != nil {
 		return nil, err
 	}
 	return rawsToPermissions(data), nil
@@ -217,25 +381,58 @@ func (m *AC) checkUnitPermissionsWithDetail(ctx context.Context, tenantUID strin
 // CheckScope 检查请求主体到指定范围约束有没有指定权限
 func (m *AC) CheckScope(ctx context.Context, tenant tpl.Tenant, subject string,
 	scope tpl.Target, permissions []string, withOrganization bool) (interface{}, error) {
+	ctx = dgraph.WithMethod(ctx, "AC.CheckScope")
 	_, _, scopeUID, err := m.acquireUnitObjectScope(ctx, tenant, nil, nil, &scope, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	dag, err := m.getUnitsDAG(ctx, subject, tenant.UID, withOrganization)
+	unitUIDs, err := m.getScopeUnitUIDs(ctx, subject, tenant.UID, scopeUID, withOrganization)
+	if err != nil {
+		return nil, err
+	}
+	return checkResult(ctx, func() (bool, error) {
+		return m.checkUnitPermissions(ctx, tenant.UID, unitUIDs, permissions)
+	}, func() ([]tpl.ACPermissionPayload, error) {
+		return m.checkUnitPermissionsWithDetail(ctx, tenant.UID, unitUIDs, permissions)
+	})
+}
+
+// CheckObject 检查请求主体通过 Scope 或 Unit -> Object 的连接关系到指定资源对象有没有指定权限，如果 ignoreScope 为 true，则要求必须有 Unit -> Object 的连接关系
+func (m *AC) CheckObject(ctx context.Context, tenant tpl.Tenant, subject string,
+	object tpl.Target, permissions []string, withOrganization, ignoreScope bool) (interface{}, error) {
+	ctx = dgraph.WithMethod(ctx, "AC.CheckObject")
+	_, objectUID, _, err := m.acquireUnitObjectScope(ctx, tenant, nil, &object, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	dag, err := m.getSubjectObjectDAG(ctx, subject, tenant.UID, objectUID, withOrganization, ignoreScope)
+	if err != nil {
+		return nil, err
+	}
+	return checkResult(ctx, func() (bool, error) {
+		return m.checkDAGPermissions(ctx, tenant.UID, dag, permissions)
+	}, func() ([]tpl.ACPermissionPayload, error) {
+		return m.checkDAGPermissionsWithDetail(ctx, tenant.UID, dag, permissions)
+	})
+}
+
+// getScopeUnitUIDs 返回请求主体到范围约束的路径上的所有管理单元
+func (m *AC) getScopeUnitUIDs(ctx context.Context, subject, tenantUID, scopeUID string, withOrganization bool) ([]string, error) {
+	dag, err := m.getUnitsDAG(ctx, subject, tenantUID, withOrganization)
 	if err != nil {
 		return nil, err
 	}
 
 	unitUIDs := getIDsFromDAG(dag, "Unit")
-	q := fmt.Sprintf(`query {
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
 		result(func: uid(%s)) @filter(uid_in(OTAC.U-Scs, %s)) {
 			uid
-		}
-	}`, strings.Join(util.FormatUIDs(unitUIDs), ", "), util.FormatUID(scopeUID))
+		}`, q.UIDs(unitUIDs), q.UID(scopeUID)))
 	data := make([]jsonUID, 0)
-	if err := m.Model.List(ctx, q, nil, &data); err != nil {
-		return false, err
+	if err := m.Model.List(ctx, query, vars, &data); err != nil {
+		return nil, err
 	}
 	scopeNode := &V{UID: scopeUID, Typ: "Scope"}
 	for _, v := range data {
@@ -246,42 +443,31 @@ func (m *AC) CheckScope(ctx context.Context, tenant tpl.Tenant, subject string,
 	}
 
 	dag = dag.CloseDAG(&V{UID: subject, Typ: "Subject"}, scopeNode)
-	unitUIDs = getIDsFromDAG(dag, "Unit")
-
-	if respondDetail(ctx) {
-		return m.checkUnitPermissionsWithDetail(ctx, tenant.UID, unitUIDs, permissions)
-	}
-	return m.checkUnitPermissions(ctx, tenant.UID, unitUIDs, permissions)
+	return getIDsFromDAG(dag, "Unit"), nil
 }
 
-// CheckObject 检查请求主体通过 Scope 或 Unit -> Object 的连接关系到指定资源对象有没有指定权限，如果 ignoreScope 为 true，则要求必须有 Unit -> Object 的连接关系
-func (m *AC) CheckObject(ctx context.Context, tenant tpl.Tenant, subject string,
-	object tpl.Target, permissions []string, withOrganization, ignoreScope bool) (interface{}, error) {
-	_, objectUID, _, err := m.acquireUnitObjectScope(ctx, tenant, nil, &object, nil, 0)
-	if err != nil {
-		return nil, err
-	}
+// getSubjectObjectDAG 返回请求主体通过 Scope 或 Unit -> Object 的连接关系到资源对象的 DAG，没有连接关系时返回空 DAG
+func (m *AC) getSubjectObjectDAG(ctx context.Context, subject, tenantUID, objectUID string, withOrganization, ignoreScope bool) (*daggo.DAG, error) {
 	objectDAG, err := m.getObjectsDAG(ctx, objectUID)
 	if err != nil {
 		return nil, err
 	}
 	if objectDAG.Len() == 0 {
-		if respondDetail(ctx) {
-			return make([]tpl.ACPermissionPayload, 0), nil
-		}
-		return false, nil
+		return daggo.New(), nil
 	}
 
-	q := `query {
+	q := dgraph.NewQuery()
+	objectUIDs := q.UIDs(getIDsFromDAG(objectDAG, "Object"))
+	fTenantUID := q.UID(tenantUID)
+	body := dgraph.Sprintf(`
 		result(func: uid(%s)) @filter(uid_in(OTAC.O-T, %s)) {
 			uid
 			units: OTAC.O-Us @filter(ge(OTAC.status, 0)) {
 				uid
 			}
-		}
-	}`
+		}`, objectUIDs, fTenantUID)
 	if !ignoreScope {
-		q = `query {
+		body = dgraph.Sprintf(`
 			result(func: uid(%s)) @filter(uid_in(OTAC.O-T, %s)) {
 				uid
 				units: OTAC.O-Us @filter(ge(OTAC.status, 0)) {
@@ -290,12 +476,11 @@ func (m *AC) CheckObject(ctx context.Context, tenant tpl.Tenant, subject string,
 				scopes: OTAC.O-Scs @filter(ge(OTAC.status, 0)) {
 					uid
 				}
-			}
-		}`
+			}`, objectUIDs, fTenantUID)
 	}
-	q = fmt.Sprintf(q, strings.Join(util.FormatUIDs(getIDsFromDAG(objectDAG, "Object")), ", "), util.FormatUID(tenant.UID))
+	query, vars := q.Build(body)
 	data := make([]jsonCheckScopeOutput, 0)
-	if err := m.Model.List(ctx, q, nil, &data); err != nil {
+	if err := m.Model.List(ctx, query, vars, &data); err != nil {
 		return nil, err
 	}
 
@@ -318,36 +503,31 @@ func (m *AC) CheckObject(ctx context.Context, tenant tpl.Tenant, subject string,
 	objectUnitUIDs := getIDsFromDAG(objectDAG, "Unit")
 	scopeUIDs := getIDsFromDAG(objectDAG, "Scope")
 	if len(objectUnitUIDs) == 0 && len(scopeUIDs) == 0 {
-		if respondDetail(ctx) {
-			return make([]tpl.ACPermissionPayload, 0), nil
-		}
-		return false, nil
+		return daggo.New(), nil
 	}
 
-	unitDAG, err := m.getUnitsDAG(ctx, subject, tenant.UID, withOrganization)
+	unitDAG, err := m.getUnitsDAG(ctx, subject, tenantUID, withOrganization)
 	if err != nil {
 		return nil, err
 	}
 	if unitDAG.Len() == 0 {
-		if respondDetail(ctx) {
-			return make([]tpl.ACPermissionPayload, 0), nil
-		}
-		return false, nil
+		return daggo.New(), nil
 	}
 
 	if len(scopeUIDs) > 0 {
-		q = fmt.Sprintf(`query {
-			result(func: uid(%s)) @filter(uid_in(OTAC.U-Scs, [%s])) {
+		q := dgraph.NewQuery()
+		query, vars := q.Build(dgraph.Sprintf(`
+			scopeUIDs as var(func: uid(%s))
+			result(func: uid(%s)) @filter(uid_in(OTAC.U-Scs, uid(scopeUIDs))) {
 				uid
 				scopes: OTAC.U-Scs @filter(ge(OTAC.status, 0)) {
 					uid
 				}
-			}
-		}`, strings.Join(util.FormatUIDs(getIDsFromDAG(unitDAG, "Unit")), ", "), strings.Join(util.FormatUIDs(scopeUIDs), ", "))
+			}`, q.UIDs(scopeUIDs), q.UIDs(getIDsFromDAG(unitDAG, "Unit"))))
 		data := make([]jsonCheckScopeOutput, 0)
 
-		if err := m.Model.List(ctx, q, nil, &data); err != nil {
-			return false, err
+		if err := m.Model.List(ctx, query, vars, &data); err != nil {
+			return nil, err
 		}
 		for _, v := range data {
 			start := &V{UID: v.UID, Typ: "Unit"}
@@ -365,11 +545,7 @@ func (m *AC) CheckObject(ctx context.Context, tenant tpl.Tenant, subject string,
 		return nil, err
 	}
 
-	dag := unitDAG.CloseDAG(&V{UID: subject, Typ: "Subject"}, &V{UID: objectUID, Typ: "Object"})
-	if respondDetail(ctx) {
-		return m.checkDAGPermissionsWithDetail(ctx, tenant.UID, dag, permissions)
-	}
-	return m.checkDAGPermissions(ctx, tenant.UID, dag, permissions)
+	return unitDAG.CloseDAG(&V{UID: subject, Typ: "Subject"}, &V{UID: objectUID, Typ: "Object"}), nil
 }
 
 type jsonDAGPermissions struct {
@@ -383,25 +559,34 @@ func (m *AC) checkDAGPermissions(ctx context.Context, tenantUID string, dag *dag
 	}
 	unitUIDs := getIDsFromDAG(dag, "Unit")
 	objectUIDs := getIDsFromDAG(dag, "Object")
-	fTenantUID := util.FormatUID(tenantUID)
-	fPermissions := strings.Join(util.FormatStrs(permissions), ", ")
-	q := fmt.Sprintf(`query {
+	pc, err := m.expandPermissions(ctx, tenantUID, permissions)
+	if err != nil {
+		return false, err
+	}
+	q := dgraph.NewQuery()
+	fTenantUID := q.UID(tenantUID)
+	fPermissions := q.Strs(pc.candidates())
+	query, vars := q.Build(dgraph.Sprintf(`
 		units(func: uid(%s)) @filter(uid_in(OTAC.U-T, %s) AND ge(OTAC.status, 0)) {
 			uid
-			permissions: OTAC.U-Ps @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, [%s])) {
+			permissions: OTAC.U-Ps %s @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, %s)) {
 				permission: OTAC.P
 			}
+			roles: OTAC.U-Rs @filter(uid_in(OTAC.R-T, %s)) {
+				role: OTAC.R
+				permissions: OTAC.R-Ps @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, %s)) {
+					permission: OTAC.P
+				}
+			}
 		}
 		objects(func: uid(%s)) @filter(uid_in(OTAC.O-T, %s)) {
 			uid
-			permissions: OTAC.O-Ps @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, [%s])) {
+			permissions: OTAC.O-Ps @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, %s)) {
 				permission: OTAC.P
 			}
-		}
-	}`, strings.Join(util.FormatUIDs(unitUIDs), ", "), fTenantUID, fTenantUID, fPermissions,
-		strings.Join(util.FormatUIDs(objectUIDs), ", "), fTenantUID, fTenantUID, fPermissions)
+		}`, q.UIDs(unitUIDs), fTenantUID, validityFacets(q, time.Now()), fTenantUID, fPermissions, fTenantUID, fTenantUID, fPermissions, q.UIDs(objectUIDs), fTenantUID, fTenantUID, fPermissions))
 	data := &jsonDAGPermissions{}
-	if err := m.Model.QueryBestEffort(ctx, q, nil, &data); err != nil {
+	if err := m.Model.QueryBestEffort(ctx, query, vars, &data); err != nil {
 		return false, err
 	}
 	if len(data.Units) == 0 && len(data.Objects) == 0 {
@@ -425,27 +610,37 @@ func (m *AC) checkDAGPermissions(ctx context.Context, tenantUID string, dag *dag
 			}
 			return acc
 		case "Object":
-			return removeACPermissionPayload(acc, val.Permissions)
+			return removeACPermissionPayload(acc, val.Permissions, pc)
 		}
 		return acc
 	})
 	return (len(ps) > 0), nil
 }
 
-func removeACPermissionPayload(acc []interface{}, allow []tpl.ACPermissionPayload) []interface{} {
+// removeACPermissionPayload 过滤掉不能透传 Object 的权限，授予的权限和透传的权限都可能是通配符权限或蕴含被检查权限的权限，
+// 只要两者能同时覆盖某个被检查的权限，该授予的权限就可以透传
+func removeACPermissionPayload(acc []interface{}, allow []tpl.ACPermissionPayload, pc permissionCover) []interface{} {
 	if len(allow) == 0 {
 		return acc
 	}
 
 	exists := map[string]struct{}{}
-	for _, p := range allow {
-		exists[p.Permission] = struct{}{}
+	for p := range pc {
+		for _, a := range allow {
+			if pc.covers(a.Permission, p) {
+				exists[p] = struct{}{}
+				break
+			}
+		}
 	}
 	res := make([]interface{}, 0)
 	for _, v := range acc {
 		p := v.(tpl.ACPermissionPayload)
-		if _, ok := exists[p.Permission]; ok {
-			res = append(res, p)
+		for permission := range exists {
+			if pc.covers(p.Permission, permission) {
+				res = append(res, p)
+				break
+			}
 		}
 	}
 	return res
@@ -458,29 +653,38 @@ func (m *AC) checkDAGPermissionsWithDetail(ctx context.Context, tenantUID string
 	}
 	unitUIDs := getIDsFromDAG(dag, "Unit")
 	objectUIDs := getIDsFromDAG(dag, "Object")
-	fTenantUID := util.FormatUID(tenantUID)
-	fPermissions := strings.Join(util.FormatStrs(permissions), ", ")
-	q := fmt.Sprintf(`query {
+	pc, err := m.expandPermissions(ctx, tenantUID, permissions)
+	if err != nil {
+		return nil, err
+	}
+	q := dgraph.NewQuery()
+	fTenantUID := q.UID(tenantUID)
+	fPermissions := q.Strs(pc.candidates())
+	query, vars := q.Build(dgraph.Sprintf(`
 		units(func: uid(%s)) @filter(uid_in(OTAC.U-T, %s) AND ge(OTAC.status, 0)) {
 			uid
 			targetType: OTAC.UType
 			targetId: OTAC.UId
-			permissions: OTAC.U-Ps @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, [%s])) @facets {
+			permissions: OTAC.U-Ps %s @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, %s)) @facets {
 				permission: OTAC.P
 			}
+			roles: OTAC.U-Rs @filter(uid_in(OTAC.R-T, %s)) {
+				role: OTAC.R
+				permissions: OTAC.R-Ps @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, %s)) {
+					permission: OTAC.P
+				}
+			}
 		}
 		objects(func: uid(%s)) @filter(uid_in(OTAC.O-T, %s)) {
 			uid
 			targetType: OTAC.OType
 			targetId: OTAC.OId
-			permissions: OTAC.O-Ps @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, [%s])) {
+			permissions: OTAC.O-Ps @filter(uid_in(OTAC.P-T, %s) AND eq(OTAC.P, %s)) {
 				permission: OTAC.P
 			}
-		}
-	}`, strings.Join(util.FormatUIDs(unitUIDs), ", "), fTenantUID, fTenantUID, fPermissions,
-		strings.Join(util.FormatUIDs(objectUIDs), ", "), fTenantUID, fTenantUID, fPermissions)
+		}`, q.UIDs(unitUIDs), fTenantUID, validityFacets(q, time.Now()), fTenantUID, fPermissions, fTenantUID, fTenantUID, fPermissions, q.UIDs(objectUIDs), fTenantUID, fTenantUID, fPermissions))
 	data := &jsonDAGPermissions{}
-	if err := m.Model.QueryBestEffort(ctx, q, nil, &data); err != nil {
+	if err := m.Model.QueryBestEffort(ctx, query, vars, &data); err != nil {
 		return nil, err
 	}
 	if len(data.Units) == 0 && len(data.Objects) == 0 {
@@ -504,7 +708,7 @@ func (m *AC) checkDAGPermissionsWithDetail(ctx context.Context, tenantUID string
 			}
 			return acc
 		case "Object":
-			return removeACPermissionPayload(acc, val.Permissions)
+			return removeACPermissionPayload(acc, val.Permissions, pc)
 		}
 		return acc
 	})
@@ -527,15 +731,15 @@ type jsonCheckObjectOutput struct {
 }
 
 func (m *AC) getObjectsDAG(ctx context.Context, objectUID string) (*daggo.DAG, error) {
-	q := fmt.Sprintf(`query {
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
 		result(func: uid(%s), first: 1) @recurse(loop: false) {
 			uid
 			OTAC.O-Os
-		}
-	}`, util.FormatUID(objectUID))
+		}`, q.UID(objectUID)))
 
 	data := make([]jsonCheckObjectOutput, 0, 10)
-	if err := m.Model.List(ctx, q, nil, &data); err != nil {
+	if err := m.Model.List(ctx, query, vars, &data); err != nil {
 		return nil, err
 	}
 	dag := daggo.New()
@@ -568,14 +772,176 @@ func (m *AC) getObjectsDAG(ctx context.Context, objectUID string) (*daggo.DAG, e
 
 // ListPermissionsByUnit 列出请求主体到指定管理单元的符合 resource 的权限，如果未指定管理单元，则会查询请求主体能触达的所有管理单元，如果 resources 为空，则会列出所有触达的有效权限
 func (m *AC) ListPermissionsByUnit(ctx context.Context, tenant tpl.Tenant, subject string, unit tpl.Target, resources []string, withOrganization bool) ([]tpl.ACPermissionPayload, error) {
+	ctx = dgraph.WithMethod(ctx, "AC.ListPermissionsByUnit")
+	unitUID := ""
+	if unit.Type != "" {
+		var err error
+		if unitUID, _, _, err = m.acquireUnitObjectScope(ctx, tenant, &unit, nil, nil, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	dag, err := m.getUnitsDAG(ctx, subject, tenant.UID, withOrganization)
+	if err != nil {
+		return nil, err
+	}
+	if unitUID != "" {
+		dag = dag.CloseDAG(&V{UID: subject, Typ: "Subject"}, &V{UID: unitUID, Typ: "Unit"})
+	}
+	return m.listUnitPermissions(ctx, tenant.UID, getIDsFromDAG(dag, "Unit"), resources)
 }
 
 // ListPermissionsByScope 列出请求主体到指定范围约束的符合 resource 的权限，如果 resources 为空，则会列出所有触达的有效权限
 func (m *AC) ListPermissionsByScope(ctx context.Context, tenant tpl.Tenant, subject string, scope tpl.Target, resources []string, withOrganization bool) ([]tpl.ACPermissionPayload, error) {
+	ctx = dgraph.WithMethod(ctx, "AC.ListPermissionsByScope")
+	_, _, scopeUID, err := m.acquireUnitObjectScope(ctx, tenant, nil, nil, &scope, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	unitUIDs, err := m.getScopeUnitUIDs(ctx, subject, tenant.UID, scopeUID, withOrganization)
+	if err != nil {
+		return nil, err
+	}
+	return m.listUnitPermissions(ctx, tenant.UID, unitUIDs, resources)
 }
 
 // ListPermissionsByObject 列出请求主体到指定资源对象的符合 resource 的权限，如果 resources 为空，则会列出所有触达的有效权限
 func (m *AC) ListPermissionsByObject(ctx context.Context, tenant tpl.Tenant, subject string, object tpl.Target, resources []string, withOrganization, ignoreScope bool) ([]tpl.ACPermissionPayload, error) {
+	ctx = dgraph.WithMethod(ctx, "AC.ListPermissionsByObject")
+	_, objectUID, _, err := m.acquireUnitObjectScope(ctx, tenant, nil, &object, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	dag, err := m.getSubjectObjectDAG(ctx, subject, tenant.UID, objectUID, withOrganization, ignoreScope)
+	if err != nil {
+		return nil, err
+	}
+	return m.listDAGPermissions(ctx, tenant.UID, dag, resources)
+}
+
+// resourcesFilter 返回按租户和资源过滤权限节点的条件，如 resources 为 ["Doc"] 时匹配 "Doc.read" 和 "Doc.*"
+func resourcesFilter(q *dgraph.Query, tenantUID dgraph.DQL, resources []string) dgraph.DQL {
+	filter := dgraph.Sprintf("uid_in(OTAC.P-T, %s)", tenantUID)
+	if len(resources) > 0 {
+		filter = dgraph.Sprintf("%s AND regexp(OTAC.P, %s)", filter, q.Regexp(`^(`+strings.Join(resources, "|")+`)\.`))
+	}
+	return filter
+}
+
+// listUnitPermissions 列出管理单元上直接授予和通过角色获得的有效权限
+func (m *AC) listUnitPermissions(ctx context.Context, tenantUID string, unitUIDs, resources []string) ([]tpl.ACPermissionPayload, error) {
+	if len(unitUIDs) == 0 {
+		return make([]tpl.ACPermissionPayload, 0), nil
+	}
+	q := dgraph.NewQuery()
+	fTenantUID := q.UID(tenantUID)
+	fResources := resourcesFilter(q, fTenantUID, resources)
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: uid(%s)) @filter(uid_in(OTAC.U-T, %s) AND ge(OTAC.status, 0)) {
+			targetType: OTAC.UType
+			targetId: OTAC.UId
+			permissions: OTAC.U-Ps %s @filter(%s) @facets {
+				permission: OTAC.P
+			}
+			roles: OTAC.U-Rs @filter(uid_in(OTAC.R-T, %s)) {
+				role: OTAC.R
+				permissions: OTAC.R-Ps @filter(%s) {
+					permission: OTAC.P
+				}
+			}
+		}`, q.UIDs(unitUIDs), fTenantUID, validityFacets(q, time.Now()), fResources, fTenantUID, fResources))
+	data := make([]jsonRawPermissionsOutput, 0)
+	if err := m.Model.List(ctx, query, vars, &data); err != nil {
+		return nil, err
+	}
+	return rawsToPermissions(data), nil
+}
+
+// listDAGPermissions 列出请求主体沿 DAG 到达资源对象的有效权限，资源对象上的权限用于限制可透传的权限，
+// 授予的权限与透传的权限互相覆盖（如 "Doc.*" 与 "Doc.read"）时才能透传
+func (m *AC) listDAGPermissions(ctx context.Context, tenantUID string, dag *daggo.DAG, resources []string) ([]tpl.ACPermissionPayload, error) {
+	res := make([]tpl.ACPermissionPayload, 0)
+	if dag.Len() == 0 {
+		return res, nil
+	}
+	q := dgraph.NewQuery()
+	fTenantUID := q.UID(tenantUID)
+	fResources := resourcesFilter(q, fTenantUID, resources)
+	query, vars := q.Build(dgraph.Sprintf(`
+		units(func: uid(%s)) @filter(uid_in(OTAC.U-T, %s) AND ge(OTAC.status, 0)) {
+			uid
+			targetType: OTAC.UType
+			targetId: OTAC.UId
+			permissions: OTAC.U-Ps %s @filter(%s) @facets {
+				permission: OTAC.P
+			}
+			roles: OTAC.U-Rs @filter(uid_in(OTAC.R-T, %s)) {
+				role: OTAC.R
+				permissions: OTAC.R-Ps @filter(%s) {
+					permission: OTAC.P
+				}
+			}
+		}
+		objects(func: uid(%s)) @filter(uid_in(OTAC.O-T, %s)) {
+			uid
+			targetType: OTAC.OType
+			targetId: OTAC.OId
+			permissions: OTAC.O-Ps @filter(uid_in(OTAC.P-T, %s)) {
+				permission: OTAC.P
+			}
+		}`, q.UIDs(getIDsFromDAG(dag, "Unit")), fTenantUID, validityFacets(q, time.Now()), fResources, fTenantUID, fResources,
+		q.UIDs(getIDsFromDAG(dag, "Object")), fTenantUID, fTenantUID))
+	data := &jsonDAGPermissions{}
+	if err := m.Model.QueryBestEffort(ctx, query, vars, &data); err != nil {
+		return nil, err
+	}
+	for _, unit := range data.Units {
+		v := dag.GetVertice("Unit", unit.UID)
+		v.(*V).Permissions = rawToPermissions(unit)
+	}
+	for _, obj := range data.Objects {
+		v := dag.GetVertice("Object", obj.UID)
+		v.(*V).Permissions = rawToPermissions(obj)
+	}
+	sub := dag.StartingVertices()[0]
+	ps := dag.Iterate(sub, nil, func(v daggo.Vertice, _ int, acc []interface{}) []interface{} {
+		val := v.(*V)
+		switch val.Typ {
+		case "Unit":
+			for _, p := range val.Permissions {
+				acc = append(acc, p)
+			}
+			return acc
+		case "Object":
+			return passACPermissionPayload(acc, val.Permissions)
+		}
+		return acc
+	})
+	for _, p := range ps {
+		res = append(res, p.(tpl.ACPermissionPayload))
+	}
+	return res, nil
+}
+
+// passACPermissionPayload 过滤掉不能透传 Object 的权限，Object 上没有权限时不限制透传
+func passACPermissionPayload(acc []interface{}, allow []tpl.ACPermissionPayload) []interface{} {
+	if len(allow) == 0 {
+		return acc
+	}
+
+	res := make([]interface{}, 0)
+	for _, v := range acc {
+		p := v.(tpl.ACPermissionPayload)
+		for _, a := range allow {
+			if tpl.MatchPermission(p.Permission, a.Permission) || tpl.MatchPermission(a.Permission, p.Permission) {
+				res = append(res, p)
+				break
+			}
+		}
+	}
+	return res
 }
 
 // ListUnits 列出请求主体参与的指定类型的管理单元
@@ -0,0 +1,185 @@
+package model
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	daggo "github.com/open-trust/dag-go"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/open-trust/ot-ac/src/util"
+	"github.com/teambition/gear"
+)
+
+// permissionKeys 返回权限列表的 管理单元:权限:角色，按字母排序
+func permissionKeys(ps []tpl.ACPermissionPayload) string {
+	keys := make([]string, len(ps))
+	for i, p := range ps {
+		keys[i] = p.Target.ID + ":" + p.Permission + ":" + p.Role
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+func TestListPermissions(t *testing.T) {
+	f := newDgraphFixture(t)
+	ac := f.ms.AC
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	f.addPermissions("Doc.read", "Doc.write", "Doc.*", "Folder.read")
+	f.addScope("p1")
+	f.addUnits("", "", "org")
+	f.addUnits("org", "p1", "dept")
+	f.addUnits("dept", "", "team")
+	f.addObjects("", "", "root")
+	f.addObjects("root", "p1", "d1")
+	f.addMember("team", "user:u1", tpl.Validity{})
+	f.grant("org", tpl.PermissionEx{Permission: "Doc.read"})
+	f.grant("dept", tpl.PermissionEx{Permission: "Folder.read"})
+	// 已过期和尚未生效的授权不会被列出，通过角色获得的权限带有角色名
+	f.grant("team", tpl.PermissionEx{Permission: "Doc.write", Validity: tpl.Validity{NotAfter: &past}},
+		tpl.PermissionEx{Permission: "Doc.*", Validity: tpl.Validity{NotBefore: &future}})
+	if _, err := f.ms.Role.Add(f.ctx, f.tenant, "editor", []string{"Doc.*"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ms.Unit.AddRoles(f.ctx, f.tenant, tpl.Target{Type: "team", ID: "team"}, []string{"editor"}); err != nil {
+		t.Fatal(err)
+	}
+
+	list := func(ps []tpl.ACPermissionPayload, err error) string {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return permissionKeys(ps)
+	}
+
+	// 未指定管理单元时列出能触达的所有管理单元的权限
+	if got := list(ac.ListPermissionsByUnit(f.ctx, f.tenant, "user:u1", tpl.Target{}, nil, false)); got != "dept:Folder.read:,org:Doc.read:,team:Doc.*:editor" {
+		t.Fatalf("all units got %s", got)
+	}
+	if got := list(ac.ListPermissionsByUnit(f.ctx, f.tenant, "user:u1", tpl.Target{Type: "team", ID: "dept"}, nil, false)); got != "dept:Folder.read:,team:Doc.*:editor" {
+		t.Fatalf("dept got %s", got)
+	}
+	// resources 按权限的资源完整匹配
+	if got := list(ac.ListPermissionsByUnit(f.ctx, f.tenant, "user:u1", tpl.Target{}, []string{"Folder"}, false)); got != "dept:Folder.read:" {
+		t.Fatalf("Folder got %s", got)
+	}
+	if got := list(ac.ListPermissionsByUnit(f.ctx, f.tenant, "user:u1", tpl.Target{}, []string{"Do"}, false)); got != "" {
+		t.Fatalf("Do got %s", got)
+	}
+	if got := list(ac.ListPermissionsByUnit(f.ctx, f.tenant, "user:u2", tpl.Target{}, nil, false)); got != "" {
+		t.Fatalf("non-member got %s", got)
+	}
+
+	if got := list(ac.ListPermissionsByScope(f.ctx, f.tenant, "user:u1", tpl.Target{Type: "project", ID: "p1"}, nil, false)); got != "dept:Folder.read:,team:Doc.*:editor" {
+		t.Fatalf("scope got %s", got)
+	}
+
+	d1 := tpl.Target{Type: "doc", ID: "d1"}
+	if got := list(ac.ListPermissionsByObject(f.ctx, f.tenant, "user:u1", d1, nil, false, false)); got != "dept:Folder.read:,team:Doc.*:editor" {
+		t.Fatalf("object got %s", got)
+	}
+	if got := list(ac.ListPermissionsByObject(f.ctx, f.tenant, "user:u1", d1, nil, false, true)); got != "" {
+		t.Fatalf("object ignoring scope got %s", got)
+	}
+	// 资源对象上的权限限制可透传的权限
+	if err := f.ms.Object.AddPermissions(f.ctx, f.tenant, d1, []string{"Doc.read"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := list(ac.ListPermissionsByObject(f.ctx, f.tenant, "user:u1", d1, nil, false, false)); got != "team:Doc.*:editor" {
+		t.Fatalf("restricted object got %s", got)
+	}
+}
+
+// vertexIDs 返回 DAG 中 typ 类型顶点对应的 ID，按字母排序，ids 为可能出现的顶点
+func (f *dgraphFixture) vertexIDs(dag *daggo.DAG, typ string, ids ...string) string {
+	names := make(map[string]string, len(ids))
+	for _, id := range ids {
+		names[f.uid(typ, id)] = id
+	}
+	res := make([]string, 0)
+	for _, v := range dag.Vertices(map[string]string{"team": "Unit", "doc": "Object"}[typ]) {
+		if name, ok := names[v.ID()]; ok {
+			res = append(res, name)
+		} else {
+			res = append(res, v.ID())
+		}
+	}
+	sort.Strings(res)
+	return strings.Join(res, ",")
+}
+
+func TestUnitsDAG(t *testing.T) {
+	f := newDgraphFixture(t)
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	f.addUnits("", "", "org")
+	f.addUnits("org", "", "dept")
+	f.addUnits("dept", "", "team", "expired", "pending")
+	f.addMember("team", "user:u1", tpl.Validity{})
+	f.addMember("expired", "user:u1", tpl.Validity{NotAfter: &past})
+	f.addMember("pending", "user:u1", tpl.Validity{NotBefore: &future})
+
+	// 其它租户的管理单元不会出现在 DAG 中
+	other := newDgraphFixture(t)
+	other.addUnits("", "", "other")
+	other.addMember("other", "user:u1", tpl.Validity{})
+
+	ids := []string{"org", "dept", "team", "expired", "pending"}
+	dag, err := f.ms.AC.getUnitsDAG(f.ctx, "user:u1", f.tenant.UID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.vertexIDs(dag, "team", ids...); got != "dept,org,team" {
+		t.Fatalf("units got %s", got)
+	}
+	if vs := dag.StartingVertices(); len(vs) != 1 || vs[0].ID() != "user:u1" {
+		t.Fatalf("starting vertices got %v", vs.IDs())
+	}
+
+	// 已禁用的管理单元及其上级不会被遍历
+	if _, err := f.ms.Model.Storage.Upsert(f.ctx, "OTACUnit", "OTAC.U.UK", util.HashBase64(f.tenant.Tenant, "team", "dept"),
+		map[string]interface{}{"OTAC.status": -1}); err != nil {
+		t.Fatal(err)
+	}
+	if dag, err = f.ms.AC.getUnitsDAG(f.ctx, "user:u1", f.tenant.UID, false); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.vertexIDs(dag, "team", ids...); got != "team" {
+		t.Fatalf("units with disabled parent got %s", got)
+	}
+
+	if dag, err = f.ms.AC.getUnitsDAG(f.ctx, "user:u2", f.tenant.UID, false); err != nil || dag.Len() != 0 {
+		t.Fatalf("unknown subject got %v, %v", dag.Len(), err)
+	}
+}
+
+func TestObjectsDAG(t *testing.T) {
+	f := newDgraphFixture(t)
+	f.addObjects("", "", "root")
+	f.addObjects("root", "", "a", "b")
+	f.addObjects("a", "", "c")
+	f.addObjects("b", "", "c")
+
+	dag, err := f.ms.AC.getObjectsDAG(f.ctx, f.uid("doc", "c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// c 经 a、b 两条路径到达 root
+	if got := f.vertexIDs(dag, "doc", "root", "a", "b", "c"); got != "a,b,c,root" {
+		t.Fatalf("objects got %s", got)
+	}
+	if vs := dag.EndingVertices(); len(vs) != 1 || vs[0].ID() != f.uid("doc", "root") {
+		t.Fatalf("ending vertices got %v", vs.IDs())
+	}
+
+	// root 作为 c 的子级会形成环
+	err = f.ms.Object.BatchAdd(f.ctx, f.tenant, []tpl.Target{{Type: "doc", ID: "root"}}, f.target("doc", "c"), nil)
+	if e, ok := err.(*gear.Error); !ok || e.Code != http.StatusConflict {
+		t.Fatalf("cyclic BatchAdd got %v", err)
+	}
+}
@@ -0,0 +1,847 @@
+package model
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/logging"
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/open-trust/ot-ac/src/util"
+	"github.com/teambition/gear"
+)
+
+// ContextWithActor 在 context 中记录操作者的 OTID，写操作的审计记录从中读取，供 HTTP 和 gRPC 接口在认证后调用
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// ActorFromContext 返回 context 中的操作者，后台任务返回空字符串
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey).(string)
+	return actor
+}
+
+// node 审计记录引用的节点，ref 为记录中的引用，kind 和 ids 用于在写操作之前查找节点
+type node struct {
+	ref  string
+	kind string
+	ids  []string
+}
+
+// anyNode 表示所有的边或节点
+var anyNode = node{ref: "*"}
+
+func newNode(kind string, ids ...string) node {
+	return node{ref: tpl.AuditRef(kind, ids...), kind: kind, ids: ids}
+}
+
+func targetNode(kind string, t tpl.Target) node {
+	return newNode(kind, t.Type, t.ID)
+}
+
+func nameNodes(kind string, names []string) []node {
+	res := make([]node, len(names))
+	for i, name := range names {
+		res[i] = newNode(kind, name)
+	}
+	return res
+}
+
+// memberNodes 组织成员节点，审计记录中以请求主体引用
+func memberNodes(org string, subjects []string) []node {
+	res := make([]node, len(subjects))
+	for i, sub := range subjects {
+		res[i] = node{ref: tpl.AuditRef("subject", sub), kind: "member", ids: []string{org, sub}}
+	}
+	return res
+}
+
+type diffEdge struct {
+	from      node
+	predicate string
+	to        node
+}
+
+// diff 写操作增加和删除的边，以及更新前后的属性
+type diff struct {
+	tenantUID string // 写操作之前租户节点的 uid，由 effective 查询
+	lookup    *auditLookup
+	added     []diffEdge
+	removed   []diffEdge
+	before    map[string]interface{}
+	after     map[string]interface{}
+}
+
+func diffEdges(from node, predicate string, to []node) []diffEdge {
+	res := make([]diffEdge, len(to))
+	for i, t := range to {
+		res[i] = diffEdge{from: from, predicate: predicate, to: t}
+	}
+	return res
+}
+
+func auditEdges(es []diffEdge) []tpl.AuditEdge {
+	if len(es) == 0 {
+		return nil
+	}
+	res := make([]tpl.AuditEdge, len(es))
+	for i, e := range es {
+		res[i] = tpl.AuditEdge{From: e.from.ref, Predicate: e.predicate, To: e.to.ref}
+	}
+	return res
+}
+
+// add 记录 from 到每个 to 的边被增加
+func (d *diff) add(from node, predicate string, to ...node) *diff {
+	d.added = append(d.added, diffEdges(from, predicate, to)...)
+	return d
+}
+
+// remove 记录 from 到每个 to 的边被删除
+func (d *diff) remove(from node, predicate string, to ...node) *diff {
+	d.removed = append(d.removed, diffEdges(from, predicate, to)...)
+	return d
+}
+
+// change 记录属性 key 更新前后的值，更新前的值未知时 before 为 nil
+func (d *diff) change(key string, before, after interface{}) *diff {
+	if before != nil {
+		if d.before == nil {
+			d.before = make(map[string]interface{})
+		}
+		d.before[key] = before
+	}
+	if d.after == nil {
+		d.after = make(map[string]interface{})
+	}
+	d.after[key] = after
+	return d
+}
+
+// attach 记录每个 froms 到 parent 和 scope 的边，parent 或 scope 为 nil 时忽略
+func (d *diff) attach(kind string, froms []tpl.Target, parent, scope *tpl.Target) *diff {
+	for _, from := range froms {
+		if parent != nil {
+			d.add(targetNode(kind, from), "parent", targetNode(kind, *parent))
+		}
+		if scope != nil {
+			d.add(targetNode(kind, from), "scope", targetNode("scope", *scope))
+		}
+	}
+	return d
+}
+
+// auditPredicates 审计记录中各类节点的边对应的存储谓词，为空表示 to 节点存在即边存在，如组织的成员
+var auditPredicates = map[string]string{
+	"unit parent":        "OTAC.U-Us",
+	"unit scope":         "OTAC.U-Scs",
+	"unit permissions":   "OTAC.U-Ps",
+	"unit roles":         "OTAC.U-Rs",
+	"unit subjects":      "OTAC.U-Ss",
+	"unit members":       "OTAC.U-Ms",
+	"unit org":           "OTAC.U-Orgs",
+	"unit ou":            "OTAC.U-OUs",
+	"object parent":      "OTAC.O-Os",
+	"object scope":       "OTAC.O-Scs",
+	"object permissions": "OTAC.O-Ps",
+	"object units":       "OTAC.O-Us",
+	"role permissions":   "OTAC.R-Ps",
+	"ou parent":          "OTAC.OU-OU",
+	"ou members":         "OTAC.OU-Ms",
+	"org members":        "",
+}
+
+// nodeKey 返回节点的唯一键，tenant 为空表示不属于租户的节点
+func nodeKey(tenant string, n node) (string, string) {
+	switch n.kind {
+	case "unit":
+		return "OTAC.U.UK", util.HashBase64(tenant, n.ids...)
+	case "object":
+		return "OTAC.O.UK", util.HashBase64(tenant, n.ids...)
+	case "scope":
+		return "OTAC.Sc.UK", util.HashBase64(tenant, n.ids...)
+	case "permission":
+		return "OTAC.P.UK", util.HashBase64(tenant, n.ids[0])
+	case "role":
+		return "OTAC.R.UK", util.HashBase64(tenant, n.ids[0])
+	case "webhook":
+		return "OTAC.WH.UK", util.HashBase64(tenant, n.ids[0])
+	case "subject":
+		return "OTAC.Sub", n.ids[0]
+	case "org":
+		return "OTAC.Org", n.ids[0]
+	case "tenant":
+		return "OTAC.T", n.ids[0]
+	case "ou":
+		return "OTAC.OU.UK", util.HashBase64(n.ids[0], n.ids[1:]...)
+	case "member":
+		return "OTAC.M.UK", util.HashBase64(n.ids[0], n.ids[1:]...)
+	}
+	return "", ""
+}
+
+// auditLookup 查询 diff 中的节点和边在写操作之前是否存在。每个 from 节点（以及 org members 等以节点存在表示边的 to 节点）
+// 一个查询块 audit_<i>，边以 e<k> 为别名按 to 节点的唯一键过滤，k 为边在 added、removed 中的序号；租户节点为 audit_t
+type auditLookup struct {
+	tenant  string
+	edges   []diffEdge
+	nodes   []node
+	index   map[string]int
+	from    []int // 每条边的 from 节点的下标，-1 表示无法查询的边
+	to      []int // 以节点存在表示边时 to 节点的下标
+	results map[string]json.RawMessage
+	fetch   *dgraph.Prefetch
+}
+
+func newAuditLookup(tenant string, d *diff) *auditLookup {
+	l := &auditLookup{tenant: tenant, index: make(map[string]int)}
+	l.edges = append(append(l.edges, d.added...), d.removed...)
+	l.from = make([]int, len(l.edges))
+	l.to = make([]int, len(l.edges))
+	for k, e := range l.edges {
+		l.from[k], l.to[k] = -1, -1
+		if err := l.check(e); err != nil {
+			logging.Errf("lookup audit edge %s %s %s error: %v", e.from.ref, e.predicate, e.to.ref, err)
+			continue
+		}
+		l.from[k] = l.node(e.from)
+		if e.predicate != "*" && auditPredicates[e.from.kind+" "+e.predicate] == "" {
+			l.to[k] = l.node(e.to)
+		}
+	}
+	return l
+}
+
+// check 检查边的节点和谓词是否可以查询
+func (l *auditLookup) check(e diffEdge) error {
+	if k, _ := nodeKey(l.tenant, e.from); k == "" {
+		return fmt.Errorf("unknown audit node %s", e.from.ref)
+	}
+	if e.predicate == "*" {
+		return nil
+	}
+	pred, ok := auditPredicates[e.from.kind+" "+e.predicate]
+	if !ok {
+		return fmt.Errorf("unknown audit edge %s %s", e.from.kind, e.predicate)
+	}
+	if e.to.ref == "*" {
+		if pred == "" {
+			return fmt.Errorf("unknown audit edge %s %s *", e.from.kind, e.predicate)
+		}
+		return nil
+	}
+	if k, _ := nodeKey(l.tenant, e.to); k == "" {
+		return fmt.Errorf("unknown audit node %s", e.to.ref)
+	}
+	return nil
+}
+
+// node 返回节点的下标，同一个节点只查询一次
+func (l *auditLookup) node(n node) int {
+	key := n.kind + "\x00" + strings.Join(n.ids, "\x00")
+	if i, ok := l.index[key]; ok {
+		return i
+	}
+	l.index[key] = len(l.nodes)
+	l.nodes = append(l.nodes, n)
+	return len(l.nodes) - 1
+}
+
+// build 生成查询块，返回查询块和查询块的名称
+func (l *auditLookup) build(q *dgraph.Query) (dgraph.DQL, []string) {
+	fields := make(map[int][]dgraph.DQL)
+	for k, e := range l.edges {
+		if l.from[k] < 0 || e.predicate == "*" || l.to[k] >= 0 {
+			continue
+		}
+		pred := dgraph.Predicate(auditPredicates[e.from.kind+" "+e.predicate])
+		var field dgraph.DQL
+		if e.to.ref == "*" {
+			field = dgraph.Sprintf("e%s: %s (first: 1) { uid }", dgraph.Index(k), pred)
+		} else {
+			ukKey, ukVal := nodeKey(l.tenant, e.to)
+			field = dgraph.Sprintf("e%s: %s @filter(eq(%s, %s)) { uid }", dgraph.Index(k), pred, dgraph.Predicate(ukKey), q.Str(ukVal))
+		}
+		fields[l.from[k]] = append(fields[l.from[k]], field)
+	}
+	blocks := make([]dgraph.DQL, 0, len(l.nodes)+1)
+	names := make([]string, 0, len(l.nodes)+1)
+	for i, n := range l.nodes {
+		ukKey, ukVal := nodeKey(l.tenant, n)
+		blocks = append(blocks, dgraph.Sprintf(`
+		audit_%s(func: eq(%s, %s), first: 1) {
+			uid
+			%s
+		}`, dgraph.Index(i), dgraph.Predicate(ukKey), q.Str(ukVal), dgraph.Join(fields[i], "\n")))
+		names = append(names, fmt.Sprintf("audit_%d", i))
+	}
+	if l.tenant != "" {
+		blocks = append(blocks, dgraph.Sprintf(`
+		audit_t(func: eq(OTAC.T, %s), first: 1) {
+			uid
+		}`, q.Str(l.tenant)))
+		names = append(names, "audit_t")
+	}
+	return dgraph.Join(blocks, "\n"), names
+}
+
+// result 返回查询块 name 中的节点，节点不存在或查询尚未执行时返回 nil
+func (l *auditLookup) result(name string) (map[string]json.RawMessage, error) {
+	if l.fetch != nil {
+		l.results, _ = l.fetch.Result()
+	}
+	data := l.results[name]
+	if len(data) == 0 {
+		return nil, nil
+	}
+	res := make([]map[string]json.RawMessage, 0, 1)
+	if err := json.Unmarshal(data, &res); err != nil || len(res) == 0 {
+		return nil, err
+	}
+	return res[0], nil
+}
+
+// exists 返回写操作之前第 k 条边是否存在，to 为 * 时表示 from 在该谓词上是否有边，谓词也为 * 时表示 from 节点是否存在
+func (l *auditLookup) exists(k int) (bool, error) {
+	from, err := l.result(fmt.Sprintf("audit_%d", l.from[k]))
+	if err != nil || from == nil || l.edges[k].predicate == "*" {
+		return from != nil, err
+	}
+	if l.to[k] >= 0 {
+		to, err := l.result(fmt.Sprintf("audit_%d", l.to[k]))
+		return to != nil, err
+	}
+	_, ok := from[fmt.Sprintf("e%d", k)]
+	return ok, nil
+}
+
+// done 返回查询是否已执行
+func (l *auditLookup) done() bool {
+	if l.fetch != nil {
+		_, ok := l.fetch.Result()
+		return ok
+	}
+	return l.results != nil
+}
+
+// effective 在写操作之前调用，返回的 diff 在写入审计记录时去掉已存在的增加的边和不存在的删除的边，使审计记录只包含实际的变更。
+// 在 begin 开始的事务中时，查询随事务的第一个请求一起执行，读取写入之前的数据而不增加请求；否则在这里执行一次查询。
+// 未启用审计日志时不查询；查询失败时保留原来的边，只记录错误日志
+func (m *Model) effective(ctx context.Context, tenant string, d *diff) *diff {
+	if m.auditSink == nil || d == nil {
+		return d
+	}
+	l := newAuditLookup(tenant, d)
+	if len(l.nodes) == 0 && tenant == "" {
+		return d
+	}
+	res := &diff{before: d.before, after: d.after, added: d.added, removed: d.removed, lookup: l}
+	if txn := dgraph.TxnFromCtx(ctx); txn != nil {
+		l.fetch = txn.Prefetch(l.build)
+		return res
+	}
+	q := dgraph.NewQuery()
+	body, _ := l.build(q)
+	query, vars := q.Build(body)
+	results := make(map[string]json.RawMessage)
+	if err := m.Query(ctx, query, vars, &results); err != nil {
+		logging.Errf("lookup audit edges error: %v", err)
+		return res
+	}
+	l.results = results
+	return res
+}
+
+// resolve 按 effective 的查询结果返回实际的变更，查询没有执行时保留原来的边
+func (d *diff) resolve() *diff {
+	l := d.lookup
+	if l == nil || !l.done() {
+		return d
+	}
+	res := &diff{before: d.before, after: d.after}
+	if t, err := l.result("audit_t"); err == nil && t != nil {
+		json.Unmarshal(t["uid"], &res.tenantUID)
+	}
+	for k, e := range l.edges {
+		added := k < len(d.added)
+		if l.from[k] >= 0 {
+			ok, err := l.exists(k)
+			if err != nil {
+				logging.Errf("lookup audit edge %s %s %s error: %v", e.from.ref, e.predicate, e.to.ref, err)
+			} else if ok == added {
+				// 已存在的增加的边或不存在的删除的边
+				continue
+			}
+		}
+		if added {
+			res.added = append(res.added, e)
+		} else {
+			res.removed = append(res.removed, e)
+		}
+	}
+	return res
+}
+
+// attr 返回唯一键为 ukVal 的节点当前的属性 key，用于记录更新前的值。未启用审计日志、节点不存在或查询失败时返回 nil
+func (m *Model) attr(ctx context.Context, ukKey, ukVal, key string) interface{} {
+	if m.auditSink == nil {
+		return nil
+	}
+	node, err := m.Storage.GetByUK(ctx, ukKey, ukVal)
+	if err != nil || node == nil {
+		return nil
+	}
+	return node.Attrs[key]
+}
+
+func ref(kind string, t tpl.Target) string {
+	return tpl.AuditRef(kind, t.Type, t.ID)
+}
+
+func refs(kind string, ts []tpl.Target) []string {
+	res := make([]string, len(ts))
+	for i, t := range ts {
+		res[i] = ref(kind, t)
+	}
+	return res
+}
+
+func nameRefs(kind string, names []string) []string {
+	res := make([]string, len(names))
+	for i, name := range names {
+		res[i] = tpl.AuditRef(kind, name)
+	}
+	return res
+}
+
+// eventTargets 变更事件涉及的节点引用
+func eventTargets(ev tpl.WatchEvent) []string {
+	res := make([]string, 0)
+	res = append(res, refs("unit", ev.Units)...)
+	res = append(res, refs("object", ev.Objects)...)
+	res = append(res, refs("scope", ev.Scopes)...)
+	res = append(res, nameRefs("permission", ev.Permissions)...)
+	res = append(res, nameRefs("role", ev.Roles)...)
+	res = append(res, nameRefs("subject", ev.Subjects)...)
+	return res
+}
+
+// audit 在 *err 为 nil 时写入审计记录，供写操作通过 defer 调用。审计记录写入失败不影响已完成的写操作，只记录错误日志
+func (m *Model) audit(ctx context.Context, err *error, tenant, op string, targets []string, d *diff) {
+	if *err != nil || m.auditSink == nil {
+		return
+	}
+	entry := &tpl.AuditEntry{
+		Tenant:    tenant,
+		Actor:     ActorFromContext(ctx),
+		Op:        op,
+		Targets:   targets,
+		CreatedAt: time.Now().UTC(),
+	}
+	if d != nil {
+		d = d.resolve()
+		entry.TenantUID = d.tenantUID
+		entry.Added = auditEdges(d.added)
+		entry.Removed = auditEdges(d.removed)
+		entry.Before = d.before
+		entry.After = d.after
+	}
+	if entry.TenantUID == "" {
+		entry.TenantUID = m.tenantUID(ctx, tenant)
+	}
+	if e := m.auditSink.Write(ctx, entry); e != nil {
+		logging.Errf("write audit entry %s %s error: %v", tenant, op, e)
+	}
+}
+
+// tenantUID 返回租户节点的 uid，租户不存在或查询失败时返回空字符串。
+// 删除租户的写操作需要在删除前通过 effective 查询
+func (m *Model) tenantUID(ctx context.Context, tenant string) string {
+	if tenant == "" {
+		return ""
+	}
+	node, err := m.Storage.GetByUK(ctx, "OTAC.T", tenant)
+	if err != nil || node == nil {
+		return ""
+	}
+	return node.UID
+}
+
+// AuditSink 审计记录的存储，由 audit.sink 配置
+type AuditSink interface {
+	Write(ctx context.Context, entry *tpl.AuditEntry) error
+	// List 按写入顺序列出租户的审计记录，只包含 tenant.UID 的租户节点写入的记录和没有记录租户节点的旧记录
+	List(ctx context.Context, tenant tpl.Tenant, input tpl.AuditListInput) ([]tpl.AuditEntry, error)
+	// Purge 删除以该 OTID 写入的至多 batchSize 条审计记录，返回删除的记录数，返回 0 表示已删除完
+	Purge(ctx context.Context, tenant string, batchSize int) (int, error)
+}
+
+// newAuditSink 根据配置创建 AuditSink，sink 为空时返回 nil，不记录审计日志
+func newAuditSink(m *Model, cfg conf.Audit) (AuditSink, error) {
+	switch cfg.Sink {
+	case "graph":
+		return &graphAuditSink{m}, nil
+	case "file":
+		f, err := os.OpenFile(cfg.Path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, err
+		}
+		return &writerAuditSink{w: f, f: f, path: cfg.Path}, nil
+	case "stdout":
+		return &writerAuditSink{w: os.Stdout}, nil
+	}
+	return nil, nil
+}
+
+// Audit 写操作的审计日志
+type Audit struct {
+	*Model
+}
+
+// List 列出租户的审计记录，未配置 audit.sink 或 sink 为 stdout 时返回 501 错误
+func (m *Audit) List(ctx context.Context, tenant tpl.Tenant, input tpl.AuditListInput) ([]tpl.AuditEntry, error) {
+	ctx = dgraph.WithMethod(ctx, "Audit.List")
+	if m.auditSink == nil {
+		return nil, gear.ErrNotImplemented.WithMsg("audit log is disabled")
+	}
+	return m.auditSink.List(ctx, tenant, input)
+}
+
+// Purge 删除租户的至多 batchSize 条审计记录，返回删除的记录数，返回 0 表示已删除完，供删除租户的任务调用。
+// 未配置 audit.sink 时返回 0，写入标准输出的记录无法删除
+func (m *Audit) Purge(ctx context.Context, tenant string, batchSize int) (int, error) {
+	ctx = dgraph.WithMethod(ctx, "Audit.Purge")
+	if m.auditSink == nil {
+		return 0, nil
+	}
+	return m.auditSink.Purge(ctx, tenant, batchSize)
+}
+
+// graphAuditSink 将审计记录存储在 Dgraph 中，以租户的 OTID 和写入时租户节点的 uid 关联租户，删除租户时由 Purge 删除
+type graphAuditSink struct {
+	m *Model
+}
+
+func (s *graphAuditSink) Write(ctx context.Context, entry *tpl.AuditEntry) error {
+	nq := &dgraph.Nquads{
+		ID:   "_:audit",
+		Type: "OTACAudit",
+		KV: map[string]interface{}{
+			"OTAC.AU.actor":     entry.Actor,
+			"OTAC.AU.op":        entry.Op,
+			"OTAC.AU.createdAt": entry.CreatedAt,
+		},
+	}
+	if entry.Tenant != "" {
+		nq.KV["OTAC.AU.tenant"] = entry.Tenant
+	}
+	if entry.TenantUID != "" {
+		nq.KV["OTAC.AU.tenantUid"] = entry.TenantUID
+	}
+	if len(entry.Targets) > 0 {
+		nq.KV["OTAC.AU.targets"] = entry.Targets
+	}
+	for pred, v := range map[string]interface{}{
+		"OTAC.AU.added":   entry.Added,
+		"OTAC.AU.removed": entry.Removed,
+		"OTAC.AU.before":  entry.Before,
+		"OTAC.AU.after":   entry.After,
+	} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if string(data) != "null" {
+			nq.KV[pred] = string(data)
+		}
+	}
+	data, err := nq.Bytes()
+	if err != nil {
+		return err
+	}
+	return s.m.Do(ctx, "", nil, nil, &api.Mutation{
+		SetNquads: data,
+	})
+}
+
+type jsonAuditEntry struct {
+	UID       string    `json:"uid"`
+	Tenant    string    `json:"tenant"`
+	Actor     string    `json:"actor"`
+	Op        string    `json:"op"`
+	Targets   []string  `json:"targets"`
+	Added     string    `json:"added"`
+	Removed   string    `json:"removed"`
+	Before    string    `json:"before"`
+	After     string    `json:"after"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (s *graphAuditSink) List(ctx context.Context, tenant tpl.Tenant, input tpl.AuditListInput) ([]tpl.AuditEntry, error) {
+	q := dgraph.NewQuery()
+	filters := make([]dgraph.DQL, 0, 6)
+	filters = append(filters, dgraph.Sprintf("(eq(OTAC.AU.tenantUid, %s) OR NOT has(OTAC.AU.tenantUid))", q.Str(tenant.UID)))
+	if input.Target != "" {
+		filters = append(filters, dgraph.Sprintf("eq(OTAC.AU.targets, %s)", q.Str(input.Target)))
+	}
+	if input.Op != "" {
+		filters = append(filters, dgraph.Sprintf("eq(OTAC.AU.op, %s)", q.Str(input.Op)))
+	}
+	if input.Actor != "" {
+		filters = append(filters, dgraph.Sprintf("eq(OTAC.AU.actor, %s)", q.Str(input.Actor)))
+	}
+	if input.Since != nil {
+		filters = append(filters, dgraph.Sprintf("ge(OTAC.AU.createdAt, %s)", q.Time(*input.Since)))
+	}
+	if input.Until != nil {
+		filters = append(filters, dgraph.Sprintf("le(OTAC.AU.createdAt, %s)", q.Time(*input.Until)))
+	}
+	filter := dgraph.Sprintf("@filter(%s)", dgraph.Join(filters, " AND "))
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(OTAC.AU.tenant, %s), first: %s, offset: %s, after: %s) %s {
+			uid
+			tenant: OTAC.AU.tenant
+			actor: OTAC.AU.actor
+			op: OTAC.AU.op
+			targets: OTAC.AU.targets
+			added: OTAC.AU.added
+			removed: OTAC.AU.removed
+			before: OTAC.AU.before
+			after: OTAC.AU.after
+			createdAt: OTAC.AU.createdAt
+		}`, q.Str(tenant.Tenant), q.Int(input.PageSize), q.Int(input.Skip), q.UID(input.PageToken), filter))
+
+	data := make([]jsonAuditEntry, 0, input.PageSize)
+	if err := s.m.List(ctx, query, vars, &data); err != nil {
+		return nil, err
+	}
+	res := make([]tpl.AuditEntry, 0, len(data))
+	for _, d := range data {
+		entry := tpl.AuditEntry{
+			UID:       d.UID,
+			Tenant:    d.Tenant,
+			Actor:     d.Actor,
+			Op:        d.Op,
+			Targets:   d.Targets,
+			CreatedAt: d.CreatedAt,
+		}
+		fields := []struct {
+			src string
+			dst interface{}
+		}{{d.Added, &entry.Added}, {d.Removed, &entry.Removed}, {d.Before, &entry.Before}, {d.After, &entry.After}}
+		for _, f := range fields {
+			if f.src != "" {
+				if err := json.Unmarshal([]byte(f.src), f.dst); err != nil {
+					return nil, err
+				}
+			}
+		}
+		res = append(res, entry)
+	}
+	return res, nil
+}
+
+func (s *graphAuditSink) Purge(ctx context.Context, tenant string, batchSize int) (int, error) {
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		auditUIDs as var(func: eq(OTAC.AU.tenant, %s), first: %s)
+		result(func: uid(auditUIDs)) {
+			count(uid)
+		}`, q.Str(tenant), q.Int(batchSize)))
+	del := &dgraph.Nquads{
+		ID: "uid(auditUIDs)",
+		KV: map[string]interface{}{
+			"*": "*",
+		},
+	}
+	delData, err := del.Bytes()
+	if err != nil {
+		return 0, err
+	}
+
+	out := &jsonDeleteNodes{}
+	err = s.m.Do(ctx, query, vars, out, &api.Mutation{
+		Cond:      "@if(gt(len(auditUIDs), 0))",
+		DelNquads: delData,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return sumCount(out.Result), nil
+}
+
+// writerAuditSink 将审计记录以 JSON lines 写入文件或标准输出，每行带有 "kind": "audit" 以便与访问日志区分。
+// 写入文件时 List 按行扫描文件，以行号作为记录的 uid，Purge 重写文件后行号会变化
+type writerAuditSink struct {
+	mu   sync.Mutex
+	w    io.Writer
+	f    *os.File // 写入文件时为打开的 audit.path，Purge 重写文件后重新打开
+	path string
+}
+
+type auditLine struct {
+	Kind      string `json:"kind"`
+	TenantUID string `json:"tenantUid,omitempty"`
+	*tpl.AuditEntry
+}
+
+func (s *writerAuditSink) Write(ctx context.Context, entry *tpl.AuditEntry) error {
+	data, err := json.Marshal(auditLine{Kind: "audit", TenantUID: entry.TenantUID, AuditEntry: entry})
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+func (s *writerAuditSink) List(ctx context.Context, tenant tpl.Tenant, input tpl.AuditListInput) ([]tpl.AuditEntry, error) {
+	if s.path == "" {
+		return nil, gear.ErrNotImplemented.WithMsg("audit entries written to stdout can not be listed")
+	}
+	after, err := strconv.ParseInt(input.PageToken[2:], 16, 64)
+	if err != nil {
+		return nil, gear.ErrBadRequest.WithMsgf("invalid PageToken %s", strconv.Quote(input.PageToken))
+	}
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	res := make([]tpl.AuditEntry, 0, input.PageSize)
+	skip := input.Skip
+	r := bufio.NewReader(f)
+	for line := int64(1); len(res) < input.PageSize; line++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		data, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line <= after {
+			continue
+		}
+		entry := tpl.AuditEntry{}
+		al := auditLine{AuditEntry: &entry}
+		if err := json.Unmarshal(data, &al); err != nil {
+			return nil, fmt.Errorf("invalid audit entry at line %d: %v", line, err)
+		}
+		if entry.Tenant != tenant.Tenant || (al.TenantUID != "" && al.TenantUID != tenant.UID) || !matchAudit(&entry, input) {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		entry.UID = "0x" + strconv.FormatInt(line, 16)
+		res = append(res, entry)
+	}
+	return res, nil
+}
+
+// Purge 重写 audit.path，一次删除该租户的所有记录，batchSize 不起作用。写入标准输出的记录无法删除，只记录警告日志
+func (s *writerAuditSink) Purge(ctx context.Context, tenant string, batchSize int) (int, error) {
+	if s.path == "" {
+		logging.Warningf("audit entries of %s written to stdout can not be purged", tenant)
+		return 0, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	tmp, err := os.OpenFile(s.path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	removed := 0
+	w := bufio.NewWriter(tmp)
+	r := bufio.NewReader(f)
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		data, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		entry := tpl.AuditEntry{}
+		if json.Unmarshal(data, &entry) == nil && entry.Tenant == tenant {
+			removed++
+			continue
+		}
+		if _, err := w.Write(data); err != nil {
+			return 0, err
+		}
+	}
+	if removed == 0 {
+		return 0, nil
+	}
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+	if err := tmp.Sync(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return 0, err
+	}
+	// 原文件已被替换，之后的记录追加到新文件
+	nf, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return 0, err
+	}
+	s.f.Close()
+	s.f, s.w = nf, nf
+	return removed, nil
+}
+
+func matchAudit(entry *tpl.AuditEntry, input tpl.AuditListInput) bool {
+	if input.Op != "" && entry.Op != input.Op {
+		return false
+	}
+	if input.Actor != "" && entry.Actor != input.Actor {
+		return false
+	}
+	if input.Since != nil && entry.CreatedAt.Before(*input.Since) {
+		return false
+	}
+	if input.Until != nil && entry.CreatedAt.After(*input.Until) {
+		return false
+	}
+	if input.Target != "" {
+		for _, t := range entry.Targets {
+			if t == input.Target {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
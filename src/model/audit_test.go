@@ -0,0 +1,145 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// auditOps 返回审计记录的写操作和增加的边数，如 Unit.BatchAdd:1
+func auditOps(entries []tpl.AuditEntry) []string {
+	res := make([]string, len(entries))
+	for i, e := range entries {
+		res[i] = e.Op + ":" + strconv.Itoa(len(e.Added))
+	}
+	return res
+}
+
+func TestFileAuditSink(t *testing.T) {
+	f := newDgraphFixture(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	fd, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &writerAuditSink{w: fd, f: fd, path: path}
+	f.ms.Model.auditSink = sink
+	other := newDgraphFixture(t)
+	other.ms.Model.auditSink = sink
+	// 先于删除租户执行，租户的清理不写入审计记录
+	t.Cleanup(func() {
+		f.ms.Model.auditSink = nil
+		other.ms.Model.auditSink = nil
+		sink.f.Close()
+	})
+	list := func(tenant tpl.Tenant) []string {
+		input := tpl.AuditListInput{}
+		if err := input.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		entries, err := sink.List(f.ctx, tenant, input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return auditOps(entries)
+	}
+
+	// 已存在的边不记为增加
+	f.addUnits("", "", "org")
+	f.addUnits("org", "", "dept")
+	f.addUnits("org", "", "dept")
+	other.addUnits("", "", "other")
+	if got := list(f.tenant); len(got) != 3 || got[0] != "Unit.BatchAdd:0" || got[1] != "Unit.BatchAdd:1" || got[2] != "Unit.BatchAdd:0" {
+		t.Fatalf("entries got %v", got)
+	}
+
+	// 以相同 OTID 重新创建的租户不能读取此前租户的记录，没有租户节点 uid 的旧记录仍按 OTID 匹配
+	recreated := tpl.Tenant{UID: "0xffff", Tenant: f.tenant.Tenant}
+	if got := list(recreated); len(got) != 0 {
+		t.Fatalf("entries of recreated tenant got %v", got)
+	}
+	if err := sink.Write(f.ctx, &tpl.AuditEntry{Tenant: f.tenant.Tenant, Op: "Legacy"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := list(recreated); len(got) != 1 || got[0] != "Legacy:0" {
+		t.Fatalf("legacy entries got %v", got)
+	}
+
+	// Purge 重写文件，只删除该租户的记录，之后的记录写入新文件
+	if n, err := f.ms.Audit.Purge(f.ctx, f.tenant.Tenant, 100); err != nil || n != 4 {
+		t.Fatalf("purge got %d, %v", n, err)
+	}
+	if n, err := f.ms.Audit.Purge(f.ctx, f.tenant.Tenant, 100); err != nil || n != 0 {
+		t.Fatalf("purge again got %d, %v", n, err)
+	}
+	if got := list(f.tenant); len(got) != 0 {
+		t.Fatalf("entries after purge got %v", got)
+	}
+	other.addUnits("other", "", "team")
+	if got := list(other.tenant); len(got) != 2 || got[1] != "Unit.BatchAdd:1" {
+		t.Fatalf("entries of other tenant got %v", got)
+	}
+}
+
+func TestAuditEffective(t *testing.T) {
+	f := newDgraphFixture(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	fd, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &writerAuditSink{w: fd, f: fd, path: path}
+	f.ms.Model.auditSink = sink
+	t.Cleanup(func() {
+		f.ms.Model.auditSink = nil
+		sink.f.Close()
+	})
+	last := func() tpl.AuditEntry {
+		input := tpl.AuditListInput{}
+		if err := input.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		entries, err := sink.List(f.ctx, f.tenant, input)
+		if err != nil || len(entries) == 0 {
+			t.Fatalf("entries got %v, %v", entries, err)
+		}
+		return entries[len(entries)-1]
+	}
+
+	f.addUnits("", "", "team")
+	if _, err := f.ms.Role.Add(f.ctx, f.tenant, "editor", nil); err != nil {
+		t.Fatal(err)
+	}
+	team := tpl.Target{Type: "team", ID: "team"}
+	// 不存在的边不记为删除，已存在的边不记为增加
+	if err := f.ms.Unit.RemoveRoles(f.ctx, f.tenant, team, []string{"editor"}); err != nil {
+		t.Fatal(err)
+	}
+	if e := last(); e.Op != "Unit.RemoveRoles" || len(e.Removed) != 0 || e.TenantUID != f.tenant.UID {
+		t.Fatalf("remove missing role got %+v", e)
+	}
+	for i := 0; i < 2; i++ {
+		if err := f.ms.Unit.AddRoles(f.ctx, f.tenant, team, []string{"editor"}); err != nil {
+			t.Fatal(err)
+		}
+		if e := last(); e.Op != "Unit.AddRoles" || len(e.Added) != 1-i {
+			t.Fatalf("add role %d got %+v", i, e)
+		}
+	}
+	if err := f.ms.Unit.RemoveRoles(f.ctx, f.tenant, team, []string{"editor"}); err != nil {
+		t.Fatal(err)
+	}
+	if e := last(); len(e.Removed) != 1 || e.Removed[0].To != "role:editor" {
+		t.Fatalf("remove role got %+v", e)
+	}
+	// 删除节点时以节点是否存在判断
+	if err := f.ms.Role.Delete(f.ctx, f.tenant, "editor"); err != nil {
+		t.Fatal(err)
+	}
+	if e := last(); e.Op != "Role.Delete" || len(e.Removed) != 1 {
+		t.Fatalf("delete role got %+v", e)
+	}
+}
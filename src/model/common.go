@@ -6,9 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/open-trust/ot-ac/src/conf"
 	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/service/storage"
 	"github.com/open-trust/ot-ac/src/tpl"
 	"github.com/open-trust/ot-ac/src/util"
 	otgo "github.com/open-trust/ot-go-lib"
@@ -20,37 +23,61 @@ func init() {
 }
 
 // Model ...
+// Storage 为与存储后端无关的图操作，尚未迁移的查询仍直接使用 Dgraph
 type Model struct {
 	*dgraph.Dgraph
+	Storage storage.Storage
+	changes *changeLog
+	// auditSink 为 nil 时不记录审计日志
+	auditSink AuditSink
 }
 
 // Models ...
 type Models struct {
 	Model        *Model
 	AC           *AC
+	GraphQL      *GraphQL
+	Job          *Job
 	Object       *Object
 	Organization *Organization
 	Permission   *Permission
+	Role         *Role
 	Scope        *Scope
+	Snapshot     *Snapshot
 	Unit         *Unit
 	Tenant       *Tenant
 	Subject      *Subject
+	Watch        *Watch
+	Webhook      *Webhook
+	Audit        *Audit
 }
 
 // NewModels ...
-func NewModels(dg *dgraph.Dgraph) *Models {
-	m := &Model{dg}
+func NewModels(dg *dgraph.Dgraph, st storage.Storage) (*Models, error) {
+	m := &Model{Dgraph: dg, Storage: st, changes: newChangeLog(conf.Config.Watch.History)}
+	sink, err := newAuditSink(m, conf.Config.Audit)
+	if err != nil {
+		return nil, err
+	}
+	m.auditSink = sink
 	return &Models{
 		Model:        m,
 		AC:           &AC{m},
+		GraphQL:      &GraphQL{m},
+		Job:          &Job{m},
 		Object:       &Object{m},
 		Organization: &Organization{m},
 		Permission:   &Permission{m},
+		Role:         &Role{m},
 		Scope:        &Scope{m},
+		Snapshot:     &Snapshot{m},
 		Unit:         &Unit{m},
 		Tenant:       &Tenant{m},
 		Subject:      &Subject{m},
-	}
+		Watch:        &Watch{m},
+		Webhook:      &Webhook{m},
+		Audit:        &Audit{m},
+	}, nil
 }
 
 type contextKey int
@@ -58,14 +85,20 @@ type contextKey int
 const (
 	idempotentKey contextKey = iota
 	respondDetailKey
+	actorKey
+	grantedByKey
 )
 
 // ContextWithPrefer ...
 func ContextWithPrefer(ctx *gear.Context) context.Context {
-	c := ctx.Context()
+	return PreferContext(ctx.Context(), ctx.GetHeaders("Prefer"))
+}
+
+// PreferContext 根据 Prefer 的值（如 respond-conflict、respond-detail）生成新的 context，供 HTTP 和 gRPC 接口共用
+func PreferContext(c context.Context, prefers []string) context.Context {
 	idempotent := true
 	respondDetail := false
-	for _, prefer := range ctx.GetHeaders("Prefer") {
+	for _, prefer := range prefers {
 		prefer = strings.ToLower(prefer)
 		if prefer == "respond-conflict" {
 			idempotent = false
@@ -93,6 +126,60 @@ func respondDetail(ctx context.Context) bool {
 	return ctx.Value(respondDetailKey) != nil
 }
 
+// ContextWithRespondDetail 返回要求权限检查返回详细结果的 context，与 Prefer: respond-detail 相同
+func ContextWithRespondDetail(ctx context.Context) context.Context {
+	return context.WithValue(ctx, respondDetailKey, struct{}{})
+}
+
+// IsRespondDetail 返回 context 是否要求权限检查返回详细结果
+func IsRespondDetail(ctx context.Context) bool {
+	return respondDetail(ctx)
+}
+
+// GrantedBy 权限检查中授予权限的管理单元（或透传权限的资源对象）、权限和角色
+type GrantedBy struct {
+	Permissions []tpl.ACPermissionPayload
+}
+
+// ContextWithGrantedBy 返回要求权限检查记录 GrantedBy 的 context，检查以 respond-detail 的方式查询，
+// 返回值仍按调用方是否要求 respond-detail，检查完成后从返回的 GrantedBy 读取，用于决策日志
+func ContextWithGrantedBy(ctx context.Context) (context.Context, *GrantedBy) {
+	g := &GrantedBy{}
+	return context.WithValue(ctx, grantedByKey, g), g
+}
+
+// GrantedByFromContext 返回 ContextWithGrantedBy 创建的 GrantedBy，没有时返回 nil
+func GrantedByFromContext(ctx context.Context) *GrantedBy {
+	g, _ := ctx.Value(grantedByKey).(*GrantedBy)
+	return g
+}
+
+// checkResult 返回权限检查的结果：要求 respond-detail 或记录 GrantedBy 时以 detail 查询，否则以 check 查询
+func checkResult(ctx context.Context, check func() (bool, error), detail func() ([]tpl.ACPermissionPayload, error)) (
+	interface{}, error) {
+	g := GrantedByFromContext(ctx)
+	if g == nil && !respondDetail(ctx) {
+		return check()
+	}
+	ps, err := detail()
+	if err != nil {
+		return nil, err
+	}
+	if g != nil {
+		g.Permissions = ps
+	}
+	if respondDetail(ctx) {
+		return ps, nil
+	}
+	return len(ps) > 0, nil
+}
+
+// validityFacets 返回按 notBefore/notAfter facets 过滤边的 DQL 指令，未设置 facets 的边始终有效
+func validityFacets(q *dgraph.Query, now time.Time) dgraph.DQL {
+	t := q.Time(now)
+	return dgraph.Sprintf("@facets(NOT gt(notBefore, %s) AND NOT lt(notAfter, %s))", t, t)
+}
+
 type jsonUID struct {
 	UID string `json:"uid"`
 }
@@ -103,11 +190,11 @@ func (m *Model) Add(ctx context.Context, nq *dgraph.Nquads) (bool, error) {
 		return false, errors.New("UK and Type required for Create")
 	}
 
-	q := fmt.Sprintf(`query {
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
 		result(func: eq(%s, %s), first: 1) {
 			_uid as uid
-		}
-	}`, nq.UKkey, util.FormatStr(nq.UKval))
+		}`, dgraph.Predicate(nq.UKkey), q.Str(nq.UKval)))
 
 	nq.ID = "_:x"
 	if _, ok := nq.KV[nq.UKkey]; !ok {
@@ -120,7 +207,7 @@ func (m *Model) Add(ctx context.Context, nq *dgraph.Nquads) (bool, error) {
 
 	r := make([]*jsonUID, 0)
 	out := &otgo.Response{Result: &r}
-	err = m.Do(ctx, q, nil, out, &api.Mutation{
+	err = m.Do(ctx, query, vars, out, &api.Mutation{
 		Cond:      "@if(eq(len(_uid), 0))",
 		SetNquads: data,
 	})
@@ -140,7 +227,8 @@ func (m *Model) BatchAdd(ctx context.Context, nqs []*dgraph.Nquads) ([]string, e
 		return uids, nil
 	}
 
-	qs := make([]string, 0, len(nqs))
+	q := dgraph.NewQuery()
+	qs := make([]dgraph.DQL, 0, len(nqs))
 	mus := make([]*api.Mutation, 0, len(nqs))
 
 	for i, nq := range nqs {
@@ -148,7 +236,8 @@ func (m *Model) BatchAdd(ctx context.Context, nqs []*dgraph.Nquads) ([]string, e
 			return nil, errors.New("UK and Type required for Create")
 		}
 		uidx := fmt.Sprintf("uid_%d", i)
-		qs = append(qs, fmt.Sprintf("%s as q_%d(func: eq(%s, %s), first: 1)", uidx, i, nq.UKkey, util.FormatStr(nq.UKval)))
+		qs = append(qs, dgraph.Sprintf("uid_%s as q_%s(func: eq(%s, %s), first: 1)",
+			dgraph.Index(i), dgraph.Index(i), dgraph.Predicate(nq.UKkey), q.Str(nq.UKval)))
 		nq.ID = "_:" + uidx
 		if _, ok := nq.KV[nq.UKkey]; !ok {
 			nq.KV[nq.UKkey] = nq.UKval
@@ -163,11 +252,8 @@ func (m *Model) BatchAdd(ctx context.Context, nqs []*dgraph.Nquads) ([]string, e
 		})
 	}
 
-	q := fmt.Sprintf(`query {
-		%s
-	}`, strings.Join(qs, "\n"))
-
-	res, err := m.DoRaw(ctx, q, nil, mus...)
+	query, vars := q.Build(dgraph.Join(qs, "\n"))
+	res, err := m.DoRaw(ctx, query, vars, mus...)
 	if err != nil {
 		return nil, err
 	}
@@ -182,16 +268,28 @@ type jsonCheckCyclic struct {
 	Result     interface{}       `json:"result"`
 }
 
+func validCheckCyclic(checkCyclic dgraph.DQL) error {
+	s := checkCyclic.String()
+	if !strings.Contains(s, "CyclicData") && !strings.Contains(s, "CyclicUID") {
+		return gear.ErrInternalServerError.WithMsgf("invalid checkCyclic query: %s", util.FormatStr(s))
+	}
+	return nil
+}
+
 // BatchAddOrUpdate ...
-func (m *Model) BatchAddOrUpdate(ctx context.Context, nqs []*dgraph.Nquads, checkCyclic string) error {
+// q 为 nil 时会新建查询，checkCyclic 需要使用 q 注册查询变量
+func (m *Model) BatchAddOrUpdate(ctx context.Context, nqs []*dgraph.Nquads, q *dgraph.Query, checkCyclic dgraph.DQL) error {
 	if len(nqs) == 0 {
 		return nil
 	}
+	if q == nil {
+		q = dgraph.NewQuery()
+	}
 
-	qs := make([]string, 0, 1+len(nqs)/2)
-	if checkCyclic != "" {
-		if !strings.Contains(checkCyclic, "CyclicData") && !strings.Contains(checkCyclic, "CyclicUID") {
-			return gear.ErrInternalServerError.WithMsgf("invalid checkCyclic query: %s", util.FormatStr(checkCyclic))
+	qs := make([]dgraph.DQL, 0, 1+len(nqs)/2)
+	if !checkCyclic.IsZero() {
+		if err := validCheckCyclic(checkCyclic); err != nil {
+			return err
 		}
 		qs = append(qs, checkCyclic)
 	}
@@ -202,7 +300,8 @@ func (m *Model) BatchAddOrUpdate(ctx context.Context, nqs []*dgraph.Nquads, chec
 			return errors.New("UK and Type required for Create")
 		}
 		uidx := fmt.Sprintf("uid_%d", i)
-		qs = append(qs, fmt.Sprintf("%s as q_%d(func: eq(%s, %s), first: 1)", uidx, i, nqs[i].UKkey, util.FormatStr(nqs[i].UKval)))
+		qs = append(qs, dgraph.Sprintf("uid_%s as q_%s(func: eq(%s, %s), first: 1)",
+			dgraph.Index(i), dgraph.Index(i), dgraph.Predicate(nqs[i].UKkey), q.Str(nqs[i].UKval)))
 		nqs[i].ID = "_:" + uidx
 		if _, ok := nqs[i].KV[nqs[i].UKkey]; !ok {
 			nqs[i].KV[nqs[i].UKkey] = nqs[i].UKval
@@ -221,7 +320,7 @@ func (m *Model) BatchAddOrUpdate(ctx context.Context, nqs []*dgraph.Nquads, chec
 			Cond:      fmt.Sprintf("@if(eq(len(%s), 0))", uidx),
 			SetNquads: data,
 		})
-		if checkCyclic != "" {
+		if !checkCyclic.IsZero() {
 			mus = append(mus, &api.Mutation{
 				Cond:      fmt.Sprintf("@if(eq(len(%s), 1) AND eq(len(CyclicUID), 0))", uidx),
 				SetNquads: updateData,
@@ -234,27 +333,28 @@ func (m *Model) BatchAddOrUpdate(ctx context.Context, nqs []*dgraph.Nquads, chec
 		}
 	}
 
-	q := fmt.Sprintf(`query {
-		%s
-	}`, strings.Join(qs, "\n"))
-
+	query, vars := q.Build(dgraph.Join(qs, "\n"))
 	out := &jsonCheckCyclic{}
-	err := m.Do(ctx, q, nil, out, mus...)
+	err := m.Do(ctx, query, vars, out, mus...)
 	if err != nil {
 		return err
 	}
-	if checkCyclic != "" && len(out.CyclicData) > 0 {
+	if !checkCyclic.IsZero() && len(out.CyclicData) > 0 {
 		return gear.ErrConflict.WithMsgf("cyclic graph will come into being: %s", string(out.CyclicData[0]))
 	}
 	return nil
 }
 
 // Update ...
-func (m *Model) Update(ctx context.Context, nq *dgraph.Nquads, checkCyclic string) error {
-	qs := make([]string, 0, 1)
-	if checkCyclic != "" {
-		if !strings.Contains(checkCyclic, "CyclicData") && !strings.Contains(checkCyclic, "CyclicUID") {
-			return gear.ErrInternalServerError.WithMsgf("invalid checkCyclic query: %s", util.FormatStr(checkCyclic))
+// q 为 nil 时会新建查询，checkCyclic 需要使用 q 注册查询变量
+func (m *Model) Update(ctx context.Context, nq *dgraph.Nquads, q *dgraph.Query, checkCyclic dgraph.DQL) error {
+	if q == nil {
+		q = dgraph.NewQuery()
+	}
+	qs := make([]dgraph.DQL, 0, 1)
+	if !checkCyclic.IsZero() {
+		if err := validCheckCyclic(checkCyclic); err != nil {
+			return err
 		}
 		qs = append(qs, checkCyclic)
 	}
@@ -262,10 +362,10 @@ func (m *Model) Update(ctx context.Context, nq *dgraph.Nquads, checkCyclic strin
 		if nq.UKkey == "" || nq.UKval == "" {
 			return errors.New("UK required for Update")
 		}
-		qs = append(qs, fmt.Sprintf(`
+		qs = append(qs, dgraph.Sprintf(`
 		  result(func: eq(%s, %s), first: 1) {
 				_uid as uid
-			}`, nq.UKkey, util.FormatStr(nq.UKval)))
+			}`, dgraph.Predicate(nq.UKkey), q.Str(nq.UKval)))
 		nq.ID = "uid(_uid)"
 	}
 
@@ -278,7 +378,7 @@ func (m *Model) Update(ctx context.Context, nq *dgraph.Nquads, checkCyclic strin
 	if nq.ID == "" {
 		conds = append(conds, "eq(len(_uid), 1)")
 	}
-	if checkCyclic != "" {
+	if !checkCyclic.IsZero() {
 		conds = append(conds, "eq(len(CyclicUID), 0)")
 	}
 
@@ -291,20 +391,19 @@ func (m *Model) Update(ctx context.Context, nq *dgraph.Nquads, checkCyclic strin
 		SetNquads: updateData,
 	}
 
-	q := ""
+	query := ""
+	var vars map[string]string
 	if len(qs) > 0 {
-		q = fmt.Sprintf(`query {
-			%s
-		}`, strings.Join(qs, "\n"))
+		query, vars = q.Build(dgraph.Join(qs, "\n"))
 	}
 
 	r := make([]jsonUID, 0)
 	out := &jsonCheckCyclic{Result: &r}
-	err = m.Do(ctx, q, nil, out, mu)
+	err = m.Do(ctx, query, vars, out, mu)
 	if err != nil {
 		return err
 	}
-	if checkCyclic != "" && len(out.CyclicData) > 0 {
+	if !checkCyclic.IsZero() && len(out.CyclicData) > 0 {
 		return gear.ErrConflict.WithMsgf("cyclic graph will come into being: %s", string(out.CyclicData[0]))
 	}
 	if nq.ID == "" {
@@ -349,29 +448,26 @@ type jsonUnitObjectScope struct {
 }
 
 func (m *Model) acquireUnitObjectScope(ctx context.Context, tenant tpl.Tenant, unit, object, scope *tpl.Target, status int) (string, string, string, error) {
-
-	q := ""
+	q := dgraph.NewQuery()
+	qs := make([]dgraph.DQL, 0, 3)
 	if unit != nil {
-		uk := util.HashBase64(tenant.Tenant, unit.Type, unit.ID)
-		q += fmt.Sprintf("\nunit(func: eq(OTAC.U.UK, %s), first: 1) @filter(ge(OTAC.status, %d)) { uid }", util.FormatStr(uk), status)
+		qs = append(qs, dgraph.Sprintf("unit(func: eq(OTAC.U.UK, %s), first: 1) @filter(ge(OTAC.status, %s)) { uid }",
+			q.Str(util.HashBase64(tenant.Tenant, unit.Type, unit.ID)), q.Int(status)))
 	}
 	if object != nil {
-		uk := util.HashBase64(tenant.Tenant, object.Type, object.ID)
-		q += fmt.Sprintf("\nobject(func: eq(OTAC.O.UK, %s), first: 1) { uid }", util.FormatStr(uk))
+		qs = append(qs, dgraph.Sprintf("object(func: eq(OTAC.O.UK, %s), first: 1) { uid }",
+			q.Str(util.HashBase64(tenant.Tenant, object.Type, object.ID))))
 	}
 	if scope != nil {
-		uk := util.HashBase64(tenant.Tenant, scope.Type, scope.ID)
-		q += fmt.Sprintf("\nscope(func: eq(OTAC.Sc.UK, %s), first: 1) @filter(ge(OTAC.status, %d)) { uid }", util.FormatStr(uk), status)
+		qs = append(qs, dgraph.Sprintf("scope(func: eq(OTAC.Sc.UK, %s), first: 1) @filter(ge(OTAC.status, %s)) { uid }",
+			q.Str(util.HashBase64(tenant.Tenant, scope.Type, scope.ID)), q.Int(status)))
 	}
-	if q == "" {
+	if len(qs) == 0 {
 		return "", "", "", nil
 	}
-	q = fmt.Sprintf(`query {
-		%s
-	}`, q)
+	query, vars := q.Build(dgraph.Join(qs, "\n"))
 	res := &jsonUnitObjectScope{}
-	err := m.Query(ctx, q, nil, res)
-	if err != nil {
+	if err := m.Query(ctx, query, vars, res); err != nil {
 		return "", "", "", err
 	}
 	unitUID := ""
@@ -403,14 +499,32 @@ func (m *Model) acquirePermissions(ctx context.Context, tenant tpl.Tenant, permi
 	for i, p := range permissions {
 		uks[i] = util.HashBase64(tenant.Tenant, p)
 	}
-	q := fmt.Sprintf(`query {
-		result(func: eq(OTAC.P.UK, [%s]), first: %d) {
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(OTAC.P.UK, %s), first: %s) {
 			uid
 			permission: OTAC.P
-		}
-	}`, strings.Join(util.FormatStrs(uks), ", "), len(uks))
+		}`, q.Strs(uks), q.Int(len(uks))))
 	out := make([]tpl.Permission, 0, len(uks))
-	if err := m.List(ctx, q, nil, &out); err != nil {
+	if err := m.List(ctx, query, vars, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (m *Model) acquireRoles(ctx context.Context, tenant tpl.Tenant, roles []string) ([]tpl.Role, error) {
+	uks := make([]string, len(roles))
+	for i, r := range roles {
+		uks[i] = util.HashBase64(tenant.Tenant, r)
+	}
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(OTAC.R.UK, %s), first: %s) {
+			uid
+			role: OTAC.R
+		}`, q.Strs(uks), q.Int(len(uks))))
+	out := make([]tpl.Role, 0, len(uks))
+	if err := m.List(ctx, query, vars, &out); err != nil {
 		return nil, err
 	}
 	return out, nil
@@ -425,17 +539,18 @@ func (m *Model) acquireOrgOU(ctx context.Context, org, ou string, status int) (s
 	if org == "" {
 		return "", "", gear.ErrBadRequest.WithMsg("organization required")
 	}
-	q := fmt.Sprintf("\norg(func: eq(OTAC.Org, %s), first: 1) @filter(ge(OTAC.status, %d)) { uid }", util.FormatStr(org), status)
+	q := dgraph.NewQuery()
+	qs := []dgraph.DQL{
+		dgraph.Sprintf("org(func: eq(OTAC.Org, %s), first: 1) @filter(ge(OTAC.status, %s)) { uid }", q.Str(org), q.Int(status)),
+	}
 	if ou != "" {
 		uk := util.HashBase64(org, ou)
-		q += fmt.Sprintf("\nou(func: eq(OTAC.OU.UK, %s), first: 1) @filter(ge(OTAC.status, %d)) { uid }", util.FormatStr(uk), status)
+		qs = append(qs, dgraph.Sprintf("ou(func: eq(OTAC.OU.UK, %s), first: 1) @filter(ge(OTAC.status, %s)) { uid }", q.Str(uk), q.Int(status)))
 	}
 
-	q = fmt.Sprintf(`query {
-		%s
-	}`, q)
+	query, vars := q.Build(dgraph.Join(qs, "\n"))
 	res := &jsonOrgOU{}
-	err := m.Query(ctx, q, nil, res)
+	err := m.Query(ctx, query, vars, res)
 	if err != nil {
 		return "", "", err
 	}
@@ -466,12 +581,13 @@ func (m *Model) acquireOrgMembers(ctx context.Context, org string, subjects []st
 		uks[i] = util.HashBase64(org, sub)
 	}
 
-	q := fmt.Sprintf(`query {
-		result(func: eq(OTAC.M.UK, [%s]), first: %d) @filter(ge(OTAC.status, %d)) { uid }
-	}`, strings.Join(util.FormatStrs(uks), ", "), len(uks), status)
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(OTAC.M.UK, %s), first: %s) @filter(ge(OTAC.status, %s)) { uid }`,
+		q.Strs(uks), q.Int(len(uks)), q.Int(status)))
 
 	res := make([]jsonUID, 0, len(uks))
-	if err := m.List(ctx, q, nil, &res); err != nil {
+	if err := m.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	uids := make([]string, len(res))
@@ -0,0 +1,181 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/service/storage"
+	"github.com/open-trust/ot-ac/src/tpl"
+	otgo "github.com/open-trust/ot-go-lib"
+)
+
+var testDgraph struct {
+	once sync.Once
+	dg   *dgraph.Dgraph
+	err  error
+}
+
+// connectTestDgraph 连接 testing 配置中的 Dgraph 并应用 schema，所有测试共用一个连接
+func connectTestDgraph() (*dgraph.Dgraph, error) {
+	testDgraph.once.Do(func() {
+		dg, err := dgraph.NewDgraph()
+		if err != nil {
+			testDgraph.err = err
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		if _, err := dg.CheckHealth(ctx); err != nil {
+			testDgraph.err = err
+			return
+		}
+		if err := dg.Migrate(context.Background()); err != nil {
+			testDgraph.err = err
+			return
+		}
+		testDgraph.dg = dg
+	})
+	return testDgraph.dg, testDgraph.err
+}
+
+// dgraphFixture 在 Dgraph 上运行 model 测试，每个测试使用新的租户，测试结束时删除租户的数据，
+// Dgraph 不可用时跳过测试
+type dgraphFixture struct {
+	t      *testing.T
+	ctx    context.Context
+	ms     *Models
+	tenant tpl.Tenant
+}
+
+func newDgraphFixture(t *testing.T) *dgraphFixture {
+	dg, err := connectTestDgraph()
+	if err != nil {
+		t.Skipf("dgraph is unavailable: %v", err)
+	}
+	ms, err := NewModels(dg, storage.NewDgraph(dg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &dgraphFixture{t: t, ctx: context.Background(), ms: ms}
+	f.tenant = f.addTenant(fmt.Sprintf("otid:ot.example.com:app:test%d", time.Now().UnixNano()))
+	return f
+}
+
+// addTenant 添加租户，测试结束时删除租户及其名下的节点
+func (f *dgraphFixture) addTenant(otid string) tpl.Tenant {
+	id, err := otgo.ParseOTID(otid)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	if _, err := f.ms.Tenant.Add(f.ctx, tpl.Tenant{Tenant: otid}); err != nil {
+		f.t.Fatal(err)
+	}
+	tenant, err := f.ms.Tenant.Get(f.ctx, id)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	f.t.Cleanup(func() {
+		tenant.Status = -1
+		if err := f.ms.Tenant.Update(f.ctx, *tenant); err != nil {
+			f.t.Error(err)
+			return
+		}
+		for _, kind := range TenantNodeKinds {
+			for {
+				n, err := f.ms.Tenant.DeleteNodes(f.ctx, tenant.UID, kind, 1000)
+				if err != nil {
+					f.t.Error(err)
+					return
+				}
+				if n == 0 {
+					break
+				}
+			}
+		}
+		if err := f.ms.Tenant.Delete(f.ctx, id); err != nil {
+			f.t.Error(err)
+		}
+	})
+	return *tenant
+}
+
+func (f *dgraphFixture) addPermissions(permissions ...string) {
+	ps := make([]tpl.Permission, len(permissions))
+	for i, p := range permissions {
+		ps[i] = tpl.Permission{Permission: p}
+	}
+	if err := f.ms.Permission.BatchAdd(f.ctx, f.tenant, ps); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+// addUnits 添加 team 类型的管理单元，parent 和 scope 为空时不指定
+func (f *dgraphFixture) addUnits(parent, scope string, ids ...string) {
+	units := make([]tpl.Target, len(ids))
+	for i, id := range ids {
+		units[i] = tpl.Target{Type: "team", ID: id}
+	}
+	if err := f.ms.Unit.BatchAdd(f.ctx, f.tenant, units, f.target("team", parent), f.target("project", scope)); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+// addObjects 添加 doc 类型的资源对象，parent 和 scope 为空时不指定
+func (f *dgraphFixture) addObjects(parent, scope string, ids ...string) {
+	objects := make([]tpl.Target, len(ids))
+	for i, id := range ids {
+		objects[i] = tpl.Target{Type: "doc", ID: id}
+	}
+	if err := f.ms.Object.BatchAdd(f.ctx, f.tenant, objects, f.target("doc", parent), f.target("project", scope)); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+func (f *dgraphFixture) addScope(id string) {
+	if _, err := f.ms.Scope.Add(f.ctx, f.tenant, tpl.Scope{TargetType: "project", TargetID: id}); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+func (f *dgraphFixture) addMember(unit, subject string, validity tpl.Validity) {
+	subs, err := f.ms.Subject.AcquireUIDsOrAdd(f.ctx, []string{subject})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	if err := f.ms.Unit.AddSubjects(f.ctx, f.tenant, tpl.Target{Type: "team", ID: unit}, subs, validity); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+func (f *dgraphFixture) grant(unit string, permissions ...tpl.PermissionEx) {
+	if err := f.ms.Unit.AddPermissions(f.ctx, f.tenant, tpl.Target{Type: "team", ID: unit}, permissions); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+// uid 返回管理单元（typ 为 team）或资源对象（typ 为 doc）的 uid，包括已禁用的管理单元
+func (f *dgraphFixture) uid(typ, id string) string {
+	target := f.target(typ, id)
+	var unit, object *tpl.Target
+	if typ == "doc" {
+		object = target
+	} else {
+		unit = target
+	}
+	unitUID, objectUID, _, err := f.ms.Model.acquireUnitObjectScope(f.ctx, f.tenant, unit, object, nil, -1)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return unitUID + objectUID
+}
+
+func (f *dgraphFixture) target(typ, id string) *tpl.Target {
+	if id == "" {
+		return nil
+	}
+	return &tpl.Target{Type: typ, ID: id}
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+)
+
+// GraphQL ...
+type GraphQL struct {
+	*Model
+}
+
+// Query 执行 GraphQL 编译生成的只读 DQL，返回 Dgraph 的原始 JSON 结果。
+// GraphQL 查询直接读取 Dgraph，不经过 Storage
+func (m *GraphQL) Query(ctx context.Context, query string, vars map[string]string) ([]byte, error) {
+	ctx = dgraph.WithMethod(ctx, "GraphQL.Query")
+	var res json.RawMessage
+	if err := m.QueryBestEffort(ctx, query, vars, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/open-trust/ot-ac/src/util"
+)
+
+// Job ...
+type Job struct {
+	*Model
+}
+
+// Get 获取指定类型和对象的任务，不存在时返回 404 错误
+func (m *Job) Get(ctx context.Context, kind, target string) (*tpl.Job, error) {
+	ctx = dgraph.WithMethod(ctx, "Job.Get")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(OTAC.Job.UK, %s), first: 1) {
+			uid
+			kind: OTAC.Job.kind
+			target: OTAC.Job.target
+			status: OTAC.Job.status
+			stage: OTAC.Job.stage
+			deleted: OTAC.Job.deleted
+			copied: OTAC.Job.copied
+			error: OTAC.Job.error
+			createdAt: OTAC.Job.createdAt
+			updatedAt: OTAC.Job.updatedAt
+		}`, q.Str(util.HashBase64(kind, target))))
+	res := &tpl.Job{}
+	if err := m.Model.Get(ctx, query, vars, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListRunning 列出指定类型的执行中的任务
+func (m *Job) ListRunning(ctx context.Context, kind string) ([]tpl.Job, error) {
+	ctx = dgraph.WithMethod(ctx, "Job.ListRunning")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(OTAC.Job.status, %s)) @filter(eq(OTAC.Job.kind, %s)) {
+			uid
+			kind: OTAC.Job.kind
+			target: OTAC.Job.target
+			status: OTAC.Job.status
+			stage: OTAC.Job.stage
+			deleted: OTAC.Job.deleted
+			copied: OTAC.Job.copied
+			createdAt: OTAC.Job.createdAt
+			updatedAt: OTAC.Job.updatedAt
+		}`, q.Str(tpl.JobRunning), q.Str(kind)))
+	res := make([]tpl.Job, 0)
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Save 按任务类型和对象创建或更新任务
+func (m *Job) Save(ctx context.Context, job *tpl.Job) error {
+	ctx = dgraph.WithMethod(ctx, "Job.Save")
+	job.UpdatedAt = time.Now().UTC()
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = job.UpdatedAt
+	}
+	kv := func() map[string]interface{} {
+		return map[string]interface{}{
+			"OTAC.Job.status":    job.Status,
+			"OTAC.Job.stage":     job.Stage,
+			"OTAC.Job.deleted":   job.Deleted,
+			"OTAC.Job.copied":    job.Copied,
+			"OTAC.Job.error":     job.Error,
+			"OTAC.Job.updatedAt": job.UpdatedAt,
+		}
+	}
+	create := &dgraph.Nquads{
+		UKkey: "OTAC.Job.UK",
+		UKval: util.HashBase64(job.Kind, job.Target),
+		Type:  "OTACJob",
+		KV:    kv(),
+	}
+	create.KV["OTAC.Job.kind"] = job.Kind
+	create.KV["OTAC.Job.target"] = job.Target
+	create.KV["OTAC.Job.createdAt"] = job.CreatedAt
+	update := &dgraph.Nquads{KV: kv()}
+	return m.Model.BatchAddOrUpdate(ctx, []*dgraph.Nquads{create, update}, nil, dgraph.DQL{})
+}
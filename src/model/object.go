@@ -2,8 +2,6 @@ package model
 
 import (
 	"context"
-	"fmt"
-	"strings"
 
 	"github.com/open-trust/ot-ac/src/service/dgraph"
 	"github.com/open-trust/ot-ac/src/tpl"
@@ -17,7 +15,10 @@ type Object struct {
 }
 
 // BatchAdd ...
-func (m *Object) BatchAdd(ctx context.Context, tenant tpl.Tenant, objects []tpl.Target, parent *tpl.Target, scope *tpl.Target) error {
+func (m *Object) BatchAdd(ctx context.Context, tenant tpl.Tenant, objects []tpl.Target, parent *tpl.Target, scope *tpl.Target) (err error) {
+	ctx = m.begin(ctx, "Object.BatchAdd")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Object.BatchAdd", Objects: targets(objects, parent), Scopes: targets(nil, scope)},
+		m.effective(ctx, tenant.Tenant, new(diff).attach("object", objects, parent, scope)))
 	nqs := make([]*dgraph.Nquads, 0, len(objects)*2)
 	_, parentUID, scopeUID, err := m.acquireUnitObjectScope(ctx, tenant, nil, parent, scope, 0)
 	if err != nil {
@@ -50,28 +51,29 @@ func (m *Object) BatchAdd(ctx context.Context, tenant tpl.Tenant, objects []tpl.
 		}
 
 		nqs = append(nqs, create)
-		uks = append(uks, util.FormatStr(create.UKval))
+		uks = append(uks, create.UKval)
 		if parentUID != "" || scopeUID != "" {
 			nqs = append(nqs, update)
 		}
 	}
 
 	if parentUID != "" {
-		checkCyclic := fmt.Sprintf(`
+		q := dgraph.NewQuery()
+		checkCyclic := dgraph.Sprintf(`
 			var(func: uid(%s), first: 1) @recurse(loop: false) {
 				uids as uid
 				OTAC.O-Os
 			}
-			CyclicData(func: uid(uids), first: 1) @filter(eq(OTAC.O.UK, [%s])) {
+			CyclicData(func: uid(uids), first: 1) @filter(eq(OTAC.O.UK, %s)) {
 				CyclicUID as uid
 				targetType: OTAC.OType
 				targetId: OTAC.OId
 			}
-		`, util.FormatUID(parentUID), strings.Join(uks, ", "))
-		return m.Model.BatchAddOrUpdate(ctx, nqs, checkCyclic)
+		`, q.UID(parentUID), q.Strs(uks))
+		return m.Model.BatchAddOrUpdate(ctx, nqs, q, checkCyclic)
 	}
 	if scopeUID != "" {
-		return m.Model.BatchAddOrUpdate(ctx, nqs, "")
+		return m.Model.BatchAddOrUpdate(ctx, nqs, nil, dgraph.DQL{})
 	}
 
 	_, err = m.Model.BatchAdd(ctx, nqs)
@@ -79,7 +81,10 @@ func (m *Object) BatchAdd(ctx context.Context, tenant tpl.Tenant, objects []tpl.
 }
 
 // AddPermissions ...
-func (m *Object) AddPermissions(ctx context.Context, tenant tpl.Tenant, object tpl.Target, permissions []string) error {
+func (m *Object) AddPermissions(ctx context.Context, tenant tpl.Tenant, object tpl.Target, permissions []string) (err error) {
+	ctx = m.begin(ctx, "Object.AddPermissions")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Object.AddPermissions", Objects: []tpl.Target{object}, Permissions: permissions},
+		m.effective(ctx, tenant.Tenant, new(diff).add(targetNode("object", object), "permissions", nameNodes("permission", permissions)...)))
 	_, objectUID, _, err := m.acquireUnitObjectScope(ctx, tenant, nil, &object, nil, 0)
 	if err != nil {
 		return err
@@ -108,5 +113,5 @@ func (m *Object) AddPermissions(ctx context.Context, tenant tpl.Tenant, object t
 			"OTAC.O-Ps": util.FormatUIDs(uids),
 		},
 	}
-	return m.Model.Update(ctx, nq, "")
+	return m.Model.Update(ctx, nq, nil, dgraph.DQL{})
 }
@@ -2,7 +2,6 @@ package model
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/open-trust/ot-ac/src/service/dgraph"
 	"github.com/open-trust/ot-ac/src/tpl"
@@ -16,7 +15,9 @@ type Organization struct {
 }
 
 // AddOrg ...
-func (m *Organization) AddOrg(ctx context.Context, org string) (bool, error) {
+func (m *Organization) AddOrg(ctx context.Context, org string) (ok bool, err error) {
+	ctx = dgraph.WithMethod(ctx, "Organization.AddOrg")
+	defer m.audit(ctx, &err, "", "Organization.AddOrg", []string{tpl.AuditRef("org", org)}, nil)
 	nq := &dgraph.Nquads{
 		UKkey: "OTAC.Org",
 		UKval: org,
@@ -30,7 +31,8 @@ func (m *Organization) AddOrg(ctx context.Context, org string) (bool, error) {
 }
 
 // UpdateOrgStatus ...
-func (m *Organization) UpdateOrgStatus(ctx context.Context, org string, status int) error {
+func (m *Organization) UpdateOrgStatus(ctx context.Context, org string, status int) (err error) {
+	ctx = dgraph.WithMethod(ctx, "Organization.UpdateOrgStatus")
 	update := &dgraph.Nquads{
 		UKkey: "OTAC.Org",
 		UKval: org,
@@ -39,8 +41,10 @@ func (m *Organization) UpdateOrgStatus(ctx context.Context, org string, status i
 			"OTAC.status": status,
 		},
 	}
+	d := new(diff).change("status", m.attr(ctx, update.UKkey, update.UKval, "OTAC.status"), status)
+	defer m.audit(ctx, &err, "", "Organization.UpdateOrgStatus", []string{tpl.AuditRef("org", org)}, d)
 
-	return m.Model.Update(ctx, update, "")
+	return m.Model.Update(ctx, update, nil, dgraph.DQL{})
 }
 
 // DeleteOrg ...
@@ -50,15 +54,16 @@ func (m *Organization) DeleteOrg(ctx context.Context, org string) error {
 
 // ListOrgs ...
 func (m *Organization) ListOrgs(ctx context.Context, pageSize, skip int, uidToken string) ([]tpl.Organization, error) {
-	q := fmt.Sprintf(`query {
-		result(func: eq(dgraph.type, "OTACOrg"), first: %d, offset: %d, after: %s) {
+	ctx = dgraph.WithMethod(ctx, "Organization.ListOrgs")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(dgraph.type, "OTACOrg"), first: %s, offset: %s, after: %s) {
 			uid
 			organization: OTAC.Org
 			status: OTAC.status
-		}
-	}`, pageSize, skip, util.FormatUID(uidToken))
+		}`, q.Int(pageSize), q.Int(skip), q.UID(uidToken)))
 	res := make([]tpl.Organization, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -66,27 +71,34 @@ func (m *Organization) ListOrgs(ctx context.Context, pageSize, skip int, uidToke
 
 // ListSubjectOrgs ...
 func (m *Organization) ListSubjectOrgs(ctx context.Context, subject string, pageSize, skip int, uidToken string) ([]tpl.Organization, error) {
-	q := fmt.Sprintf(`query {
-		var(func: eq(OTAC.Sub, %s), first: 1) @filter(ge(OTAC.status, %d)) {
-			~OTAC.M-S @filter(ge(OTAC.status, %d)) {
-				orgUIDs as OTAC.M-Org @filter(ge(OTAC.status, %d))
+	ctx = dgraph.WithMethod(ctx, "Organization.ListSubjectOrgs")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		var(func: eq(OTAC.Sub, %s), first: 1) @filter(ge(OTAC.status, %s)) {
+			~OTAC.M-S @filter(ge(OTAC.status, %s)) {
+				orgUIDs as OTAC.M-Org @filter(ge(OTAC.status, %s))
 			}
 		}
-		result(func: uid(orgUIDs), first: %d, offset: %d, after: %s) {
+		result(func: uid(orgUIDs), first: %s, offset: %s, after: %s) {
 			uid
 			organization: OTAC.Org
 			status: OTAC.status
-		}
-	}`, util.FormatStr(subject), 0, 0, 0, pageSize, skip, util.FormatUID(uidToken))
+		}`, q.Str(subject), q.Int(0), q.Int(0), q.Int(0), q.Int(pageSize), q.Int(skip), q.UID(uidToken)))
 	res := make([]tpl.Organization, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
 // AddOU ...
-func (m *Organization) AddOU(ctx context.Context, org string, input tpl.OrganizationAddOUInput) (bool, error) {
+func (m *Organization) AddOU(ctx context.Context, org string, input tpl.OrganizationAddOUInput) (ok bool, err error) {
+	ctx = dgraph.WithMethod(ctx, "Organization.AddOU")
+	d := new(diff)
+	if input.Parent != "" {
+		d.add(newNode("ou", org, input.OU), "parent", newNode("ou", org, input.Parent))
+	}
+	defer m.audit(ctx, &err, "", "Organization.AddOU", []string{tpl.AuditRef("ou", org, input.OU)}, m.effective(ctx, "", d))
 	orgUID, parentUID, err := m.acquireOrgOU(ctx, org, input.Parent, 0)
 	if err != nil {
 		return false, err
@@ -110,7 +122,10 @@ func (m *Organization) AddOU(ctx context.Context, org string, input tpl.Organiza
 }
 
 // UpdateOUParent ...
-func (m *Organization) UpdateOUParent(ctx context.Context, org string, input tpl.OrganizationUpdateOUParentInput) error {
+func (m *Organization) UpdateOUParent(ctx context.Context, org string, input tpl.OrganizationUpdateOUParentInput) (err error) {
+	ctx = dgraph.WithMethod(ctx, "Organization.UpdateOUParent")
+	defer m.audit(ctx, &err, "", "Organization.UpdateOUParent", []string{tpl.AuditRef("ou", org, input.OU)},
+		m.effective(ctx, "", new(diff).add(newNode("ou", org, input.OU), "parent", newNode("ou", org, input.Parent))))
 	_, parentUID, err := m.acquireOrgOU(ctx, org, input.Parent, 0)
 	if err != nil {
 		return err
@@ -119,7 +134,8 @@ func (m *Organization) UpdateOUParent(ctx context.Context, org string, input tpl
 	if err != nil {
 		return err
 	}
-	checkCyclic := fmt.Sprintf(`
+	q := dgraph.NewQuery()
+	checkCyclic := dgraph.Sprintf(`
 		var(func: uid(%s), first: 1) @recurse(loop: false) {
 			uids as uid
 			OTAC.OU-OU
@@ -128,7 +144,7 @@ func (m *Organization) UpdateOUParent(ctx context.Context, org string, input tpl
 			CyclicUID as uid
 			ou: OTAC.OU
 		}
-	`, util.FormatUID(parentUID), util.FormatUID(ouUID))
+	`, q.UID(parentUID), q.UID(ouUID))
 
 	nq := &dgraph.Nquads{
 		ID: util.FormatUID(ouUID),
@@ -136,7 +152,7 @@ func (m *Organization) UpdateOUParent(ctx context.Context, org string, input tpl
 			"OTAC.OU-OU": util.FormatUID(parentUID),
 		},
 	}
-	return m.Model.Update(ctx, nq, checkCyclic)
+	return m.Model.Update(ctx, nq, q, checkCyclic)
 }
 
 // UpdateOUStatus ...
@@ -156,23 +172,25 @@ func (m *Organization) DeleteOU(ctx context.Context, org string) error {
 
 // ListOUs ...
 func (m *Organization) ListOUs(ctx context.Context, org, parent string, pageSize, skip int, uidToken string) ([]tpl.OU, error) {
-	q := ""
+	ctx = dgraph.WithMethod(ctx, "Organization.ListOUs")
+	q := dgraph.NewQuery()
+	var query string
+	var vars map[string]string
 	if parent == "" {
-		q = fmt.Sprintf(`query {
+		query, vars = q.Build(dgraph.Sprintf(`
 			var(func: eq(OTAC.Org, %s), first: 1) {
 				orgUID as uid
 			}
-			result(func: eq(dgraph.type, "OTACOU"), first: %d, offset: %d, after: %s) @filter(uid_in(OTAC.OU-Org, uid(orgUID)) AND NOT has(OTAC.OU-OU)) @normalize {
+			result(func: eq(dgraph.type, "OTACOU"), first: %s, offset: %s, after: %s) @filter(uid_in(OTAC.OU-Org, uid(orgUID)) AND NOT has(OTAC.OU-OU)) @normalize {
 				uid: uid
 				ou: OTAC.OU
 				status: OTAC.status
-			}
-		}`, util.FormatStr(org), pageSize, skip, util.FormatUID(uidToken))
+			}`, q.Str(org), q.Int(pageSize), q.Int(skip), q.UID(uidToken)))
 	} else {
 		uk := util.HashBase64(org, parent)
-		q = fmt.Sprintf(`query {
+		query, vars = q.Build(dgraph.Sprintf(`
 			result(func: eq(OTAC.OU.UK, %s), first: 1) @normalize {
-				~OTAC.OU-OU (first: %d, offset: %d, after: %s) {
+				~OTAC.OU-OU (first: %s, offset: %s, after: %s) {
 					uid: uid
 					ou: OTAC.OU
 					status: OTAC.status
@@ -180,11 +198,10 @@ func (m *Organization) ListOUs(ctx context.Context, org, parent string, pageSize
 						parent: OTAC.OU
 					}
 				}
-			}
-		}`, util.FormatStr(uk), pageSize, skip, util.FormatUID(uidToken))
+			}`, q.Str(uk), q.Int(pageSize), q.Int(skip), q.UID(uidToken)))
 	}
 	res := make([]tpl.OU, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -192,26 +209,27 @@ func (m *Organization) ListOUs(ctx context.Context, org, parent string, pageSize
 
 // ListSubjectOUs ...
 func (m *Organization) ListSubjectOUs(ctx context.Context, subject, org string, pageSize, skip int, uidToken string) ([]tpl.OU, error) {
-	q := fmt.Sprintf(`query {
-		var(func: eq(OTAC.Org, %s), first: 1) @filter(ge(OTAC.status, %d)) {
+	ctx = dgraph.WithMethod(ctx, "Organization.ListSubjectOUs")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		var(func: eq(OTAC.Org, %s), first: 1) @filter(ge(OTAC.status, %s)) {
 			orgUID as uid
 		}
-		var(func: eq(OTAC.Sub, %s), first: 1) @filter(ge(OTAC.status, %d)) {
-			~OTAC.M-S @filter(uid_in(OTAC.M-Org, uid(orgUID)) AND ge(OTAC.status, %d)) {
-				ouUIDs as ~OTAC.OU-Ms @filter(ge(OTAC.status, %d))
+		var(func: eq(OTAC.Sub, %s), first: 1) @filter(ge(OTAC.status, %s)) {
+			~OTAC.M-S @filter(uid_in(OTAC.M-Org, uid(orgUID)) AND ge(OTAC.status, %s)) {
+				ouUIDs as ~OTAC.OU-Ms @filter(ge(OTAC.status, %s))
 			}
 		}
-		result(func: uid(ouUIDs), first: %d, offset: %d, after: %s) @normalize {
+		result(func: uid(ouUIDs), first: %s, offset: %s, after: %s) @normalize {
 			uid: uid
 			ou: OTAC.OU
 			status: OTAC.status
 			OTAC.OU-OU {
 				parent: OTAC.OU
 			}
-		}
-	}`, util.FormatStr(org), 0, util.FormatStr(subject), 0, 0, 0, pageSize, skip, util.FormatUID(uidToken))
+		}`, q.Str(org), q.Int(0), q.Str(subject), q.Int(0), q.Int(0), q.Int(0), q.Int(pageSize), q.Int(skip), q.UID(uidToken)))
 	res := make([]tpl.OU, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -219,28 +237,36 @@ func (m *Organization) ListSubjectOUs(ctx context.Context, subject, org string,
 
 // SearchOUs ...
 func (m *Organization) SearchOUs(ctx context.Context, org, term string, pageSize, skip int, uidToken string) ([]tpl.OU, error) {
-	q := fmt.Sprintf(`query {
+	ctx = dgraph.WithMethod(ctx, "Organization.SearchOUs")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
 		var(func: eq(OTAC.Org, %s), first: 1) {
 			orgUID as uid
 		}
-		result(func: eq(dgraph.type, "OTACOU"), first: %d, offset: %d, after: %s) @filter(uid_in(OTAC.OU-Org, uid(orgUID)) AND allofterms(OTAC.OU.terms, %s)) @normalize {
+		result(func: eq(dgraph.type, "OTACOU"), first: %s, offset: %s, after: %s) @filter(uid_in(OTAC.OU-Org, uid(orgUID)) AND allofterms(OTAC.OU.terms, %s)) @normalize {
 			uid: uid
 			ou: OTAC.OU
 			status: OTAC.status
 			OTAC.OU-OU {
 				parent: OTAC.OU
 			}
-		}
-	}`, util.FormatStr(org), pageSize, skip, util.FormatUID(uidToken), util.FormatStr(term))
+		}`, q.Str(org), q.Int(pageSize), q.Int(skip), q.UID(uidToken), q.Str(term)))
 	res := make([]tpl.OU, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
 // BatchAddMember ...
-func (m *Organization) BatchAddMember(ctx context.Context, org string, input tpl.OrganizationBatchAddMemberInput) error {
+func (m *Organization) BatchAddMember(ctx context.Context, org string, input tpl.OrganizationBatchAddMemberInput) (err error) {
+	ctx = dgraph.WithMethod(ctx, "Organization.BatchAddMember")
+	subjects := make([]string, len(input.Subjects))
+	for i, sub := range input.Subjects {
+		subjects[i] = sub.Sub
+	}
+	defer m.audit(ctx, &err, "", "Organization.BatchAddMember", []string{tpl.AuditRef("org", org)},
+		m.effective(ctx, "", new(diff).add(newNode("org", org), "members", memberNodes(org, subjects)...)))
 	orgUID, _, err := m.acquireOrgOU(ctx, org, "", 0)
 	if err != nil {
 		return err
@@ -285,19 +311,20 @@ func (m *Organization) RemoveMember(ctx context.Context, org string) error {
 
 // ListMembers ...
 func (m *Organization) ListMembers(ctx context.Context, org string, pageSize, skip int, uidToken string) ([]tpl.Member, error) {
-	q := fmt.Sprintf(`query {
+	ctx = dgraph.WithMethod(ctx, "Organization.ListMembers")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
 		result(func: eq(OTAC.Org, %s), first: 1) @normalize {
-			~OTAC.M-Org (first: %d, offset: %d, after: %s) {
+			~OTAC.M-Org (first: %s, offset: %s, after: %s) {
 				uid: uid
 				status: OTAC.status
 				OTAC.M-S {
 					subject: OTAC.Sub
 				}
 			}
-		}
-	}`, util.FormatStr(org), pageSize, skip, util.FormatUID(uidToken))
+		}`, q.Str(org), q.Int(pageSize), q.Int(skip), q.UID(uidToken)))
 	res := make([]tpl.Member, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -305,26 +332,30 @@ func (m *Organization) ListMembers(ctx context.Context, org string, pageSize, sk
 
 // SearchMember ...
 func (m *Organization) SearchMember(ctx context.Context, org, term string, pageSize, skip int, uidToken string) ([]tpl.Member, error) {
-	q := fmt.Sprintf(`query {
+	ctx = dgraph.WithMethod(ctx, "Organization.SearchMember")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
 		result(func: eq(OTAC.Org, %s), first: 1) @normalize {
-			~OTAC.M-Org @filter(allofterms(OTAC.M.terms, %s)) (first: %d, offset: %d, after: %s) {
+			~OTAC.M-Org @filter(allofterms(OTAC.M.terms, %s)) (first: %s, offset: %s, after: %s) {
 				uid: uid
 				status: OTAC.status
 				OTAC.M-S {
 					subject: OTAC.Sub
 				}
 			}
-		}
-	}`, util.FormatStr(org), util.FormatStr(term), pageSize, skip, util.FormatUID(uidToken))
+		}`, q.Str(org), q.Str(term), q.Int(pageSize), q.Int(skip), q.UID(uidToken)))
 	res := make([]tpl.Member, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
 // BatchAddOUMember ...
-func (m *Organization) BatchAddOUMember(ctx context.Context, org string, input tpl.OrganizationBatchAddOUMemberInput) error {
+func (m *Organization) BatchAddOUMember(ctx context.Context, org string, input tpl.OrganizationBatchAddOUMemberInput) (err error) {
+	ctx = dgraph.WithMethod(ctx, "Organization.BatchAddOUMember")
+	defer m.audit(ctx, &err, "", "Organization.BatchAddOUMember", []string{tpl.AuditRef("ou", org, input.OU)},
+		m.effective(ctx, "", new(diff).add(newNode("ou", org, input.OU), "members", memberNodes(org, input.Subjects)...)))
 	_, ouUID, err := m.acquireOrgOU(ctx, org, input.OU, 0)
 	if err != nil {
 		return err
@@ -342,7 +373,7 @@ func (m *Organization) BatchAddOUMember(ctx context.Context, org string, input t
 			"OTAC.OU-Ms": util.FormatUIDs(memberUIDs),
 		},
 	}
-	return m.Model.Update(ctx, nq, "")
+	return m.Model.Update(ctx, nq, nil, dgraph.DQL{})
 }
 
 // RemoveOUMember ...
@@ -352,20 +383,21 @@ func (m *Organization) RemoveOUMember(ctx context.Context, org string) error {
 
 // ListOUMembers ...
 func (m *Organization) ListOUMembers(ctx context.Context, org, ou string, pageSize, skip int, uidToken string) ([]tpl.Member, error) {
+	ctx = dgraph.WithMethod(ctx, "Organization.ListOUMembers")
 	uk := util.HashBase64(org, ou)
-	q := fmt.Sprintf(`query {
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
 		result(func: eq(OTAC.OU.UK, %s), first: 1) @normalize {
-			OTAC.OU-Ms (first: %d, offset: %d, after: %s) {
+			OTAC.OU-Ms (first: %s, offset: %s, after: %s) {
 				uid: uid
 				status: OTAC.status
 				OTAC.M-S {
 					subject: OTAC.Sub
 				}
 			}
-		}
-	}`, util.FormatStr(uk), pageSize, skip, util.FormatUID(uidToken))
+		}`, q.Str(uk), q.Int(pageSize), q.Int(skip), q.UID(uidToken)))
 	res := make([]tpl.Member, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -373,8 +405,10 @@ func (m *Organization) ListOUMembers(ctx context.Context, org, ou string, pageSi
 
 // ListOUDescendantMembers ...
 func (m *Organization) ListOUDescendantMembers(ctx context.Context, org, ou string, pageSize, skip int, uidToken string) ([]tpl.Member, error) {
+	ctx = dgraph.WithMethod(ctx, "Organization.ListOUDescendantMembers")
 	uk := util.HashBase64(org, ou)
-	q := fmt.Sprintf(`query {
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
 		var(func: eq(OTAC.OU.UK, %s), first: 1) @recurse(loop: false) {
 			ouUIDs as uid
 			~OTAC.OU-OU
@@ -382,16 +416,15 @@ func (m *Organization) ListOUDescendantMembers(ctx context.Context, org, ou stri
 		var(func: uid(ouUIDs)) {
 			mUIDs as OTAC.OU-Ms
 		 }
-		result(func: uid(mUIDs), first: %d, offset: %d, after: %s) @normalize {
+		result(func: uid(mUIDs), first: %s, offset: %s, after: %s) @normalize {
 			uid: uid
 			status: OTAC.status
 			OTAC.M-S {
 				subject: OTAC.Sub
 			}
-		}
-	}`, util.FormatStr(uk), pageSize, skip, util.FormatUID(uidToken))
+		}`, q.Str(uk), q.Int(pageSize), q.Int(skip), q.UID(uidToken)))
 	res := make([]tpl.Member, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
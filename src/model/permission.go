@@ -1,7 +1,9 @@
 package model
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -9,6 +11,7 @@ import (
 	"github.com/open-trust/ot-ac/src/service/dgraph"
 	"github.com/open-trust/ot-ac/src/tpl"
 	"github.com/open-trust/ot-ac/src/util"
+	"github.com/teambition/gear"
 )
 
 // Permission ...
@@ -16,96 +19,735 @@ type Permission struct {
 	*Model
 }
 
-// BatchAdd ...
-func (m *Permission) BatchAdd(ctx context.Context, tenant tpl.Tenant, permissions []string) error {
-	nqs := make([]*dgraph.Nquads, 0, len(permissions))
+type jsonPermission struct {
+	UID         string           `json:"uid"`
+	Permission  string           `json:"permission"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Deprecated  bool             `json:"deprecated"`
+	Implies     []tpl.Permission `json:"implies"`
+}
 
+func (p jsonPermission) toPermission() *tpl.Permission {
+	res := &tpl.Permission{
+		UID:         p.UID,
+		Permission:  p.Permission,
+		Name:        p.Name,
+		Description: p.Description,
+		Deprecated:  p.Deprecated,
+	}
+	for _, v := range p.Implies {
+		res.Implies = append(res.Implies, v.Permission)
+	}
+	return res
+}
+
+// BatchAdd 批量添加权限，字符串形式的权限仅在不存在时创建，
+// 对象形式的权限会覆盖已存在权限的 name、description、deprecated 和 implies，蕴含的权限必须预先存在或在同一批次中添加。
+// 创建、覆盖元数据与蕴含关系在同一个 upsert 请求中完成，蕴含关系将形成环时返回 409 错误
+func (m *Permission) BatchAdd(ctx context.Context, tenant tpl.Tenant, permissions []tpl.Permission) (err error) {
+	ctx = m.begin(ctx, "Permission.BatchAdd")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Permission.BatchAdd", Permissions: permissionNames(permissions)}, nil)
+	batch := make(map[string]int, len(permissions))
+	implies := make([]string, 0)
+	hasImplies := false
+	for i, p := range permissions {
+		batch[p.Permission] = i
+	}
 	for _, p := range permissions {
-		nqs = append(nqs, &dgraph.Nquads{
-			UKkey: "OTAC.P.UK",
-			UKval: util.HashBase64(tenant.Tenant, p),
-			Type:  "OTACPermission",
+		if !p.HasMetadata() {
+			continue
+		}
+		hasImplies = hasImplies || len(p.Implies) > 0
+		for _, i := range p.Implies {
+			if _, ok := batch[i]; !ok {
+				implies = append(implies, i)
+			}
+		}
+	}
+
+	var ps []tpl.Permission
+	if len(implies) > 0 {
+		if ps, err = m.acquirePermissions(ctx, tenant, implies); err != nil {
+			return err
+		}
+		for _, i := range implies {
+			if tpl.GetPermissionUID(ps, i) == "" {
+				return gear.ErrBadRequest.WithMsgf("implied permission %s not found", util.FormatStr(i))
+			}
+		}
+	}
+
+	q := dgraph.NewQuery()
+	qs := make([]dgraph.DQL, 0, len(permissions))
+	mus := make([]*api.Mutation, 0, len(permissions)*2)
+	for i, p := range permissions {
+		qs = append(qs, dgraph.Sprintf("uid_%s as q_%s(func: eq(OTAC.P.UK, %s), first: 1)",
+			dgraph.Index(i), dgraph.Index(i), q.Str(util.HashBase64(tenant.Tenant, p.Permission))))
+	}
+	for i, p := range permissions {
+		uidx := fmt.Sprintf("uid_%d", i)
+		create := &dgraph.Nquads{
+			ID:   "_:" + uidx,
+			Type: "OTACPermission",
 			KV: map[string]interface{}{
-				"OTAC.P-T": util.FormatUID(tenant.UID),
-				"OTAC.P":   p,
+				"OTAC.P.UK": util.HashBase64(tenant.Tenant, p.Permission),
+				"OTAC.P-T":  util.FormatUID(tenant.UID),
+				"OTAC.P":    p.Permission,
 			},
+		}
+		if !p.HasMetadata() {
+			mu, err := condMutation(fmt.Sprintf("eq(len(%s), 0)", uidx), create, nil)
+			if err != nil {
+				return err
+			}
+			mus = append(mus, mu)
+			continue
+		}
+
+		update := &dgraph.Nquads{
+			ID: fmt.Sprintf("uid(%s)", uidx),
+			KV: map[string]interface{}{
+				"OTAC.name":        p.Name,
+				"OTAC.description": p.Description,
+				"OTAC.deprecated":  p.Deprecated,
+			},
+		}
+		for k, v := range update.KV {
+			create.KV[k] = v
+		}
+		// 批次外的蕴含权限已经查到 UID，直接写入；批次内的蕴含权限可能是新建节点，按两端是否已存在分别写入
+		existing := make([]string, 0, len(p.Implies))
+		for _, v := range p.Implies {
+			if _, ok := batch[v]; !ok {
+				existing = append(existing, util.FormatUID(tpl.GetPermissionUID(ps, v)))
+			}
+		}
+		if len(existing) > 0 {
+			create.KV["OTAC.P-Ps"] = existing
+			update.KV["OTAC.P-Ps"] = existing
+		}
+		mu, err := condMutation(fmt.Sprintf("eq(len(%s), 0)", uidx), create, nil)
+		if err != nil {
+			return err
+		}
+		mus = append(mus, mu)
+		// 覆盖已存在权限的蕴含关系，Dgraph 在同一个 mutation 中先删除后写入
+		mu, err = condMutation(fmt.Sprintf("eq(len(%s), 1)", uidx), update, &dgraph.Nquads{
+			ID: update.ID,
+			KV: map[string]interface{}{"OTAC.P-Ps": "*"},
 		})
+		if err != nil {
+			return err
+		}
+		mus = append(mus, mu)
+
+		for _, v := range p.Implies {
+			j, ok := batch[v]
+			if !ok {
+				continue
+			}
+			uidy := fmt.Sprintf("uid_%d", j)
+			for _, from := range []int{0, 1} {
+				for _, to := range []int{0, 1} {
+					edge := &dgraph.Nquads{
+						ID: permissionRef(uidx, from),
+						KV: map[string]interface{}{"OTAC.P-Ps": permissionRef(uidy, to)},
+					}
+					mu, err := condMutation(fmt.Sprintf("eq(len(%s), %d) AND eq(len(%s), %d)", uidx, from, uidy, to), edge, nil)
+					if err != nil {
+						return err
+					}
+					mus = append(mus, mu)
+				}
+			}
+		}
 	}
 
-	_, err := m.Model.BatchAdd(ctx, nqs)
-	return err
+	query, vars := q.Build(dgraph.Join(qs, "\n"))
+	if !hasImplies {
+		_, err = m.DoRaw(ctx, query, vars, mus...)
+		return err
+	}
+	// 蕴含关系的环检测与写入在同一个事务中
+	return m.Txn(ctx, func(txn *dgraph.Txn) error {
+		if err := m.checkImpliesCyclic(ctx, txn, tenant, permissions); err != nil {
+			return err
+		}
+		_, err := txn.Do(ctx, query, vars, mus...)
+		return err
+	})
+}
+
+// jsonImplies @recurse 查询结果不支持别名，使用谓词原名
+type jsonImplies struct {
+	Permission string        `json:"OTAC.P"`
+	Implies    []jsonImplies `json:"OTAC.P-Ps"`
+}
+
+// checkImpliesCyclic 检查批次写入后蕴含关系是否成环，成环时返回 409 错误。对象形式的权限的蕴含关系会被批次覆盖，
+// 其它权限沿用已存在的蕴含关系，从批次蕴含的权限出发查询已存在的蕴含链，与批次的蕴含关系合并后检测
+func (m *Permission) checkImpliesCyclic(ctx context.Context, txn *dgraph.Txn, tenant tpl.Tenant, permissions []tpl.Permission) error {
+	graph := make(map[string][]string)
+	targets := make([]string, 0)
+	for _, p := range permissions {
+		if p.HasMetadata() {
+			graph[p.Permission] = p.Implies
+			targets = append(targets, p.Implies...)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(OTAC.P, %s)) @filter(uid_in(OTAC.P-T, %s)) @recurse(loop: false) {
+			OTAC.P
+			OTAC.P-Ps
+		}`, q.Strs(targets), q.UID(tenant.UID)))
+	resp, err := txn.QueryWithVars(ctx, query, vars)
+	if err != nil {
+		return err
+	}
+	out := &struct {
+		Result []jsonImplies `json:"result"`
+	}{}
+	if err := json.Unmarshal(resp.Json, out); err != nil {
+		return err
+	}
+	existing := make(map[string]map[string]struct{})
+	var walk func(v jsonImplies)
+	walk = func(v jsonImplies) {
+		if existing[v.Permission] == nil {
+			existing[v.Permission] = make(map[string]struct{})
+		}
+		for _, i := range v.Implies {
+			existing[v.Permission][i.Permission] = struct{}{}
+			walk(i)
+		}
+	}
+	for _, v := range out.Result {
+		walk(v)
+	}
+	for p, implies := range existing {
+		if _, ok := graph[p]; ok {
+			continue
+		}
+		for i := range implies {
+			graph[p] = append(graph[p], i)
+		}
+	}
+
+	for _, p := range permissions {
+		if cycle := findCycle(graph, p.Permission); cycle != nil {
+			return gear.ErrConflict.WithMsgf("cyclic implies will come into being: %s", strings.Join(cycle, " -> "))
+		}
+	}
+	return nil
+}
+
+// findCycle 返回从 start 出发能到达的环，如 [A B A]，没有环时返回 nil
+func findCycle(graph map[string][]string, start string) []string {
+	// 0 未访问，1 在当前路径上，2 已确认无环
+	state := make(map[string]int)
+	path := make([]string, 0)
+	var visit func(p string) []string
+	visit = func(p string) []string {
+		switch state[p] {
+		case 1:
+			for i, v := range path {
+				if v == p {
+					return append(path[i:], p)
+				}
+			}
+		case 2:
+			return nil
+		}
+		state[p] = 1
+		path = append(path, p)
+		for _, i := range graph[p] {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[p] = 2
+		return nil
+	}
+	return visit(start)
+}
+
+// permissionRef 返回批次中权限节点的引用，不存在的权限引用同一请求中新建的空白节点
+func permissionRef(uidx string, exists int) string {
+	if exists == 1 {
+		return fmt.Sprintf("uid(%s)", uidx)
+	}
+	return "_:" + uidx
+}
+
+// condMutation 构造带条件的 mutation，del 不为 nil 时同时删除
+func condMutation(cond string, set, del *dgraph.Nquads) (*api.Mutation, error) {
+	mu := &api.Mutation{Cond: fmt.Sprintf("@if(%s)", cond)}
+	data, err := set.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	mu.SetNquads = data
+	if del != nil {
+		if mu.DelNquads, err = del.Bytes(); err != nil {
+			return nil, err
+		}
+	}
+	return mu, nil
 }
 
 // List ...
 func (m *Permission) List(ctx context.Context, tenant tpl.Tenant, resources []string, pageSize, skip int, uidToken string) (
 	[]*tpl.Permission, error) {
-	q := fmt.Sprintf(`query {
-		result(func: eq(dgraph.type, "OTACPermission"), first: %d, offset: %d, after: %s) @filter(uid_in(OTAC.P-T, %s)) {
+	ctx = dgraph.WithMethod(ctx, "Permission.List")
+	q := dgraph.NewQuery()
+	filter := dgraph.Sprintf("uid_in(OTAC.P-T, %s)", q.UID(tenant.UID))
+	if len(resources) > 0 {
+		filter = dgraph.Sprintf("%s AND regexp(OTAC.P, %s)", filter, q.Regexp("^("+strings.Join(resources, "|")+")"))
+	}
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(dgraph.type, "OTACPermission"), first: %s, offset: %s, after: %s) @filter(%s) {
 			uid
 			permission: OTAC.P
-		}
-	}`, pageSize, skip, util.FormatUID(uidToken), util.FormatUID(tenant.UID))
-	if len(resources) > 0 {
-		q = fmt.Sprintf(`query {
-			result(func: eq(dgraph.type, "OTACPermission"), first: %d, offset: %d, after: %s) @filter(uid_in(OTAC.P-T, %s) AND regexp(OTAC.P, /^(%s)/)) {
-				uid
+			name: OTAC.name
+			description: OTAC.description
+			deprecated: OTAC.deprecated
+			implies: OTAC.P-Ps {
 				permission: OTAC.P
 			}
-		}`, pageSize, skip, util.FormatUID(uidToken), util.FormatUID(tenant.UID), strings.Join(resources, "|"))
-	}
-	res := make([]*tpl.Permission, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+		}`, q.Int(pageSize), q.Int(skip), q.UID(uidToken), filter))
+	data := make([]jsonPermission, 0, pageSize)
+	if err := m.Model.List(ctx, query, vars, &data); err != nil {
 		return nil, err
 	}
+	res := make([]*tpl.Permission, 0, len(data))
+	for _, p := range data {
+		res = append(res, p.toPermission())
+	}
 	return res, nil
 }
 
-// Delete ...
-func (m *Permission) Delete(ctx context.Context, tenant tpl.Tenant, permission string) error {
-	q := fmt.Sprintf(`query {
-		permissionUid as var(func: eq(OTAC.P, %s)) @filter(uid_in(OTAC.P-T, %s))
-		objectUids as var(func: has(OTAC.O-Ps)) @filter(uid_in(OTAC.O-Ps, uid(permissionUid)))
-		unitsUids as var(func: has(OTAC.U-Ps)) @filter(uid_in(OTAC.U-Ps, uid(permissionUid)))
-	}`, util.FormatStr(permission), util.FormatUID(tenant.UID))
-	delPermission := &dgraph.Nquads{
+type jsonPermissionUsage struct {
+	Units   []jsonCount `json:"units"`
+	Objects []jsonCount `json:"objects"`
+	Roles   []jsonCount `json:"roles"`
+}
+
+type jsonCount struct {
+	Count int `json:"count"`
+}
+
+func sumCount(cs []jsonCount) int {
+	n := 0
+	for _, c := range cs {
+		n += c.Count
+	}
+	return n
+}
+
+// Usage 统计引用权限的管理单元、资源对象和角色数量
+func (m *Permission) Usage(ctx context.Context, tenant tpl.Tenant, permission string) (*tpl.PermissionUsage, error) {
+	ctx = dgraph.WithMethod(ctx, "Permission.Usage")
+	q := dgraph.NewQuery()
+	fTenantUID := q.UID(tenant.UID)
+	query, vars := q.Build(dgraph.Sprintf(`
+		permissionUid as var(func: eq(OTAC.P.UK, %s), first: 1)
+		units(func: has(OTAC.U-Ps)) @filter(uid_in(OTAC.U-T, %s) AND uid_in(OTAC.U-Ps, uid(permissionUid))) {
+			count(uid)
+		}
+		objects(func: has(OTAC.O-Ps)) @filter(uid_in(OTAC.O-T, %s) AND uid_in(OTAC.O-Ps, uid(permissionUid))) {
+			count(uid)
+		}
+		roles(func: has(OTAC.R-Ps)) @filter(uid_in(OTAC.R-T, %s) AND uid_in(OTAC.R-Ps, uid(permissionUid))) {
+			count(uid)
+		}`, q.Str(util.HashBase64(tenant.Tenant, permission)), fTenantUID, fTenantUID, fTenantUID))
+	out := &jsonPermissionUsage{}
+	if err := m.Query(ctx, query, vars, out); err != nil {
+		return nil, err
+	}
+	return &tpl.PermissionUsage{
+		Permission: permission,
+		Units:      sumCount(out.Units),
+		Objects:    sumCount(out.Objects),
+		Roles:      sumCount(out.Roles),
+	}, nil
+}
+
+type jsonPermissionDelete struct {
+	Permission []jsonUID                  `json:"permission"`
+	ImpliedBy  []tpl.Permission           `json:"impliedBy"`
+	Units      []jsonRawPermissionsOutput `json:"units"`
+	Objects    []jsonRawPermissionsOutput `json:"objects"`
+	Roles      []tpl.Role                 `json:"roles"`
+}
+
+// Delete 删除权限，权限不存在时返回 404 错误，被其它权限蕴含的权限不能删除。
+// 当权限仍被管理单元、资源对象或角色引用时，force 为 false 会返回 409 错误，
+// force 为 true 会在同一个事务中解除所有引用关系并删除权限，返回受影响的管理单元、资源对象和角色
+func (m *Permission) Delete(ctx context.Context, tenant tpl.Tenant, permission string, force bool) (_ *tpl.PermissionDeleteOutput, err error) {
+	ctx = m.begin(ctx, "Permission.Delete")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Permission.Delete", Permissions: []string{permission}},
+		m.effective(ctx, tenant.Tenant, new(diff).remove(newNode("permission", permission), "*", anyNode)))
+	q := dgraph.NewQuery()
+	fTenantUID := q.UID(tenant.UID)
+	query, vars := q.Build(dgraph.Sprintf(`
+		permission(func: eq(OTAC.P.UK, %s), first: 1) {
+			permissionUid as uid
+		}
+		impliedBy(func: has(OTAC.P-Ps)) @filter(uid_in(OTAC.P-T, %s) AND uid_in(OTAC.P-Ps, uid(permissionUid))) {
+			impliedByUids as uid
+			permission: OTAC.P
+		}
+		units(func: has(OTAC.U-Ps)) @filter(uid_in(OTAC.U-T, %s) AND uid_in(OTAC.U-Ps, uid(permissionUid))) {
+			unitsUids as uid
+			targetType: OTAC.UType
+			targetId: OTAC.UId
+		}
+		objects(func: has(OTAC.O-Ps)) @filter(uid_in(OTAC.O-T, %s) AND uid_in(OTAC.O-Ps, uid(permissionUid))) {
+			objectsUids as uid
+			targetType: OTAC.OType
+			targetId: OTAC.OId
+		}
+		roles(func: has(OTAC.R-Ps)) @filter(uid_in(OTAC.R-T, %s) AND uid_in(OTAC.R-Ps, uid(permissionUid))) {
+			rolesUids as uid
+			role: OTAC.R
+		}`, q.Str(util.HashBase64(tenant.Tenant, permission)), fTenantUID, fTenantUID, fTenantUID, fTenantUID))
+
+	cond := "@if(eq(len(permissionUid), 1) AND eq(len(impliedByUids), 0))"
+	if !force {
+		cond = "@if(eq(len(permissionUid), 1) AND eq(len(impliedByUids), 0) AND eq(len(unitsUids), 0) AND eq(len(objectsUids), 0) AND eq(len(rolesUids), 0))"
+	}
+	nqs := []*dgraph.Nquads{{
 		ID: "uid(permissionUid)",
 		KV: map[string]interface{}{
 			"*": "*",
 		},
-	}
-	delPermissionData, err := delPermission.Bytes()
-	if err != nil {
-		return err
-	}
-	delObjects := &dgraph.Nquads{
-		ID: "uid(objectUids)",
+	}, {
+		ID: "uid(unitsUids)",
 		KV: map[string]interface{}{
-			"*": "*",
+			"OTAC.U-Ps": "uid(permissionUid)",
+		},
+	}, {
+		ID: "uid(objectsUids)",
+		KV: map[string]interface{}{
+			"OTAC.O-Ps": "uid(permissionUid)",
 		},
+	}, {
+		ID: "uid(rolesUids)",
+		KV: map[string]interface{}{
+			"OTAC.R-Ps": "uid(permissionUid)",
+		},
+	}}
+	mus := make([]*api.Mutation, 0, len(nqs))
+	for _, nq := range nqs {
+		data, err := nq.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		mus = append(mus, &api.Mutation{
+			Cond:      cond,
+			DelNquads: data,
+		})
 	}
-	ddelObjectsData, err := delObjects.Bytes()
-	if err != nil {
-		return err
+
+	out := &jsonPermissionDelete{}
+	if err := m.Do(ctx, query, vars, out, mus...); err != nil {
+		return nil, err
 	}
-	delUnits := &dgraph.Nquads{
-		ID: "uid(unitsUids)",
+	if len(out.Permission) == 0 {
+		return nil, gear.ErrNotFound.WithMsgf("permission %s not found", util.FormatStr(permission))
+	}
+	if len(out.ImpliedBy) > 0 {
+		impliedBy := make([]string, 0, len(out.ImpliedBy))
+		for _, p := range out.ImpliedBy {
+			impliedBy = append(impliedBy, p.Permission)
+		}
+		return nil, gear.ErrConflict.WithMsgf("permission %s is implied by %s", util.FormatStr(permission),
+			strings.Join(util.FormatStrs(impliedBy), ", "))
+	}
+	if !force && len(out.Units)+len(out.Objects)+len(out.Roles) > 0 {
+		return nil, gear.ErrConflict.WithMsgf("permission %s is referenced by %d units, %d objects and %d roles",
+			util.FormatStr(permission), len(out.Units), len(out.Objects), len(out.Roles))
+	}
+
+	res := &tpl.PermissionDeleteOutput{
+		Units:   make([]tpl.Target, 0, len(out.Units)),
+		Objects: make([]tpl.Target, 0, len(out.Objects)),
+		Roles:   make([]string, 0, len(out.Roles)),
+	}
+	for _, v := range out.Units {
+		res.Units = append(res.Units, tpl.Target{Type: v.Type, ID: v.ID})
+	}
+	for _, v := range out.Objects {
+		res.Objects = append(res.Objects, tpl.Target{Type: v.Type, ID: v.ID})
+	}
+	for _, v := range out.Roles {
+		res.Roles = append(res.Roles, v.Role)
+	}
+	return res, nil
+}
+
+// Rename 重命名权限，原地更新权限节点，所有引用关系及其 facets 保持不变，新的权限不能已存在
+func (m *Permission) Rename(ctx context.Context, tenant tpl.Tenant, from, to string) (_ *tpl.PermissionMigrateOutput, err error) {
+	ctx = m.begin(ctx, "Permission.Rename")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Permission.Rename", Permissions: []string{from, to}},
+		new(diff).change("permission", from, to))
+	toUK := util.HashBase64(tenant.Tenant, to)
+	q := dgraph.NewQuery()
+	fTenantUID := q.UID(tenant.UID)
+	query, vars := q.Build(dgraph.Sprintf(`
+		permission(func: eq(OTAC.P.UK, %s), first: 1) @filter(uid_in(OTAC.P-T, %s)) {
+			fromUid as uid
+		}
+		exists(func: eq(OTAC.P.UK, %s), first: 1) {
+			toUid as uid
+		}
+		units(func: has(OTAC.U-Ps)) @filter(uid_in(OTAC.U-T, %s) AND uid_in(OTAC.U-Ps, uid(fromUid))) {
+			count(uid)
+		}
+		objects(func: has(OTAC.O-Ps)) @filter(uid_in(OTAC.O-T, %s) AND uid_in(OTAC.O-Ps, uid(fromUid))) {
+			count(uid)
+		}
+		roles(func: has(OTAC.R-Ps)) @filter(uid_in(OTAC.R-T, %s) AND uid_in(OTAC.R-Ps, uid(fromUid))) {
+			count(uid)
+		}`, q.Str(util.HashBase64(tenant.Tenant, from)), fTenantUID, q.Str(toUK), fTenantUID, fTenantUID, fTenantUID))
+	nq := &dgraph.Nquads{
+		ID: "uid(fromUid)",
 		KV: map[string]interface{}{
-			"*": "*",
+			"OTAC.P":    to,
+			"OTAC.P.UK": toUK,
 		},
 	}
-	delUnitsData, err := delUnits.Bytes()
+	data, err := nq.Bytes()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	out := &struct {
+		Permission []jsonUID `json:"permission"`
+		Exists     []jsonUID `json:"exists"`
+		jsonPermissionUsage
+	}{}
+	if err := m.Do(ctx, query, vars, out, &api.Mutation{
+		Cond:      "@if(eq(len(fromUid), 1) AND eq(len(toUid), 0))",
+		SetNquads: data,
+	}); err != nil {
+		return nil, err
+	}
+	if len(out.Permission) == 0 {
+		return nil, gear.ErrNotFound.WithMsgf("permission %s not found", util.FormatStr(from))
+	}
+	if len(out.Exists) > 0 {
+		return nil, gear.ErrConflict.WithMsgf("permission %s exists", util.FormatStr(to))
+	}
+	return &tpl.PermissionMigrateOutput{
+		Units:   sumCount(out.Units),
+		Objects: sumCount(out.Objects),
+		Roles:   sumCount(out.Roles),
+	}, nil
+}
+
+type jsonMergeTarget struct {
+	UID  string                   `json:"uid"`
+	From []map[string]interface{} `json:"from"`
+	Into []jsonUID                `json:"into"`
+}
+
+type jsonPermissionMerge struct {
+	From      []jsonRawUIDs     `json:"from"`
+	Into      []jsonUID         `json:"into"`
+	ImpliedBy []jsonUID         `json:"impliedBy"`
+	Units     []jsonMergeTarget `json:"units"`
+	Objects   []jsonMergeTarget `json:"objects"`
+	Roles     []jsonMergeTarget `json:"roles"`
+}
+
+type jsonRawUIDs struct {
+	UID     string    `json:"uid"`
+	Implies []jsonUID `json:"implies"`
+}
+
+// mergeFacets 从 "from|key" 形式的 facets 中还原出授权关系的 facets
+func mergeFacets(raw map[string]interface{}) map[string]interface{} {
+	kv := make(map[string]interface{})
+	for k, v := range raw {
+		if !strings.HasPrefix(k, "from|") {
+			continue
+		}
+		switch k = k[5:]; k {
+		case "notBefore", "notAfter":
+			if t := parseFacetTime(v); t != nil {
+				kv[k] = *t
+			}
+		default:
+			kv[k] = v
+		}
 	}
+	return kv
+}
+
+// Merge 将权限 from 合并到已存在的权限 into，并删除 from。
+// 管理单元、资源对象和角色对 from 的引用会在同一个事务中改为引用 into，授权关系上的 facets 会被保留，
+// 已同时引用 into 的管理单元和资源对象保留原有的 into 授权关系；蕴含 from 的权限改为蕴含 into，from 蕴含的权限并入 into
+func (m *Permission) Merge(ctx context.Context, tenant tpl.Tenant, from, into string) (_ *tpl.PermissionMigrateOutput, err error) {
+	ctx = m.begin(ctx, "Permission.Merge")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Permission.Merge", Permissions: []string{from, into}},
+		m.effective(ctx, tenant.Tenant, new(diff).remove(newNode("permission", from), "*", anyNode)))
+	q := dgraph.NewQuery()
+	fTenantUID := q.UID(tenant.UID)
+	query, vars := q.Build(dgraph.Sprintf(`
+		from(func: eq(OTAC.P.UK, %s), first: 1) @filter(uid_in(OTAC.P-T, %s)) {
+			fromUid as uid
+			implies: OTAC.P-Ps {
+				uid
+			}
+		}
+		into(func: eq(OTAC.P.UK, %s), first: 1) @filter(uid_in(OTAC.P-T, %s)) {
+			intoUid as uid
+		}
+		impliedBy(func: has(OTAC.P-Ps)) @filter(uid_in(OTAC.P-T, %s) AND uid_in(OTAC.P-Ps, uid(fromUid))) {
+			uid
+		}
+		units(func: has(OTAC.U-Ps)) @filter(uid_in(OTAC.U-T, %s) AND uid_in(OTAC.U-Ps, uid(fromUid))) {
+			uid
+			from: OTAC.U-Ps @filter(uid(fromUid)) @facets {
+				uid
+			}
+			into: OTAC.U-Ps @filter(uid(intoUid)) {
+				uid
+			}
+		}
+		objects(func: has(OTAC.O-Ps)) @filter(uid_in(OTAC.O-T, %s) AND uid_in(OTAC.O-Ps, uid(fromUid))) {
+			uid
+			from: OTAC.O-Ps @filter(uid(fromUid)) @facets {
+				uid
+			}
+			into: OTAC.O-Ps @filter(uid(intoUid)) {
+				uid
+			}
+		}
+		roles(func: has(OTAC.R-Ps)) @filter(uid_in(OTAC.R-T, %s) AND uid_in(OTAC.R-Ps, uid(fromUid))) {
+			uid
+		}`, q.Str(util.HashBase64(tenant.Tenant, from)), fTenantUID, q.Str(util.HashBase64(tenant.Tenant, into)), fTenantUID, fTenantUID, fTenantUID, fTenantUID, fTenantUID))
+
+	res := &tpl.PermissionMigrateOutput{}
+	err = m.Txn(ctx, func(txn *dgraph.Txn) error {
+		resp, err := txn.QueryWithVars(ctx, query, vars)
+		if err != nil {
+			return err
+		}
+		out := &jsonPermissionMerge{}
+		if err := json.Unmarshal(resp.Json, out); err != nil {
+			return err
+		}
+		if len(out.From) == 0 {
+			return gear.ErrNotFound.WithMsgf("permission %s not found", util.FormatStr(from))
+		}
+		if len(out.Into) == 0 {
+			return gear.ErrNotFound.WithMsgf("permission %s not found", util.FormatStr(into))
+		}
+		fromUID := util.FormatUID(out.From[0].UID)
+		intoUID := util.FormatUID(out.Into[0].UID)
+
+		set := new(bytes.Buffer)
+		del := new(bytes.Buffer)
+		write := func(w *bytes.Buffer, nq *dgraph.Nquads) error {
+			data, err := nq.Bytes()
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		}
+		mergeTargets := func(targets []jsonMergeTarget, predicate string) error {
+			for _, t := range targets {
+				if err := write(del, &dgraph.Nquads{
+					ID: t.UID,
+					KV: map[string]interface{}{predicate: fromUID},
+				}); err != nil {
+					return err
+				}
+				if len(t.Into) > 0 {
+					continue
+				}
+				v := dgraph.WithFacets{V: intoUID}
+				if len(t.From) > 0 {
+					v.KV = mergeFacets(t.From[0])
+				}
+				if err := write(set, &dgraph.Nquads{
+					ID: t.UID,
+					KV: map[string]interface{}{predicate: v},
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := mergeTargets(out.Units, "OTAC.U-Ps"); err != nil {
+			return err
+		}
+		if err := mergeTargets(out.Objects, "OTAC.O-Ps"); err != nil {
+			return err
+		}
+		roles := make([]jsonMergeTarget, 0, len(out.Roles))
+		for _, r := range out.Roles {
+			roles = append(roles, jsonMergeTarget{UID: r.UID})
+		}
+		if err := mergeTargets(roles, "OTAC.R-Ps"); err != nil {
+			return err
+		}
+		for _, p := range out.ImpliedBy {
+			if err := write(del, &dgraph.Nquads{
+				ID: p.UID,
+				KV: map[string]interface{}{"OTAC.P-Ps": fromUID},
+			}); err != nil {
+				return err
+			}
+			if p.UID != out.Into[0].UID {
+				if err := write(set, &dgraph.Nquads{
+					ID: p.UID,
+					KV: map[string]interface{}{"OTAC.P-Ps": intoUID},
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		for _, p := range out.From[0].Implies {
+			if p.UID != out.Into[0].UID {
+				if err := write(set, &dgraph.Nquads{
+					ID: out.Into[0].UID,
+					KV: map[string]interface{}{"OTAC.P-Ps": util.FormatUID(p.UID)},
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		if err := write(del, &dgraph.Nquads{
+			ID: out.From[0].UID,
+			KV: map[string]interface{}{"*": "*"},
+		}); err != nil {
+			return err
+		}
 
-	return m.Do(ctx, q, nil, nil, &api.Mutation{
-		Cond:      "@if(gt(len(permissionUid), 0))",
-		DelNquads: delPermissionData,
-	}, &api.Mutation{
-		Cond:      "@if(gt(len(objectUids), 0))",
-		DelNquads: ddelObjectsData,
-	}, &api.Mutation{
-		Cond:      "@if(gt(len(unitsUids), 0))",
-		DelNquads: delUnitsData,
+		if _, err := txn.Mutate(ctx, &api.Mutation{
+			SetNquads: set.Bytes(),
+			DelNquads: del.Bytes(),
+		}); err != nil {
+			return err
+		}
+		res.Units = len(out.Units)
+		res.Objects = len(out.Objects)
+		res.Roles = len(out.Roles)
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
 }
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/teambition/gear"
+)
+
+func TestFindCycle(t *testing.T) {
+	graph := map[string][]string{
+		"A": {"B", "C"},
+		"B": {"C"},
+		"C": {"D"},
+		"E": {"F"},
+		"F": {"G"},
+		"G": {"E"},
+	}
+	if cycle := findCycle(graph, "A"); cycle != nil {
+		t.Fatalf("A got %v", cycle)
+	}
+	if cycle := strings.Join(findCycle(graph, "E"), " -> "); cycle != "E -> F -> G -> E" {
+		t.Fatalf("E got %s", cycle)
+	}
+	graph["D"] = []string{"B"}
+	if cycle := strings.Join(findCycle(graph, "A"), " -> "); cycle != "B -> C -> D -> B" {
+		t.Fatalf("A got %s", cycle)
+	}
+	if cycle := strings.Join(findCycle(map[string][]string{"A": {"A"}}, "A"), " -> "); cycle != "A -> A" {
+		t.Fatalf("self got %s", cycle)
+	}
+}
+
+// permissionDefs 解析对象形式的权限
+func permissionDefs(t *testing.T, data string) []tpl.Permission {
+	ps := make([]tpl.Permission, 0)
+	if err := json.Unmarshal([]byte(data), &ps); err != nil {
+		t.Fatal(err)
+	}
+	return ps
+}
+
+func TestPermissionImpliesCyclic(t *testing.T) {
+	f := newDgraphFixture(t)
+	conflict := func(err error) bool {
+		e, ok := err.(*gear.Error)
+		return ok && e.Code == http.StatusConflict
+	}
+
+	f.addPermissions("Doc.read")
+	if err := f.ms.Permission.BatchAdd(f.ctx, f.tenant, permissionDefs(t, `[
+		{"permission": "Doc.edit", "implies": ["Doc.read"]},
+		{"permission": "Doc.admin", "implies": ["Doc.edit"]}
+	]`)); err != nil {
+		t.Fatal(err)
+	}
+
+	// 与已存在的蕴含链成环
+	err := f.ms.Permission.BatchAdd(f.ctx, f.tenant, permissionDefs(t, `[{"permission": "Doc.read", "implies": ["Doc.admin"]}]`))
+	if !conflict(err) || !strings.Contains(err.Error(), "Doc.read -> Doc.admin -> Doc.edit -> Doc.read") {
+		t.Fatalf("existing chain got %v", err)
+	}
+	// 同一批次中成环
+	err = f.ms.Permission.BatchAdd(f.ctx, f.tenant, permissionDefs(t, `[
+		{"permission": "Folder.read", "implies": ["Folder.list"]},
+		{"permission": "Folder.list", "implies": ["Folder.read"]}
+	]`))
+	if !conflict(err) {
+		t.Fatalf("same batch got %v", err)
+	}
+	if ps, err := f.ms.Permission.List(f.ctx, f.tenant, []string{"Folder"}, 10, 0, "0x0"); err != nil || len(ps) != 0 {
+		t.Fatalf("rejected batch wrote %v, %v", ps, err)
+	}
+
+	// 覆盖的蕴含关系不再参与环检测
+	if err := f.ms.Permission.BatchAdd(f.ctx, f.tenant, permissionDefs(t, `[
+		{"permission": "Doc.edit"},
+		{"permission": "Doc.read", "implies": ["Doc.admin"]}
+	]`)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPermissionDelete(t *testing.T) {
+	f := newDgraphFixture(t)
+	notFound := func(err error) bool {
+		e, ok := err.(*gear.Error)
+		return ok && e.Code == http.StatusNotFound
+	}
+
+	if _, err := f.ms.Permission.Delete(f.ctx, f.tenant, "Doc.read", false); !notFound(err) {
+		t.Fatalf("nonexistent permission got %v", err)
+	}
+	f.addPermissions("Doc.read")
+	if _, err := f.ms.Permission.Delete(f.ctx, f.tenant, "Doc.read", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.ms.Permission.Delete(f.ctx, f.tenant, "Doc.read", false); !notFound(err) {
+		t.Fatalf("deleted permission got %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package model
+
+import (
+	"context"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/open-trust/ot-ac/src/util"
+	"github.com/teambition/gear"
+)
+
+// Role ...
+type Role struct {
+	*Model
+}
+
+type jsonRole struct {
+	UID         string           `json:"uid"`
+	Role        string           `json:"role"`
+	Permissions []tpl.Permission `json:"permissions"`
+}
+
+func (r jsonRole) toRole() *tpl.Role {
+	res := &tpl.Role{UID: r.UID, Role: r.Role, Permissions: make([]string, 0, len(r.Permissions))}
+	for _, p := range r.Permissions {
+		res.Permissions = append(res.Permissions, p.Permission)
+	}
+	return res
+}
+
+func (m *Role) acquirePermissionUIDs(ctx context.Context, tenant tpl.Tenant, permissions []string) ([]string, error) {
+	uids := make([]string, 0, len(permissions))
+	if len(permissions) == 0 {
+		return uids, nil
+	}
+	ps, err := m.acquirePermissions(ctx, tenant, permissions)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range permissions {
+		uid := tpl.GetPermissionUID(ps, p)
+		if uid == "" {
+			return nil, gear.ErrBadRequest.WithMsgf("permission %s not found", util.FormatStr(p))
+		}
+		uids = append(uids, uid)
+	}
+	return uids, nil
+}
+
+// Add 创建角色，权限必须预先存在
+func (m *Role) Add(ctx context.Context, tenant tpl.Tenant, role string, permissions []string) (ok bool, err error) {
+	ctx = m.begin(ctx, "Role.Add")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Role.Add", Roles: []string{role}, Permissions: permissions},
+		m.effective(ctx, tenant.Tenant, new(diff).add(newNode("role", role), "permissions", nameNodes("permission", permissions)...)))
+	uids, err := m.acquirePermissionUIDs(ctx, tenant, permissions)
+	if err != nil {
+		return false, err
+	}
+	nq := &dgraph.Nquads{
+		UKkey: "OTAC.R.UK",
+		UKval: util.HashBase64(tenant.Tenant, role),
+		Type:  "OTACRole",
+		KV: map[string]interface{}{
+			"OTAC.R-T": util.FormatUID(tenant.UID),
+			"OTAC.R":   role,
+		},
+	}
+	if len(uids) > 0 {
+		nq.KV["OTAC.R-Ps"] = util.FormatUIDs(uids)
+	}
+	return m.Model.Add(ctx, nq)
+}
+
+// Update 覆盖角色的权限，权限必须预先存在，当 permissions 为空时会清空权限。
+// 管理单元通过 OTAC.U-Rs 引用角色，更新后对所有持有该角色的管理单元立即生效
+func (m *Role) Update(ctx context.Context, tenant tpl.Tenant, role string, permissions []string) (err error) {
+	ctx = m.begin(ctx, "Role.Update")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Role.Update", Roles: []string{role}, Permissions: permissions},
+		m.permissionsDiff(ctx, tenant, role, permissions))
+	uids, err := m.acquirePermissionUIDs(ctx, tenant, permissions)
+	if err != nil {
+		return err
+	}
+
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(OTAC.R.UK, %s), first: 1) {
+			roleUid as uid
+		}`, q.Str(util.HashBase64(tenant.Tenant, role))))
+	del := &dgraph.Nquads{
+		ID: "uid(roleUid)",
+		KV: map[string]interface{}{
+			"OTAC.R-Ps": "*",
+		},
+	}
+	delData, err := del.Bytes()
+	if err != nil {
+		return err
+	}
+	mus := []*api.Mutation{{
+		Cond:      "@if(eq(len(roleUid), 1))",
+		DelNquads: delData,
+	}}
+	if len(uids) > 0 {
+		set := &dgraph.Nquads{
+			ID: "uid(roleUid)",
+			KV: map[string]interface{}{
+				"OTAC.R-Ps": util.FormatUIDs(uids),
+			},
+		}
+		setData, err := set.Bytes()
+		if err != nil {
+			return err
+		}
+		mus = append(mus, &api.Mutation{
+			Cond:      "@if(eq(len(roleUid), 1))",
+			SetNquads: setData,
+		})
+	}
+
+	r := make([]jsonUID, 0)
+	out := &jsonCheckCyclic{Result: &r}
+	if err := m.Do(ctx, query, vars, out, mus...); err != nil {
+		return err
+	}
+	if len(r) == 0 {
+		return gear.ErrNotFound.WithMsgf("Role(%s) not found", role)
+	}
+	return nil
+}
+
+// permissionsDiff 对比角色当前的权限与 permissions，用于审计记录；未启用审计日志时返回 nil，当前的权限未知时记录删除所有权限
+func (m *Role) permissionsDiff(ctx context.Context, tenant tpl.Tenant, role string, permissions []string) *diff {
+	if m.auditSink == nil {
+		return nil
+	}
+	from := newNode("role", role)
+	before, err := m.Get(ctx, tenant, role)
+	if err != nil {
+		return m.effective(ctx, tenant.Tenant, new(diff).remove(from, "permissions", anyNode).add(from, "permissions", nameNodes("permission", permissions)...))
+	}
+	notIn := func(ss []string) func(string) bool {
+		return func(s string) bool {
+			return !util.StringsHas(ss, func(v string) bool { return v == s })
+		}
+	}
+	return new(diff).
+		remove(from, "permissions", nameNodes("permission", util.StringsFilter(before.Permissions, notIn(permissions)))...).
+		add(from, "permissions", nameNodes("permission", util.StringsFilter(permissions, notIn(before.Permissions)))...)
+}
+
+// Get 获取角色及其权限
+func (m *Role) Get(ctx context.Context, tenant tpl.Tenant, role string) (*tpl.Role, error) {
+	ctx = dgraph.WithMethod(ctx, "Role.Get")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(OTAC.R.UK, %s), first: 1) {
+			uid
+			role: OTAC.R
+			permissions: OTAC.R-Ps @filter(uid_in(OTAC.P-T, %s)) {
+				permission: OTAC.P
+			}
+		}`, q.Str(util.HashBase64(tenant.Tenant, role)), q.UID(tenant.UID)))
+	res := jsonRole{}
+	if err := m.Model.Get(ctx, query, vars, &res); err != nil {
+		return nil, err
+	}
+	return res.toRole(), nil
+}
+
+// List 列出租户的所有角色及其权限
+func (m *Role) List(ctx context.Context, tenant tpl.Tenant, pageSize, skip int, uidToken string) ([]*tpl.Role, error) {
+	ctx = dgraph.WithMethod(ctx, "Role.List")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(dgraph.type, "OTACRole"), first: %s, offset: %s, after: %s) @filter(uid_in(OTAC.R-T, %s)) {
+			uid
+			role: OTAC.R
+			permissions: OTAC.R-Ps @filter(uid_in(OTAC.P-T, %s)) {
+				permission: OTAC.P
+			}
+		}`, q.Int(pageSize), q.Int(skip), q.UID(uidToken), q.UID(tenant.UID), q.UID(tenant.UID)))
+	data := make([]jsonRole, 0, pageSize)
+	if err := m.Model.List(ctx, query, vars, &data); err != nil {
+		return nil, err
+	}
+	res := make([]*tpl.Role, 0, len(data))
+	for _, r := range data {
+		res = append(res, r.toRole())
+	}
+	return res, nil
+}
+
+// Delete 删除角色，并解除所有管理单元与该角色的关系
+func (m *Role) Delete(ctx context.Context, tenant tpl.Tenant, role string) (err error) {
+	ctx = m.begin(ctx, "Role.Delete")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Role.Delete", Roles: []string{role}},
+		m.effective(ctx, tenant.Tenant, new(diff).remove(newNode("role", role), "*", anyNode)))
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		roleUid as var(func: eq(OTAC.R.UK, %s), first: 1)
+		unitsUids as var(func: has(OTAC.U-Rs)) @filter(uid_in(OTAC.U-Rs, uid(roleUid)))`, q.Str(util.HashBase64(tenant.Tenant, role))))
+	delRole := &dgraph.Nquads{
+		ID: "uid(roleUid)",
+		KV: map[string]interface{}{
+			"*": "*",
+		},
+	}
+	delRoleData, err := delRole.Bytes()
+	if err != nil {
+		return err
+	}
+	delUnits := &dgraph.Nquads{
+		ID: "uid(unitsUids)",
+		KV: map[string]interface{}{
+			"OTAC.U-Rs": "uid(roleUid)",
+		},
+	}
+	delUnitsData, err := delUnits.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return m.Do(ctx, query, vars, nil, &api.Mutation{
+		Cond:      "@if(gt(len(roleUid), 0))",
+		DelNquads: delRoleData,
+	}, &api.Mutation{
+		Cond:      "@if(gt(len(unitsUids), 0))",
+		DelNquads: delUnitsData,
+	})
+}
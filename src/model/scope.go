@@ -2,7 +2,6 @@ package model
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/dgraph-io/dgo/v200/protos/api"
 	"github.com/open-trust/ot-ac/src/service/dgraph"
@@ -16,7 +15,10 @@ type Scope struct {
 }
 
 // Add 创建范围约束
-func (m *Scope) Add(ctx context.Context, tenant tpl.Tenant, input tpl.Scope) (bool, error) {
+func (m *Scope) Add(ctx context.Context, tenant tpl.Tenant, input tpl.Scope) (ok bool, err error) {
+	ctx = m.begin(ctx, "Scope.Add")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Scope.Add", Scopes: []tpl.Target{{Type: input.TargetType, ID: input.TargetID}}},
+		new(diff).change("status", nil, input.Status))
 	nq := &dgraph.Nquads{
 		UKkey: "OTAC.Sc.UK",
 		UKval: util.HashBase64(tenant.Tenant, input.TargetType, input.TargetID),
@@ -33,7 +35,8 @@ func (m *Scope) Add(ctx context.Context, tenant tpl.Tenant, input tpl.Scope) (bo
 }
 
 // UpdateStatus 更新范围约束的状态，-1 表示停用
-func (m *Scope) UpdateStatus(ctx context.Context, tenant tpl.Tenant, scope tpl.Target, status int) error {
+func (m *Scope) UpdateStatus(ctx context.Context, tenant tpl.Tenant, scope tpl.Target, status int) (err error) {
+	ctx = m.begin(ctx, "Scope.UpdateStatus")
 	update := &dgraph.Nquads{
 		UKkey: "OTAC.Sc.UK",
 		UKval: util.HashBase64(tenant.Tenant, scope.Type, scope.ID),
@@ -42,22 +45,25 @@ func (m *Scope) UpdateStatus(ctx context.Context, tenant tpl.Tenant, scope tpl.T
 			"OTAC.status": status,
 		},
 	}
-	return m.Model.Update(ctx, update, "")
+	d := new(diff).change("status", m.attr(ctx, update.UKkey, update.UKval, "OTAC.status"), status)
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Scope.UpdateStatus", Scopes: []tpl.Target{scope}}, d)
+	return m.Model.Update(ctx, update, nil, dgraph.DQL{})
 }
 
 // List 列出该系统当前所有指定目标类型的范围约束
 func (m *Scope) List(ctx context.Context, tenant tpl.Tenant, targetType string,
 	pageSize, skip int, uidToken string) ([]*tpl.Scope, error) {
-	q := fmt.Sprintf(`query {
-		result(func: eq(OTAC.ScType, %s), first: %d, offset: %d, after: %s) @filter(uid_in(OTAC.Sc-T, %s)) {
+	ctx = dgraph.WithMethod(ctx, "Scope.List")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(OTAC.ScType, %s), first: %s, offset: %s, after: %s) @filter(uid_in(OTAC.Sc-T, %s)) {
 			uid
 			status: OTAC.status
 			targetId: OTAC.ScId
 			targetType: OTAC.ScType
-		}
-	}`, util.FormatStr(targetType), pageSize, skip, util.FormatUID(uidToken), util.FormatUID(tenant.UID))
+		}`, q.Str(targetType), q.Int(pageSize), q.Int(skip), q.UID(uidToken), q.UID(tenant.UID)))
 	res := make([]*tpl.Scope, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -66,17 +72,18 @@ func (m *Scope) List(ctx context.Context, tenant tpl.Tenant, targetType string,
 // ListUnits 列出范围约束下指定目标类型的直属的管理单元
 func (m *Scope) ListUnits(ctx context.Context, tenant tpl.Tenant, scope tpl.Target, targetType string,
 	pageSize, skip int, uidToken string) ([]*tpl.Unit, error) {
-	q := fmt.Sprintf(`query {
+	ctx = dgraph.WithMethod(ctx, "Scope.ListUnits")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
 		scopeUid as var(func: eq(OTAC.ScId, %s), first: 1) @filter(eq(OTAC.ScType, %s) AND uid_in(OTAC.Sc-T, %s))
-		result(func: eq(OTAC.UType, %s), first: %d, offset: %d, after: %s) @filter(uid_in(OTAC.U-Scs, uid(scopeUid))) {
+		result(func: eq(OTAC.UType, %s), first: %s, offset: %s, after: %s) @filter(uid_in(OTAC.U-Scs, uid(scopeUid))) {
 			uid
 			status: OTAC.status
 			targetId: OTAC.UId
 			targetType: OTAC.UType
-		}
-	}`, util.FormatStr(scope.ID), util.FormatStr(scope.Type), util.FormatUID(tenant.UID), util.FormatStr(targetType), pageSize, skip, util.FormatUID(uidToken))
+		}`, q.Str(scope.ID), q.Str(scope.Type), q.UID(tenant.UID), q.Str(targetType), q.Int(pageSize), q.Int(skip), q.UID(uidToken)))
 	res := make([]*tpl.Unit, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -85,29 +92,33 @@ func (m *Scope) ListUnits(ctx context.Context, tenant tpl.Tenant, scope tpl.Targ
 // ListObjects 列出范围约束下指定目标类型的直属的资源对象
 func (m *Scope) ListObjects(ctx context.Context, tenant tpl.Tenant, scope tpl.Target, targetType string,
 	pageSize, skip int, uidToken string) ([]*tpl.Object, error) {
-	q := fmt.Sprintf(`query {
+	ctx = dgraph.WithMethod(ctx, "Scope.ListObjects")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
 			scopeUid as var(func: eq(OTAC.ScId, %s), first: 1) @filter(eq(OTAC.ScType, %s) AND uid_in(OTAC.Sc-T, %s))
-			result(func: eq(OTAC.OType, %s), first: %d, offset: %d, after: %s) @filter(uid_in(OTAC.O-Scs, uid(scopeUid))) {
+			result(func: eq(OTAC.OType, %s), first: %s, offset: %s, after: %s) @filter(uid_in(OTAC.O-Scs, uid(scopeUid))) {
 				uid
 				status: OTAC.status
 				targetId: OTAC.OId
 				targetType: OTAC.OType
-			}
-		}`, util.FormatStr(scope.ID), util.FormatStr(scope.Type), util.FormatUID(tenant.UID), util.FormatStr(targetType), pageSize, skip, util.FormatUID(uidToken))
+			}`, q.Str(scope.ID), q.Str(scope.Type), q.UID(tenant.UID), q.Str(targetType), q.Int(pageSize), q.Int(skip), q.UID(uidToken)))
 	res := make([]*tpl.Object, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
 // Delete 删除范围约束
-func (m *Scope) Delete(ctx context.Context, tenant tpl.Tenant, scope tpl.Target) error {
-	q := fmt.Sprintf(`query {
+func (m *Scope) Delete(ctx context.Context, tenant tpl.Tenant, scope tpl.Target) (err error) {
+	ctx = m.begin(ctx, "Scope.Delete")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Scope.Delete", Scopes: []tpl.Target{scope}},
+		m.effective(ctx, tenant.Tenant, new(diff).remove(targetNode("scope", scope), "*", anyNode)))
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
 		scopeUid as var(func: eq(OTAC.ScId, %s), first: 1) @filter(eq(OTAC.ScType, %s) AND uid_in(OTAC.Sc-T, %s))
 		objectUids as var(func: has(OTAC.O-Scs)) @filter(uid_in(OTAC.O-Scs, uid(scopeUid)))
-		unitsUids as var(func: has(OTAC.U-Scs)) @filter(uid_in(OTAC.U-Scs, uid(scopeUid)))
-	}`, util.FormatStr(scope.ID), util.FormatStr(scope.Type), util.FormatUID(tenant.UID))
+		unitsUids as var(func: has(OTAC.U-Scs)) @filter(uid_in(OTAC.U-Scs, uid(scopeUid)))`, q.Str(scope.ID), q.Str(scope.Type), q.UID(tenant.UID)))
 	delScope := &dgraph.Nquads{
 		ID: "uid(scopeUid)",
 		KV: map[string]interface{}{
@@ -139,7 +150,7 @@ func (m *Scope) Delete(ctx context.Context, tenant tpl.Tenant, scope tpl.Target)
 		return err
 	}
 
-	return m.Do(ctx, q, nil, nil, &api.Mutation{
+	return m.Do(ctx, query, vars, nil, &api.Mutation{
 		Cond:      "@if(gt(len(scopeUid), 0))",
 		DelNquads: delScopeData,
 	}, &api.Mutation{
@@ -152,8 +163,12 @@ func (m *Scope) Delete(ctx context.Context, tenant tpl.Tenant, scope tpl.Target)
 }
 
 // DeleteAll 删除范围约束及范围内的所有 Unit 和 Object
-func (m *Scope) DeleteAll(ctx context.Context, tenant tpl.Tenant, scope tpl.Target) error {
-	q := fmt.Sprintf(`query {
+func (m *Scope) DeleteAll(ctx context.Context, tenant tpl.Tenant, scope tpl.Target) (err error) {
+	ctx = m.begin(ctx, "Scope.DeleteAll")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Scope.DeleteAll", Scopes: []tpl.Target{scope}},
+		m.effective(ctx, tenant.Tenant, new(diff).remove(targetNode("scope", scope), "*", anyNode)))
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
 		scopeUid as var(func: eq(OTAC.ScId, %s), first: 1) @filter(eq(OTAC.ScType, %s) AND uid_in(OTAC.Sc-T, %s))
 		objectUids as var(func: has(OTAC.O-Scs)) @filter(uid_in(OTAC.O-Scs, uid(scopeUid)))
 		unitsUids as var(func: has(OTAC.U-Scs)) @filter(uid_in(OTAC.U-Scs, uid(scopeUid)))
@@ -162,8 +177,7 @@ func (m *Scope) DeleteAll(ctx context.Context, tenant tpl.Tenant, scope tpl.Targ
 		}
 		descendantUnitUids as var(func: uid(unitsUids)) @recurse(loop: false) {
 			~OTAC.U-Us
-		}
-	}`, util.FormatStr(scope.ID), util.FormatStr(scope.Type), util.FormatUID(tenant.UID))
+		}`, q.Str(scope.ID), q.Str(scope.Type), q.UID(tenant.UID)))
 	delScope := &dgraph.Nquads{
 		ID: "uid(scopeUid)",
 		KV: map[string]interface{}{
@@ -195,7 +209,7 @@ func (m *Scope) DeleteAll(ctx context.Context, tenant tpl.Tenant, scope tpl.Targ
 		return err
 	}
 
-	return m.Do(ctx, q, nil, nil, &api.Mutation{
+	return m.Do(ctx, query, vars, nil, &api.Mutation{
 		Cond:      "@if(gt(len(scopeUid), 0))",
 		DelNquads: delScopeData,
 	}, &api.Mutation{
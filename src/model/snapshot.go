@@ -0,0 +1,449 @@
+package model
+
+import (
+	"context"
+	"strings"
+
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/open-trust/ot-ac/src/util"
+)
+
+// Snapshot 租户快照，分页导出租户的全部节点和关系，导入通过其它 model 的幂等方法完成
+type Snapshot struct {
+	*Model
+}
+
+// snapshotPageSize 导出时每次查询的节点数，管理单元的关系较多，不宜过大
+const snapshotPageSize = 100
+
+type jsonSnapshotTarget struct {
+	UID         string                   `json:"uid"`
+	Status      int                      `json:"status"`
+	ID          string                   `json:"targetId"`
+	Type        string                   `json:"targetType"`
+	Terms       string                   `json:"terms"`
+	Parents     []tpl.Target             `json:"parents"`
+	Scopes      []tpl.Target             `json:"scopes"`
+	Permissions []map[string]interface{} `json:"permissions"`
+	Roles       []tpl.Role               `json:"roles"`
+	Subjects    []map[string]interface{} `json:"subjects"`
+	Orgs        []tpl.Organization       `json:"orgs"`
+	Units       []tpl.Target             `json:"units"`
+}
+
+type jsonSnapshotOrgLink struct {
+	ID      string `json:"targetId"`
+	Type    string `json:"targetType"`
+	Org     string `json:"org"`
+	OU      string `json:"ou"`
+	Subject string `json:"subject"`
+}
+
+type jsonSnapshotEdges struct {
+	Result  []jsonSnapshotTarget  `json:"result"`
+	OUs     []jsonSnapshotOrgLink `json:"ous"`
+	Members []jsonSnapshotOrgLink `json:"members"`
+}
+
+// snapshotFacets 从 "alias|key" 形式的 facets 中还原出有效时间窗口和扩展属性
+func snapshotFacets(raw map[string]interface{}, alias string) (tpl.Validity, tpl.Extensions) {
+	validity := tpl.Validity{}
+	var ext tpl.Extensions
+	prefix := alias + "|"
+	for k, v := range raw {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		switch k = k[len(prefix):]; k {
+		case "notBefore":
+			validity.NotBefore = parseFacetTime(v)
+		case "notAfter":
+			validity.NotAfter = parseFacetTime(v)
+		default:
+			if ext == nil {
+				ext = make(tpl.Extensions)
+			}
+			ext[k] = v
+		}
+	}
+	return validity, ext
+}
+
+func targetRef(t tpl.Target) *tpl.Target {
+	return &t
+}
+
+// Export 按 permission、role、scope、unit、object 节点及 unit、object 关系的顺序导出租户的快照记录，
+// 每条记录调用一次 emit，emit 返回错误时中止导出
+func (m *Snapshot) Export(ctx context.Context, tenant tpl.Tenant, emit func(*tpl.SnapshotRecord) error) error {
+	ctx = dgraph.WithMethod(ctx, "Snapshot.Export")
+	steps := []func(context.Context, tpl.Tenant, func(*tpl.SnapshotRecord) error) error{
+		m.exportPermissions,
+		m.exportRoles,
+		m.exportScopes,
+		m.exportUnits,
+		m.exportObjects,
+		m.exportUnitEdges,
+		m.exportObjectEdges,
+	}
+	for _, step := range steps {
+		if err := step(ctx, tenant, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Snapshot) exportPermissions(ctx context.Context, tenant tpl.Tenant, emit func(*tpl.SnapshotRecord) error) error {
+	for uidToken := ""; ; {
+		q := dgraph.NewQuery()
+		fTenantUID := q.UID(tenant.UID)
+		query, vars := q.Build(dgraph.Sprintf(`
+			result(func: eq(dgraph.type, "OTACPermission"), first: %s, after: %s) @filter(uid_in(OTAC.P-T, %s)) {
+				uid
+				permission: OTAC.P
+				name: OTAC.name
+				description: OTAC.description
+				deprecated: OTAC.deprecated
+				implies: OTAC.P-Ps @filter(uid_in(OTAC.P-T, %s)) {
+					permission: OTAC.P
+				}
+			}`, q.Int(snapshotPageSize), q.UID(uidToken), fTenantUID, fTenantUID))
+		data := make([]jsonPermission, 0, snapshotPageSize)
+		if err := m.Model.List(ctx, query, vars, &data); err != nil {
+			return err
+		}
+		for _, v := range data {
+			p := v.toPermission()
+			if err := emit(&tpl.SnapshotRecord{
+				Kind:        tpl.SnapshotPermission,
+				Permission:  p.Permission,
+				Name:        p.Name,
+				Description: p.Description,
+				Deprecated:  p.Deprecated,
+				Implies:     p.Implies,
+			}); err != nil {
+				return err
+			}
+		}
+		if len(data) < snapshotPageSize {
+			return nil
+		}
+		uidToken = data[len(data)-1].UID
+	}
+}
+
+func (m *Snapshot) exportRoles(ctx context.Context, tenant tpl.Tenant, emit func(*tpl.SnapshotRecord) error) error {
+	rs := &Role{m.Model}
+	for uidToken := ""; ; {
+		data, err := rs.List(ctx, tenant, snapshotPageSize, 0, uidToken)
+		if err != nil {
+			return err
+		}
+		for _, r := range data {
+			if err := emit(&tpl.SnapshotRecord{
+				Kind:        tpl.SnapshotRole,
+				Role:        r.Role,
+				Permissions: r.Permissions,
+			}); err != nil {
+				return err
+			}
+		}
+		if len(data) < snapshotPageSize {
+			return nil
+		}
+		uidToken = data[len(data)-1].UID
+	}
+}
+
+func (m *Snapshot) exportScopes(ctx context.Context, tenant tpl.Tenant, emit func(*tpl.SnapshotRecord) error) error {
+	for uidToken := ""; ; {
+		q := dgraph.NewQuery()
+		query, vars := q.Build(dgraph.Sprintf(`
+			result(func: eq(dgraph.type, "OTACScope"), first: %s, after: %s) @filter(uid_in(OTAC.Sc-T, %s)) {
+				uid
+				status: OTAC.status
+				targetId: OTAC.ScId
+				targetType: OTAC.ScType
+			}`, q.Int(snapshotPageSize), q.UID(uidToken), q.UID(tenant.UID)))
+		data := make([]tpl.Scope, 0, snapshotPageSize)
+		if err := m.Model.List(ctx, query, vars, &data); err != nil {
+			return err
+		}
+		for _, s := range data {
+			if err := emit(&tpl.SnapshotRecord{
+				Kind:       tpl.SnapshotScope,
+				Status:     s.Status,
+				TargetType: s.TargetType,
+				TargetID:   s.TargetID,
+			}); err != nil {
+				return err
+			}
+		}
+		if len(data) < snapshotPageSize {
+			return nil
+		}
+		uidToken = data[len(data)-1].UID
+	}
+}
+
+func (m *Snapshot) exportUnits(ctx context.Context, tenant tpl.Tenant, emit func(*tpl.SnapshotRecord) error) error {
+	for uidToken := ""; ; {
+		q := dgraph.NewQuery()
+		query, vars := q.Build(dgraph.Sprintf(`
+			result(func: eq(dgraph.type, "OTACUnit"), first: %s, after: %s) @filter(uid_in(OTAC.U-T, %s)) {
+				uid
+				status: OTAC.status
+				targetId: OTAC.UId
+				targetType: OTAC.UType
+			}`, q.Int(snapshotPageSize), q.UID(uidToken), q.UID(tenant.UID)))
+		data := make([]jsonSnapshotTarget, 0, snapshotPageSize)
+		if err := m.Model.List(ctx, query, vars, &data); err != nil {
+			return err
+		}
+		for _, u := range data {
+			if err := emit(&tpl.SnapshotRecord{
+				Kind:       tpl.SnapshotUnit,
+				Status:     u.Status,
+				TargetType: u.Type,
+				TargetID:   u.ID,
+			}); err != nil {
+				return err
+			}
+		}
+		if len(data) < snapshotPageSize {
+			return nil
+		}
+		uidToken = data[len(data)-1].UID
+	}
+}
+
+func (m *Snapshot) exportObjects(ctx context.Context, tenant tpl.Tenant, emit func(*tpl.SnapshotRecord) error) error {
+	for uidToken := ""; ; {
+		q := dgraph.NewQuery()
+		query, vars := q.Build(dgraph.Sprintf(`
+			result(func: eq(dgraph.type, "OTACObject"), first: %s, after: %s) @filter(uid_in(OTAC.O-T, %s)) {
+				uid
+				targetId: OTAC.OId
+				targetType: OTAC.OType
+				terms: OTAC.terms
+			}`, q.Int(snapshotPageSize), q.UID(uidToken), q.UID(tenant.UID)))
+		data := make([]jsonSnapshotTarget, 0, snapshotPageSize)
+		if err := m.Model.List(ctx, query, vars, &data); err != nil {
+			return err
+		}
+		for _, o := range data {
+			if err := emit(&tpl.SnapshotRecord{
+				Kind:       tpl.SnapshotObject,
+				TargetType: o.Type,
+				TargetID:   o.ID,
+				Terms:      o.Terms,
+			}); err != nil {
+				return err
+			}
+		}
+		if len(data) < snapshotPageSize {
+			return nil
+		}
+		uidToken = data[len(data)-1].UID
+	}
+}
+
+func (m *Snapshot) exportUnitEdges(ctx context.Context, tenant tpl.Tenant, emit func(*tpl.SnapshotRecord) error) error {
+	for uidToken := ""; ; {
+		q := dgraph.NewQuery()
+		query, vars := q.Build(dgraph.Sprintf(`
+			unitUIDs as var(func: eq(dgraph.type, "OTACUnit"), first: %s, after: %s) @filter(uid_in(OTAC.U-T, %s))
+			result(func: uid(unitUIDs)) {
+				uid
+				targetId: OTAC.UId
+				targetType: OTAC.UType
+				parents: OTAC.U-Us {
+					targetId: OTAC.UId
+					targetType: OTAC.UType
+				}
+				scopes: OTAC.U-Scs {
+					targetId: OTAC.ScId
+					targetType: OTAC.ScType
+				}
+				permissions: OTAC.U-Ps @facets {
+					permission: OTAC.P
+				}
+				roles: OTAC.U-Rs {
+					role: OTAC.R
+				}
+				subjects: OTAC.U-Ss @facets {
+					subject: OTAC.Sub
+				}
+				orgs: OTAC.U-Orgs {
+					organization: OTAC.Org
+				}
+			}
+			ous(func: uid(unitUIDs)) @normalize {
+				targetId: OTAC.UId
+				targetType: OTAC.UType
+				OTAC.U-OUs {
+					ou: OTAC.OU
+					OTAC.OU-Org {
+						org: OTAC.Org
+					}
+				}
+			}
+			members(func: uid(unitUIDs)) @normalize {
+				targetId: OTAC.UId
+				targetType: OTAC.UType
+				OTAC.U-Ms {
+					OTAC.M-S {
+						subject: OTAC.Sub
+					}
+					OTAC.M-Org {
+						org: OTAC.Org
+					}
+				}
+			}`, q.Int(snapshotPageSize), q.UID(uidToken), q.UID(tenant.UID)))
+		out := &jsonSnapshotEdges{}
+		if err := m.Query(ctx, query, vars, out); err != nil {
+			return err
+		}
+
+		records := make([]*tpl.SnapshotRecord, 0)
+		for _, u := range out.Result {
+			unit := &tpl.Target{Type: u.Type, ID: u.ID}
+			for _, p := range u.Parents {
+				records = append(records, &tpl.SnapshotRecord{Kind: tpl.SnapshotUnitParent, Unit: unit, Parent: targetRef(p)})
+			}
+			for _, s := range u.Scopes {
+				records = append(records, &tpl.SnapshotRecord{Kind: tpl.SnapshotUnitScope, Unit: unit, Scope: targetRef(s)})
+			}
+			for _, p := range u.Permissions {
+				permission, _ := p["permission"].(string)
+				validity, ext := snapshotFacets(p, "permissions")
+				records = append(records, &tpl.SnapshotRecord{Kind: tpl.SnapshotUnitPermission, Unit: unit,
+					Permission: permission, Extensions: ext, Validity: validity})
+			}
+			for _, r := range u.Roles {
+				records = append(records, &tpl.SnapshotRecord{Kind: tpl.SnapshotUnitRole, Unit: unit, Role: r.Role})
+			}
+			for _, s := range u.Subjects {
+				subject, _ := s["subject"].(string)
+				validity, _ := snapshotFacets(s, "subjects")
+				records = append(records, &tpl.SnapshotRecord{Kind: tpl.SnapshotUnitSubject, Unit: unit,
+					Subject: subject, Validity: validity})
+			}
+			for _, o := range u.Orgs {
+				records = append(records, &tpl.SnapshotRecord{Kind: tpl.SnapshotUnitOrg, Unit: unit, Org: o.Org})
+			}
+		}
+		for _, l := range out.OUs {
+			if l.OU != "" && l.Org != "" {
+				records = append(records, &tpl.SnapshotRecord{Kind: tpl.SnapshotUnitOU,
+					Unit: &tpl.Target{Type: l.Type, ID: l.ID}, Org: l.Org, OU: l.OU})
+			}
+		}
+		for _, l := range out.Members {
+			if l.Subject != "" && l.Org != "" {
+				records = append(records, &tpl.SnapshotRecord{Kind: tpl.SnapshotUnitMember,
+					Unit: &tpl.Target{Type: l.Type, ID: l.ID}, Org: l.Org, Subject: l.Subject})
+			}
+		}
+		for _, r := range records {
+			if err := emit(r); err != nil {
+				return err
+			}
+		}
+		if len(out.Result) < snapshotPageSize {
+			return nil
+		}
+		uidToken = out.Result[len(out.Result)-1].UID
+	}
+}
+
+func (m *Snapshot) exportObjectEdges(ctx context.Context, tenant tpl.Tenant, emit func(*tpl.SnapshotRecord) error) error {
+	for uidToken := ""; ; {
+		q := dgraph.NewQuery()
+		query, vars := q.Build(dgraph.Sprintf(`
+			result(func: eq(dgraph.type, "OTACObject"), first: %s, after: %s) @filter(uid_in(OTAC.O-T, %s)) {
+				uid
+				targetId: OTAC.OId
+				targetType: OTAC.OType
+				parents: OTAC.O-Os {
+					targetId: OTAC.OId
+					targetType: OTAC.OType
+				}
+				scopes: OTAC.O-Scs {
+					targetId: OTAC.ScId
+					targetType: OTAC.ScType
+				}
+				permissions: OTAC.O-Ps {
+					permission: OTAC.P
+				}
+				units: OTAC.O-Us {
+					targetId: OTAC.UId
+					targetType: OTAC.UType
+				}
+			}`, q.Int(snapshotPageSize), q.UID(uidToken), q.UID(tenant.UID)))
+		data := make([]jsonSnapshotTarget, 0, snapshotPageSize)
+		if err := m.Model.List(ctx, query, vars, &data); err != nil {
+			return err
+		}
+		for _, o := range data {
+			object := &tpl.Target{Type: o.Type, ID: o.ID}
+			records := make([]*tpl.SnapshotRecord, 0)
+			for _, p := range o.Parents {
+				records = append(records, &tpl.SnapshotRecord{Kind: tpl.SnapshotObjectParent, Object: object, Parent: targetRef(p)})
+			}
+			for _, s := range o.Scopes {
+				records = append(records, &tpl.SnapshotRecord{Kind: tpl.SnapshotObjectScope, Object: object, Scope: targetRef(s)})
+			}
+			for _, p := range o.Permissions {
+				permission, _ := p["permission"].(string)
+				records = append(records, &tpl.SnapshotRecord{Kind: tpl.SnapshotObjectPermission, Object: object, Permission: permission})
+			}
+			for _, u := range o.Units {
+				records = append(records, &tpl.SnapshotRecord{Kind: tpl.SnapshotObjectUnit, Object: object, Unit: targetRef(u)})
+			}
+			for _, r := range records {
+				if err := emit(r); err != nil {
+					return err
+				}
+			}
+		}
+		if len(data) < snapshotPageSize {
+			return nil
+		}
+		uidToken = data[len(data)-1].UID
+	}
+}
+
+// UpdateUnitStatus 更新管理单元的状态，用于导入快照
+func (m *Snapshot) UpdateUnitStatus(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, status int) (err error) {
+	ctx = m.begin(ctx, "Snapshot.UpdateUnitStatus")
+	update := &dgraph.Nquads{
+		UKkey: "OTAC.U.UK",
+		UKval: util.HashBase64(tenant.Tenant, unit.Type, unit.ID),
+		Type:  "OTACUnit",
+		KV: map[string]interface{}{
+			"OTAC.status": status,
+		},
+	}
+	d := new(diff).change("status", m.attr(ctx, update.UKkey, update.UKval, "OTAC.status"), status)
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Unit.UpdateStatus", Units: []tpl.Target{unit}}, d)
+	return m.Model.Update(ctx, update, nil, dgraph.DQL{})
+}
+
+// UpdateObjectTerms 更新资源对象的检索词，用于导入快照
+func (m *Snapshot) UpdateObjectTerms(ctx context.Context, tenant tpl.Tenant, object tpl.Target, terms string) (err error) {
+	ctx = m.begin(ctx, "Snapshot.UpdateObjectTerms")
+	update := &dgraph.Nquads{
+		UKkey: "OTAC.O.UK",
+		UKval: util.HashBase64(tenant.Tenant, object.Type, object.ID),
+		Type:  "OTACObject",
+		KV: map[string]interface{}{
+			"OTAC.terms": terms,
+		},
+	}
+	d := new(diff).change("terms", m.attr(ctx, update.UKkey, update.UKval, "OTAC.terms"), terms)
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Object.UpdateTerms", Objects: []tpl.Target{object}}, d)
+	return m.Model.Update(ctx, update, nil, dgraph.DQL{})
+}
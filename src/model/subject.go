@@ -2,12 +2,9 @@ package model
 
 import (
 	"context"
-	"fmt"
-	"strings"
 
 	"github.com/open-trust/ot-ac/src/service/dgraph"
 	"github.com/open-trust/ot-ac/src/tpl"
-	"github.com/open-trust/ot-ac/src/util"
 )
 
 // Subject ...
@@ -17,22 +14,25 @@ type Subject struct {
 
 // List ...
 func (m *Subject) List(ctx context.Context, pageSize, skip int, uidToken string) ([]tpl.Subject, error) {
-	q := fmt.Sprintf(`query {
-		result(func: eq(dgraph.type, "OTACSubject"), first: %d, offset: %d, after: %s) {
+	ctx = dgraph.WithMethod(ctx, "Subject.List")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(dgraph.type, "OTACSubject"), first: %s, offset: %s, after: %s) {
 			uid
 			status: OTAC.status
 			subject: OTAC.Sub
-		}
-	}`, pageSize, skip, util.FormatUID(uidToken))
+		}`, q.Int(pageSize), q.Int(skip), q.UID(uidToken)))
 	res := make([]tpl.Subject, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
 // BatchAdd ...
-func (m *Subject) BatchAdd(ctx context.Context, input []string) error {
+func (m *Subject) BatchAdd(ctx context.Context, input []string) (err error) {
+	ctx = dgraph.WithMethod(ctx, "Subject.BatchAdd")
+	defer m.audit(ctx, &err, "", "Subject.BatchAdd", nameRefs("subject", input), nil)
 	nqs := make([]*dgraph.Nquads, 0, len(input))
 
 	for _, sub := range input {
@@ -46,21 +46,22 @@ func (m *Subject) BatchAdd(ctx context.Context, input []string) error {
 		})
 	}
 
-	_, err := m.Model.BatchAdd(ctx, nqs)
+	_, err = m.Model.BatchAdd(ctx, nqs)
 	return err
 }
 
 // AcquireUIDsOrAdd ...
 func (m *Subject) AcquireUIDsOrAdd(ctx context.Context, input []string) ([]tpl.Subject, error) {
+	ctx = dgraph.WithMethod(ctx, "Subject.AcquireUIDsOrAdd")
 	size := len(input)
-	q := fmt.Sprintf(`query {
-		result(func: eq(OTAC.Sub, [%s]), first: %d) {
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(OTAC.Sub, %s), first: %s) {
 			uid
 			subject: OTAC.Sub
-		}
-	}`, strings.Join(util.FormatStrs(input), ", "), size)
+		}`, q.Strs(input), q.Int(size)))
 	out := make([]tpl.Subject, 0, size)
-	if err := m.Model.List(ctx, q, nil, &out); err != nil {
+	if err := m.Model.List(ctx, query, vars, &out); err != nil {
 		return nil, err
 	}
 
@@ -86,14 +87,14 @@ func (m *Subject) AcquireUIDsOrAdd(ctx context.Context, input []string) ([]tpl.S
 			return nil, err
 		}
 
-		q = fmt.Sprintf(`query {
-			result(func: eq(OTAC.Sub, [%s]), first: %d) {
+		q = dgraph.NewQuery()
+		query, vars := q.Build(dgraph.Sprintf(`
+			result(func: eq(OTAC.Sub, %s), first: %s) {
 				uid
 				subject: OTAC.Sub
-			}
-		}`, strings.Join(util.FormatStrs(newSubjects), ", "), len(newSubjects))
+			}`, q.Strs(newSubjects), q.Int(len(newSubjects))))
 		newOut := make([]tpl.Subject, 0, len(newSubjects))
-		if err := m.Model.List(ctx, q, nil, &out); err != nil {
+		if err := m.Model.List(ctx, query, vars, &out); err != nil {
 			return nil, err
 		}
 		out = append(out, newOut...)
@@ -102,7 +103,8 @@ func (m *Subject) AcquireUIDsOrAdd(ctx context.Context, input []string) ([]tpl.S
 }
 
 // Update ...
-func (m *Subject) Update(ctx context.Context, input tpl.Subject) error {
+func (m *Subject) Update(ctx context.Context, input tpl.Subject) (err error) {
+	ctx = dgraph.WithMethod(ctx, "Subject.Update")
 	update := &dgraph.Nquads{
 		UKkey: "OTAC.Sub",
 		UKval: input.Sub,
@@ -111,8 +113,10 @@ func (m *Subject) Update(ctx context.Context, input tpl.Subject) error {
 			"OTAC.status": input.Status,
 		},
 	}
+	d := new(diff).change("status", m.attr(ctx, update.UKkey, update.UKval, "OTAC.status"), input.Status)
+	defer m.audit(ctx, &err, "", "Subject.Update", []string{tpl.AuditRef("subject", input.Sub)}, d)
 
-	return m.Model.Update(ctx, update, "")
+	return m.Model.Update(ctx, update, nil, dgraph.DQL{})
 }
 
 // // ListUnits ...
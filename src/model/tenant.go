@@ -2,13 +2,12 @@ package model
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/dgraph-io/dgo/v200/protos/api"
 	"github.com/open-trust/ot-ac/src/service/dgraph"
 	"github.com/open-trust/ot-ac/src/tpl"
-	"github.com/open-trust/ot-ac/src/util"
 	otgo "github.com/open-trust/ot-go-lib"
+	"github.com/teambition/gear"
 )
 
 // Tenant ...
@@ -18,15 +17,16 @@ type Tenant struct {
 
 // Get ...
 func (m *Tenant) Get(ctx context.Context, tenant otgo.OTID) (*tpl.Tenant, error) {
+	ctx = dgraph.WithMethod(ctx, "Tenant.Get")
 	res := tpl.Tenant{Tenant: tenant.String(), Status: -1}
-	q := fmt.Sprintf(`query {
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
 		result(func: eq(OTAC.T, %s), first: 1) {
 			uid
 			status: OTAC.status
-		}
-	}`, util.FormatStr(res.Tenant))
+		}`, q.Str(res.Tenant)))
 
-	if err := m.Model.Get(ctx, q, nil, &res); err != nil {
+	if err := m.Model.Get(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return &res, nil
@@ -34,22 +34,26 @@ func (m *Tenant) Get(ctx context.Context, tenant otgo.OTID) (*tpl.Tenant, error)
 
 // List ...
 func (m *Tenant) List(ctx context.Context, pageSize, skip int, uidToken string) ([]tpl.Tenant, error) {
-	q := fmt.Sprintf(`query {
-		result(func: eq(dgraph.type, "OTACTenant"), first: %d, offset: %d, after: %s) {
+	ctx = dgraph.WithMethod(ctx, "Tenant.List")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(dgraph.type, "OTACTenant"), first: %s, offset: %s, after: %s) {
 			uid
 			tenant: OTAC.T
 			status: OTAC.status
-		}
-	}`, pageSize, skip, util.FormatUID(uidToken))
+		}`, q.Int(pageSize), q.Int(skip), q.UID(uidToken)))
 	res := make([]tpl.Tenant, 0, pageSize)
-	if err := m.Model.List(ctx, q, nil, &res); err != nil {
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
 // Add ...
-func (m *Tenant) Add(ctx context.Context, input tpl.Tenant) (bool, error) {
+func (m *Tenant) Add(ctx context.Context, input tpl.Tenant) (ok bool, err error) {
+	ctx = dgraph.WithMethod(ctx, "Tenant.Add")
+	defer m.audit(ctx, &err, input.Tenant, "Tenant.Add", []string{tpl.AuditRef("tenant", input.Tenant)},
+		new(diff).change("status", nil, input.Status))
 	nq := &dgraph.Nquads{
 		UKkey: "OTAC.T",
 		UKval: input.Tenant,
@@ -63,7 +67,8 @@ func (m *Tenant) Add(ctx context.Context, input tpl.Tenant) (bool, error) {
 }
 
 // Update ...
-func (m *Tenant) Update(ctx context.Context, input tpl.Tenant) error {
+func (m *Tenant) Update(ctx context.Context, input tpl.Tenant) (err error) {
+	ctx = m.begin(ctx, "Tenant.Update")
 	update := &dgraph.Nquads{
 		UKkey: "OTAC.T",
 		UKval: input.Tenant,
@@ -72,84 +77,91 @@ func (m *Tenant) Update(ctx context.Context, input tpl.Tenant) error {
 			"OTAC.status": input.Status,
 		},
 	}
+	d := new(diff).change("status", m.attr(ctx, update.UKkey, update.UKval, "OTAC.status"), input.Status)
+	defer m.record(ctx, &err, input.Tenant, tpl.WatchEvent{Op: "Tenant.Update"}, d)
 
-	return m.Model.Update(ctx, update, "")
+	return m.Model.Update(ctx, update, nil, dgraph.DQL{})
 }
 
-// Delete ...
-func (m *Tenant) Delete(ctx context.Context, tenant otgo.OTID) error {
-	q := fmt.Sprintf(`query {
-		tenantUid as var(func: eq(OTAC.T, %s), first: 1) @filter(lt(OTAC.status, 0))
-		objectUids as var(func: has(OTAC.O-T)) @filter(uid_in(OTAC.O-T, uid(tenantUid)))
-		unitsUids as var(func: has(OTAC.U-T)) @filter(uid_in(OTAC.U-T, uid(tenantUid)))
-		permissionsUids as var(func: has(OTAC.P-T)) @filter(uid_in(OTAC.P-T, uid(tenantUid)))
-		scopesUids as var(func: has(OTAC.Sc-T)) @filter(uid_in(OTAC.Sc-T, uid(tenantUid)))
-	}`, util.FormatStr(tenant.String()))
-	delTenant := &dgraph.Nquads{
-		ID: "uid(tenantUid)",
-		KV: map[string]interface{}{
-			"*": "*",
-		},
-	}
-	delTenantData, err := delTenant.Bytes()
-	if err != nil {
-		return err
-	}
-	delObjects := &dgraph.Nquads{
-		ID: "uid(objectUids)",
-		KV: map[string]interface{}{
-			"*": "*",
-		},
-	}
-	ddelObjectsData, err := delObjects.Bytes()
-	if err != nil {
-		return err
+// TenantNodeKinds 租户名下的节点类型，按删除顺序排列
+var TenantNodeKinds = []string{"Object", "Unit", "Role", "Scope", "Permission", "WebhookDeadLetter", "WebhookDelivery", "Webhook", "Change"}
+
+// tenantPredicates 各类型节点关联到租户的谓词
+var tenantPredicates = map[string]string{
+	"Object":            "OTAC.O-T",
+	"Unit":              "OTAC.U-T",
+	"Role":              "OTAC.R-T",
+	"Scope":             "OTAC.Sc-T",
+	"Permission":        "OTAC.P-T",
+	"WebhookDeadLetter": "OTAC.DL-T",
+	"WebhookDelivery":   "OTAC.WD-T",
+	"Webhook":           "OTAC.WH-T",
+	"Change":            "OTAC.C-T",
+}
+
+type jsonDeleteNodes struct {
+	Result []jsonCount `json:"result"`
+}
+
+// DeleteNodes 删除租户名下至多 batchSize 个 kind 类型的节点及其所有谓词，返回删除的节点数，返回 0 表示该类型的节点已删除完。
+// 每次调用是一个独立的事务，事务大小受 batchSize 限制
+func (m *Tenant) DeleteNodes(ctx context.Context, tenantUID, kind string, batchSize int) (int, error) {
+	ctx = dgraph.WithMethod(ctx, "Tenant.DeleteNodes")
+	predicate, ok := tenantPredicates[kind]
+	if !ok {
+		return 0, gear.ErrInternalServerError.WithMsgf("unknown tenant node kind %s", kind)
 	}
-	delUnits := &dgraph.Nquads{
-		ID: "uid(unitsUids)",
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		var(func: uid(%s)) {
+			nodeUIDs as %s (first: %s)
+		}
+		result(func: uid(nodeUIDs)) {
+			count(uid)
+		}`, q.UID(tenantUID), dgraph.Predicate("~"+predicate), q.Int(batchSize)))
+	del := &dgraph.Nquads{
+		ID: "uid(nodeUIDs)",
 		KV: map[string]interface{}{
 			"*": "*",
 		},
 	}
-	delUnitsData, err := delUnits.Bytes()
+	delData, err := del.Bytes()
 	if err != nil {
-		return err
-	}
-	delPermissions := &dgraph.Nquads{
-		ID: "uid(permissionsUids)",
-		KV: map[string]interface{}{
-			"*": "*",
-		},
+		return 0, err
 	}
-	delPermissionsData, err := delPermissions.Bytes()
+
+	out := &jsonDeleteNodes{}
+	err = m.Do(ctx, query, vars, out, &api.Mutation{
+		Cond:      "@if(gt(len(nodeUIDs), 0))",
+		DelNquads: delData,
+	})
 	if err != nil {
-		return err
+		return 0, err
 	}
-	delScopes := &dgraph.Nquads{
-		ID: "uid(scopesUids)",
+	return sumCount(out.Result), nil
+}
+
+// Delete 删除租户节点，status 必须小于 0，租户名下的节点需要先通过 DeleteNodes 删除
+func (m *Tenant) Delete(ctx context.Context, tenant otgo.OTID) (err error) {
+	ctx = m.begin(ctx, "Tenant.Delete")
+	defer m.record(ctx, &err, tenant.String(), tpl.WatchEvent{Op: "Tenant.Delete"},
+		m.effective(ctx, tenant.String(), new(diff).remove(newNode("tenant", tenant.String()), "*", anyNode)))
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		tenantUid as var(func: eq(OTAC.T, %s), first: 1) @filter(lt(OTAC.status, 0))`, q.Str(tenant.String())))
+	delTenant := &dgraph.Nquads{
+		ID: "uid(tenantUid)",
 		KV: map[string]interface{}{
 			"*": "*",
 		},
 	}
-	delScopesData, err := delScopes.Bytes()
+	delTenantData, err := delTenant.Bytes()
 	if err != nil {
 		return err
 	}
 
-	return m.Do(ctx, q, nil, nil, &api.Mutation{
+	return m.Do(ctx, query, vars, nil, &api.Mutation{
 		Cond:      "@if(gt(len(tenantUid), 0))",
 		DelNquads: delTenantData,
-	}, &api.Mutation{
-		Cond:      "@if(gt(len(objectUids), 0))",
-		DelNquads: ddelObjectsData,
-	}, &api.Mutation{
-		Cond:      "@if(gt(len(unitsUids), 0))",
-		DelNquads: delUnitsData,
-	}, &api.Mutation{
-		Cond:      "@if(gt(len(permissionsUids), 0))",
-		DelNquads: delPermissionsData,
-	}, &api.Mutation{
-		Cond:      "@if(gt(len(scopesUids), 0))",
-		DelNquads: delScopesData,
 	})
 }
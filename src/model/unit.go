@@ -2,8 +2,7 @@ package model
 
 import (
 	"context"
-	"fmt"
-	"strings"
+	"time"
 
 	"github.com/dgraph-io/dgo/v200/protos/api"
 	"github.com/open-trust/ot-ac/src/service/dgraph"
@@ -18,7 +17,10 @@ type Unit struct {
 }
 
 // BatchAdd ...
-func (m *Unit) BatchAdd(ctx context.Context, tenant tpl.Tenant, units []tpl.Target, parent *tpl.Target, scope *tpl.Target) error {
+func (m *Unit) BatchAdd(ctx context.Context, tenant tpl.Tenant, units []tpl.Target, parent *tpl.Target, scope *tpl.Target) (err error) {
+	ctx = m.begin(ctx, "Unit.BatchAdd")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Unit.BatchAdd", Units: targets(units, parent), Scopes: targets(nil, scope)},
+		m.effective(ctx, tenant.Tenant, new(diff).attach("unit", units, parent, scope)))
 	nqs := make([]*dgraph.Nquads, 0, len(units)*2)
 	parentUID, _, scopeUID, err := m.acquireUnitObjectScope(ctx, tenant, parent, nil, scope, 0)
 	if err != nil {
@@ -52,28 +54,29 @@ func (m *Unit) BatchAdd(ctx context.Context, tenant tpl.Tenant, units []tpl.Targ
 		}
 
 		nqs = append(nqs, create)
-		uks = append(uks, util.FormatStr(create.UKval))
+		uks = append(uks, create.UKval)
 		if parentUID != "" || scopeUID != "" {
 			nqs = append(nqs, update)
 		}
 	}
 
 	if parentUID != "" {
-		checkCyclic := fmt.Sprintf(`
+		q := dgraph.NewQuery()
+		checkCyclic := dgraph.Sprintf(`
 			var(func: uid(%s), first: 1) @recurse(loop: false) {
 				uids as uid
 				OTAC.U-Us
 			}
-			CyclicData(func: uid(uids), first: 1) @filter(eq(OTAC.U.UK, [%s])) {
+			CyclicData(func: uid(uids), first: 1) @filter(eq(OTAC.U.UK, %s)) {
 				CyclicUID as uid
 				targetType: OTAC.UType
 				targetId: OTAC.UId
 			}
-		`, util.FormatUID(parentUID), strings.Join(uks, ", "))
-		return m.Model.BatchAddOrUpdate(ctx, nqs, checkCyclic)
+		`, q.UID(parentUID), q.Strs(uks))
+		return m.Model.BatchAddOrUpdate(ctx, nqs, q, checkCyclic)
 	}
 	if scopeUID != "" {
-		return m.Model.BatchAddOrUpdate(ctx, nqs, "")
+		return m.Model.BatchAddOrUpdate(ctx, nqs, nil, dgraph.DQL{})
 	}
 
 	_, err = m.Model.BatchAdd(ctx, nqs)
@@ -81,7 +84,10 @@ func (m *Unit) BatchAdd(ctx context.Context, tenant tpl.Tenant, units []tpl.Targ
 }
 
 // AddFromOrg 从组织服务的 Org 创建管理单元，当检测到将形成环时会返回 400 错误
-func (m *Unit) AddFromOrg(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, org string, parent *tpl.Target, scope *tpl.Target) error {
+func (m *Unit) AddFromOrg(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, org string, parent *tpl.Target, scope *tpl.Target) (err error) {
+	ctx = m.begin(ctx, "Unit.AddFromOrg")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Unit.AddFromOrg", Units: targets([]tpl.Target{unit}, parent), Scopes: targets(nil, scope)},
+		m.effective(ctx, tenant.Tenant, new(diff).attach("unit", []tpl.Target{unit}, parent, scope).add(targetNode("unit", unit), "org", newNode("org", org))))
 	orgUID, _, err := m.acquireOrgOU(ctx, org, "", 0)
 	if err != nil {
 		return err
@@ -121,7 +127,8 @@ func (m *Unit) AddFromOrg(ctx context.Context, tenant tpl.Tenant, unit tpl.Targe
 	}
 	nqs = append(nqs, create, update)
 	if parentUID != "" {
-		checkCyclic := fmt.Sprintf(`
+		q := dgraph.NewQuery()
+		checkCyclic := dgraph.Sprintf(`
 			var(func: uid(%s), first: 1) @recurse(loop: false) {
 				uids as uid
 				OTAC.U-Us
@@ -131,14 +138,17 @@ func (m *Unit) AddFromOrg(ctx context.Context, tenant tpl.Tenant, unit tpl.Targe
 				targetType: OTAC.UType
 				targetId: OTAC.UId
 			}
-		`, util.FormatUID(parentUID), util.FormatStr(create.UKval))
-		return m.Model.BatchAddOrUpdate(ctx, nqs, checkCyclic)
+		`, q.UID(parentUID), q.Str(create.UKval))
+		return m.Model.BatchAddOrUpdate(ctx, nqs, q, checkCyclic)
 	}
-	return m.Model.BatchAddOrUpdate(ctx, nqs, "")
+	return m.Model.BatchAddOrUpdate(ctx, nqs, nil, dgraph.DQL{})
 }
 
 // AddFromOU 从组织服务的 OU 创建管理单元，当检测到将形成环时会返回 400 错误
-func (m *Unit) AddFromOU(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, org, ou string, parent *tpl.Target, scope *tpl.Target) error {
+func (m *Unit) AddFromOU(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, org, ou string, parent *tpl.Target, scope *tpl.Target) (err error) {
+	ctx = m.begin(ctx, "Unit.AddFromOU")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Unit.AddFromOU", Units: targets([]tpl.Target{unit}, parent), Scopes: targets(nil, scope)},
+		m.effective(ctx, tenant.Tenant, new(diff).attach("unit", []tpl.Target{unit}, parent, scope).add(targetNode("unit", unit), "ou", newNode("ou", org, ou))))
 	_, ouUID, err := m.acquireOrgOU(ctx, org, ou, 0)
 	if err != nil {
 		return err
@@ -178,7 +188,8 @@ func (m *Unit) AddFromOU(ctx context.Context, tenant tpl.Tenant, unit tpl.Target
 	}
 	nqs = append(nqs, create, update)
 	if parentUID != "" {
-		checkCyclic := fmt.Sprintf(`
+		q := dgraph.NewQuery()
+		checkCyclic := dgraph.Sprintf(`
 			var(func: uid(%s), first: 1) @recurse(loop: false) {
 				uids as uid
 				OTAC.U-Us
@@ -188,14 +199,17 @@ func (m *Unit) AddFromOU(ctx context.Context, tenant tpl.Tenant, unit tpl.Target
 				targetType: OTAC.UType
 				targetId: OTAC.UId
 			}
-		`, util.FormatUID(parentUID), util.FormatStr(create.UKval))
-		return m.Model.BatchAddOrUpdate(ctx, nqs, checkCyclic)
+		`, q.UID(parentUID), q.Str(create.UKval))
+		return m.Model.BatchAddOrUpdate(ctx, nqs, q, checkCyclic)
 	}
-	return m.Model.BatchAddOrUpdate(ctx, nqs, "")
+	return m.Model.BatchAddOrUpdate(ctx, nqs, nil, dgraph.DQL{})
 }
 
 // AddFromMembers 从组织服务的 Members 创建管理单元，当检测到将形成环时会返回 400 错误
-func (m *Unit) AddFromMembers(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, org string, subjects []string, parent *tpl.Target, scope *tpl.Target) error {
+func (m *Unit) AddFromMembers(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, org string, subjects []string, parent *tpl.Target, scope *tpl.Target) (err error) {
+	ctx = m.begin(ctx, "Unit.AddFromMembers")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Unit.AddFromMembers", Units: targets([]tpl.Target{unit}, parent), Scopes: targets(nil, scope), Subjects: subjects},
+		m.effective(ctx, tenant.Tenant, new(diff).attach("unit", []tpl.Target{unit}, parent, scope).add(targetNode("unit", unit), "members", memberNodes(org, subjects)...)))
 	memberUIDs, err := m.acquireOrgMembers(ctx, org, subjects, 0)
 	if err != nil {
 		return err
@@ -235,7 +249,8 @@ func (m *Unit) AddFromMembers(ctx context.Context, tenant tpl.Tenant, unit tpl.T
 	}
 	nqs = append(nqs, create, update)
 	if parentUID != "" {
-		checkCyclic := fmt.Sprintf(`
+		q := dgraph.NewQuery()
+		checkCyclic := dgraph.Sprintf(`
 			var(func: uid(%s), first: 1) @recurse(loop: false) {
 				uids as uid
 				OTAC.U-Us
@@ -245,26 +260,34 @@ func (m *Unit) AddFromMembers(ctx context.Context, tenant tpl.Tenant, unit tpl.T
 				targetType: OTAC.UType
 				targetId: OTAC.UId
 			}
-		`, util.FormatUID(parentUID), util.FormatStr(create.UKval))
-		return m.Model.BatchAddOrUpdate(ctx, nqs, checkCyclic)
+		`, q.UID(parentUID), q.Str(create.UKval))
+		return m.Model.BatchAddOrUpdate(ctx, nqs, q, checkCyclic)
 	}
-	return m.Model.BatchAddOrUpdate(ctx, nqs, "")
+	return m.Model.BatchAddOrUpdate(ctx, nqs, nil, dgraph.DQL{})
 }
 
-// AddSubjects ...
-func (m *Unit) AddSubjects(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, subjectUIDs []string) error {
+// AddSubjects 给管理单元添加请求主体，subjects 需要包含 uid
+func (m *Unit) AddSubjects(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, subjects []tpl.Subject, validity tpl.Validity) (err error) {
+	ctx = m.begin(ctx, "Unit.AddSubjects")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Unit.AddSubjects", Units: []tpl.Target{unit}, Subjects: subjectNames(subjects)},
+		m.effective(ctx, tenant.Tenant, new(diff).add(targetNode("unit", unit), "subjects", nameNodes("subject", subjectNames(subjects))...)))
 	unitUID, _, _, err := m.acquireUnitObjectScope(ctx, tenant, &unit, nil, nil, 0)
 	if err != nil {
 		return err
 	}
-	if len(subjectUIDs) == 0 {
+	if len(subjects) == 0 {
 		return nil
 	}
 
+	facets := validity.Facets()
+	fs := make([]dgraph.WithFacets, len(subjects))
+	for i, sub := range subjects {
+		fs[i] = dgraph.WithFacets{V: util.FormatUID(sub.UID), KV: facets}
+	}
 	nq := &dgraph.Nquads{
 		ID: util.FormatUID(unitUID),
 		KV: map[string]interface{}{
-			"OTAC.U-Ss": util.FormatUIDs(subjectUIDs),
+			"OTAC.U-Ss": fs,
 		},
 	}
 	data, err := nq.Bytes()
@@ -278,7 +301,10 @@ func (m *Unit) AddSubjects(ctx context.Context, tenant tpl.Tenant, unit tpl.Targ
 }
 
 // AddPermissions ...
-func (m *Unit) AddPermissions(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, permissions []tpl.PermissionEx) error {
+func (m *Unit) AddPermissions(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, permissions []tpl.PermissionEx) (err error) {
+	ctx = m.begin(ctx, "Unit.AddPermissions")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Unit.AddPermissions", Units: []tpl.Target{unit}, Permissions: permissionExNames(permissions)},
+		m.effective(ctx, tenant.Tenant, new(diff).add(targetNode("unit", unit), "permissions", nameNodes("permission", permissionExNames(permissions))...)))
 	unitUID, _, _, err := m.acquireUnitObjectScope(ctx, tenant, &unit, nil, nil, 0)
 	if err != nil {
 		return err
@@ -287,7 +313,7 @@ func (m *Unit) AddPermissions(ctx context.Context, tenant tpl.Tenant, unit tpl.T
 		return nil
 	}
 
-	ss := make([]string, 0, len(permissions))
+	ss := make([]string, len(permissions))
 	for i, p := range permissions {
 		ss[i] = p.Permission
 	}
@@ -302,7 +328,11 @@ func (m *Unit) AddPermissions(ctx context.Context, tenant tpl.Tenant, unit tpl.T
 		if uid == "" {
 			return gear.ErrBadRequest.WithMsgf("permission %s not found", util.FormatStr(p.Permission))
 		}
-		fs[i] = dgraph.WithFacets{V: util.FormatUID(uid), KV: p.Extensions}
+		kv := p.Validity.Facets()
+		for k, v := range p.Extensions {
+			kv[k] = v
+		}
+		fs[i] = dgraph.WithFacets{V: util.FormatUID(uid), KV: kv}
 	}
 
 	nq := &dgraph.Nquads{
@@ -321,8 +351,96 @@ func (m *Unit) AddPermissions(ctx context.Context, tenant tpl.Tenant, unit tpl.T
 	})
 }
 
+// AddRoles 给管理单元添加角色，角色必须预先存在
+func (m *Unit) AddRoles(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, roles []string) (err error) {
+	ctx = m.begin(ctx, "Unit.AddRoles")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Unit.AddRoles", Units: []tpl.Target{unit}, Roles: roles},
+		m.effective(ctx, tenant.Tenant, new(diff).add(targetNode("unit", unit), "roles", nameNodes("role", roles)...)))
+	unitUID, _, _, err := m.acquireUnitObjectScope(ctx, tenant, &unit, nil, nil, 0)
+	if err != nil {
+		return err
+	}
+	uids, err := m.acquireRoleUIDs(ctx, tenant, roles)
+	if err != nil {
+		return err
+	}
+	if len(uids) == 0 {
+		return nil
+	}
+
+	nq := &dgraph.Nquads{
+		ID: util.FormatUID(unitUID),
+		KV: map[string]interface{}{
+			"OTAC.U-Rs": util.FormatUIDs(uids),
+		},
+	}
+	data, err := nq.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return m.Do(ctx, "", nil, nil, &api.Mutation{
+		SetNquads: data,
+	})
+}
+
+// RemoveRoles 移除管理单元的角色
+func (m *Unit) RemoveRoles(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, roles []string) (err error) {
+	ctx = m.begin(ctx, "Unit.RemoveRoles")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Unit.RemoveRoles", Units: []tpl.Target{unit}, Roles: roles},
+		m.effective(ctx, tenant.Tenant, new(diff).remove(targetNode("unit", unit), "roles", nameNodes("role", roles)...)))
+	unitUID, _, _, err := m.acquireUnitObjectScope(ctx, tenant, &unit, nil, nil, 0)
+	if err != nil {
+		return err
+	}
+	uids, err := m.acquireRoleUIDs(ctx, tenant, roles)
+	if err != nil {
+		return err
+	}
+	if len(uids) == 0 {
+		return nil
+	}
+
+	nq := &dgraph.Nquads{
+		ID: util.FormatUID(unitUID),
+		KV: map[string]interface{}{
+			"OTAC.U-Rs": util.FormatUIDs(uids),
+		},
+	}
+	data, err := nq.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return m.Do(ctx, "", nil, nil, &api.Mutation{
+		DelNquads: data,
+	})
+}
+
+func (m *Unit) acquireRoleUIDs(ctx context.Context, tenant tpl.Tenant, roles []string) ([]string, error) {
+	uids := make([]string, 0, len(roles))
+	if len(roles) == 0 {
+		return uids, nil
+	}
+	rs, err := m.acquireRoles(ctx, tenant, roles)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range roles {
+		uid := tpl.GetRoleUID(rs, r)
+		if uid == "" {
+			return nil, gear.ErrBadRequest.WithMsgf("role %s not found", util.FormatStr(r))
+		}
+		uids = append(uids, uid)
+	}
+	return uids, nil
+}
+
 // AssignParent ...
-func (m *Unit) AssignParent(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, parent tpl.Target) error {
+func (m *Unit) AssignParent(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, parent tpl.Target) (err error) {
+	ctx = m.begin(ctx, "Unit.AssignParent")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Unit.AssignParent", Units: []tpl.Target{unit, parent}},
+		m.effective(ctx, tenant.Tenant, new(diff).add(targetNode("unit", unit), "parent", targetNode("unit", parent))))
 	unitUID, _, _, err := m.acquireUnitObjectScope(ctx, tenant, &unit, nil, nil, 0)
 	if err != nil {
 		return err
@@ -331,7 +449,8 @@ func (m *Unit) AssignParent(ctx context.Context, tenant tpl.Tenant, unit tpl.Tar
 	if err != nil {
 		return err
 	}
-	checkCyclic := fmt.Sprintf(`
+	q := dgraph.NewQuery()
+	checkCyclic := dgraph.Sprintf(`
 		var(func: uid(%s), first: 1) @recurse(loop: false) {
 			uids as uid
 			OTAC.U-Us
@@ -341,7 +460,7 @@ func (m *Unit) AssignParent(ctx context.Context, tenant tpl.Tenant, unit tpl.Tar
 			targetType: OTAC.UType
 			targetId: OTAC.UId
 		}
-	`, util.FormatUID(parentUID), util.FormatUID(unitUID))
+	`, q.UID(parentUID), q.UID(unitUID))
 
 	nq := &dgraph.Nquads{
 		ID: util.FormatUID(unitUID),
@@ -349,11 +468,14 @@ func (m *Unit) AssignParent(ctx context.Context, tenant tpl.Tenant, unit tpl.Tar
 			"OTAC.U-Us": util.FormatUID(parentUID),
 		},
 	}
-	return m.Model.Update(ctx, nq, checkCyclic)
+	return m.Model.Update(ctx, nq, q, checkCyclic)
 }
 
 // AssignScope ...
-func (m *Unit) AssignScope(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, scope tpl.Target) error {
+func (m *Unit) AssignScope(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, scope tpl.Target) (err error) {
+	ctx = m.begin(ctx, "Unit.AssignScope")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Unit.AssignScope", Units: []tpl.Target{unit}, Scopes: []tpl.Target{scope}},
+		m.effective(ctx, tenant.Tenant, new(diff).add(targetNode("unit", unit), "scope", targetNode("scope", scope))))
 	unitUID, _, scopeUID, err := m.acquireUnitObjectScope(ctx, tenant, &unit, nil, &scope, 0)
 	if err != nil {
 		return err
@@ -364,11 +486,14 @@ func (m *Unit) AssignScope(ctx context.Context, tenant tpl.Tenant, unit tpl.Targ
 			"OTAC.U-Scs": util.FormatUID(scopeUID),
 		},
 	}
-	return m.Model.Update(ctx, nq, "")
+	return m.Model.Update(ctx, nq, nil, dgraph.DQL{})
 }
 
 // AssignObject 建立管理单元与资源对象的关系
-func (m *Unit) AssignObject(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, object tpl.Target) error {
+func (m *Unit) AssignObject(ctx context.Context, tenant tpl.Tenant, unit tpl.Target, object tpl.Target) (err error) {
+	ctx = m.begin(ctx, "Unit.AssignObject")
+	defer m.record(ctx, &err, tenant.Tenant, tpl.WatchEvent{Op: "Unit.AssignObject", Units: []tpl.Target{unit}, Objects: []tpl.Target{object}},
+		m.effective(ctx, tenant.Tenant, new(diff).add(targetNode("object", object), "units", targetNode("unit", unit))))
 	unitUID, objectUID, _, err := m.acquireUnitObjectScope(ctx, tenant, &unit, &object, nil, 0)
 	if err != nil {
 		return err
@@ -379,5 +504,113 @@ func (m *Unit) AssignObject(ctx context.Context, tenant tpl.Tenant, unit tpl.Tar
 			"OTAC.O-Us": util.FormatUID(unitUID),
 		},
 	}
-	return m.Model.Update(ctx, nq, "")
+	return m.Model.Update(ctx, nq, nil, dgraph.DQL{})
+}
+
+type jsonExpiredGrant struct {
+	UID        string `json:"uid"`
+	Permission string `json:"permission"`
+	Subject    string `json:"subject"`
+	PNotAfter  string `json:"permissions|notAfter"`
+	SNotAfter  string `json:"subjects|notAfter"`
+}
+
+type jsonExpiredGrants struct {
+	UID    string `json:"uid"`
+	ID     string `json:"targetId"`
+	Type   string `json:"targetType"`
+	Tenant struct {
+		Tenant string `json:"tenant"`
+	} `json:"tenant"`
+	Permissions []jsonExpiredGrant `json:"permissions"`
+	Subjects    []jsonExpiredGrant `json:"subjects"`
+}
+
+// DeleteExpiredGrants 删除 notAfter 早于 now 的 OTAC.U-Ps 和 OTAC.U-Ss 边，每次处理 pageSize 个管理单元，
+// 返回被删除的授权关系和下一页的 uidToken，uidToken 为空表示已处理完
+func (m *Unit) DeleteExpiredGrants(ctx context.Context, now time.Time, pageSize int, uidToken string) ([]tpl.ExpiredGrant, string, error) {
+	ctx = dgraph.WithMethod(ctx, "Unit.DeleteExpiredGrants")
+	q := dgraph.NewQuery()
+	t := q.Time(now)
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(dgraph.type, "OTACUnit"), first: %s, after: %s) {
+			uid
+			targetType: OTAC.UType
+			targetId: OTAC.UId
+			tenant: OTAC.U-T {
+				tenant: OTAC.T
+			}
+			permissions: OTAC.U-Ps @facets(lt(notAfter, %s)) @facets(notAfter) {
+				uid
+				permission: OTAC.P
+			}
+			subjects: OTAC.U-Ss @facets(lt(notAfter, %s)) @facets(notAfter) {
+				uid
+				subject: OTAC.Sub
+			}
+		}`, q.Int(pageSize), q.UID(uidToken), t, t))
+	data := make([]jsonExpiredGrants, 0, pageSize)
+	if err := m.Model.List(ctx, query, vars, &data); err != nil {
+		return nil, "", err
+	}
+
+	nextToken := ""
+	if len(data) >= pageSize {
+		nextToken = data[len(data)-1].UID
+	}
+	res := make([]tpl.ExpiredGrant, 0)
+	buf := make([]byte, 0)
+	units := make([]jsonExpiredGrants, 0)
+	for _, unit := range data {
+		if len(unit.Permissions) == 0 && len(unit.Subjects) == 0 {
+			continue
+		}
+		units = append(units, unit)
+		target := tpl.Target{Type: unit.Type, ID: unit.ID}
+		puids := make([]string, 0, len(unit.Permissions))
+		for _, p := range unit.Permissions {
+			puids = append(puids, p.UID)
+			res = append(res, tpl.ExpiredGrant{Unit: target, Permission: p.Permission, NotAfter: parseFacetTime(p.PNotAfter)})
+		}
+		suids := make([]string, 0, len(unit.Subjects))
+		for _, s := range unit.Subjects {
+			suids = append(suids, s.UID)
+			res = append(res, tpl.ExpiredGrant{Unit: target, Subject: s.Subject, NotAfter: parseFacetTime(s.SNotAfter)})
+		}
+
+		nq := &dgraph.Nquads{
+			ID: util.FormatUID(unit.UID),
+			KV: map[string]interface{}{},
+		}
+		if len(puids) > 0 {
+			nq.KV["OTAC.U-Ps"] = util.FormatUIDs(puids)
+		}
+		if len(suids) > 0 {
+			nq.KV["OTAC.U-Ss"] = util.FormatUIDs(suids)
+		}
+		b, err := nq.Bytes()
+		if err != nil {
+			return nil, "", err
+		}
+		buf = append(buf, b...)
+	}
+
+	if len(buf) > 0 {
+		if err := m.Do(ctx, "", nil, nil, &api.Mutation{DelNquads: buf}); err != nil {
+			return nil, "", err
+		}
+	}
+	for _, unit := range units {
+		target := targetNode("unit", tpl.Target{Type: unit.Type, ID: unit.ID})
+		d := new(diff)
+		for _, p := range unit.Permissions {
+			d.remove(target, "permissions", newNode("permission", p.Permission))
+		}
+		for _, s := range unit.Subjects {
+			d.remove(target, "subjects", newNode("subject", s.Subject))
+		}
+		var err error
+		m.audit(ctx, &err, unit.Tenant.Tenant, "Unit.DeleteExpiredGrants", []string{target.ref}, d)
+	}
+	return res, nextToken, nil
 }
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/teambition/gear"
+)
+
+func TestUnitAssignParent(t *testing.T) {
+	f := newDgraphFixture(t)
+	f.addUnits("", "", "org")
+	f.addUnits("org", "", "dept")
+	f.addUnits("dept", "", "team")
+
+	target := func(id string) tpl.Target { return tpl.Target{Type: "team", ID: id} }
+	status := func(err error) int {
+		if err == nil {
+			return 0
+		}
+		return gear.ParseError(err).Status()
+	}
+	cases := []struct {
+		unit, parent string
+		status       int
+	}{
+		{"org", "team", 409},
+		{"org", "org", 409},
+		{"dept", "team", 409},
+		{"team", "org", 0},
+		{"team", "missing", 404},
+	}
+	for _, c := range cases {
+		if err := f.ms.Unit.AssignParent(f.ctx, f.tenant, target(c.unit), target(c.parent)); status(err) != c.status {
+			t.Fatalf("AssignParent(%s, %s) got %v, want %d", c.unit, c.parent, err, c.status)
+		}
+	}
+}
@@ -0,0 +1,392 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200"
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/open-trust/ot-ac/src/util"
+	"github.com/teambition/gear"
+)
+
+// changeLog 进程内的变更通知：本进程的写操作通过它唤醒等待中的 Watch 请求并推送给订阅方。
+// 变更事件持久化为 OTACChange 节点，由 Dgraph 分配 revision，这里不保存事件
+type changeLog struct {
+	mu sync.Mutex
+	// history 每个租户保留的变更事件数
+	history int
+	tenants map[string]*tenantLog
+	subs    []chan<- Change
+	dropped []Change
+}
+
+// Change 租户的一个变更事件
+type Change struct {
+	Tenant string
+	Event  tpl.WatchEvent
+}
+
+type tenantLog struct {
+	// changed 有新事件时关闭
+	changed chan struct{}
+}
+
+func newChangeLog(history int) *changeLog {
+	return &changeLog{
+		history: history,
+		tenants: make(map[string]*tenantLog),
+	}
+}
+
+func (c *changeLog) tenant(tenant string) *tenantLog {
+	l, ok := c.tenants[tenant]
+	if !ok {
+		l = &tenantLog{changed: make(chan struct{})}
+		c.tenants[tenant] = l
+	}
+	return l
+}
+
+// publish 通知已持久化的事件，ev.Revision 由 Dgraph 分配
+func (c *changeLog) publish(tenant string, ev tpl.WatchEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.notify(tenant, ev)
+}
+
+// notify 唤醒等待租户新事件的请求并推送给订阅方，调用方需持有锁
+func (c *changeLog) notify(tenant string, ev tpl.WatchEvent) {
+	l := c.tenant(tenant)
+	close(l.changed)
+	l.changed = make(chan struct{})
+	// 订阅方处理不过来时不阻塞写操作，丢弃的事件由订阅方通过 takeDropped 取出另行处理
+	for _, ch := range c.subs {
+		select {
+		case ch <- Change{Tenant: tenant, Event: ev}:
+		default:
+			c.dropped = append(c.dropped, Change{Tenant: tenant, Event: ev})
+		}
+	}
+}
+
+func (c *changeLog) subscribe(size int) <-chan Change {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan Change, size)
+	c.subs = append(c.subs, ch)
+	return ch
+}
+
+// takeDropped 返回并清空因订阅方处理不过来而没有推送的事件
+func (c *changeLog) takeDropped() []Change {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dropped := c.dropped
+	c.dropped = nil
+	return dropped
+}
+
+// changed 返回租户有新事件时关闭的 channel
+func (c *changeLog) changed(tenant string) <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tenant(tenant).changed
+}
+
+// changeRetries 写入变更事件的事务与其它写入冲突时的重试次数
+const changeRetries = 10
+
+type jsonTenantChanges struct {
+	Result []struct {
+		UID       string `json:"uid"`
+		Rev       int64  `json:"rev"`
+		Compacted int64  `json:"compacted"`
+		Changes   []struct {
+			UID   string `json:"uid"`
+			Event string `json:"event"`
+		} `json:"changes"`
+	} `json:"result"`
+}
+
+// changeTxn 执行读写租户 revision 计数器的事务。写操作的事务和并发的 changeTxn 都会写租户节点的 OTAC.T.rev，
+// Dgraph 只会提交其中一个，因此 revision 按提交顺序连续分配，这里以 ErrAborted 失败后重试
+func (m *Model) changeTxn(ctx context.Context, fn func(txn *dgraph.Txn) error) error {
+	for i := 0; ; i++ {
+		err := m.Txn(ctx, fn)
+		if err != dgo.ErrAborted || i >= changeRetries {
+			return err
+		}
+	}
+}
+
+// tenantChanges 在事务中查询租户的 revision 计数器，revision 不为 0 时同时返回 revision 不大于它的变更事件节点。
+// 租户不存在时返回 nil
+func tenantChanges(ctx context.Context, txn *dgraph.Txn, tenant string, revision int64) (*jsonTenantChanges, error) {
+	q := dgraph.NewQuery()
+	changes := dgraph.DQL{}
+	if revision > 0 {
+		changes = dgraph.Sprintf(`changes: ~OTAC.C-T @filter(le(OTAC.C.rev, %s)) {
+				uid
+			}`, q.Int64(revision))
+	}
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(OTAC.T, %s), first: 1) {
+			uid
+			rev: OTAC.T.rev
+			compacted: OTAC.T.revCompacted
+			%s
+		}`, q.Str(tenant), changes))
+	resp, err := txn.QueryWithVars(ctx, query, vars)
+	if err != nil {
+		return nil, err
+	}
+	out := &jsonTenantChanges{}
+	if err := json.Unmarshal(resp.Json, out); err != nil {
+		return nil, err
+	}
+	if len(out.Result) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+// initRevision 租户还没有 revision 计数器时以当前时间（微秒）初始化，并作为已删除的最大 revision，
+// 因此以相同 OTID 重新创建的租户的 revision 总是大于此前租户的，旧的 revision 会被判定为过期
+func initRevision(kv map[string]interface{}, rev, compacted *int64) {
+	if *rev == 0 {
+		*rev = time.Now().UnixNano() / int64(time.Microsecond)
+		*compacted = *rev
+		kv["OTAC.T.rev"] = *rev
+		kv["OTAC.T.revCompacted"] = *compacted
+	}
+}
+
+// persistChange 在写操作的事务中将变更事件保存为租户的 OTACChange 节点并分配 revision，租户已删除时不保存。
+// 事件数超过 2 倍 watch.history 时在同一事务中删除旧的事件，只保留最近的 history 个
+func (m *Model) persistChange(ctx context.Context, txn *dgraph.Txn, tenant string, ev *tpl.WatchEvent) error {
+	out, err := tenantChanges(ctx, txn, tenant, 0)
+	if err != nil || out == nil {
+		return err
+	}
+	t := out.Result[0]
+	kv := make(map[string]interface{})
+	initRevision(kv, &t.Rev, &t.Compacted)
+	ev.Revision = t.Rev + 1
+	kv["OTAC.T.rev"] = ev.Revision
+
+	del := new(bytes.Buffer)
+	if ev.Revision-t.Compacted >= int64(2*m.changes.history) {
+		compacted := ev.Revision - int64(m.changes.history)
+		old, err := tenantChanges(ctx, txn, tenant, compacted)
+		if err != nil || old == nil {
+			return err
+		}
+		for _, c := range old.Result[0].Changes {
+			data, err := (&dgraph.Nquads{ID: util.FormatUID(c.UID), KV: map[string]interface{}{"*": "*"}}).Bytes()
+			if err != nil {
+				return err
+			}
+			del.Write(data)
+		}
+		kv["OTAC.T.revCompacted"] = compacted
+	}
+
+	event, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	set := new(bytes.Buffer)
+	for _, nq := range []*dgraph.Nquads{
+		{ID: util.FormatUID(t.UID), KV: kv},
+		{ID: "_:change", Type: "OTACChange", KV: map[string]interface{}{
+			"OTAC.C-T":     util.FormatUID(t.UID),
+			"OTAC.C.rev":   ev.Revision,
+			"OTAC.C.event": string(event),
+		}},
+	} {
+		data, err := nq.Bytes()
+		if err != nil {
+			return err
+		}
+		set.Write(data)
+	}
+	mu := &api.Mutation{SetNquads: set.Bytes()}
+	if del.Len() > 0 {
+		mu.DelNquads = del.Bytes()
+	}
+	_, err = txn.Mutate(ctx, mu)
+	return err
+}
+
+// begin 标记写操作的 model 方法并开始它的读写事务，写操作经由 context 发起的 Dgraph 请求都在这个事务中执行，
+// 调用方需要紧接着通过 defer 调用 record 提交或丢弃事务
+func (m *Model) begin(ctx context.Context, method string) context.Context {
+	ctx, _ = m.WithTxn(dgraph.WithMethod(ctx, method))
+	return ctx
+}
+
+// record 结束 begin 开始的事务：*err 为 nil 时在同一事务中记录租户的变更事件并提交，之后以 d 写入审计记录；
+// 否则丢弃事务。事件保存或提交失败时写操作失败，错误写入 *err，与其它写操作冲突时为 409 错误，调用方可以重试
+func (m *Model) record(ctx context.Context, err *error, tenant string, ev tpl.WatchEvent, d *diff) {
+	txn := dgraph.TxnFromCtx(ctx)
+	if *err != nil {
+		txn.Discard(ctx)
+		return
+	}
+	ev.CreatedAt = time.Now().UTC()
+	if e := m.persistChange(ctx, txn, tenant, &ev); e != nil {
+		txn.Discard(ctx)
+		*err = e
+		return
+	}
+	if e := txn.Commit(ctx); e != nil {
+		if e == dgo.ErrAborted {
+			e = gear.ErrConflict.WithMsgf("%s conflicts with concurrent writes of tenant %s, please retry", ev.Op, tenant)
+		}
+		*err = e
+		return
+	}
+	if ev.Revision > 0 {
+		m.changes.publish(tenant, ev)
+	}
+	targets := eventTargets(ev)
+	if len(targets) == 0 {
+		// 租户自身的写操作
+		targets = append(targets, tpl.AuditRef("tenant", tenant))
+	}
+	m.audit(ctx, err, tenant, ev.Op, targets, d)
+}
+
+// Watch 租户的变更日志，由 Unit、Object、Scope、Permission、Role、Snapshot 和 Tenant 的写操作记录
+type Watch struct {
+	*Model
+}
+
+// Revision 返回租户当前的 revision，租户还没有 revision 计数器时初始化它
+func (m *Watch) Revision(ctx context.Context, tenant tpl.Tenant) (int64, error) {
+	ctx = dgraph.WithMethod(ctx, "Watch.Revision")
+	var rev int64
+	err := m.changeTxn(ctx, func(txn *dgraph.Txn) error {
+		out, err := tenantChanges(ctx, txn, tenant.Tenant, 0)
+		if err != nil {
+			return err
+		}
+		if out == nil {
+			return gear.ErrNotFound.WithMsgf("tenant %s not found", tenant.Tenant)
+		}
+		t := out.Result[0]
+		rev = t.Rev
+		if rev != 0 {
+			return nil
+		}
+		kv := make(map[string]interface{})
+		initRevision(kv, &rev, &t.Compacted)
+		data, err := (&dgraph.Nquads{ID: util.FormatUID(t.UID), KV: kv}).Bytes()
+		if err != nil {
+			return err
+		}
+		_, err = txn.Mutate(ctx, &api.Mutation{SetNquads: data})
+		return err
+	})
+	return rev, err
+}
+
+type jsonChanges struct {
+	Result []struct {
+		Rev       int64 `json:"rev"`
+		Compacted int64 `json:"compacted"`
+		Changes   []struct {
+			Event string `json:"event"`
+		} `json:"changes"`
+	} `json:"result"`
+}
+
+// Since 返回租户在 revision 之后至多 limit 个事件和下次读取使用的 revision，返回的 channel 会在本进程有新事件时关闭。
+// 事件由所有实例共享，其它实例的写操作不会关闭 channel，调用方需要定期重新读取。
+// revision 早于保留的事件或大于当前的 revision（如来自以相同 OTID 重新创建前的租户）时返回 410 错误，消费方需要重新全量同步
+func (m *Watch) Since(ctx context.Context, tenant tpl.Tenant, revision int64, limit int) (
+	*tpl.WatchOutput, <-chan struct{}, error) {
+	ctx = dgraph.WithMethod(ctx, "Watch.Since")
+	changed := m.changes.changed(tenant.Tenant)
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: uid(%s)) {
+			rev: OTAC.T.rev
+			compacted: OTAC.T.revCompacted
+			changes: ~OTAC.C-T (orderasc: OTAC.C.rev, first: %s) @filter(gt(OTAC.C.rev, %s)) {
+				event: OTAC.C.event
+			}
+		}`, q.UID(tenant.UID), q.Int(limit), q.Int64(revision)))
+	out := &jsonChanges{}
+	if err := m.Query(ctx, query, vars, out); err != nil {
+		return nil, nil, err
+	}
+	if len(out.Result) == 0 {
+		return nil, nil, gear.ErrNotFound.WithMsgf("tenant %s not found", tenant.Tenant)
+	}
+	t := out.Result[0]
+	if revision < t.Compacted || revision > t.Rev {
+		return nil, nil, gear.ErrGone.WithMsgf("revision %d is too old or unknown, the oldest available revision is %d", revision, t.Compacted)
+	}
+	res := &tpl.WatchOutput{Revision: t.Rev, Events: make([]tpl.WatchEvent, len(t.Changes))}
+	for i, c := range t.Changes {
+		if err := json.Unmarshal([]byte(c.Event), &res.Events[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(res.Events) == limit {
+		res.Revision = res.Events[limit-1].Revision
+	}
+	return res, changed, nil
+}
+
+// targets 返回 ts 和 extra 中不为 nil 的目标
+func targets(ts []tpl.Target, extra ...*tpl.Target) []tpl.Target {
+	res := append(make([]tpl.Target, 0, len(ts)+len(extra)), ts...)
+	for _, t := range extra {
+		if t != nil {
+			res = append(res, *t)
+		}
+	}
+	return res
+}
+
+func permissionNames(ps []tpl.Permission) []string {
+	res := make([]string, len(ps))
+	for i, p := range ps {
+		res[i] = p.Permission
+	}
+	return res
+}
+
+func permissionExNames(ps []tpl.PermissionEx) []string {
+	res := make([]string, len(ps))
+	for i, p := range ps {
+		res[i] = p.Permission
+	}
+	return res
+}
+
+func subjectNames(subs []tpl.Subject) []string {
+	res := make([]string, len(subs))
+	for i, s := range subs {
+		res[i] = s.Sub
+	}
+	return res
+}
+
+// Subscribe 订阅之后所有租户的变更事件，channel 缓冲满时新事件不会推送，需通过 Dropped 取出
+func (m *Watch) Subscribe(size int) <-chan Change {
+	return m.changes.subscribe(size)
+}
+
+// Dropped 返回上次调用之后因订阅方处理不过来而没有推送的事件
+func (m *Watch) Dropped() []Change {
+	return m.changes.takeDropped()
+}
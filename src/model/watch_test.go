@@ -0,0 +1,44 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+func TestWatchSince(t *testing.T) {
+	f := newDgraphFixture(t)
+	rev, err := f.ms.Watch.Revision(f.ctx, f.tenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ops := func(revision int64) ([]string, int64) {
+		out, _, err := f.ms.Watch.Since(f.ctx, f.tenant, revision, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := make([]string, len(out.Events))
+		for i, ev := range out.Events {
+			res[i] = ev.Op
+			if ev.Revision != revision+int64(i)+1 {
+				t.Fatalf("event %s revision got %d, want %d", ev.Op, ev.Revision, revision+int64(i)+1)
+			}
+		}
+		return res, out.Revision
+	}
+
+	f.addUnits("", "", "org")
+	f.addUnits("org", "", "team")
+	got, next := ops(rev)
+	if len(got) != 2 || got[0] != "Unit.BatchAdd" || next != rev+2 {
+		t.Fatalf("events got %v, %d", got, next)
+	}
+
+	// 失败的写操作不会提交，也不会记录事件
+	if err := f.ms.Unit.AssignParent(f.ctx, f.tenant, tpl.Target{Type: "team", ID: "org"}, tpl.Target{Type: "team", ID: "team"}); err == nil {
+		t.Fatal("cyclic AssignParent should fail")
+	}
+	if got, next = ops(next); len(got) != 0 || next != rev+2 {
+		t.Fatalf("events after failed write got %v, %d", got, next)
+	}
+}
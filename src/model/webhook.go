@@ -0,0 +1,442 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200"
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/open-trust/ot-ac/src/util"
+	otgo "github.com/open-trust/ot-go-lib"
+	"github.com/teambition/gear"
+)
+
+// Webhook 租户注册的 webhook 及投递失败的死信，直接存储在 Dgraph 中
+type Webhook struct {
+	*Model
+}
+
+// Add 注册 webhook，url 在租户内唯一
+func (m *Webhook) Add(ctx context.Context, tenant tpl.Tenant, input tpl.Webhook) (ok bool, err error) {
+	ctx = dgraph.WithMethod(ctx, "Webhook.Add")
+	defer m.audit(ctx, &err, tenant.Tenant, "Webhook.Add", []string{tpl.AuditRef("webhook", input.URL)},
+		new(diff).change("events", nil, input.Events))
+	nq := &dgraph.Nquads{
+		UKkey: "OTAC.WH.UK",
+		UKval: util.HashBase64(tenant.Tenant, input.URL),
+		Type:  "OTACWebhook",
+		KV: map[string]interface{}{
+			"OTAC.WH-T":         util.FormatUID(tenant.UID),
+			"OTAC.status":       input.Status,
+			"OTAC.WH.url":       input.URL,
+			"OTAC.WH.secret":    input.Secret,
+			"OTAC.WH.events":    input.Events,
+			"OTAC.WH.createdAt": input.CreatedAt,
+		},
+	}
+	return m.Model.Add(ctx, nq)
+}
+
+// Update 更新 webhook 的事件类型、签名密钥或状态，为空的字段不更新
+func (m *Webhook) Update(ctx context.Context, tenant tpl.Tenant, input tpl.WebhookUpdateInput) (err error) {
+	ctx = dgraph.WithMethod(ctx, "Webhook.Update")
+	// 签名密钥不写入审计日志
+	d := new(diff)
+	if len(input.Events) > 0 {
+		d.change("events", m.attr(ctx, "OTAC.WH.UK", util.HashBase64(tenant.Tenant, input.URL), "OTAC.WH.events"), input.Events)
+	}
+	if input.Status != nil {
+		d.change("status", m.attr(ctx, "OTAC.WH.UK", util.HashBase64(tenant.Tenant, input.URL), "OTAC.status"), *input.Status)
+	}
+	defer m.audit(ctx, &err, tenant.Tenant, "Webhook.Update", []string{tpl.AuditRef("webhook", input.URL)}, d)
+
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(OTAC.WH.UK, %s), first: 1) {
+			webhookUid as uid
+		}`, q.Str(util.HashBase64(tenant.Tenant, input.URL))))
+
+	mus := make([]*api.Mutation, 0, 2)
+	set := &dgraph.Nquads{
+		ID: "uid(webhookUid)",
+		KV: map[string]interface{}{},
+	}
+	if len(input.Events) > 0 {
+		del := &dgraph.Nquads{
+			ID: "uid(webhookUid)",
+			KV: map[string]interface{}{
+				"OTAC.WH.events": "*",
+			},
+		}
+		delData, err := del.Bytes()
+		if err != nil {
+			return err
+		}
+		mus = append(mus, &api.Mutation{
+			Cond:      "@if(eq(len(webhookUid), 1))",
+			DelNquads: delData,
+		})
+		set.KV["OTAC.WH.events"] = input.Events
+	}
+	if input.Secret != "" {
+		set.KV["OTAC.WH.secret"] = input.Secret
+	}
+	if input.Status != nil {
+		set.KV["OTAC.status"] = *input.Status
+	}
+	if len(set.KV) > 0 {
+		setData, err := set.Bytes()
+		if err != nil {
+			return err
+		}
+		mus = append(mus, &api.Mutation{
+			Cond:      "@if(eq(len(webhookUid), 1))",
+			SetNquads: setData,
+		})
+	}
+
+	r := make([]jsonUID, 0)
+	out := &otgo.Response{Result: &r}
+	if err = m.Do(ctx, query, vars, out, mus...); err != nil {
+		return err
+	}
+	if len(r) == 0 {
+		return gear.ErrNotFound.WithMsgf("Webhook(%s) not found", input.URL)
+	}
+	return nil
+}
+
+// Delete 删除 webhook 及其死信和等待重试的投递
+func (m *Webhook) Delete(ctx context.Context, tenant tpl.Tenant, url string) (err error) {
+	ctx = dgraph.WithMethod(ctx, "Webhook.Delete")
+	defer m.audit(ctx, &err, tenant.Tenant, "Webhook.Delete", []string{tpl.AuditRef("webhook", url)},
+		m.effective(ctx, tenant.Tenant, new(diff).remove(newNode("webhook", url), "*", anyNode)))
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		webhookUid as var(func: eq(OTAC.WH.UK, %s), first: 1) {
+			deadLetterUids as ~OTAC.DL-WH
+			deliveryUids as ~OTAC.WD-WH
+		}`, q.Str(util.HashBase64(tenant.Tenant, url))))
+	delWebhook := &dgraph.Nquads{
+		ID: "uid(webhookUid)",
+		KV: map[string]interface{}{
+			"*": "*",
+		},
+	}
+	delWebhookData, err := delWebhook.Bytes()
+	if err != nil {
+		return err
+	}
+	delDeadLetters := &dgraph.Nquads{
+		ID: "uid(deadLetterUids)",
+		KV: map[string]interface{}{
+			"*": "*",
+		},
+	}
+	delDeadLettersData, err := delDeadLetters.Bytes()
+	if err != nil {
+		return err
+	}
+
+	delDeliveries := &dgraph.Nquads{
+		ID: "uid(deliveryUids)",
+		KV: map[string]interface{}{
+			"*": "*",
+		},
+	}
+	delDeliveriesData, err := delDeliveries.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return m.Do(ctx, query, vars, nil, &api.Mutation{
+		Cond:      "@if(gt(len(webhookUid), 0))",
+		DelNquads: delWebhookData,
+	}, &api.Mutation{
+		Cond:      "@if(gt(len(deadLetterUids), 0))",
+		DelNquads: delDeadLettersData,
+	}, &api.Mutation{
+		Cond:      "@if(gt(len(deliveryUids), 0))",
+		DelNquads: delDeliveriesData,
+	})
+}
+
+// List 列出租户的 webhook，不返回签名密钥
+func (m *Webhook) List(ctx context.Context, tenant tpl.Tenant, pageSize, skip int, uidToken string) ([]tpl.Webhook, error) {
+	ctx = dgraph.WithMethod(ctx, "Webhook.List")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(dgraph.type, "OTACWebhook"), first: %s, offset: %s, after: %s) @filter(uid_in(OTAC.WH-T, %s)) {
+			uid
+			url: OTAC.WH.url
+			events: OTAC.WH.events
+			status: OTAC.status
+			createdAt: OTAC.WH.createdAt
+		}`, q.Int(pageSize), q.Int(skip), q.UID(uidToken), q.UID(tenant.UID)))
+	res := make([]tpl.Webhook, 0, pageSize)
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListByEvents 列出租户中订阅了 events 中任一事件类型的启用的 webhook，包括签名密钥，用于投递事件
+func (m *Webhook) ListByEvents(ctx context.Context, tenant string, events []string) ([]tpl.Webhook, error) {
+	ctx = dgraph.WithMethod(ctx, "Webhook.ListByEvents")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		var(func: eq(OTAC.T, %s), first: 1) {
+			webhookUids as ~OTAC.WH-T @filter(ge(OTAC.status, 0) AND eq(OTAC.WH.events, %s))
+		}
+		result(func: uid(webhookUids)) {
+			uid
+			url: OTAC.WH.url
+			secret: OTAC.WH.secret
+			events: OTAC.WH.events
+			status: OTAC.status
+			createdAt: OTAC.WH.createdAt
+		}`, q.Str(tenant), q.Strs(events)))
+	res := make([]tpl.Webhook, 0)
+	if err := m.Model.List(ctx, query, vars, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AddDeadLetter 记录重试后仍投递失败的事件
+func (m *Webhook) AddDeadLetter(ctx context.Context, tenant, webhookUID string, dl tpl.WebhookDeadLetter) error {
+	ctx = dgraph.WithMethod(ctx, "Webhook.AddDeadLetter")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		tenantUid as var(func: eq(OTAC.T, %s), first: 1)`, q.Str(tenant)))
+	nq := &dgraph.Nquads{
+		ID:   "_:dl",
+		Type: "OTACWebhookDeadLetter",
+		KV: map[string]interface{}{
+			"OTAC.DL-T":         "uid(tenantUid)",
+			"OTAC.DL-WH":        util.FormatUID(webhookUID),
+			"OTAC.DL.type":      dl.Type,
+			"OTAC.DL.payload":   string(dl.Payload),
+			"OTAC.DL.error":     dl.Error,
+			"OTAC.DL.attempts":  dl.Attempts,
+			"OTAC.DL.createdAt": dl.CreatedAt,
+		},
+	}
+	data, err := nq.Bytes()
+	if err != nil {
+		return err
+	}
+	return m.Do(ctx, query, vars, nil, &api.Mutation{
+		Cond:      "@if(eq(len(tenantUid), 1))",
+		SetNquads: data,
+	})
+}
+
+type jsonDeadLetter struct {
+	UID       string    `json:"uid"`
+	Type      string    `json:"type"`
+	Payload   string    `json:"payload"`
+	Error     string    `json:"error"`
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"createdAt"`
+	Webhook   struct {
+		URL string `json:"url"`
+	} `json:"webhook"`
+}
+
+// ListDeadLetters 列出租户的死信，url 不为空时只列出该 webhook 的死信
+func (m *Webhook) ListDeadLetters(ctx context.Context, tenant tpl.Tenant, url string, pageSize, skip int, uidToken string) (
+	[]tpl.WebhookDeadLetter, error) {
+	ctx = dgraph.WithMethod(ctx, "Webhook.ListDeadLetters")
+	q := dgraph.NewQuery()
+	filter := dgraph.Sprintf("uid_in(OTAC.DL-T, %s)", q.UID(tenant.UID))
+	qs := make([]dgraph.DQL, 0, 2)
+	if url != "" {
+		qs = append(qs, dgraph.Sprintf("webhookUid as var(func: eq(OTAC.WH.UK, %s), first: 1)",
+			q.Str(util.HashBase64(tenant.Tenant, url))))
+		filter = dgraph.Sprintf("%s AND uid_in(OTAC.DL-WH, uid(webhookUid))", filter)
+	}
+	qs = append(qs, dgraph.Sprintf(`
+		result(func: eq(dgraph.type, "OTACWebhookDeadLetter"), first: %s, offset: %s, after: %s) @filter(%s) {
+			uid
+			type: OTAC.DL.type
+			payload: OTAC.DL.payload
+			error: OTAC.DL.error
+			attempts: OTAC.DL.attempts
+			createdAt: OTAC.DL.createdAt
+			webhook: OTAC.DL-WH {
+				url: OTAC.WH.url
+			}
+		}`, q.Int(pageSize), q.Int(skip), q.UID(uidToken), filter))
+	query, vars := q.Build(dgraph.Join(qs, "\n"))
+
+	data := make([]jsonDeadLetter, 0, pageSize)
+	if err := m.Model.List(ctx, query, vars, &data); err != nil {
+		return nil, err
+	}
+	res := make([]tpl.WebhookDeadLetter, 0, len(data))
+	for _, d := range data {
+		dl := tpl.WebhookDeadLetter{
+			UID:       d.UID,
+			URL:       d.Webhook.URL,
+			Type:      d.Type,
+			Error:     d.Error,
+			Attempts:  d.Attempts,
+			CreatedAt: d.CreatedAt,
+		}
+		if json.Valid([]byte(d.Payload)) {
+			dl.Payload = json.RawMessage(d.Payload)
+		}
+		res = append(res, dl)
+	}
+	return res, nil
+}
+
+// SaveDelivery 保存等待重试的投递，d.UID 为空时新建，否则更新尝试次数、错误和下次投递时间。
+// 租户或 webhook 已删除时不保存
+func (m *Webhook) SaveDelivery(ctx context.Context, d tpl.WebhookDelivery) error {
+	ctx = dgraph.WithMethod(ctx, "Webhook.SaveDelivery")
+	q := dgraph.NewQuery()
+	kv := map[string]interface{}{
+		"OTAC.WD.error":         d.Error,
+		"OTAC.WD.attempts":      d.Attempts,
+		"OTAC.WD.nextAttemptAt": d.NextAttemptAt,
+	}
+	var query string
+	var vars map[string]string
+	nq := &dgraph.Nquads{ID: "uid(deliveryUid)", KV: kv}
+	cond := "@if(eq(len(deliveryUid), 1))"
+	if d.UID == "" {
+		query, vars = q.Build(dgraph.Sprintf(`
+			tenantUid as var(func: eq(OTAC.T, %s), first: 1)
+			webhookUid as var(func: uid(%s)) @filter(eq(dgraph.type, "OTACWebhook"))`,
+			q.Str(d.Tenant), q.UID(d.Webhook.UID)))
+		nq.ID = "_:wd"
+		nq.Type = "OTACWebhookDelivery"
+		kv["OTAC.WD-T"] = "uid(tenantUid)"
+		kv["OTAC.WD-WH"] = "uid(webhookUid)"
+		kv["OTAC.WD.id"] = d.ID
+		kv["OTAC.WD.type"] = d.Type
+		kv["OTAC.WD.payload"] = string(d.Payload)
+		cond = "@if(eq(len(tenantUid), 1) AND eq(len(webhookUid), 1))"
+	} else {
+		query, vars = q.Build(dgraph.Sprintf(`
+			deliveryUid as var(func: uid(%s)) @filter(eq(dgraph.type, "OTACWebhookDelivery"))`, q.UID(d.UID)))
+	}
+	data, err := nq.Bytes()
+	if err != nil {
+		return err
+	}
+	return m.Do(ctx, query, vars, nil, &api.Mutation{
+		Cond:      cond,
+		SetNquads: data,
+	})
+}
+
+type jsonDelivery struct {
+	UID      string `json:"uid"`
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Payload  string `json:"payload"`
+	Error    string `json:"error"`
+	Attempts int    `json:"attempts"`
+	Tenant   struct {
+		Tenant string `json:"tenant"`
+	} `json:"tenant"`
+	Webhook *tpl.Webhook `json:"webhook"`
+}
+
+// ClaimDeliveries 取出最多 limit 个到期的等待重试的投递，并在同一事务中将其下次投递时间推迟 lease 作为租约，
+// 多个实例同时取出时只有一个事务能提交，其余的返回空列表；持有租约的实例退出时投递在租约到期后由其它实例继续。
+// webhook 已删除的投递返回的 Webhook.UID 为空
+func (m *Webhook) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) (
+	[]tpl.WebhookDelivery, error) {
+	ctx = dgraph.WithMethod(ctx, "Webhook.ClaimDeliveries")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		deliveryUids as var(func: le(OTAC.WD.nextAttemptAt, %s), first: %s)
+		result(func: uid(deliveryUids)) {
+			uid
+			id: OTAC.WD.id
+			type: OTAC.WD.type
+			payload: OTAC.WD.payload
+			error: OTAC.WD.error
+			attempts: OTAC.WD.attempts
+			tenant: OTAC.WD-T {
+				tenant: OTAC.T
+			}
+			webhook: OTAC.WD-WH {
+				uid
+				url: OTAC.WH.url
+				secret: OTAC.WH.secret
+				events: OTAC.WH.events
+				status: OTAC.status
+				createdAt: OTAC.WH.createdAt
+			}
+		}`, q.Time(now), q.Int(limit)))
+	nq := &dgraph.Nquads{
+		ID: "uid(deliveryUids)",
+		KV: map[string]interface{}{
+			"OTAC.WD.nextAttemptAt": now.Add(lease),
+		},
+	}
+	data, err := nq.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]jsonDelivery, 0, limit)
+	out := &otgo.Response{Result: &r}
+	err = m.Do(ctx, query, vars, out, &api.Mutation{
+		Cond:      "@if(gt(len(deliveryUids), 0))",
+		SetNquads: data,
+	})
+	if err == dgo.ErrAborted {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := make([]tpl.WebhookDelivery, 0, len(r))
+	for _, d := range r {
+		wd := tpl.WebhookDelivery{
+			UID:      d.UID,
+			Tenant:   d.Tenant.Tenant,
+			ID:       d.ID,
+			Type:     d.Type,
+			Payload:  json.RawMessage(d.Payload),
+			Error:    d.Error,
+			Attempts: d.Attempts,
+		}
+		if d.Webhook != nil {
+			wd.Webhook = *d.Webhook
+		}
+		res = append(res, wd)
+	}
+	return res, nil
+}
+
+// DeleteDelivery 删除投递成功或已记为死信的投递
+func (m *Webhook) DeleteDelivery(ctx context.Context, uid string) error {
+	ctx = dgraph.WithMethod(ctx, "Webhook.DeleteDelivery")
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		deliveryUid as var(func: uid(%s)) @filter(eq(dgraph.type, "OTACWebhookDelivery"))`, q.UID(uid)))
+	nq := &dgraph.Nquads{
+		ID: "uid(deliveryUid)",
+		KV: map[string]interface{}{
+			"*": "*",
+		},
+	}
+	data, err := nq.Bytes()
+	if err != nil {
+		return err
+	}
+	return m.Do(ctx, query, vars, nil, &api.Mutation{
+		Cond:      "@if(eq(len(deliveryUid), 1))",
+		DelNquads: data,
+	})
+}
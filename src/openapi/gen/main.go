@@ -0,0 +1,283 @@
+// gen 根据 app.NewRouters 的路由、api 的处理函数和 tpl 的类型（包括 Validate 方法中的约束）生成 OpenAPI 3 文档：
+// doc/openapi.yaml 和服务在 /openapi.json 提供的 src/openapi/spec_gen.go。-check 只检查文档是否与代码一致，不一致时退出码为 1。
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	root  = flag.String("root", ".", "repository root")
+	check = flag.Bool("check", false, "check that the generated files are up to date instead of writing them")
+)
+
+const header = "Code generated by src/openapi/gen from app.NewRouters and src/tpl. DO NOT EDIT."
+
+// responseOverrides 不返回 tpl.SuccessResponseType 的 bll 方法，值为 components.schemas 中的响应类型
+var responseOverrides = map[string]string{
+	"GraphQLQuery": "GraphQLResponse",
+}
+
+func main() {
+	flag.Parse()
+	files, err := generate(*root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	stale := false
+	for _, f := range files {
+		file := filepath.Join(*root, f.path)
+		if *check {
+			old, _ := ioutil.ReadFile(file)
+			if !bytes.Equal(old, f.data) {
+				fmt.Fprintf(os.Stderr, "%s is out of date, run make openapi\n", f.path)
+				stale = true
+			}
+			continue
+		}
+		if err := ioutil.WriteFile(file, f.data, 0644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	if stale {
+		os.Exit(1)
+	}
+}
+
+type outputFile struct {
+	path string
+	data []byte
+}
+
+func generate(root string) ([]outputFile, error) {
+	spec, err := build(root)
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := yaml.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	y = append([]byte("# "+header+"\n"), y...)
+
+	j, err := marshalJSON(spec)
+	if err != nil {
+		return nil, err
+	}
+	var indented bytes.Buffer
+	if err = json.Indent(&indented, j, "", "  "); err != nil {
+		return nil, err
+	}
+	src := fmt.Sprintf("// %s\n\npackage openapi\n\n// specJSON OpenAPI 文档\nconst specJSON = `%s`\n",
+		header, strings.Replace(indented.String(), "`", "` + \"`\" + `", -1))
+	g, err := format.Source([]byte(src))
+	if err != nil {
+		return nil, err
+	}
+	return []outputFile{
+		{path: filepath.Join("doc", "openapi.yaml"), data: y},
+		{path: filepath.Join("src", "openapi", "spec_gen.go"), data: g},
+	}, nil
+}
+
+func build(root string) (object, error) {
+	tpl, err := parsePackage(filepath.Join(root, "src", "tpl"))
+	if err != nil {
+		return nil, err
+	}
+	app, err := parsePackage(filepath.Join(root, "src", "app"))
+	if err != nil {
+		return nil, err
+	}
+	api, err := parsePackage(filepath.Join(root, "src", "api"))
+	if err != nil {
+		return nil, err
+	}
+	routes, err := parseRoutes(app, api)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &schemas{p: tpl, components: make(map[string]object)}
+	paths := object{}
+	ids := make(map[string]bool)
+	for _, r := range routes {
+		// 同一个处理函数注册在多个路径上时（如 /livez、/readyz），operationId 加上路径以保持唯一
+		id := r.operationID()
+		if ids[id] {
+			id += "_" + strings.Trim(strings.Replace(r.path, "/", "_", -1), "_")
+		}
+		ids[id] = true
+		item, _ := paths.get(r.path).(object)
+		paths = paths.set(r.path, item.set(r.method, operation(s, r, id)))
+	}
+
+	s.ref("SuccessResponseType")
+	s.components["ErrorResponseType"] = errorResponse
+	s.components["GraphQLResponse"] = graphQLResponse
+
+	return object{
+		{"openapi", "3.0.3"},
+		{"info", object{
+			{"title", "OT-AC"},
+			{"description", "Open Trust Access Control service. 除特别说明外，接口的请求体和响应均为 JSON，成功时响应 tpl.SuccessResponseType，失败时响应 tpl.ErrorResponseType。"},
+			{"version", "1.0.0"},
+		}},
+		{"paths", paths},
+		{"components", object{
+			{"schemas", s.sorted()},
+			{"securitySchemes", securitySchemes},
+		}},
+	}, nil
+}
+
+func ref(name string) object {
+	return object{{"$ref", "#/components/schemas/" + name}}
+}
+
+func jsonContent(schema object) object {
+	return object{{"application/json", object{{"schema", schema}}}}
+}
+
+func operation(s *schemas, r *route, id string) object {
+	tag := r.group
+	if tag == "" {
+		tag = "Service"
+	}
+	op := object{
+		{"tags", []string{tag}},
+		{"operationId", id},
+	}
+	if r.doc != "" {
+		op = op.set("summary", r.doc)
+	}
+	if r.unimplemented {
+		op = op.set("description", "尚未实现，当前总是返回空响应。")
+		op = op.set("x-unimplemented", true)
+	}
+	if r.prefer {
+		op = op.set("parameters", []interface{}{preferHeader})
+	}
+
+	switch {
+	case r.ndjson:
+		op = op.set("requestBody", object{
+			{"required", true},
+			{"content", object{{"application/x-ndjson", object{{"schema", object{
+				{"type", "string"},
+				{"description", "每行一个 tpl.SnapshotRecord"},
+			}}}}}},
+		})
+	case r.input != "":
+		op = op.set("requestBody", object{
+			{"required", true},
+			{"content", jsonContent(s.ref(r.input))},
+		})
+	}
+
+	ok := object{{"description", "OK"}}
+	switch {
+	case r.unimplemented:
+	case r.stream != "":
+		ok = ok.set("content", object{{r.stream, object{{"schema", object{{"type", "string"}}}}}})
+	case responseOverrides[r.operationID()] != "":
+		ok = ok.set("content", jsonContent(ref(responseOverrides[r.operationID()])))
+	case r.success:
+		ok = ok.set("content", jsonContent(ref("SuccessResponseType")))
+	default:
+		ok = ok.set("content", jsonContent(object{{"type", "object"}}))
+	}
+	op = op.set("responses", object{
+		{"200", ok},
+		{"default", object{
+			{"description", "Error"},
+			{"content", jsonContent(ref("ErrorResponseType"))},
+		}},
+	})
+	if r.security != "" {
+		op = op.set("security", []interface{}{object{{r.security, []string{}}}})
+	}
+	return op
+}
+
+var preferHeader = object{
+	{"name", "Prefer"},
+	{"in", "header"},
+	{"description", "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情"},
+	{"schema", object{{"type", "string"}}},
+}
+
+// errorResponse gear.ErrorResponse
+var errorResponse = object{
+	{"type", "object"},
+	{"properties", object{
+		{"error", object{
+			{"type", "object"},
+			{"properties", object{
+				{"code", object{{"type", "integer"}}},
+				{"status", object{{"type", "string"}}},
+				{"message", object{{"type", "string"}}},
+				{"data", object{}},
+			}},
+			{"required", []string{"code", "status", "message"}},
+		}},
+	}},
+	{"required", []string{"error"}},
+}
+
+// graphQLResponse gql.Response，查询无效时只有 errors
+var graphQLResponse = object{
+	{"type", "object"},
+	{"properties", object{
+		{"data", object{{"type", "object"}}},
+		{"errors", object{
+			{"type", "array"},
+			{"items", object{
+				{"type", "object"},
+				{"properties", object{
+					{"message", object{{"type", "string"}}},
+					{"locations", object{
+						{"type", "array"},
+						{"items", object{
+							{"type", "object"},
+							{"properties", object{
+								{"line", object{{"type", "integer"}}},
+								{"column", object{{"type", "integer"}}},
+							}},
+						}},
+					}},
+				}},
+				{"required", []string{"message"}},
+			}},
+		}},
+	}},
+}
+
+var securitySchemes = object{
+	{"tenant", object{
+		{"type", "http"},
+		{"scheme", "bearer"},
+		{"bearerFormat", "OTVID"},
+		{"description", "租户的 OTVID，由信任域签发，aud 为 ot-ac 的 OTID"},
+	}},
+	{"admin", object{
+		{"type", "http"},
+		{"scheme", "bearer"},
+		{"bearerFormat", "OTVID"},
+		{"description", "ot-ac 自身 OTID 的 OTVID"},
+	}},
+}
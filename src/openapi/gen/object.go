@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v2"
+)
+
+// object 保持键顺序的 JSON/YAML 对象，使生成的文档稳定，便于检查是否与代码一致
+type object []member
+
+type member struct {
+	key string
+	val interface{}
+}
+
+func (o object) set(key string, val interface{}) object {
+	for i, m := range o {
+		if m.key == key {
+			o[i].val = val
+			return o
+		}
+	}
+	return append(o, member{key, val})
+}
+
+func (o object) get(key string) interface{} {
+	for _, m := range o {
+		if m.key == key {
+			return m.val
+		}
+	}
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler
+func (o object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := marshalJSON(m.key)
+		if err != nil {
+			return nil, err
+		}
+		val, err := marshalJSON(m.val)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(val)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// MarshalYAML 实现 yaml.Marshaler
+func (o object) MarshalYAML() (interface{}, error) {
+	ms := make(yaml.MapSlice, len(o))
+	for i, m := range o {
+		ms[i] = yaml.MapItem{Key: m.key, Value: m.val}
+	}
+	return ms, nil
+}
+
+// marshalJSON 不转义 <、>、&，与 YAML 中的描述保持一致
+func marshalJSON(v interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(b.Bytes(), "\n"), nil
+}
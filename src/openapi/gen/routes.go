@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// route app.NewRouters 中注册的路由
+type route struct {
+	method        string
+	path          string
+	security      string // tenant、admin 或空
+	group         string // api 的类型名，如 Unit，app 包中的处理函数为空
+	name          string // 处理函数名
+	doc           string
+	input         string // 请求体对应的 tpl 类型
+	ndjson        bool   // 请求体为 NDJSON 流
+	stream        string // 以流的形式响应时的 content type
+	success       bool   // 响应为 tpl.SuccessResponseType
+	prefer        bool   // 支持 Prefer header
+	unimplemented bool
+}
+
+func (r *route) operationID() string {
+	return r.group + strings.Title(r.name)
+}
+
+var routerMethods = map[string]bool{"Get": true, "Post": true, "Put": true, "Patch": true, "Delete": true}
+
+// parseRoutes 按注册顺序读取 app.NewRouters 中的路由，并分析处理函数的请求和响应
+func parseRoutes(app, api *pkg) ([]*route, error) {
+	fn := app.funcs["NewRouters"]
+	if fn == nil {
+		return nil, fmt.Errorf("app.NewRouters not found")
+	}
+	var routes []*route
+	var err error
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || err != nil {
+			return err == nil
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !routerMethods[sel.Sel.Name] || !isIdent(sel.X, "router") || len(call.Args) < 2 {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		r := &route{method: strings.ToLower(sel.Sel.Name)}
+		r.path, _ = strconv.Unquote(lit.Value)
+		for _, h := range call.Args[1 : len(call.Args)-1] {
+			switch {
+			case isSelector(h, "middleware", "VerifyTenant"):
+				r.security = "tenant"
+			case isSelector(h, "middleware", "VerifyAdmin"):
+				r.security = "admin"
+			}
+		}
+
+		var handler *ast.FuncDecl
+		switch h := call.Args[len(call.Args)-1].(type) {
+		case *ast.Ident:
+			r.name = h.Name
+			handler = app.funcs[h.Name]
+		case *ast.SelectorExpr:
+			// apis.Unit.BatchAdd
+			if x, ok := h.X.(*ast.SelectorExpr); ok && isIdent(x.X, "apis") {
+				r.group, r.name = x.Sel.Name, h.Sel.Name
+				handler = api.methods[r.group][r.name]
+			}
+		}
+		if handler == nil {
+			err = fmt.Errorf("%s %s: handler not found", strings.ToUpper(r.method), r.path)
+			return false
+		}
+		r.doc = docText(handler.Doc, r.name)
+		analyzeHandler(r, handler)
+		routes = append(routes, r)
+		return true
+	})
+	return routes, err
+}
+
+func isIdent(e ast.Expr, name string) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == name
+}
+
+// analyzeHandler 从处理函数中读取请求体类型、响应形式和是否支持 Prefer
+func analyzeHandler(r *route, fn *ast.FuncDecl) {
+	if len(fn.Body.List) == 1 {
+		if ret, ok := fn.Body.List[0].(*ast.ReturnStmt); ok && len(ret.Results) == 1 && isIdent(ret.Results[0], "nil") {
+			r.unimplemented = true
+			return
+		}
+	}
+	// 由 blls 的业务方法（不含 blls.Models）返回的结果为 tpl.SuccessResponseType
+	fromBll := make(map[string]bool)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.AssignStmt:
+			if len(v.Rhs) != 1 {
+				break
+			}
+			if lit, ok := v.Rhs[0].(*ast.CompositeLit); ok && r.input == "" {
+				if sel, ok := lit.Type.(*ast.SelectorExpr); ok && isIdent(sel.X, "tpl") {
+					r.input = sel.Sel.Name
+				}
+			}
+			if call, ok := v.Rhs[0].(*ast.CallExpr); ok && isBllCall(call) {
+				if id, ok := v.Lhs[0].(*ast.Ident); ok {
+					fromBll[id.Name] = true
+				}
+			}
+		case *ast.SelectorExpr:
+			if x, ok := v.X.(*ast.SelectorExpr); ok && v.Sel.Name == "Body" && x.Sel.Name == "Req" {
+				r.ndjson = true
+			}
+		case *ast.CallExpr:
+			sel, ok := v.Fun.(*ast.SelectorExpr)
+			if !ok {
+				break
+			}
+			switch {
+			case isSelector(sel, "model", "ContextWithPrefer"):
+				r.prefer = true
+			case sel.Sel.Name == "Stream" && len(v.Args) == 3:
+				if lit, ok := v.Args[1].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					r.stream, _ = strconv.Unquote(lit.Value)
+				}
+			case sel.Sel.Name == "OkJSON" && len(v.Args) == 1:
+				if id, ok := v.Args[0].(*ast.Ident); ok && fromBll[id.Name] {
+					r.success = true
+				}
+			}
+		}
+		return true
+	})
+}
+
+// isBllCall 判断是否为 a.blls.X.Method(...) 的调用
+func isBllCall(call *ast.CallExpr) bool {
+	method, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	group, ok := method.X.(*ast.SelectorExpr)
+	if !ok || group.Sel.Name == "Models" {
+		return false
+	}
+	blls, ok := group.X.(*ast.SelectorExpr)
+	return ok && blls.Sel.Name == "blls"
+}
@@ -0,0 +1,772 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pkg 解析后的 Go 包，只记录生成文档需要的声明
+type pkg struct {
+	types        map[string]*ast.TypeSpec
+	docs         map[string]string
+	methods      map[string]map[string]*ast.FuncDecl // 类型 -> 方法名 -> 方法
+	funcs        map[string]*ast.FuncDecl
+	regexps      map[string]string   // regexp.MustCompile 定义的包级变量 -> 正则
+	consts       map[string]ast.Expr // 包级常量
+	checkers     map[string]*constraint
+	checkerStack map[string]bool
+}
+
+func parsePackage(dir string) (*pkg, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	p := &pkg{
+		types:        make(map[string]*ast.TypeSpec),
+		docs:         make(map[string]string),
+		methods:      make(map[string]map[string]*ast.FuncDecl),
+		funcs:        make(map[string]*ast.FuncDecl),
+		regexps:      make(map[string]string),
+		consts:       make(map[string]ast.Expr),
+		checkers:     make(map[string]*constraint),
+		checkerStack: make(map[string]bool),
+	}
+	for _, ap := range pkgs {
+		names := make([]string, 0, len(ap.Files))
+		for name := range ap.Files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			p.addFile(ap.Files[name])
+		}
+	}
+	return p, nil
+}
+
+func (p *pkg) addFile(f *ast.File) {
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				p.funcs[d.Name.Name] = d
+				continue
+			}
+			recv := typeName(d.Recv.List[0].Type)
+			if p.methods[recv] == nil {
+				p.methods[recv] = make(map[string]*ast.FuncDecl)
+			}
+			p.methods[recv][d.Name.Name] = d
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					p.types[s.Name.Name] = s
+					doc := s.Doc
+					if doc == nil && len(d.Specs) == 1 {
+						doc = d.Doc
+					}
+					p.docs[s.Name.Name] = docText(doc, s.Name.Name)
+				case *ast.ValueSpec:
+					for i, name := range s.Names {
+						if i >= len(s.Values) {
+							break
+						}
+						if d.Tok == token.CONST {
+							p.consts[name.Name] = s.Values[i]
+						} else if re, ok := regexpLiteral(s.Values[i]); ok {
+							p.regexps[name.Name] = re
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func typeName(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.StarExpr:
+		return typeName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// regexpLiteral 读取 regexp.MustCompile(`...`) 中的正则
+func regexpLiteral(e ast.Expr) (string, bool) {
+	call, ok := e.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !isSelector(call.Fun, "regexp", "MustCompile") {
+		return "", false
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+func isSelector(e ast.Expr, x, sel string) bool {
+	s, ok := e.(*ast.SelectorExpr)
+	if !ok || s.Sel.Name != sel {
+		return false
+	}
+	id, ok := s.X.(*ast.Ident)
+	return ok && id.Name == x
+}
+
+// docText 返回注释的第一段，去掉以名称开头的前缀，"..." 之类没有内容的注释返回空字符串
+func docText(doc *ast.CommentGroup, name string) string {
+	if doc == nil {
+		return ""
+	}
+	text := strings.TrimSpace(doc.Text())
+	if i := strings.Index(text, "\n\n"); i >= 0 {
+		text = text[:i]
+	}
+	text = strings.TrimSpace(strings.TrimPrefix(text, name))
+	text = strings.Join(strings.Fields(strings.Replace(text, "\n", " ", -1)), " ")
+	if strings.Trim(text, ".") == "" {
+		return ""
+	}
+	return text
+}
+
+func (p *pkg) intValue(e ast.Expr) (int, bool) {
+	switch v := e.(type) {
+	case *ast.BasicLit:
+		if v.Kind == token.INT {
+			i, err := strconv.Atoi(v.Value)
+			return i, err == nil
+		}
+	case *ast.ParenExpr:
+		return p.intValue(v.X)
+	case *ast.Ident:
+		if c, ok := p.consts[v.Name]; ok {
+			return p.intValue(c)
+		}
+	case *ast.UnaryExpr:
+		if i, ok := p.intValue(v.X); ok && v.Op == token.SUB {
+			return -i, true
+		}
+	case *ast.BinaryExpr:
+		x, ok1 := p.intValue(v.X)
+		y, ok2 := p.intValue(v.Y)
+		if !ok1 || !ok2 {
+			return 0, false
+		}
+		switch v.Op {
+		case token.ADD:
+			return x + y, true
+		case token.SUB:
+			return x - y, true
+		case token.MUL:
+			return x * y, true
+		case token.QUO:
+			if y != 0 {
+				return x / y, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// constraint 从 Validate 方法和检查函数推导出的字段约束，长度对字符串、数组和 map 分别对应
+// minLength/maxLength、minItems/maxItems 和 minProperties/maxProperties
+type constraint struct {
+	required bool
+	minLen   *int
+	maxLen   *int
+	min      *int
+	max      *int
+	pattern  string
+	format   string
+	items    *constraint
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func (c *constraint) merge(o *constraint) {
+	if o.minLen != nil {
+		c.minLen = o.minLen
+	}
+	if o.maxLen != nil {
+		c.maxLen = o.maxLen
+	}
+	if o.min != nil {
+		c.min = o.min
+	}
+	if o.max != nil {
+		c.max = o.max
+	}
+	if o.pattern != "" {
+		c.pattern = o.pattern
+	}
+	if o.format != "" {
+		c.format = o.format
+	}
+}
+
+func (c *constraint) nonEmpty() bool {
+	return c.minLen != nil && *c.minLen > 0
+}
+
+// apply 将约束添加到 schema，$ref 不能带有其它关键字，因此只处理内联的类型
+func (c *constraint) apply(s object) object {
+	switch s.get("type") {
+	case "string":
+		if c.minLen != nil {
+			s = s.set("minLength", *c.minLen)
+		}
+		if c.maxLen != nil {
+			s = s.set("maxLength", *c.maxLen)
+		}
+		if c.pattern != "" {
+			s = s.set("pattern", c.pattern)
+		}
+		if c.format != "" {
+			s = s.set("format", c.format)
+		}
+	case "array":
+		if c.minLen != nil {
+			s = s.set("minItems", *c.minLen)
+		}
+		if c.maxLen != nil {
+			s = s.set("maxItems", *c.maxLen)
+		}
+		if items, ok := s.get("items").(object); ok && c.items != nil {
+			s = s.set("items", c.items.apply(items))
+		}
+	case "object":
+		if c.minLen != nil {
+			s = s.set("minProperties", *c.minLen)
+		}
+		if c.maxLen != nil {
+			s = s.set("maxProperties", *c.maxLen)
+		}
+	case "integer", "number":
+		if c.min != nil {
+			s = s.set("minimum", *c.min)
+		}
+		if c.max != nil {
+			s = s.set("maximum", *c.max)
+		}
+	}
+	return s
+}
+
+// checker 推导检查函数（如 CheckResource）对参数的约束，不是单参数检查函数时返回 nil
+func (p *pkg) checker(name string) *constraint {
+	if c, ok := p.checkers[name]; ok {
+		return c
+	}
+	fn := p.funcs[name]
+	if fn == nil || len(fn.Type.Params.List) != 1 || len(fn.Type.Params.List[0].Names) != 1 || p.checkerStack[name] {
+		return nil
+	}
+	p.checkerStack[name] = true
+	a := newAnalyzer(p, fn.Type.Params.List[0].Names[0].Name, true, nil)
+	a.walk(fn.Body.List, nil)
+	delete(p.checkerStack, name)
+
+	c := a.get("", false)
+	c.merge(p.checkerOverride(name))
+	p.checkers[name] = c
+	return c
+}
+
+func trimAnchors(re string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(re, "^"), "$")
+}
+
+// checkerOverride 无法从函数体推导的约束：权限按 "." 分段检查，由 resourceReg 和 permissionReg 组成
+func (p *pkg) checkerOverride(name string) *constraint {
+	permission := "^" + trimAnchors(p.regexps["resourceReg"]) + `(\.` + trimAnchors(p.regexps["permissionReg"]) + `)*`
+	switch name {
+	case "CheckPermission":
+		return &constraint{pattern: permission + "$"}
+	case "CheckWildcardPermission":
+		return &constraint{pattern: permission + `(\.\*)?$`}
+	case "CheckSubject":
+		return &constraint{format: "otid-subject"}
+	case "checkTenant":
+		return &constraint{format: "otid"}
+	}
+	return &constraint{}
+}
+
+// analyzer 分析 Validate 方法或检查函数的函数体，recv 为接收者或检查函数的参数
+type analyzer struct {
+	p      *pkg
+	recv   string
+	param  bool
+	fields map[string]ast.Expr // 结构体的字段类型，不含嵌入的字段
+	ranges map[string]string   // range 的值变量 -> 字段
+	res    map[string]*constraint
+}
+
+func newAnalyzer(p *pkg, recv string, param bool, fields map[string]ast.Expr) *analyzer {
+	return &analyzer{
+		p:      p,
+		recv:   recv,
+		param:  param,
+		fields: fields,
+		ranges: make(map[string]string),
+		res:    make(map[string]*constraint),
+	}
+}
+
+func (a *analyzer) get(field string, items bool) *constraint {
+	c := a.res[field]
+	if c == nil {
+		c = &constraint{}
+		a.res[field] = c
+	}
+	if items {
+		if c.items == nil {
+			c.items = &constraint{}
+		}
+		return c.items
+	}
+	return c
+}
+
+// target 判断表达式是否为接收者的字段、检查函数的参数或 range 字段得到的元素
+func (a *analyzer) target(e ast.Expr) (string, bool, bool) {
+	switch v := e.(type) {
+	case *ast.Ident:
+		if a.param && v.Name == a.recv {
+			return "", false, true
+		}
+		if f, ok := a.ranges[v.Name]; ok {
+			return f, true, true
+		}
+	case *ast.SelectorExpr:
+		if id, ok := v.X.(*ast.Ident); ok && !a.param && id.Name == a.recv {
+			return v.Sel.Name, false, true
+		}
+	case *ast.ParenExpr:
+		return a.target(v.X)
+	}
+	return "", false, false
+}
+
+func (a *analyzer) walk(stmts []ast.Stmt, guarded map[string]bool) {
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *ast.IfStmt:
+			if s.Init != nil {
+				a.calls(s.Init, guarded)
+			}
+			a.calls(s.Cond, guarded)
+			if returnsError(s.Body) {
+				a.cond(s.Cond, guarded)
+			}
+			a.walk(s.Body.List, a.guards(s.Cond, guarded))
+			if s.Else != nil {
+				a.walk([]ast.Stmt{s.Else}, guarded)
+			}
+		case *ast.RangeStmt:
+			f, items, ok := a.target(s.X)
+			v, isIdent := s.Value.(*ast.Ident)
+			if ok && !items && isIdent {
+				prev, had := a.ranges[v.Name]
+				a.ranges[v.Name] = f
+				a.walk(s.Body.List, guarded)
+				if had {
+					a.ranges[v.Name] = prev
+				} else {
+					delete(a.ranges, v.Name)
+				}
+				continue
+			}
+			a.walk(s.Body.List, guarded)
+		case *ast.ForStmt:
+			a.walk(s.Body.List, guarded)
+		case *ast.BlockStmt:
+			a.walk(s.List, guarded)
+		default:
+			a.calls(stmt, guarded)
+		}
+	}
+}
+
+// calls 处理检查函数和字段的 Validate 调用
+func (a *analyzer) calls(n ast.Node, guarded map[string]bool) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			_, isFunc := n.(*ast.FuncLit)
+			return !isFunc
+		}
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			if len(call.Args) != 1 {
+				break
+			}
+			f, items, ok := a.target(call.Args[0])
+			if !ok {
+				break
+			}
+			c := a.p.checker(fun.Name)
+			if c == nil {
+				break
+			}
+			a.get(f, items).merge(c)
+			if !items && c.nonEmpty() && !guarded[f] {
+				a.get(f, false).required = true
+			}
+		case *ast.SelectorExpr:
+			if fun.Sel.Name != "Validate" {
+				break
+			}
+			f, items, ok := a.target(fun.X)
+			if !ok || items || guarded[f] {
+				break
+			}
+			// 非指针的结构体字段会被校验，视为必填
+			if typ, ok := a.fields[f].(*ast.Ident); ok {
+				if ts := a.p.types[typ.Name]; ts != nil {
+					if _, isStruct := ts.Type.(*ast.StructType); isStruct {
+						a.get(f, false).required = true
+					}
+				}
+			}
+		}
+		return true
+	})
+}
+
+// returnsError 判断代码块是否直接返回错误
+func returnsError(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(v.Results) > 0 {
+				if id, ok := v.Results[0].(*ast.Ident); !ok || id.Name != "nil" {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// lenTarget 判断表达式是否为 len(x) 或 utf8.RuneCountInString(x)
+func (a *analyzer) lenTarget(e ast.Expr) (string, bool, bool) {
+	call, ok := e.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return "", false, false
+	}
+	if id, ok := call.Fun.(*ast.Ident); (ok && id.Name == "len") || isSelector(call.Fun, "utf8", "RuneCountInString") {
+		return a.target(call.Args[0])
+	}
+	return "", false, false
+}
+
+// cond 处理返回错误的条件，即值无效的条件
+func (a *analyzer) cond(e ast.Expr, guarded map[string]bool) {
+	switch v := e.(type) {
+	case *ast.ParenExpr:
+		a.cond(v.X, guarded)
+	case *ast.UnaryExpr:
+		call, ok := v.X.(*ast.CallExpr)
+		if v.Op != token.NOT || !ok || len(call.Args) != 1 {
+			return
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "MatchString" {
+			return
+		}
+		reg, ok := sel.X.(*ast.Ident)
+		if !ok || a.p.regexps[reg.Name] == "" {
+			return
+		}
+		if f, items, ok := a.target(call.Args[0]); ok {
+			a.get(f, items).pattern = a.p.regexps[reg.Name]
+		}
+	case *ast.BinaryExpr:
+		if v.Op == token.LOR {
+			a.cond(v.X, guarded)
+			a.cond(v.Y, guarded)
+			return
+		}
+		n, isInt := a.p.intValue(v.Y)
+		if f, items, ok := a.lenTarget(v.X); ok && isInt {
+			c := a.get(f, items)
+			switch v.Op {
+			case token.EQL:
+				if n == 0 {
+					c.minLen = intPtr(1)
+					c.required = c.required || (!items && !guarded[f])
+				}
+			case token.LSS:
+				c.minLen = intPtr(n)
+			case token.LEQ:
+				c.minLen = intPtr(n + 1)
+			case token.GTR:
+				c.maxLen = intPtr(n)
+			case token.GEQ:
+				c.maxLen = intPtr(n - 1)
+			}
+			return
+		}
+		f, items, ok := a.target(v.X)
+		if !ok {
+			return
+		}
+		c := a.get(f, items)
+		switch {
+		case isInt && v.Op == token.LSS:
+			c.min = intPtr(n)
+		case isInt && v.Op == token.LEQ:
+			c.min = intPtr(n + 1)
+		case isInt && v.Op == token.GTR:
+			c.max = intPtr(n)
+		case isInt && v.Op == token.GEQ:
+			c.max = intPtr(n - 1)
+		case v.Op == token.EQL && isEmpty(v.Y):
+			if lit, ok := v.Y.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				c.minLen = intPtr(1)
+			}
+			c.required = c.required || (!items && !guarded[f])
+		}
+	}
+}
+
+func isEmpty(e ast.Expr) bool {
+	switch v := e.(type) {
+	case *ast.BasicLit:
+		return v.Kind == token.STRING && (v.Value == `""` || v.Value == "``")
+	case *ast.Ident:
+		return v.Name == "nil"
+	}
+	return false
+}
+
+// guards 返回条件成立时非空的字段，这些字段内的检查不代表字段必填
+func (a *analyzer) guards(e ast.Expr, guarded map[string]bool) map[string]bool {
+	res := make(map[string]bool, len(guarded)+1)
+	for k := range guarded {
+		res[k] = true
+	}
+	v, ok := e.(*ast.BinaryExpr)
+	if !ok {
+		return res
+	}
+	switch {
+	case v.Op == token.LAND:
+		for k := range a.guards(v.X, nil) {
+			res[k] = true
+		}
+		for k := range a.guards(v.Y, nil) {
+			res[k] = true
+		}
+	case v.Op == token.NEQ && isEmpty(v.Y):
+		if f, items, ok := a.target(v.X); ok && !items {
+			res[f] = true
+		}
+	case v.Op == token.GTR:
+		if f, items, ok := a.lenTarget(v.X); ok && !items {
+			res[f] = true
+		}
+	}
+	return res
+}
+
+// schemas 根据 tpl 的类型生成 components.schemas
+type schemas struct {
+	p          *pkg
+	components map[string]object
+}
+
+func (s *schemas) ref(name string) object {
+	if _, ok := s.components[name]; !ok {
+		s.components[name] = nil // 占位，避免递归引用时重复生成
+		s.components[name] = s.named(name)
+	}
+	return object{{"$ref", "#/components/schemas/" + name}}
+}
+
+func (s *schemas) sorted() object {
+	names := make([]string, 0, len(s.components))
+	for name := range s.components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	res := make(object, 0, len(names))
+	for _, name := range names {
+		res = append(res, member{name, s.components[name]})
+	}
+	return res
+}
+
+func (s *schemas) named(name string) object {
+	ts := s.p.types[name]
+	var res object
+	if st, ok := ts.Type.(*ast.StructType); ok {
+		props, required := s.structFields(name, st)
+		res = object{{"type", "object"}, {"properties", props}}
+		if len(required) > 0 {
+			res = res.set("required", required)
+		}
+	} else {
+		res = s.typeSchema(ts.Type)
+	}
+	// 实现了 UnmarshalJSON 的类型同时接受字符串形式，如 tpl.Permission
+	if fn := s.p.methods[name]["UnmarshalJSON"]; fn != nil {
+		res = object{{"oneOf", []interface{}{object{{"type", "string"}}, res}}}
+		if doc := docText(fn.Doc, "UnmarshalJSON"); doc != "" {
+			res = res.set("description", doc)
+		}
+	}
+	if doc := s.p.docs[name]; doc != "" && res.get("description") == nil {
+		res = res.set("description", doc)
+	}
+	return res
+}
+
+// structFields 生成结构体的属性，嵌入的结构体字段展开到当前结构体中
+func (s *schemas) structFields(name string, st *ast.StructType) (object, []string) {
+	props := object{}
+	var required []string
+	jsonNames := make(map[string]string)
+	fields := make(map[string]ast.Expr)
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			emb := typeName(f.Type)
+			if ets, ok := s.p.types[emb]; ok {
+				if est, ok := ets.Type.(*ast.StructType); ok {
+					ep, er := s.structFields(emb, est)
+					props = append(props, ep...)
+					required = append(required, er...)
+				}
+			}
+			continue
+		}
+		for _, n := range f.Names {
+			if !n.IsExported() {
+				continue
+			}
+			jsonName := n.Name
+			if f.Tag != nil {
+				tag, _ := strconv.Unquote(f.Tag.Value)
+				if v, ok := reflect.StructTag(tag).Lookup("json"); ok {
+					v = strings.Split(v, ",")[0]
+					if v == "-" {
+						continue
+					}
+					if v != "" {
+						jsonName = v
+					}
+				}
+			}
+			sch := s.typeSchema(f.Type)
+			doc := docText(f.Comment, "")
+			if doc == "" {
+				doc = docText(f.Doc, n.Name)
+			}
+			if doc != "" {
+				if sch.get("$ref") != nil {
+					sch = object{{"allOf", []interface{}{sch}}}
+				}
+				sch = sch.set("description", doc)
+			}
+			props = props.set(jsonName, sch)
+			jsonNames[n.Name] = jsonName
+			fields[n.Name] = f.Type
+		}
+	}
+
+	fn := s.p.methods[name]["Validate"]
+	if fn == nil || fn.Recv.List[0].Names == nil {
+		return props, required
+	}
+	a := newAnalyzer(s.p, fn.Recv.List[0].Names[0].Name, false, fields)
+	a.walk(fn.Body.List, nil)
+	for i, m := range props {
+		for goName, jsonName := range jsonNames {
+			c := a.res[goName]
+			if jsonName != m.key || c == nil {
+				continue
+			}
+			props[i].val = c.apply(m.val.(object))
+			if c.required && !contains(required, jsonName) {
+				required = append(required, jsonName)
+			}
+		}
+	}
+	return props, required
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *schemas) typeSchema(e ast.Expr) object {
+	switch t := e.(type) {
+	case *ast.StarExpr:
+		return s.typeSchema(t.X)
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return object{{"type", "string"}}
+		case "bool":
+			return object{{"type", "boolean"}}
+		case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32":
+			return object{{"type", "integer"}}
+		case "int64", "uint64":
+			return object{{"type", "integer"}, {"format", "int64"}}
+		case "float32", "float64":
+			return object{{"type", "number"}}
+		}
+		if _, ok := s.p.types[t.Name]; ok {
+			return s.ref(t.Name)
+		}
+	case *ast.ArrayType:
+		if id, ok := t.Elt.(*ast.Ident); ok && id.Name == "byte" {
+			return object{{"type", "string"}, {"format", "byte"}}
+		}
+		return object{{"type", "array"}, {"items", s.typeSchema(t.Elt)}}
+	case *ast.MapType:
+		return object{{"type", "object"}, {"additionalProperties", s.typeSchema(t.Value)}}
+	case *ast.StructType:
+		props, required := s.structFields("", t)
+		res := object{{"type", "object"}, {"properties", props}}
+		if len(required) > 0 {
+			res = res.set("required", required)
+		}
+		return res
+	case *ast.SelectorExpr:
+		switch {
+		case isSelector(t, "time", "Time"):
+			return object{{"type", "string"}, {"format", "date-time"}}
+		case isSelector(t, "otgo", "OTID"):
+			return object{{"type", "string"}, {"format", "otid"}}
+		}
+	}
+	return object{}
+}
@@ -0,0 +1,25 @@
+// Package openapi 提供由 src/openapi/gen 根据 app.NewRouters 的路由和 tpl 的类型生成的 OpenAPI 3 文档，
+// 路由或 tpl 的类型变化后需要执行 make openapi 重新生成，make openapi-check 检查文档是否与代码一致。
+package openapi
+
+import (
+	"encoding/json"
+)
+
+//go:generate go run ./gen -root ../..
+
+// JSON 返回 OpenAPI 文档，servers 为服务的访问地址，如配置中的 service_endpoints
+func JSON(servers []string) []byte {
+	if len(servers) == 0 {
+		return []byte(specJSON)
+	}
+	list := make([]map[string]string, len(servers))
+	for i, s := range servers {
+		list[i] = map[string]string{"url": s}
+	}
+	// 字符串的编码不会失败
+	b, _ := json.Marshal(map[string]interface{}{"servers": list})
+	// 将 servers 插入到文档的第一个字段
+	b = append(b[:len(b)-1], ',')
+	return append(b, specJSON[1:]...)
+}
@@ -0,0 +1,26 @@
+package openapi
+
+import (
+	"encoding/json"
+	"os/exec"
+	"testing"
+)
+
+// TestSpecUpToDate 以 -check 运行生成器，路由或 tpl 的类型变化后没有执行 make openapi 时失败
+func TestSpecUpToDate(t *testing.T) {
+	out, err := exec.Command("go", "run", "./gen", "-root", "../..", "-check").CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v: %s", err, out)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	doc := make(map[string]interface{})
+	if err := json.Unmarshal(JSON([]string{"https://ac.example.com"}), &doc); err != nil {
+		t.Fatal(err)
+	}
+	servers, _ := doc["servers"].([]interface{})
+	if len(servers) != 1 || doc["openapi"] != "3.0.3" {
+		t.Fatalf("servers got %v, openapi got %v", servers, doc["openapi"])
+	}
+}
@@ -0,0 +1,6481 @@
+// Code generated by src/openapi/gen from app.NewRouters and src/tpl. DO NOT EDIT.
+
+package openapi
+
+// specJSON OpenAPI 文档
+const specJSON = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "OT-AC",
+    "description": "Open Trust Access Control service. 除特别说明外，接口的请求体和响应均为 JSON，成功时响应 tpl.SuccessResponseType，失败时响应 tpl.ErrorResponseType。",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/": {
+      "get": {
+        "tags": [
+          "Service"
+        ],
+        "operationId": "GetVersion",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/version": {
+      "get": {
+        "tags": [
+          "Service"
+        ],
+        "operationId": "GetVersion_version",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/livez": {
+      "get": {
+        "tags": [
+          "Healthz"
+        ],
+        "operationId": "HealthzCheck",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/readyz": {
+      "get": {
+        "tags": [
+          "Healthz"
+        ],
+        "operationId": "HealthzCheck_readyz",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/healthz": {
+      "get": {
+        "tags": [
+          "Healthz"
+        ],
+        "operationId": "HealthzCheck_healthz",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/openapi.json": {
+      "get": {
+        "tags": [
+          "Service"
+        ],
+        "operationId": "GetOpenAPI",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/metrics": {
+      "get": {
+        "tags": [
+          "Service"
+        ],
+        "operationId": "GetMetrics",
+        "summary": "以 Prometheus 文本格式输出指标",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "text/plain; version=0.0.4; charset=utf-8": {
+                "schema": {
+                  "type": "string"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/AC/CheckUnit": {
+      "post": {
+        "tags": [
+          "AC"
+        ],
+        "operationId": "ACCheckUnit",
+        "summary": "检查请求主体到指定管理单元有没有指定权限",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ACCheckPermissionsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/AC/CheckScope": {
+      "post": {
+        "tags": [
+          "AC"
+        ],
+        "operationId": "ACCheckScope",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ACCheckPermissionsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/AC/CheckObject": {
+      "post": {
+        "tags": [
+          "AC"
+        ],
+        "operationId": "ACCheckObject",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ACCheckPermissionsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/AC/ListPermissionsByUnit": {
+      "post": {
+        "tags": [
+          "AC"
+        ],
+        "operationId": "ACListPermissionsByUnit",
+        "summary": "列出请求主体到指定管理单元的权限，未指定管理单元时列出请求主体能触达的所有管理单元的权限",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ACListPermissionsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/AC/ListPermissionsByScope": {
+      "post": {
+        "tags": [
+          "AC"
+        ],
+        "operationId": "ACListPermissionsByScope",
+        "summary": "列出请求主体到指定范围约束的权限",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ACListPermissionsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/AC/ListPermissionsByObject": {
+      "post": {
+        "tags": [
+          "AC"
+        ],
+        "operationId": "ACListPermissionsByObject",
+        "summary": "列出请求主体通过 Scope 或 Unit-Object 的连接关系到指定资源对象的权限",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ACListPermissionsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/AC/ListObject": {
+      "post": {
+        "tags": [
+          "AC"
+        ],
+        "operationId": "ACListObject",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/AC/SearchObject": {
+      "post": {
+        "tags": [
+          "AC"
+        ],
+        "operationId": "ACSearchObject",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/BatchAdd": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectBatchAdd",
+        "summary": "批量添加资源对象，当检测到将形成环时会返回 400 错误",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/TargetBatchAddInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/AssignParent": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectAssignParent",
+        "summary": "建立资源对象与父级对象的关系，当检测到将会形成环时会返回 400 错误",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/AssignScope": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectAssignScope",
+        "summary": "建立资源对象与范围约束的关系",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/RemoveParent": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectRemoveParent",
+        "summary": "清除资源对象与父级对象的关系",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/RemoveScope": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectRemoveScope",
+        "summary": "清除资源对象与范围约束的关系",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/Delete": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectDelete",
+        "summary": "删除资源对象及其所有子孙资源对象和链接关系",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/UpdateTerms": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectUpdateTerms",
+        "summary": "更新资源对象的搜索关键词",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/AddPermissions": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectAddPermissions",
+        "summary": "给资源对象添加可透传的权限，权限必须预先存在",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ObjectAddPermissionsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/UpdatePermissions": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectUpdatePermissions",
+        "summary": "覆盖资源对象可透传的权限，权限必须预先存在，当 permissions 为空时会清空权限",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/RemovePermissions": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectRemovePermissions",
+        "summary": "移除资源对象可透传的权限",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/ListChildren": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectListChildren",
+        "summary": "列出资源对象的指定目标类型的子级资源对象",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/ListDescendant": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectListDescendant",
+        "summary": "列出资源对象的所有指定目标类型的子孙资源对象 depth 定义对 *tpl.TargetType 类型资源对象的递归查询深度，而不是指定 object 到 *tpl.TargetType 类型资源对象的深度，默认对 *tpl.TargetType 类型资源对象查到底",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/ListPermissions": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectListPermissions",
+        "summary": "列出资源对象可透传的权限",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/GetDAG": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectGetDAG",
+        "summary": "根据 start 和 ends 找出一个 DAG，其中 start 为 Object，ends 为 0 到多个 Object",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Object/Search": {
+      "post": {
+        "tags": [
+          "Object"
+        ],
+        "operationId": "ObjectSearch",
+        "summary": "根据关键词在资源对象的所有指定类型的子孙资源对象中进行搜索，term 为空不匹配任何资源对象",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/BatchAdd": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitBatchAdd",
+        "summary": "批量添加管理单元，当检测到将形成环时会返回 400 错误",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/TargetBatchAddInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/AddFromOrg": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitAddFromOrg",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/UnitAddFromOrgInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/AddFromOU": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitAddFromOU",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/UnitAddFromOUInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/AddFromMembers": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitAddFromMembers",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/UnitAddFromMembersInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/AssignParent": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitAssignParent",
+        "summary": "建立管理单元与父级管理单元的关系，当检测到将形成环时会返回 400 错误",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/UnitAssignParentInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/AssignScope": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitAssignScope",
+        "summary": "建立管理单元与范围约束的关系",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/UnitAssignScopeInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/AssignObject": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitAssignObject",
+        "summary": "建立管理单元与资源对象的关系",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/UnitAssignObjectInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/RemoveParent": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitRemoveParent",
+        "summary": "清除管理单元与父级对象的关系",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/RemoveScope": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitRemoveScope",
+        "summary": "清除管理单元与范围约束的关系",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/RemoveObject": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitRemoveObject",
+        "summary": "清除管理单元与资源对象的关系",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/Delete": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitDelete",
+        "summary": "删除管理单元及其所有子孙管理单元和链接关系",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/UpdateStatus": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitUpdateStatus",
+        "summary": "更新管理单元的状态，-1 表示停用",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/AddSubjects": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitAddSubjects",
+        "summary": "管理单元批量添加请求主体，当请求主体不存在时会自动创建，可通过 notBefore/notAfter 限定成员关系的有效期",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/UnitAddSubjectsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/RemoveSubjects": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitRemoveSubjects",
+        "summary": "管理单元批量移除请求主体",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/AddPermissions": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitAddPermissions",
+        "summary": "给管理单元添加权限，权限必须预先存在",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/UnitAddPermissionsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/UpdatePermissions": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitUpdatePermissions",
+        "summary": "覆盖管理单元的权限，权限必须预先存在，当 permissions 为空时会清空权限",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/RemovePermissions": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitRemovePermissions",
+        "summary": "移除管理单元的权限",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/AddRoles": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitAddRoles",
+        "summary": "给管理单元添加角色，角色必须预先存在",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/UnitRolesInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/RemoveRoles": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitRemoveRoles",
+        "summary": "移除管理单元的角色",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/UnitRolesInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/ListChildren": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitListChildren",
+        "summary": "列出管理单元的指定目标类型的子级管理单元，不包含 status 为 -1 的节点",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/ListDescendant": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitListDescendant",
+        "summary": "列出管理单元的指定目标类型的所有子孙管理单元，不包含 status 为 -1 的管理单元 depth 定义对 targetType 类型管理单元的递归查询深度，而不是指定 unit 到 targetType 类型管理单元的深度，默认对 targetType 类型管理单元查到底",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/ListPermissions": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitListPermissions",
+        "summary": "列出管理单元的直属权限",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/ListSubjects": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitListSubjects",
+        "summary": "列出管理单元的直属请求主体，不包含 status 为 -1 的请求主体",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/ListDescendantSubjects": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitListDescendantSubjects",
+        "summary": "列出管理单元及子孙管理单元下所有的请求主体，不包含 status 为 -1 的请求主体",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Unit/GetDAG": {
+      "post": {
+        "tags": [
+          "Unit"
+        ],
+        "operationId": "UnitGetDAG",
+        "summary": "根据 start 和 ends 找出一个 DAG，其中 start 可以为 Subject 或 Unit，ends 为 0 到多个 Unit，不包含 status 为 -1 的节点",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Scope/Add": {
+      "post": {
+        "tags": [
+          "Scope"
+        ],
+        "operationId": "ScopeAdd",
+        "summary": "创建范围约束",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Target"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Scope/Delete": {
+      "post": {
+        "tags": [
+          "Scope"
+        ],
+        "operationId": "ScopeDelete",
+        "summary": "删除范围约束",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Target"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Scope/DeleteAll": {
+      "post": {
+        "tags": [
+          "Scope"
+        ],
+        "operationId": "ScopeDeleteAll",
+        "summary": "删除范围约束及范围内的所有 Unit 和 Object",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Target"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Scope/UpdateStatus": {
+      "post": {
+        "tags": [
+          "Scope"
+        ],
+        "operationId": "ScopeUpdateStatus",
+        "summary": "更新范围约束的状态，-1 表示停用",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ScopeUpdateInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Scope/List": {
+      "post": {
+        "tags": [
+          "Scope"
+        ],
+        "operationId": "ScopeList",
+        "summary": "列出该系统当前所有指定目标类型的范围约束",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ScopeListInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Scope/ListUnits": {
+      "post": {
+        "tags": [
+          "Scope"
+        ],
+        "operationId": "ScopeListUnits",
+        "summary": "列出范围约束下指定目标类型的直属的管理单元",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ScopeListUnitObjectsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Scope/ListObjects": {
+      "post": {
+        "tags": [
+          "Scope"
+        ],
+        "operationId": "ScopeListObjects",
+        "summary": "列出范围约束下指定目标类型的直属的资源对象",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ScopeListUnitObjectsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Permission/BatchAdd": {
+      "post": {
+        "tags": [
+          "Permission"
+        ],
+        "operationId": "PermissionBatchAdd",
+        "summary": "批量添加权限",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/PermissionBatchAddInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Permission/List": {
+      "post": {
+        "tags": [
+          "Permission"
+        ],
+        "operationId": "PermissionList",
+        "summary": "列出该系统当前指定资源类型的权限，当 resource 为空时列出所有权限",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/PermissionListInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Permission/Delete": {
+      "post": {
+        "tags": [
+          "Permission"
+        ],
+        "operationId": "PermissionDelete",
+        "summary": "删除权限",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/PermissionDeleteInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Permission/Usage": {
+      "post": {
+        "tags": [
+          "Permission"
+        ],
+        "operationId": "PermissionUsage",
+        "summary": "统计引用权限的管理单元、资源对象和角色数量",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/PermissionInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Permission/Rename": {
+      "post": {
+        "tags": [
+          "Permission"
+        ],
+        "operationId": "PermissionRename",
+        "summary": "重命名权限，所有引用关系及其 facets 保持不变",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/PermissionMigrateInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Permission/Merge": {
+      "post": {
+        "tags": [
+          "Permission"
+        ],
+        "operationId": "PermissionMerge",
+        "summary": "将权限 from 合并到已存在的权限 to，并删除 from",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/PermissionMigrateInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Role/Add": {
+      "post": {
+        "tags": [
+          "Role"
+        ],
+        "operationId": "RoleAdd",
+        "summary": "创建角色，权限必须预先存在",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/RoleAddInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Role/Get": {
+      "post": {
+        "tags": [
+          "Role"
+        ],
+        "operationId": "RoleGet",
+        "summary": "获取角色及其权限",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/RoleInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Role/Update": {
+      "post": {
+        "tags": [
+          "Role"
+        ],
+        "operationId": "RoleUpdate",
+        "summary": "覆盖角色的权限，权限必须预先存在，当 permissions 为空时会清空权限",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/RoleAddInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Role/Delete": {
+      "post": {
+        "tags": [
+          "Role"
+        ],
+        "operationId": "RoleDelete",
+        "summary": "删除角色，并解除所有管理单元与该角色的关系",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/RoleInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Role/List": {
+      "post": {
+        "tags": [
+          "Role"
+        ],
+        "operationId": "RoleList",
+        "summary": "列出该系统当前所有角色",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/RoleListInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/graphql": {
+      "post": {
+        "tags": [
+          "GraphQL"
+        ],
+        "operationId": "GraphQLQuery",
+        "summary": "租户范围的只读 GraphQL 查询，查询无效时返回 400 和 GraphQL 格式的 errors",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/GraphQLInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/GraphQLResponse"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Watch": {
+      "post": {
+        "tags": [
+          "Watch"
+        ],
+        "operationId": "WatchWatch",
+        "summary": "长轮询租户的变更事件，从 revision 之后的事件开始返回，revision 过期时返回 410 错误，需要重新全量同步",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/WatchInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Webhook/Add": {
+      "post": {
+        "tags": [
+          "Webhook"
+        ],
+        "operationId": "WebhookAdd",
+        "summary": "注册 webhook，secret 为空时自动生成，只在本次返回",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/WebhookAddInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Webhook/Update": {
+      "post": {
+        "tags": [
+          "Webhook"
+        ],
+        "operationId": "WebhookUpdate",
+        "summary": "更新 webhook 的事件类型、签名密钥或状态",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/WebhookUpdateInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Webhook/Delete": {
+      "post": {
+        "tags": [
+          "Webhook"
+        ],
+        "operationId": "WebhookDelete",
+        "summary": "删除 webhook 及其死信",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/WebhookInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Webhook/List": {
+      "post": {
+        "tags": [
+          "Webhook"
+        ],
+        "operationId": "WebhookList",
+        "summary": "列出租户的 webhook，不返回签名密钥",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/WebhookListInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Webhook/ListDeadLetters": {
+      "post": {
+        "tags": [
+          "Webhook"
+        ],
+        "operationId": "WebhookListDeadLetters",
+        "summary": "列出重试后仍投递失败的事件",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/WebhookDeadLetterListInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Audit/List": {
+      "post": {
+        "tags": [
+          "Audit"
+        ],
+        "operationId": "AuditList",
+        "summary": "列出租户的审计记录，可以按涉及的节点、写操作、操作者和时间过滤",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/AuditListInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "tenant": []
+          }
+        ]
+      }
+    },
+    "/Admin/AddTenant": {
+      "post": {
+        "tags": [
+          "Admin"
+        ],
+        "operationId": "AdminAddTenant",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/TenantAddInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Admin/UpdateTenantStatus": {
+      "post": {
+        "tags": [
+          "Admin"
+        ],
+        "operationId": "AdminUpdateTenantStatus",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/TenantAddInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Admin/DeleteTenant": {
+      "post": {
+        "tags": [
+          "Admin"
+        ],
+        "operationId": "AdminDeleteTenant",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/TenantAddInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Admin/GetDeleteTenantJob": {
+      "post": {
+        "tags": [
+          "Admin"
+        ],
+        "operationId": "AdminGetDeleteTenantJob",
+        "summary": "获取删除租户任务的状态",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/TenantAddInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Admin/CloneTenant": {
+      "post": {
+        "tags": [
+          "Admin"
+        ],
+        "operationId": "AdminCloneTenant",
+        "summary": "启动复制租户的后台任务",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/TenantCloneInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Admin/GetCloneTenantJob": {
+      "post": {
+        "tags": [
+          "Admin"
+        ],
+        "operationId": "AdminGetCloneTenantJob",
+        "summary": "获取复制租户任务的状态",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/TenantAddInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Admin/ListTenants": {
+      "post": {
+        "tags": [
+          "Admin"
+        ],
+        "operationId": "AdminListTenants",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Pagination"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Admin/ExportTenant": {
+      "post": {
+        "tags": [
+          "Admin"
+        ],
+        "operationId": "AdminExportTenant",
+        "summary": "以 NDJSON 流的形式导出租户快照",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/TenantAddInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/x-ndjson": {
+                "schema": {
+                  "type": "string"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Admin/ImportTenant": {
+      "post": {
+        "tags": [
+          "Admin"
+        ],
+        "operationId": "AdminImportTenant",
+        "summary": "导入 NDJSON 格式的租户快照，请求体为 ExportTenant 的输出",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/x-ndjson": {
+              "schema": {
+                "type": "string",
+                "description": "每行一个 tpl.SnapshotRecord"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Admin/BatchAddSubjects": {
+      "post": {
+        "tags": [
+          "Admin"
+        ],
+        "operationId": "AdminBatchAddSubjects",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/SubjectsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Admin/UpdateSubjectStatus": {
+      "post": {
+        "tags": [
+          "Admin"
+        ],
+        "operationId": "AdminUpdateSubjectStatus",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/SubjectUpdateInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Admin/ListSubjects": {
+      "post": {
+        "tags": [
+          "Admin"
+        ],
+        "operationId": "AdminListSubjects",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Pagination"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/AddOrg": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationAddOrg",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/UpdateOrgStatus": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationUpdateOrgStatus",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationStatusInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/DeleteOrg": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationDeleteOrg",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/ListOrgs": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationListOrgs",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Pagination"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/ListSubjectOrgs": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationListSubjectOrgs",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationListSubjectOrgsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/AddOU": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationAddOU",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationAddOUInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/UpdateOUParent": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationUpdateOUParent",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationUpdateOUParentInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/UpdateOUStatus": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationUpdateOUStatus",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/UpdateOUTerms": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationUpdateOUTerms",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/DeleteOU": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationDeleteOU",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/ListOUs": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationListOUs",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationListOUsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/ListSubjectOUs": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationListSubjectOUs",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationListSubjectOUsInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/SearchOUs": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationSearchOUs",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationSearchInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/BatchAddMember": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationBatchAddMember",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationBatchAddMemberInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/UpdateMemberStatus": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationUpdateMemberStatus",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/UpdateMemberTerms": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationUpdateMemberTerms",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/RemoveMember": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationRemoveMember",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/ListMembers": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationListMembers",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationListInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/SearchMember": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationSearchMember",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationSearchInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/BatchAddOUMember": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationBatchAddOUMember",
+        "parameters": [
+          {
+            "name": "Prefer",
+            "in": "header",
+            "description": "respond-conflict：写入的资源已存在时响应 409；respond-detail：访问控制检查返回授予权限的详情",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationBatchAddOUMemberInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/RemoveOUMember": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationRemoveOUMember",
+        "description": "尚未实现，当前总是返回空响应。",
+        "x-unimplemented": true,
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/ListOUMembers": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationListOUMembers",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationListOUMembersInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    },
+    "/Organization/ListOUDescendantMembers": {
+      "post": {
+        "tags": [
+          "Organization"
+        ],
+        "operationId": "OrganizationListOUDescendantMembers",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/OrganizationListOUMembersInput"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/SuccessResponseType"
+                }
+              }
+            }
+          },
+          "default": {
+            "description": "Error",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorResponseType"
+                }
+              }
+            }
+          }
+        },
+        "security": [
+          {
+            "admin": []
+          }
+        ]
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "ACCheckPermissionsInput": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          },
+          "permissions": {
+            "type": "array",
+            "items": {
+              "type": "string",
+              "minLength": 1,
+              "pattern": "^[A-Za-z]{2,32}(\\.[0-9A-Za-z]{2,32})*(\\.\\*)?$"
+            },
+            "minItems": 1
+          },
+          "subject": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "withOrganization": {
+            "type": "boolean"
+          },
+          "ignoreScope": {
+            "type": "boolean",
+            "description": "仅对 Object 权限检查有效"
+          }
+        },
+        "required": [
+          "targetType",
+          "permissions",
+          "subject"
+        ]
+      },
+      "ACListPermissionsInput": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          },
+          "resources": {
+            "type": "array",
+            "items": {
+              "type": "string",
+              "minLength": 1,
+              "pattern": "^[A-Za-z]{2,32}$"
+            }
+          },
+          "subject": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "withOrganization": {
+            "type": "boolean"
+          },
+          "ignoreScope": {
+            "type": "boolean",
+            "description": "仅对 Object 权限列表有效"
+          }
+        },
+        "required": [
+          "targetType",
+          "subject"
+        ]
+      },
+      "AuditListInput": {
+        "type": "object",
+        "properties": {
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          },
+          "target": {
+            "type": "string",
+            "description": "只列出涉及该节点的记录，如 unit:Dept:d1",
+            "maxLength": 1024
+          },
+          "op": {
+            "type": "string",
+            "description": "只列出该写操作的记录，如 Role.Update"
+          },
+          "actor": {
+            "type": "string",
+            "description": "只列出该操作者的记录"
+          },
+          "since": {
+            "type": "string",
+            "format": "date-time"
+          },
+          "until": {
+            "type": "string",
+            "format": "date-time"
+          }
+        }
+      },
+      "ErrorResponseType": {
+        "type": "object",
+        "properties": {
+          "error": {
+            "type": "object",
+            "properties": {
+              "code": {
+                "type": "integer"
+              },
+              "status": {
+                "type": "string"
+              },
+              "message": {
+                "type": "string"
+              },
+              "data": {}
+            },
+            "required": [
+              "code",
+              "status",
+              "message"
+            ]
+          }
+        },
+        "required": [
+          "error"
+        ]
+      },
+      "Extensions": {
+        "type": "object",
+        "additionalProperties": {}
+      },
+      "GraphQLInput": {
+        "type": "object",
+        "properties": {
+          "query": {
+            "type": "string",
+            "minLength": 1,
+            "maxLength": 65536
+          },
+          "operationName": {
+            "type": "string"
+          },
+          "variables": {
+            "type": "object",
+            "additionalProperties": {}
+          }
+        },
+        "required": [
+          "query"
+        ],
+        "description": "GraphQL 请求，格式与 GraphQL over HTTP 的 POST 请求一致"
+      },
+      "GraphQLResponse": {
+        "type": "object",
+        "properties": {
+          "data": {
+            "type": "object"
+          },
+          "errors": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {
+                "message": {
+                  "type": "string"
+                },
+                "locations": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "properties": {
+                      "line": {
+                        "type": "integer"
+                      },
+                      "column": {
+                        "type": "integer"
+                      }
+                    }
+                  }
+                }
+              },
+              "required": [
+                "message"
+              ]
+            }
+          }
+        }
+      },
+      "ObjectAddPermissionsInput": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          },
+          "permissions": {
+            "type": "array",
+            "items": {
+              "type": "string",
+              "minLength": 1,
+              "pattern": "^[A-Za-z]{2,32}(\\.[0-9A-Za-z]{2,32})*(\\.\\*)?$"
+            },
+            "minItems": 1,
+            "maxItems": 100
+          }
+        },
+        "required": [
+          "targetType",
+          "permissions"
+        ]
+      },
+      "OrganizationAddOUInput": {
+        "type": "object",
+        "properties": {
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "ou": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "parent": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "terms": {
+            "type": "string",
+            "minLength": 3,
+            "maxLength": 1024
+          }
+        },
+        "required": [
+          "organization",
+          "ou"
+        ]
+      },
+      "OrganizationBatchAddMemberInput": {
+        "type": "object",
+        "properties": {
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "subjects": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {
+                "uid": {
+                  "type": "string"
+                },
+                "status": {
+                  "type": "integer"
+                },
+                "subject": {
+                  "type": "string"
+                },
+                "terms": {
+                  "type": "string"
+                }
+              }
+            },
+            "minItems": 1,
+            "maxItems": 1000
+          }
+        },
+        "required": [
+          "organization",
+          "subjects"
+        ]
+      },
+      "OrganizationBatchAddOUMemberInput": {
+        "type": "object",
+        "properties": {
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "ou": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "subjects": {
+            "type": "array",
+            "items": {
+              "type": "string",
+              "minLength": 1,
+              "format": "otid-subject"
+            },
+            "minItems": 1,
+            "maxItems": 1000
+          }
+        },
+        "required": [
+          "organization",
+          "ou",
+          "subjects"
+        ]
+      },
+      "OrganizationInput": {
+        "type": "object",
+        "properties": {
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          }
+        },
+        "required": [
+          "organization"
+        ]
+      },
+      "OrganizationListInput": {
+        "type": "object",
+        "properties": {
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          }
+        },
+        "required": [
+          "organization"
+        ]
+      },
+      "OrganizationListOUMembersInput": {
+        "type": "object",
+        "properties": {
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          },
+          "ou": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          }
+        },
+        "required": [
+          "organization",
+          "ou"
+        ]
+      },
+      "OrganizationListOUsInput": {
+        "type": "object",
+        "properties": {
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          },
+          "parent": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          }
+        },
+        "required": [
+          "organization"
+        ]
+      },
+      "OrganizationListSubjectOUsInput": {
+        "type": "object",
+        "properties": {
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          },
+          "subject": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          }
+        },
+        "required": [
+          "subject",
+          "organization"
+        ]
+      },
+      "OrganizationListSubjectOrgsInput": {
+        "type": "object",
+        "properties": {
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          },
+          "subject": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          }
+        },
+        "required": [
+          "subject"
+        ]
+      },
+      "OrganizationSearchInput": {
+        "type": "object",
+        "properties": {
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          },
+          "term": {
+            "type": "string",
+            "minLength": 3
+          }
+        },
+        "required": [
+          "organization"
+        ]
+      },
+      "OrganizationStatusInput": {
+        "type": "object",
+        "properties": {
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "status": {
+            "type": "integer",
+            "minimum": -1
+          }
+        },
+        "required": [
+          "organization"
+        ]
+      },
+      "OrganizationUpdateOUParentInput": {
+        "type": "object",
+        "properties": {
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "ou": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "parent": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          }
+        },
+        "required": [
+          "organization",
+          "ou",
+          "parent"
+        ]
+      },
+      "Pagination": {
+        "type": "object",
+        "properties": {
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          }
+        }
+      },
+      "Permission": {
+        "oneOf": [
+          {
+            "type": "string"
+          },
+          {
+            "type": "object",
+            "properties": {
+              "uid": {
+                "type": "string"
+              },
+              "permission": {
+                "type": "string",
+                "minLength": 1,
+                "pattern": "^[A-Za-z]{2,32}(\\.[0-9A-Za-z]{2,32})*(\\.\\*)?$"
+              },
+              "name": {
+                "type": "string",
+                "description": "展示名称",
+                "maxLength": 64
+              },
+              "description": {
+                "type": "string",
+                "description": "描述",
+                "maxLength": 1024
+              },
+              "deprecated": {
+                "type": "boolean",
+                "description": "是否已废弃，废弃的权限仍然有效，仅用于提示"
+              },
+              "implies": {
+                "type": "array",
+                "items": {
+                  "type": "string",
+                  "minLength": 1,
+                  "pattern": "^[A-Za-z]{2,32}(\\.[0-9A-Za-z]{2,32})*(\\.\\*)?$"
+                },
+                "description": "蕴含的权限，如 \"Doc.edit\" 蕴含 \"Doc.read\"",
+                "maxItems": 100
+              }
+            },
+            "required": [
+              "permission"
+            ]
+          }
+        ],
+        "description": "兼容字符串形式的权限，如 \"Doc.read\"，对象形式的权限会被标记为带有元数据"
+      },
+      "PermissionBatchAddInput": {
+        "type": "object",
+        "properties": {
+          "permissions": {
+            "type": "array",
+            "items": {
+              "$ref": "#/components/schemas/Permission"
+            },
+            "minItems": 1
+          }
+        },
+        "required": [
+          "permissions"
+        ]
+      },
+      "PermissionDeleteInput": {
+        "type": "object",
+        "properties": {
+          "permission": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}(\\.[0-9A-Za-z]{2,32})*(\\.\\*)?$"
+          },
+          "force": {
+            "type": "boolean",
+            "description": "为 true 时解除所有引用关系后删除权限"
+          }
+        },
+        "required": [
+          "permission"
+        ]
+      },
+      "PermissionEx": {
+        "type": "object",
+        "properties": {
+          "permission": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}(\\.[0-9A-Za-z]{2,32})*(\\.\\*)?$"
+          },
+          "extensions": {
+            "$ref": "#/components/schemas/Extensions"
+          },
+          "notBefore": {
+            "type": "string",
+            "format": "date-time"
+          },
+          "notAfter": {
+            "type": "string",
+            "format": "date-time"
+          }
+        },
+        "required": [
+          "permission"
+        ]
+      },
+      "PermissionInput": {
+        "type": "object",
+        "properties": {
+          "permission": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}(\\.[0-9A-Za-z]{2,32})*(\\.\\*)?$"
+          }
+        },
+        "required": [
+          "permission"
+        ]
+      },
+      "PermissionListInput": {
+        "type": "object",
+        "properties": {
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          },
+          "resources": {
+            "type": "array",
+            "items": {
+              "type": "string",
+              "minLength": 1,
+              "pattern": "^[A-Za-z]{2,32}$"
+            }
+          }
+        }
+      },
+      "PermissionMigrateInput": {
+        "type": "object",
+        "properties": {
+          "from": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}(\\.[0-9A-Za-z]{2,32})*(\\.\\*)?$"
+          },
+          "to": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}(\\.[0-9A-Za-z]{2,32})*(\\.\\*)?$"
+          }
+        },
+        "required": [
+          "from",
+          "to"
+        ]
+      },
+      "RoleAddInput": {
+        "type": "object",
+        "properties": {
+          "role": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[0-9A-Za-z][0-9A-Za-z_-]{1,63}$"
+          },
+          "permissions": {
+            "type": "array",
+            "items": {
+              "type": "string",
+              "minLength": 1,
+              "pattern": "^[A-Za-z]{2,32}(\\.[0-9A-Za-z]{2,32})*(\\.\\*)?$"
+            },
+            "maxItems": 1000
+          }
+        },
+        "required": [
+          "role"
+        ]
+      },
+      "RoleInput": {
+        "type": "object",
+        "properties": {
+          "role": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[0-9A-Za-z][0-9A-Za-z_-]{1,63}$"
+          }
+        },
+        "required": [
+          "role"
+        ]
+      },
+      "RoleListInput": {
+        "type": "object",
+        "properties": {
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          }
+        }
+      },
+      "ScopeListInput": {
+        "type": "object",
+        "properties": {
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          },
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          }
+        },
+        "required": [
+          "targetType"
+        ]
+      },
+      "ScopeListUnitObjectsInput": {
+        "type": "object",
+        "properties": {
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          },
+          "scope": {
+            "$ref": "#/components/schemas/Target"
+          },
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          }
+        },
+        "required": [
+          "scope",
+          "targetType"
+        ]
+      },
+      "ScopeUpdateInput": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          },
+          "status": {
+            "type": "integer",
+            "minimum": -1
+          }
+        },
+        "required": [
+          "targetType"
+        ]
+      },
+      "SubjectUpdateInput": {
+        "type": "object",
+        "properties": {
+          "subject": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "status": {
+            "type": "integer",
+            "minimum": -1
+          }
+        },
+        "required": [
+          "subject"
+        ]
+      },
+      "SubjectsInput": {
+        "type": "object",
+        "properties": {
+          "subjects": {
+            "type": "array",
+            "items": {
+              "type": "string",
+              "minLength": 1,
+              "format": "otid-subject"
+            },
+            "minItems": 1,
+            "maxItems": 1000
+          }
+        },
+        "required": [
+          "subjects"
+        ]
+      },
+      "SuccessResponseType": {
+        "type": "object",
+        "properties": {
+          "totalCount": {
+            "type": "integer"
+          },
+          "nextToken": {
+            "type": "string"
+          },
+          "result": {}
+        },
+        "description": "定义了标准的 API 接口成功时返回数据模型"
+      },
+      "Target": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          }
+        },
+        "required": [
+          "targetType"
+        ]
+      },
+      "TargetBatchAddInput": {
+        "type": "object",
+        "properties": {
+          "targets": {
+            "type": "array",
+            "items": {
+              "$ref": "#/components/schemas/Target"
+            },
+            "minItems": 1,
+            "maxItems": 1000
+          },
+          "parent": {
+            "$ref": "#/components/schemas/Target"
+          },
+          "scope": {
+            "$ref": "#/components/schemas/Target"
+          }
+        },
+        "required": [
+          "targets"
+        ]
+      },
+      "TenantAddInput": {
+        "type": "object",
+        "properties": {
+          "tenant": {
+            "type": "string",
+            "format": "otid"
+          },
+          "status": {
+            "type": "integer",
+            "minimum": -1
+          }
+        }
+      },
+      "TenantCloneInput": {
+        "type": "object",
+        "properties": {
+          "source": {
+            "type": "string",
+            "format": "otid"
+          },
+          "target": {
+            "type": "string",
+            "format": "otid"
+          },
+          "withSubjects": {
+            "type": "boolean",
+            "description": "是否复制管理单元的请求主体、组织、OU 和组织成员关系"
+          },
+          "subjectMapping": {
+            "type": "object",
+            "additionalProperties": {
+              "type": "string"
+            },
+            "description": "复制请求主体关系时改写请求主体，映射为空字符串的请求主体不复制"
+          }
+        }
+      },
+      "UnitAddFromMembersInput": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          },
+          "parent": {
+            "$ref": "#/components/schemas/Target"
+          },
+          "scope": {
+            "$ref": "#/components/schemas/Target"
+          },
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "subjects": {
+            "type": "array",
+            "items": {
+              "type": "string",
+              "minLength": 1,
+              "format": "otid-subject"
+            },
+            "minItems": 1,
+            "maxItems": 1000
+          }
+        },
+        "required": [
+          "targetType",
+          "organization",
+          "subjects"
+        ]
+      },
+      "UnitAddFromOUInput": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          },
+          "parent": {
+            "$ref": "#/components/schemas/Target"
+          },
+          "scope": {
+            "$ref": "#/components/schemas/Target"
+          },
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          },
+          "ou": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          }
+        },
+        "required": [
+          "targetType",
+          "organization",
+          "ou"
+        ]
+      },
+      "UnitAddFromOrgInput": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          },
+          "parent": {
+            "$ref": "#/components/schemas/Target"
+          },
+          "scope": {
+            "$ref": "#/components/schemas/Target"
+          },
+          "organization": {
+            "type": "string",
+            "minLength": 1,
+            "format": "otid-subject"
+          }
+        },
+        "required": [
+          "targetType",
+          "organization"
+        ]
+      },
+      "UnitAddPermissionsInput": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          },
+          "permissions": {
+            "type": "array",
+            "items": {
+              "$ref": "#/components/schemas/PermissionEx"
+            },
+            "minItems": 1,
+            "maxItems": 1000
+          }
+        },
+        "required": [
+          "targetType",
+          "permissions"
+        ]
+      },
+      "UnitAddSubjectsInput": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          },
+          "subjects": {
+            "type": "array",
+            "items": {
+              "type": "string",
+              "minLength": 1,
+              "format": "otid-subject"
+            },
+            "minItems": 1,
+            "maxItems": 1000
+          },
+          "notBefore": {
+            "type": "string",
+            "format": "date-time"
+          },
+          "notAfter": {
+            "type": "string",
+            "format": "date-time"
+          }
+        },
+        "required": [
+          "targetType",
+          "subjects"
+        ]
+      },
+      "UnitAssignObjectInput": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          },
+          "object": {
+            "$ref": "#/components/schemas/Target"
+          }
+        },
+        "required": [
+          "targetType",
+          "object"
+        ]
+      },
+      "UnitAssignParentInput": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          },
+          "parent": {
+            "$ref": "#/components/schemas/Target"
+          }
+        },
+        "required": [
+          "targetType",
+          "parent"
+        ]
+      },
+      "UnitAssignScopeInput": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          },
+          "scope": {
+            "$ref": "#/components/schemas/Target"
+          }
+        },
+        "required": [
+          "targetType",
+          "scope"
+        ]
+      },
+      "UnitRolesInput": {
+        "type": "object",
+        "properties": {
+          "targetType": {
+            "type": "string",
+            "minLength": 1,
+            "pattern": "^[A-Za-z]{2,32}$"
+          },
+          "targetId": {
+            "type": "string"
+          },
+          "roles": {
+            "type": "array",
+            "items": {
+              "type": "string",
+              "minLength": 1,
+              "pattern": "^[0-9A-Za-z][0-9A-Za-z_-]{1,63}$"
+            },
+            "minItems": 1,
+            "maxItems": 100
+          }
+        },
+        "required": [
+          "targetType",
+          "roles"
+        ]
+      },
+      "WatchInput": {
+        "type": "object",
+        "properties": {
+          "revision": {
+            "type": "integer",
+            "format": "int64",
+            "description": "从该 revision 之后的事件开始返回，为 0 时不等待，直接返回当前的 revision",
+            "minimum": 0
+          },
+          "timeout": {
+            "type": "integer",
+            "description": "没有新事件时最多等待的秒数，默认 30，最大 60，为 0 时使用默认值",
+            "minimum": 0,
+            "maximum": 60
+          },
+          "limit": {
+            "type": "integer",
+            "description": "每次最多返回的事件数，默认 100，最大 1000",
+            "minimum": 0,
+            "maximum": 1000
+          }
+        }
+      },
+      "WebhookAddInput": {
+        "type": "object",
+        "properties": {
+          "url": {
+            "type": "string",
+            "minLength": 1,
+            "maxLength": 2048
+          },
+          "events": {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "minItems": 1,
+            "maxItems": 100
+          },
+          "secret": {
+            "type": "string",
+            "description": "签名密钥，为空时自动生成"
+          }
+        },
+        "required": [
+          "url",
+          "events"
+        ]
+      },
+      "WebhookDeadLetterListInput": {
+        "type": "object",
+        "properties": {
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          },
+          "url": {
+            "type": "string",
+            "description": "为空时列出所有 webhook 的死信",
+            "minLength": 1,
+            "maxLength": 2048
+          }
+        }
+      },
+      "WebhookInput": {
+        "type": "object",
+        "properties": {
+          "url": {
+            "type": "string",
+            "minLength": 1,
+            "maxLength": 2048
+          }
+        },
+        "required": [
+          "url"
+        ]
+      },
+      "WebhookListInput": {
+        "type": "object",
+        "properties": {
+          "pageToken": {
+            "type": "string",
+            "pattern": "^0x[0-9a-f]{1,16}$"
+          },
+          "pageSize": {
+            "type": "integer",
+            "maximum": 1000
+          },
+          "skip": {
+            "type": "integer"
+          }
+        }
+      },
+      "WebhookUpdateInput": {
+        "type": "object",
+        "properties": {
+          "url": {
+            "type": "string",
+            "minLength": 1,
+            "maxLength": 2048
+          },
+          "events": {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "description": "为空时不更新",
+            "minItems": 1,
+            "maxItems": 100
+          },
+          "secret": {
+            "type": "string",
+            "description": "为空时不更新"
+          },
+          "status": {
+            "type": "integer",
+            "description": "为空时不更新，-1 表示停用"
+          }
+        },
+        "required": [
+          "url"
+        ]
+      }
+    },
+    "securitySchemes": {
+      "tenant": {
+        "type": "http",
+        "scheme": "bearer",
+        "bearerFormat": "OTVID",
+        "description": "租户的 OTVID，由信任域签发，aud 为 ot-ac 的 OTID"
+      },
+      "admin": {
+        "type": "http",
+        "scheme": "bearer",
+        "bearerFormat": "OTVID",
+        "description": "ot-ac 自身 OTID 的 OTVID"
+      }
+    }
+  }
+}`
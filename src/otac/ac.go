@@ -0,0 +1,75 @@
+package otac
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// AC 访问控制查询，需要租户身份
+type AC struct {
+	c *Client
+}
+
+// CheckUnit 检查请求主体到指定管理单元有没有指定权限
+func (s *AC) CheckUnit(ctx context.Context, input tpl.ACCheckPermissionsInput) (bool, error) {
+	return s.check(ctx, "/AC/CheckUnit", input)
+}
+
+// CheckUnitDetail 同 CheckUnit，返回授予权限的详情，没有权限时为空
+func (s *AC) CheckUnitDetail(ctx context.Context, input tpl.ACCheckPermissionsInput) ([]tpl.ACPermissionPayload, error) {
+	return s.checkDetail(ctx, "/AC/CheckUnit", input)
+}
+
+// CheckScope 检查请求主体到指定范围约束有没有指定权限
+func (s *AC) CheckScope(ctx context.Context, input tpl.ACCheckPermissionsInput) (bool, error) {
+	return s.check(ctx, "/AC/CheckScope", input)
+}
+
+// CheckScopeDetail 同 CheckScope，返回授予权限的详情，没有权限时为空
+func (s *AC) CheckScopeDetail(ctx context.Context, input tpl.ACCheckPermissionsInput) ([]tpl.ACPermissionPayload, error) {
+	return s.checkDetail(ctx, "/AC/CheckScope", input)
+}
+
+// CheckObject 检查请求主体通过 Scope 或 Unit-Object 的连接关系到指定资源对象有没有指定权限
+func (s *AC) CheckObject(ctx context.Context, input tpl.ACCheckPermissionsInput) (bool, error) {
+	return s.check(ctx, "/AC/CheckObject", input)
+}
+
+// CheckObjectDetail 同 CheckObject，返回授予权限的详情，没有权限时为空
+func (s *AC) CheckObjectDetail(ctx context.Context, input tpl.ACCheckPermissionsInput) ([]tpl.ACPermissionPayload, error) {
+	return s.checkDetail(ctx, "/AC/CheckObject", input)
+}
+
+// ListPermissionsByUnit 列出请求主体到指定管理单元的有效权限，未指定管理单元时列出请求主体能触达的所有管理单元的权限
+func (s *AC) ListPermissionsByUnit(ctx context.Context, input tpl.ACListPermissionsInput) ([]tpl.ACPermissionPayload, error) {
+	return s.listPermissions(ctx, "/AC/ListPermissionsByUnit", input)
+}
+
+// ListPermissionsByScope 列出请求主体到指定范围约束的有效权限
+func (s *AC) ListPermissionsByScope(ctx context.Context, input tpl.ACListPermissionsInput) ([]tpl.ACPermissionPayload, error) {
+	return s.listPermissions(ctx, "/AC/ListPermissionsByScope", input)
+}
+
+// ListPermissionsByObject 列出请求主体到指定资源对象的有效权限
+func (s *AC) ListPermissionsByObject(ctx context.Context, input tpl.ACListPermissionsInput) ([]tpl.ACPermissionPayload, error) {
+	return s.listPermissions(ctx, "/AC/ListPermissionsByObject", input)
+}
+
+func (s *AC) check(ctx context.Context, path string, input tpl.ACCheckPermissionsInput) (bool, error) {
+	var ok bool
+	_, err := s.c.Do(ctx, path, input, &ok)
+	return ok, err
+}
+
+func (s *AC) checkDetail(ctx context.Context, path string, input tpl.ACCheckPermissionsInput) ([]tpl.ACPermissionPayload, error) {
+	res := make([]tpl.ACPermissionPayload, 0)
+	_, err := s.c.Do(WithPrefer(ctx, PreferRespondDetail), path, input, &res)
+	return res, err
+}
+
+func (s *AC) listPermissions(ctx context.Context, path string, input tpl.ACListPermissionsInput) ([]tpl.ACPermissionPayload, error) {
+	res := make([]tpl.ACPermissionPayload, 0)
+	_, err := s.c.Do(ctx, path, input, &res)
+	return res, err
+}
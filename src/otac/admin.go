@@ -0,0 +1,134 @@
+package otac
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+	otgo "github.com/open-trust/ot-go-lib"
+)
+
+// Admin 租户和请求主体管理，需要管理员身份
+type Admin struct {
+	c *Client
+}
+
+// AddTenant 添加租户，返回是否新添加
+func (s *Admin) AddTenant(ctx context.Context, tenant otgo.OTID) (bool, error) {
+	var ok bool
+	_, err := s.c.Do(ctx, "/Admin/AddTenant", tpl.TenantAddInput{Tenant: tenant}, &ok)
+	return ok, err
+}
+
+// UpdateTenantStatus 更新租户状态，-1 表示停用
+func (s *Admin) UpdateTenantStatus(ctx context.Context, input tpl.TenantAddInput) (*tpl.Tenant, error) {
+	res := &tpl.Tenant{}
+	if _, err := s.c.Do(ctx, "/Admin/UpdateTenantStatus", input, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteTenant 启动删除租户的后台任务，返回任务状态；租户已不存在且没有删除任务时返回 nil
+func (s *Admin) DeleteTenant(ctx context.Context, tenant otgo.OTID) (*tpl.Job, error) {
+	return s.job(ctx, "/Admin/DeleteTenant", tpl.TenantAddInput{Tenant: tenant})
+}
+
+// GetDeleteTenantJob 获取删除租户任务的状态
+func (s *Admin) GetDeleteTenantJob(ctx context.Context, tenant otgo.OTID) (*tpl.Job, error) {
+	return s.job(ctx, "/Admin/GetDeleteTenantJob", tpl.TenantAddInput{Tenant: tenant})
+}
+
+// CloneTenant 启动复制租户的后台任务，返回任务状态
+func (s *Admin) CloneTenant(ctx context.Context, input tpl.TenantCloneInput) (*tpl.Job, error) {
+	return s.job(ctx, "/Admin/CloneTenant", input)
+}
+
+// GetCloneTenantJob 获取复制租户任务的状态，tenant 为目标租户
+func (s *Admin) GetCloneTenantJob(ctx context.Context, tenant otgo.OTID) (*tpl.Job, error) {
+	return s.job(ctx, "/Admin/GetCloneTenantJob", tpl.TenantAddInput{Tenant: tenant})
+}
+
+func (s *Admin) job(ctx context.Context, path string, input interface{}) (*tpl.Job, error) {
+	var raw json.RawMessage
+	if _, err := s.c.Do(ctx, path, input, &raw); err != nil {
+		return nil, err
+	}
+	// 租户已被删除时 DeleteTenant 的结果为 true
+	if bytes.Equal(raw, []byte("true")) {
+		return nil, nil
+	}
+	job := &tpl.Job{}
+	if err := json.Unmarshal(raw, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ExportTenant 导出 NDJSON 格式的租户快照，调用方负责关闭返回的 ReadCloser。
+// 快照可能很大，建议使用没有超时的 Options.HTTPClient，通过 ctx 控制超时
+func (s *Admin) ExportTenant(ctx context.Context, tenant otgo.OTID) (io.ReadCloser, error) {
+	body, err := json.Marshal(tpl.TenantAddInput{Tenant: tenant})
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.c.open(ctx, http.MethodPost, "/Admin/ExportTenant", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= 300 {
+		defer res.Body.Close()
+		data, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, decode(&rawResponse{status: res.StatusCode, body: data}, nil)
+	}
+	return res.Body, nil
+}
+
+// ImportTenant 导入 ExportTenant 导出的快照，r 只能读取一次，因此不会重试
+func (s *Admin) ImportTenant(ctx context.Context, r io.Reader) (*tpl.SnapshotImportOutput, error) {
+	raw, err := s.c.send(ctx, http.MethodPost, "/Admin/ImportTenant", "application/x-ndjson", r)
+	if err != nil {
+		return nil, err
+	}
+	res := &tpl.SnapshotImportOutput{}
+	if err := decode(raw, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListTenants 列出租户，返回 nextToken
+func (s *Admin) ListTenants(ctx context.Context, pg tpl.Pagination) ([]tpl.Tenant, string, error) {
+	res := make([]tpl.Tenant, 0)
+	next, err := s.c.Do(ctx, "/Admin/ListTenants", pg, &res)
+	return res, next, err
+}
+
+// BatchAddSubjects 批量添加请求主体
+func (s *Admin) BatchAddSubjects(ctx context.Context, subjects []string) error {
+	_, err := s.c.Do(ctx, "/Admin/BatchAddSubjects", tpl.SubjectsInput{Subjects: subjects}, nil)
+	return err
+}
+
+// UpdateSubjectStatus 更新请求主体状态，-1 表示停用
+func (s *Admin) UpdateSubjectStatus(ctx context.Context, input tpl.SubjectUpdateInput) (*tpl.Subject, error) {
+	res := &tpl.Subject{}
+	if _, err := s.c.Do(ctx, "/Admin/UpdateSubjectStatus", input, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListSubjects 列出请求主体，返回 nextToken
+func (s *Admin) ListSubjects(ctx context.Context, pg tpl.Pagination) ([]tpl.Subject, string, error) {
+	res := make([]tpl.Subject, 0)
+	next, err := s.c.Do(ctx, "/Admin/ListSubjects", pg, &res)
+	return res, next, err
+}
@@ -0,0 +1,302 @@
+// Package otac 是 ot-ac HTTP API 的 Go 客户端，请求与响应直接使用 tpl 中的类型。
+package otac
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+	otgo "github.com/open-trust/ot-go-lib"
+)
+
+// Prefer 的取值，通过 WithPrefer 设置
+const (
+	PreferRespondDetail   = "respond-detail"   // 权限检查返回授予权限的详情
+	PreferRespondConflict = "respond-conflict" // 写入资源冲突时响应 409 错误
+)
+
+type ctxKey int
+
+const preferKey ctxKey = iota
+
+// WithPrefer 返回携带 Prefer header 的 context，使用该 context 的请求都会带上这些 Prefer
+func WithPrefer(ctx context.Context, prefers ...string) context.Context {
+	prev, _ := ctx.Value(preferKey).([]string)
+	return context.WithValue(ctx, preferKey, append(append([]string{}, prev...), prefers...))
+}
+
+func prefersFromCtx(ctx context.Context) []string {
+	prefers, _ := ctx.Value(preferKey).([]string)
+	return prefers
+}
+
+// TokenSource 提供访问 ot-ac 的 OTVID
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken 固定的 OTVID，适用于测试或短时间运行的工具
+type StaticToken string
+
+// Token 实现 TokenSource
+func (t StaticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+type otClientToken struct {
+	sc *otgo.ServiceClient
+}
+
+// OTClientToken 通过 otgo.OTClient 签发访问 aud（ot-ac 服务的 OTID）的 OTVID，OTVID 由 otgo 缓存并在过期前更新
+func OTClientToken(oc *otgo.OTClient, aud otgo.OTID) TokenSource {
+	return &otClientToken{sc: oc.Service(aud)}
+}
+
+func (t *otClientToken) Token(ctx context.Context) (string, error) {
+	cfg, err := t.sc.Resolve(ctx)
+	if err != nil {
+		return "", err
+	}
+	return cfg.OTVID.Token(), nil
+}
+
+// Error ot-ac 返回的错误
+type Error struct {
+	Code    int         `json:"code"` // HTTP 状态码
+	Status  string      `json:"status"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Error 实现 error
+func (e *Error) Error() string {
+	return fmt.Sprintf("otac: %d %s: %s", e.Code, e.Status, e.Message)
+}
+
+// StatusCode 返回 err 的 HTTP 状态码，err 不是 *Error 时返回 0
+func StatusCode(err error) int {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return 0
+}
+
+// Options 客户端配置
+type Options struct {
+	Endpoint   string        // ot-ac 服务地址，如 https://ot.example.com/ac
+	Token      TokenSource   // 租户或管理员的 OTVID
+	HTTPClient *http.Client  // 默认为 5 秒超时的 http.Client
+	MaxRetries int           // 网络错误、429、502、503 和 504 响应的最大重试次数，默认为 2，小于 0 表示不重试
+	RetryWait  time.Duration // 第一次重试前的等待时间，之后每次翻倍，默认为 100ms
+}
+
+// Client ot-ac 客户端，各服务的方法与 app.NewRouters 中的路由一一对应
+type Client struct {
+	opts Options
+
+	AC           *AC
+	Admin        *Admin
+	Object       *Object
+	Organization *Organization
+	Permission   *Permission
+	Role         *Role
+	Scope        *Scope
+	Unit         *Unit
+}
+
+// New 创建客户端
+func New(opts Options) (*Client, error) {
+	if opts.Endpoint == "" {
+		return nil, errors.New("otac: endpoint required")
+	}
+	if opts.Token == nil {
+		return nil, errors.New("otac: token source required")
+	}
+	opts.Endpoint = strings.TrimRight(opts.Endpoint, "/")
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: time.Second * 5}
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = 2
+	}
+	if opts.RetryWait <= 0 {
+		opts.RetryWait = time.Millisecond * 100
+	}
+
+	c := &Client{opts: opts}
+	c.AC = &AC{c}
+	c.Admin = &Admin{c}
+	c.Object = &Object{c}
+	c.Organization = &Organization{c}
+	c.Permission = &Permission{c}
+	c.Role = &Role{c}
+	c.Scope = &Scope{c}
+	c.Unit = &Unit{c}
+	return c, nil
+}
+
+// response ot-ac 的响应，成功时为 tpl.SuccessResponseType，失败时为 tpl.ErrorResponseType
+type response struct {
+	TotalCount int             `json:"totalCount"`
+	NextToken  string          `json:"nextToken"`
+	Result     json.RawMessage `json:"result"`
+	Error      *Error          `json:"error"`
+}
+
+// Do 以 JSON 格式发送 input 到 path（如 /AC/CheckObject），并将响应的 result 解码到 output，返回 nextToken。
+// 写操作都是幂等的，因此失败时会重试；设置了 respond-conflict 时不重试，因为重试可能得到此前请求造成的 409 错误
+func (c *Client) Do(ctx context.Context, path string, input, output interface{}) (string, error) {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+	retries := c.opts.MaxRetries
+	for _, p := range prefersFromCtx(ctx) {
+		if p == PreferRespondConflict {
+			retries = 0
+		}
+	}
+
+	wait := c.opts.RetryWait
+	for i := 0; ; i++ {
+		res, err := c.send(ctx, http.MethodPost, path, "application/json", bytes.NewReader(body))
+		if err == nil {
+			if err = decode(res, output); err == nil {
+				return res.nextToken, nil
+			}
+		}
+		if i >= retries || !shouldRetry(ctx, err) {
+			return "", err
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+type rawResponse struct {
+	status    int
+	body      []byte
+	nextToken string
+}
+
+func (c *Client) send(ctx context.Context, method, path, contentType string, body io.Reader) (*rawResponse, error) {
+	res, err := c.open(ctx, method, path, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &rawResponse{status: res.StatusCode, body: data}, nil
+}
+
+// open 发送请求并返回未读取的响应，调用方负责关闭 Body
+func (c *Client) open(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	token, err := c.opts.Token.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.opts.Endpoint+path, body)
+	if err != nil {
+		return nil, err
+	}
+	otgo.AddTokenToHeader(req.Header, token)
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for _, p := range prefersFromCtx(ctx) {
+		req.Header.Add("Prefer", p)
+	}
+	return c.opts.HTTPClient.Do(req)
+}
+
+func decode(raw *rawResponse, output interface{}) error {
+	res := &response{}
+	if err := json.Unmarshal(raw.body, res); err != nil {
+		if raw.status >= 300 {
+			return &Error{Code: raw.status, Status: http.StatusText(raw.status), Message: string(raw.body)}
+		}
+		return fmt.Errorf("otac: decoding response error: %v", err)
+	}
+	if res.Error != nil || raw.status >= 300 {
+		e := res.Error
+		if e == nil {
+			e = &Error{Status: http.StatusText(raw.status)}
+		}
+		e.Code = raw.status
+		return e
+	}
+	raw.nextToken = res.NextToken
+	if output != nil && len(res.Result) > 0 {
+		return json.Unmarshal(res.Result, output)
+	}
+	return nil
+}
+
+// shouldRetry 网络错误和 429、502、503、504 响应可以重试
+func shouldRetry(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		switch e.Code {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var ue *url.Error
+	return errors.As(err, &ue)
+}
+
+// Version 获取 ot-ac 的版本信息
+func (c *Client) Version(ctx context.Context) (map[string]interface{}, error) {
+	res, err := c.send(ctx, http.MethodGet, "/version", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	info := make(map[string]interface{})
+	if err := json.Unmarshal(res.body, &info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// EachPage 从 pg 开始依次获取每一页，直到 nextToken 为空或 list 返回错误。list 获取 pg 指定的页并返回 nextToken，如：
+//
+//	otac.EachPage(ctx, tpl.Pagination{PageSize: 100}, func(ctx context.Context, pg tpl.Pagination) (string, error) {
+//		tenants, next, err := cli.Admin.ListTenants(ctx, pg)
+//		// 处理 tenants
+//		return next, err
+//	})
+func EachPage(ctx context.Context, pg tpl.Pagination, list func(ctx context.Context, pg tpl.Pagination) (string, error)) error {
+	for {
+		next, err := list(ctx, pg)
+		if err != nil {
+			return err
+		}
+		if next == "" || next == pg.PageToken {
+			return nil
+		}
+		pg.PageToken = next
+		pg.Skip = 0
+	}
+}
@@ -0,0 +1,276 @@
+package otac
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/app"
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/tpl"
+	otgo "github.com/open-trust/ot-go-lib"
+)
+
+// adminToken 用测试配置的私钥签发管理员的 OTVID，并将对应的公钥设为信任域公钥
+func adminToken(t *testing.T) StaticToken {
+	t.Helper()
+	keys, err := otgo.ParseSet(conf.Config.OpenTrust.PrivateKeys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf.OT.SetDomainKeys(*otgo.LookupPublicKeys(keys))
+	key, err := otgo.LookupSigningKey(keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vid := &otgo.OTVID{
+		ID:       conf.OT.OTID,
+		Issuer:   conf.OT.TrustDomain.OTID(),
+		Audience: conf.OT.OTID,
+		Expiry:   time.Now().Add(time.Minute),
+	}
+	token, err := vid.Sign(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return StaticToken(token)
+}
+
+// testServer 在 app.New() 前插入的 handler，可以让指定路径的前几次请求失败，或替换需要 Dgraph 的接口
+type testServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests map[string]int
+	prefers  map[string][]string
+	failures map[string]int // 路径 -> 前几次请求响应 503
+	stubs    map[string]http.HandlerFunc
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{
+		requests: make(map[string]int),
+		prefers:  make(map[string][]string),
+		failures: make(map[string]int),
+		stubs:    make(map[string]http.HandlerFunc),
+	}
+	h := app.New()
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[r.URL.Path]++
+		n := s.requests[r.URL.Path]
+		s.prefers[r.URL.Path] = r.Header.Values("Prefer")
+		fail := n <= s.failures[r.URL.Path]
+		stub := s.stubs[r.URL.Path]
+		s.mu.Unlock()
+
+		switch {
+		case fail:
+			http.Error(w, "upstream unavailable", http.StatusServiceUnavailable)
+		case stub != nil:
+			stub(w, r)
+		default:
+			h.ServeHTTP(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testServer) count(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+func (s *testServer) client(t *testing.T, token TokenSource, maxRetries int) *Client {
+	cli, err := New(Options{Endpoint: s.URL + "/", Token: token, MaxRetries: maxRetries, RetryWait: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
+
+func TestVersion(t *testing.T) {
+	s := newTestServer(t)
+	info, err := s.client(t, adminToken(t), 0).Version(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info["name"] != conf.AppName {
+		t.Fatalf("Version got %v", info)
+	}
+}
+
+func TestDoRetries(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t)
+	cli := s.client(t, adminToken(t), 2)
+	// 不属于信任域的租户由 app 在访问 Dgraph 前响应 400
+	foreign, err := otgo.ParseOTID("otid:other.example.com:app:t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 两次 503 后由 app 处理，响应 400 后不再重试
+	s.failures["/Admin/AddTenant"] = 2
+	_, err = cli.Admin.AddTenant(ctx, foreign)
+	if StatusCode(err) != http.StatusBadRequest {
+		t.Fatalf("AddTenant got %v", err)
+	}
+	if n := s.count("/Admin/AddTenant"); n != 3 {
+		t.Fatalf("AddTenant requests got %d, want 3", n)
+	}
+
+	// 超过最大重试次数时返回最后一次的错误
+	s.failures["/Admin/BatchAddSubjects"] = 10
+	err = cli.Admin.BatchAddSubjects(ctx, []string{"user:1"})
+	if StatusCode(err) != http.StatusServiceUnavailable || !strings.Contains(err.Error(), "upstream unavailable") {
+		t.Fatalf("BatchAddSubjects got %v", err)
+	}
+	if n := s.count("/Admin/BatchAddSubjects"); n != 3 {
+		t.Fatalf("BatchAddSubjects requests got %d, want 3", n)
+	}
+
+	// 设置 respond-conflict 时不重试
+	s.failures["/Admin/UpdateTenantStatus"] = 1
+	_, err = cli.Admin.UpdateTenantStatus(WithPrefer(ctx, PreferRespondConflict), tpl.TenantAddInput{Tenant: foreign})
+	if StatusCode(err) != http.StatusServiceUnavailable || s.count("/Admin/UpdateTenantStatus") != 1 {
+		t.Fatalf("UpdateTenantStatus got %v after %d requests", err, s.count("/Admin/UpdateTenantStatus"))
+	}
+
+	// 网络错误重试后返回原始错误，不是 *Error；ctx 结束后不再重试
+	closed := newTestServer(t)
+	closed.Close()
+	cli = closed.client(t, adminToken(t), 2)
+	if _, err = cli.Admin.AddTenant(ctx, foreign); err == nil || StatusCode(err) != 0 || !shouldRetry(ctx, err) {
+		t.Fatalf("AddTenant to closed server got %v", err)
+	}
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err = cli.Admin.AddTenant(cctx, foreign); err == nil || shouldRetry(cctx, err) {
+		t.Fatalf("AddTenant with canceled context got %v", err)
+	}
+}
+
+func TestPrefer(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t)
+	cli := s.client(t, adminToken(t), 0)
+	input := tpl.ACCheckPermissionsInput{Target: tpl.Target{Type: "doc", ID: "a"}}
+	input.Subject = "user:1"
+	input.Permissions = []string{"read"}
+
+	// 管理员身份不是租户，由 app 响应 401
+	_, err := cli.AC.CheckObjectDetail(WithPrefer(ctx, PreferRespondConflict), input)
+	if StatusCode(err) != http.StatusUnauthorized {
+		t.Fatalf("CheckObjectDetail got %v", err)
+	}
+	if got := strings.Join(s.prefers["/AC/CheckObject"], ","); got != "respond-conflict,respond-detail" {
+		t.Fatalf("Prefer got %q", got)
+	}
+	if _, err = cli.AC.CheckUnit(ctx, input); StatusCode(err) != http.StatusUnauthorized {
+		t.Fatalf("CheckUnit got %v", err)
+	}
+	if got := s.prefers["/AC/CheckUnit"]; len(got) != 0 {
+		t.Fatalf("Prefer got %q", got)
+	}
+
+	// WithPrefer 不修改父 context 的 Prefer
+	parent := WithPrefer(ctx, PreferRespondDetail)
+	WithPrefer(parent, PreferRespondConflict)
+	if got := prefersFromCtx(parent); len(got) != 1 || got[0] != PreferRespondDetail {
+		t.Fatalf("parent Prefer got %q", got)
+	}
+}
+
+func TestEachPage(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t)
+	token := adminToken(t)
+	pages := map[string]tpl.SuccessResponseType{
+		"":   {NextToken: "p2", Result: []tpl.Tenant{{Tenant: "otid:ot.example.com:app:t1"}, {Tenant: "otid:ot.example.com:app:t2"}}},
+		"p2": {NextToken: "p3", Result: []tpl.Tenant{{Tenant: "otid:ot.example.com:app:t3"}}},
+		"p3": {Result: []tpl.Tenant{}},
+	}
+	// ListTenants 需要 Dgraph，这里按 pageToken 返回固定的页
+	s.stubs["/Admin/ListTenants"] = func(w http.ResponseWriter, r *http.Request) {
+		if otgo.ExtractTokenFromHeader(r.Header) != string(token) {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		pg := tpl.Pagination{}
+		if err := json.NewDecoder(r.Body).Decode(&pg); err != nil || pg.PageSize != 2 {
+			http.Error(w, "invalid pagination", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pages[pg.PageToken])
+	}
+
+	cli := s.client(t, token, 0)
+	tenants := make([]string, 0)
+	err := EachPage(ctx, tpl.Pagination{PageSize: 2}, func(ctx context.Context, pg tpl.Pagination) (string, error) {
+		res, next, err := cli.Admin.ListTenants(ctx, pg)
+		for _, v := range res {
+			tenants = append(tenants, v.Tenant)
+		}
+		return next, err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tenants) != 3 || s.count("/Admin/ListTenants") != 3 {
+		t.Fatalf("EachPage got %v after %d requests", tenants, s.count("/Admin/ListTenants"))
+	}
+
+	// list 返回错误时停止
+	s.failures["/Admin/ListTenants"] = 100
+	err = EachPage(ctx, tpl.Pagination{PageSize: 2}, func(ctx context.Context, pg tpl.Pagination) (string, error) {
+		_, next, err := cli.Admin.ListTenants(ctx, pg)
+		return next, err
+	})
+	if StatusCode(err) != http.StatusServiceUnavailable {
+		t.Fatalf("EachPage got %v", err)
+	}
+}
+
+func TestErrorDecoding(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer(t)
+
+	// app 渲染的 JSON 错误
+	_, _, err := s.client(t, StaticToken("invalid"), 0).Admin.ListTenants(ctx, tpl.Pagination{PageSize: 10})
+	e, ok := err.(*Error)
+	if !ok || e.Code != http.StatusUnauthorized || e.Message == "" {
+		t.Fatalf("ListTenants with invalid token got %#v", err)
+	}
+	if _, _, err = s.client(t, StaticToken(""), 0).Admin.ListTenants(ctx, tpl.Pagination{PageSize: 10}); StatusCode(err) != http.StatusUnauthorized {
+		t.Fatalf("ListTenants without token got %v", err)
+	}
+
+	// 非 JSON 的错误响应以响应体作为消息
+	s.stubs["/Admin/ListSubjects"] = func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}
+	_, _, err = s.client(t, adminToken(t), -1).Admin.ListSubjects(ctx, tpl.Pagination{PageSize: 10})
+	if e, ok = err.(*Error); !ok || e.Code != http.StatusBadGateway || e.Status != "Bad Gateway" || !strings.Contains(e.Message, "bad gateway") {
+		t.Fatalf("ListSubjects got %#v", err)
+	}
+	if s.count("/Admin/ListSubjects") != 1 {
+		t.Fatalf("ListSubjects with MaxRetries -1 got %d requests", s.count("/Admin/ListSubjects"))
+	}
+
+	// 成功响应中无法解码的 result
+	s.stubs["/Admin/UpdateSubjectStatus"] = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result": "not a subject"}`))
+	}
+	if _, err = s.client(t, adminToken(t), 0).Admin.UpdateSubjectStatus(ctx, tpl.SubjectUpdateInput{}); err == nil || StatusCode(err) != 0 {
+		t.Fatalf("UpdateSubjectStatus got %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package otac
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Object 资源对象，需要租户身份
+type Object struct {
+	c *Client
+}
+
+// BatchAdd 批量添加资源对象
+func (s *Object) BatchAdd(ctx context.Context, input tpl.TargetBatchAddInput) error {
+	_, err := s.c.Do(ctx, "/Object/BatchAdd", input, nil)
+	return err
+}
+
+// AddPermissions 添加资源对象的透传权限
+func (s *Object) AddPermissions(ctx context.Context, input tpl.ObjectAddPermissionsInput) error {
+	_, err := s.c.Do(ctx, "/Object/AddPermissions", input, nil)
+	return err
+}
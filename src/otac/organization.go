@@ -0,0 +1,113 @@
+package otac
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Organization 组织、OU 和组织成员，需要管理员身份
+type Organization struct {
+	c *Client
+}
+
+// AddOrg 添加组织，返回是否新添加
+func (s *Organization) AddOrg(ctx context.Context, input tpl.OrganizationInput) (bool, error) {
+	var ok bool
+	_, err := s.c.Do(ctx, "/Organization/AddOrg", input, &ok)
+	return ok, err
+}
+
+// UpdateOrgStatus 更新组织状态
+func (s *Organization) UpdateOrgStatus(ctx context.Context, input tpl.OrganizationStatusInput) error {
+	_, err := s.c.Do(ctx, "/Organization/UpdateOrgStatus", input, nil)
+	return err
+}
+
+// ListOrgs 列出组织，返回 nextToken
+func (s *Organization) ListOrgs(ctx context.Context, pg tpl.Pagination) ([]tpl.Organization, string, error) {
+	res := make([]tpl.Organization, 0)
+	next, err := s.c.Do(ctx, "/Organization/ListOrgs", pg, &res)
+	return res, next, err
+}
+
+// ListSubjectOrgs 列出请求主体所属的组织，返回 nextToken
+func (s *Organization) ListSubjectOrgs(ctx context.Context, input tpl.OrganizationListSubjectOrgsInput) ([]tpl.Organization, string, error) {
+	res := make([]tpl.Organization, 0)
+	next, err := s.c.Do(ctx, "/Organization/ListSubjectOrgs", input, &res)
+	return res, next, err
+}
+
+// AddOU 添加 OU，返回是否新添加
+func (s *Organization) AddOU(ctx context.Context, input tpl.OrganizationAddOUInput) (bool, error) {
+	var ok bool
+	_, err := s.c.Do(ctx, "/Organization/AddOU", input, &ok)
+	return ok, err
+}
+
+// UpdateOUParent 更新 OU 的父级
+func (s *Organization) UpdateOUParent(ctx context.Context, input tpl.OrganizationUpdateOUParentInput) error {
+	_, err := s.c.Do(ctx, "/Organization/UpdateOUParent", input, nil)
+	return err
+}
+
+// ListOUs 列出组织的 OU，返回 nextToken
+func (s *Organization) ListOUs(ctx context.Context, input tpl.OrganizationListOUsInput) ([]tpl.OU, string, error) {
+	res := make([]tpl.OU, 0)
+	next, err := s.c.Do(ctx, "/Organization/ListOUs", input, &res)
+	return res, next, err
+}
+
+// ListSubjectOUs 列出请求主体所属的 OU，返回 nextToken
+func (s *Organization) ListSubjectOUs(ctx context.Context, input tpl.OrganizationListSubjectOUsInput) ([]tpl.OU, string, error) {
+	res := make([]tpl.OU, 0)
+	next, err := s.c.Do(ctx, "/Organization/ListSubjectOUs", input, &res)
+	return res, next, err
+}
+
+// SearchOUs 搜索组织的 OU，返回 nextToken
+func (s *Organization) SearchOUs(ctx context.Context, input tpl.OrganizationSearchInput) ([]tpl.OU, string, error) {
+	res := make([]tpl.OU, 0)
+	next, err := s.c.Do(ctx, "/Organization/SearchOUs", input, &res)
+	return res, next, err
+}
+
+// BatchAddMember 批量添加组织成员
+func (s *Organization) BatchAddMember(ctx context.Context, input tpl.OrganizationBatchAddMemberInput) error {
+	_, err := s.c.Do(ctx, "/Organization/BatchAddMember", input, nil)
+	return err
+}
+
+// ListMembers 列出组织成员，返回 nextToken
+func (s *Organization) ListMembers(ctx context.Context, input tpl.OrganizationListInput) ([]tpl.Member, string, error) {
+	res := make([]tpl.Member, 0)
+	next, err := s.c.Do(ctx, "/Organization/ListMembers", input, &res)
+	return res, next, err
+}
+
+// SearchMember 搜索组织成员，返回 nextToken
+func (s *Organization) SearchMember(ctx context.Context, input tpl.OrganizationSearchInput) ([]tpl.Member, string, error) {
+	res := make([]tpl.Member, 0)
+	next, err := s.c.Do(ctx, "/Organization/SearchMember", input, &res)
+	return res, next, err
+}
+
+// BatchAddOUMember 批量添加 OU 成员
+func (s *Organization) BatchAddOUMember(ctx context.Context, input tpl.OrganizationBatchAddOUMemberInput) error {
+	_, err := s.c.Do(ctx, "/Organization/BatchAddOUMember", input, nil)
+	return err
+}
+
+// ListOUMembers 列出 OU 的直接成员，返回 nextToken
+func (s *Organization) ListOUMembers(ctx context.Context, input tpl.OrganizationListOUMembersInput) ([]tpl.Member, string, error) {
+	res := make([]tpl.Member, 0)
+	next, err := s.c.Do(ctx, "/Organization/ListOUMembers", input, &res)
+	return res, next, err
+}
+
+// ListOUDescendantMembers 列出 OU 及其子孙 OU 的成员，返回 nextToken
+func (s *Organization) ListOUDescendantMembers(ctx context.Context, input tpl.OrganizationListOUMembersInput) ([]tpl.Member, string, error) {
+	res := make([]tpl.Member, 0)
+	next, err := s.c.Do(ctx, "/Organization/ListOUDescendantMembers", input, &res)
+	return res, next, err
+}
@@ -0,0 +1,61 @@
+package otac
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Permission 权限定义，需要租户身份
+type Permission struct {
+	c *Client
+}
+
+// BatchAdd 批量添加或更新权限定义
+func (s *Permission) BatchAdd(ctx context.Context, input tpl.PermissionBatchAddInput) error {
+	_, err := s.c.Do(ctx, "/Permission/BatchAdd", input, nil)
+	return err
+}
+
+// List 列出权限定义，返回 nextToken
+func (s *Permission) List(ctx context.Context, input tpl.PermissionListInput) ([]tpl.Permission, string, error) {
+	res := make([]tpl.Permission, 0)
+	next, err := s.c.Do(ctx, "/Permission/List", input, &res)
+	return res, next, err
+}
+
+// Delete 删除权限定义
+func (s *Permission) Delete(ctx context.Context, input tpl.PermissionDeleteInput) (*tpl.PermissionDeleteOutput, error) {
+	res := &tpl.PermissionDeleteOutput{}
+	if _, err := s.c.Do(ctx, "/Permission/Delete", input, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Usage 获取权限的使用情况
+func (s *Permission) Usage(ctx context.Context, input tpl.PermissionInput) (*tpl.PermissionUsage, error) {
+	res := &tpl.PermissionUsage{}
+	if _, err := s.c.Do(ctx, "/Permission/Usage", input, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Rename 重命名权限
+func (s *Permission) Rename(ctx context.Context, input tpl.PermissionMigrateInput) (*tpl.PermissionMigrateOutput, error) {
+	res := &tpl.PermissionMigrateOutput{}
+	if _, err := s.c.Do(ctx, "/Permission/Rename", input, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Merge 将权限合并到另一个权限
+func (s *Permission) Merge(ctx context.Context, input tpl.PermissionMigrateInput) (*tpl.PermissionMigrateOutput, error) {
+	res := &tpl.PermissionMigrateOutput{}
+	if _, err := s.c.Do(ctx, "/Permission/Merge", input, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,47 @@
+package otac
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Role 角色，需要租户身份
+type Role struct {
+	c *Client
+}
+
+// Add 添加角色，返回是否新添加
+func (s *Role) Add(ctx context.Context, input tpl.RoleAddInput) (bool, error) {
+	var ok bool
+	_, err := s.c.Do(ctx, "/Role/Add", input, &ok)
+	return ok, err
+}
+
+// Get 获取角色
+func (s *Role) Get(ctx context.Context, input tpl.RoleInput) (*tpl.Role, error) {
+	res := &tpl.Role{}
+	if _, err := s.c.Do(ctx, "/Role/Get", input, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Update 更新角色的权限
+func (s *Role) Update(ctx context.Context, input tpl.RoleAddInput) error {
+	_, err := s.c.Do(ctx, "/Role/Update", input, nil)
+	return err
+}
+
+// Delete 删除角色
+func (s *Role) Delete(ctx context.Context, input tpl.RoleInput) error {
+	_, err := s.c.Do(ctx, "/Role/Delete", input, nil)
+	return err
+}
+
+// List 列出角色，返回 nextToken
+func (s *Role) List(ctx context.Context, input tpl.RoleListInput) ([]tpl.Role, string, error) {
+	res := make([]tpl.Role, 0)
+	next, err := s.c.Do(ctx, "/Role/List", input, &res)
+	return res, next, err
+}
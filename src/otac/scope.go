@@ -0,0 +1,61 @@
+package otac
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Scope 范围约束，需要租户身份
+type Scope struct {
+	c *Client
+}
+
+// Add 添加范围约束，返回是否新添加
+func (s *Scope) Add(ctx context.Context, input tpl.Target) (bool, error) {
+	var ok bool
+	_, err := s.c.Do(ctx, "/Scope/Add", input, &ok)
+	return ok, err
+}
+
+// Delete 删除范围约束
+func (s *Scope) Delete(ctx context.Context, input tpl.Target) error {
+	_, err := s.c.Do(ctx, "/Scope/Delete", input, nil)
+	return err
+}
+
+// DeleteAll 删除范围约束及其关联的管理单元和资源对象
+func (s *Scope) DeleteAll(ctx context.Context, input tpl.Target) error {
+	_, err := s.c.Do(ctx, "/Scope/DeleteAll", input, nil)
+	return err
+}
+
+// UpdateStatus 更新范围约束的状态
+func (s *Scope) UpdateStatus(ctx context.Context, input tpl.ScopeUpdateInput) (*tpl.Scope, error) {
+	res := &tpl.Scope{}
+	if _, err := s.c.Do(ctx, "/Scope/UpdateStatus", input, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// List 列出范围约束，返回 nextToken
+func (s *Scope) List(ctx context.Context, input tpl.ScopeListInput) ([]tpl.Scope, string, error) {
+	res := make([]tpl.Scope, 0)
+	next, err := s.c.Do(ctx, "/Scope/List", input, &res)
+	return res, next, err
+}
+
+// ListUnits 列出范围约束下的管理单元，返回 nextToken
+func (s *Scope) ListUnits(ctx context.Context, input tpl.ScopeListUnitObjectsInput) ([]tpl.Unit, string, error) {
+	res := make([]tpl.Unit, 0)
+	next, err := s.c.Do(ctx, "/Scope/ListUnits", input, &res)
+	return res, next, err
+}
+
+// ListObjects 列出范围约束下的资源对象，返回 nextToken
+func (s *Scope) ListObjects(ctx context.Context, input tpl.ScopeListUnitObjectsInput) ([]tpl.Object, string, error) {
+	res := make([]tpl.Object, 0)
+	next, err := s.c.Do(ctx, "/Scope/ListObjects", input, &res)
+	return res, next, err
+}
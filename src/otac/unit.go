@@ -0,0 +1,78 @@
+package otac
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Unit 管理单元，需要租户身份
+type Unit struct {
+	c *Client
+}
+
+// BatchAdd 批量添加管理单元
+func (s *Unit) BatchAdd(ctx context.Context, input tpl.TargetBatchAddInput) error {
+	_, err := s.c.Do(ctx, "/Unit/BatchAdd", input, nil)
+	return err
+}
+
+// AddFromOrg 从组织添加管理单元
+func (s *Unit) AddFromOrg(ctx context.Context, input tpl.UnitAddFromOrgInput) error {
+	_, err := s.c.Do(ctx, "/Unit/AddFromOrg", input, nil)
+	return err
+}
+
+// AddFromOU 从 OU 添加管理单元
+func (s *Unit) AddFromOU(ctx context.Context, input tpl.UnitAddFromOUInput) error {
+	_, err := s.c.Do(ctx, "/Unit/AddFromOU", input, nil)
+	return err
+}
+
+// AddFromMembers 从组织成员添加管理单元
+func (s *Unit) AddFromMembers(ctx context.Context, input tpl.UnitAddFromMembersInput) error {
+	_, err := s.c.Do(ctx, "/Unit/AddFromMembers", input, nil)
+	return err
+}
+
+// AssignParent 为管理单元添加父级
+func (s *Unit) AssignParent(ctx context.Context, input tpl.UnitAssignParentInput) error {
+	_, err := s.c.Do(ctx, "/Unit/AssignParent", input, nil)
+	return err
+}
+
+// AssignScope 为管理单元添加范围约束
+func (s *Unit) AssignScope(ctx context.Context, input tpl.UnitAssignScopeInput) error {
+	_, err := s.c.Do(ctx, "/Unit/AssignScope", input, nil)
+	return err
+}
+
+// AssignObject 为管理单元关联资源对象
+func (s *Unit) AssignObject(ctx context.Context, input tpl.UnitAssignObjectInput) error {
+	_, err := s.c.Do(ctx, "/Unit/AssignObject", input, nil)
+	return err
+}
+
+// AddSubjects 为管理单元添加请求主体
+func (s *Unit) AddSubjects(ctx context.Context, input tpl.UnitAddSubjectsInput) error {
+	_, err := s.c.Do(ctx, "/Unit/AddSubjects", input, nil)
+	return err
+}
+
+// AddPermissions 为管理单元添加权限
+func (s *Unit) AddPermissions(ctx context.Context, input tpl.UnitAddPermissionsInput) error {
+	_, err := s.c.Do(ctx, "/Unit/AddPermissions", input, nil)
+	return err
+}
+
+// AddRoles 为管理单元添加角色
+func (s *Unit) AddRoles(ctx context.Context, input tpl.UnitRolesInput) error {
+	_, err := s.c.Do(ctx, "/Unit/AddRoles", input, nil)
+	return err
+}
+
+// RemoveRoles 移除管理单元的角色
+func (s *Unit) RemoveRoles(ctx context.Context, input tpl.UnitRolesInput) error {
+	_, err := s.c.Do(ctx, "/Unit/RemoveRoles", input, nil)
+	return err
+}
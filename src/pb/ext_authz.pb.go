@@ -0,0 +1,1155 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        (unknown)
+// source: ext_authz.proto
+
+package pb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// 对应 envoy.service.auth.v3.CheckRequest
+type ExtAuthzCheckRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Attributes *ExtAuthzAttributeContext `protobuf:"bytes,1,opt,name=attributes,proto3" json:"attributes,omitempty"`
+}
+
+func (x *ExtAuthzCheckRequest) Reset() {
+	*x = ExtAuthzCheckRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ext_authz_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtAuthzCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtAuthzCheckRequest) ProtoMessage() {}
+
+func (x *ExtAuthzCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ext_authz_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtAuthzCheckRequest.ProtoReflect.Descriptor instead.
+func (*ExtAuthzCheckRequest) Descriptor() ([]byte, []int) {
+	return file_ext_authz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExtAuthzCheckRequest) GetAttributes() *ExtAuthzAttributeContext {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// 对应 envoy.service.auth.v3.AttributeContext
+type ExtAuthzAttributeContext struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source            *ExtAuthzPeer     `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Destination       *ExtAuthzPeer     `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Request           *ExtAuthzRequest  `protobuf:"bytes,4,opt,name=request,proto3" json:"request,omitempty"`
+	ContextExtensions map[string]string `protobuf:"bytes,10,rep,name=context_extensions,json=contextExtensions,proto3" json:"context_extensions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ExtAuthzAttributeContext) Reset() {
+	*x = ExtAuthzAttributeContext{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ext_authz_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtAuthzAttributeContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtAuthzAttributeContext) ProtoMessage() {}
+
+func (x *ExtAuthzAttributeContext) ProtoReflect() protoreflect.Message {
+	mi := &file_ext_authz_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtAuthzAttributeContext.ProtoReflect.Descriptor instead.
+func (*ExtAuthzAttributeContext) Descriptor() ([]byte, []int) {
+	return file_ext_authz_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExtAuthzAttributeContext) GetSource() *ExtAuthzPeer {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *ExtAuthzAttributeContext) GetDestination() *ExtAuthzPeer {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *ExtAuthzAttributeContext) GetRequest() *ExtAuthzRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *ExtAuthzAttributeContext) GetContextExtensions() map[string]string {
+	if x != nil {
+		return x.ContextExtensions
+	}
+	return nil
+}
+
+// 对应 envoy.service.auth.v3.AttributeContext.Peer
+type ExtAuthzPeer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Service     string            `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Principal   string            `protobuf:"bytes,4,opt,name=principal,proto3" json:"principal,omitempty"`
+	Certificate string            `protobuf:"bytes,5,opt,name=certificate,proto3" json:"certificate,omitempty"`
+}
+
+func (x *ExtAuthzPeer) Reset() {
+	*x = ExtAuthzPeer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ext_authz_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtAuthzPeer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtAuthzPeer) ProtoMessage() {}
+
+func (x *ExtAuthzPeer) ProtoReflect() protoreflect.Message {
+	mi := &file_ext_authz_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtAuthzPeer.ProtoReflect.Descriptor instead.
+func (*ExtAuthzPeer) Descriptor() ([]byte, []int) {
+	return file_ext_authz_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExtAuthzPeer) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *ExtAuthzPeer) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ExtAuthzPeer) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+func (x *ExtAuthzPeer) GetCertificate() string {
+	if x != nil {
+		return x.Certificate
+	}
+	return ""
+}
+
+// 对应 envoy.service.auth.v3.AttributeContext.Request
+type ExtAuthzRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Http *ExtAuthzHttpRequest `protobuf:"bytes,2,opt,name=http,proto3" json:"http,omitempty"`
+}
+
+func (x *ExtAuthzRequest) Reset() {
+	*x = ExtAuthzRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ext_authz_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtAuthzRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtAuthzRequest) ProtoMessage() {}
+
+func (x *ExtAuthzRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ext_authz_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtAuthzRequest.ProtoReflect.Descriptor instead.
+func (*ExtAuthzRequest) Descriptor() ([]byte, []int) {
+	return file_ext_authz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExtAuthzRequest) GetHttp() *ExtAuthzHttpRequest {
+	if x != nil {
+		return x.Http
+	}
+	return nil
+}
+
+// 对应 envoy.service.auth.v3.AttributeContext.HttpRequest，headers 的键为小写
+type ExtAuthzHttpRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Method   string            `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Headers  map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Path     string            `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	Host     string            `protobuf:"bytes,5,opt,name=host,proto3" json:"host,omitempty"`
+	Scheme   string            `protobuf:"bytes,6,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	Query    string            `protobuf:"bytes,7,opt,name=query,proto3" json:"query,omitempty"`
+	Fragment string            `protobuf:"bytes,8,opt,name=fragment,proto3" json:"fragment,omitempty"`
+	Size     int64             `protobuf:"varint,9,opt,name=size,proto3" json:"size,omitempty"`
+	Protocol string            `protobuf:"bytes,10,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Body     string            `protobuf:"bytes,11,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (x *ExtAuthzHttpRequest) Reset() {
+	*x = ExtAuthzHttpRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ext_authz_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtAuthzHttpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtAuthzHttpRequest) ProtoMessage() {}
+
+func (x *ExtAuthzHttpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ext_authz_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtAuthzHttpRequest.ProtoReflect.Descriptor instead.
+func (*ExtAuthzHttpRequest) Descriptor() ([]byte, []int) {
+	return file_ext_authz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExtAuthzHttpRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExtAuthzHttpRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ExtAuthzHttpRequest) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *ExtAuthzHttpRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ExtAuthzHttpRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ExtAuthzHttpRequest) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *ExtAuthzHttpRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ExtAuthzHttpRequest) GetFragment() string {
+	if x != nil {
+		return x.Fragment
+	}
+	return ""
+}
+
+func (x *ExtAuthzHttpRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ExtAuthzHttpRequest) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *ExtAuthzHttpRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+// 对应 envoy.service.auth.v3.CheckResponse，denied_response 与 ok_response 只会设置一个
+type ExtAuthzCheckResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status          *ExtAuthzStatus             `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	DeniedResponse  *ExtAuthzDeniedHttpResponse `protobuf:"bytes,2,opt,name=denied_response,json=deniedResponse,proto3" json:"denied_response,omitempty"`
+	OkResponse      *ExtAuthzOkHttpResponse     `protobuf:"bytes,3,opt,name=ok_response,json=okResponse,proto3" json:"ok_response,omitempty"`
+	DynamicMetadata *_struct.Struct             `protobuf:"bytes,4,opt,name=dynamic_metadata,json=dynamicMetadata,proto3" json:"dynamic_metadata,omitempty"`
+}
+
+func (x *ExtAuthzCheckResponse) Reset() {
+	*x = ExtAuthzCheckResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ext_authz_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtAuthzCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtAuthzCheckResponse) ProtoMessage() {}
+
+func (x *ExtAuthzCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ext_authz_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtAuthzCheckResponse.ProtoReflect.Descriptor instead.
+func (*ExtAuthzCheckResponse) Descriptor() ([]byte, []int) {
+	return file_ext_authz_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExtAuthzCheckResponse) GetStatus() *ExtAuthzStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ExtAuthzCheckResponse) GetDeniedResponse() *ExtAuthzDeniedHttpResponse {
+	if x != nil {
+		return x.DeniedResponse
+	}
+	return nil
+}
+
+func (x *ExtAuthzCheckResponse) GetOkResponse() *ExtAuthzOkHttpResponse {
+	if x != nil {
+		return x.OkResponse
+	}
+	return nil
+}
+
+func (x *ExtAuthzCheckResponse) GetDynamicMetadata() *_struct.Struct {
+	if x != nil {
+		return x.DynamicMetadata
+	}
+	return nil
+}
+
+// 对应 google.rpc.Status
+type ExtAuthzStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ExtAuthzStatus) Reset() {
+	*x = ExtAuthzStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ext_authz_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtAuthzStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtAuthzStatus) ProtoMessage() {}
+
+func (x *ExtAuthzStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_ext_authz_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtAuthzStatus.ProtoReflect.Descriptor instead.
+func (*ExtAuthzStatus) Descriptor() ([]byte, []int) {
+	return file_ext_authz_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExtAuthzStatus) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ExtAuthzStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// 对应 envoy.service.auth.v3.DeniedHttpResponse
+type ExtAuthzDeniedHttpResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status  *ExtAuthzHttpStatus          `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Headers []*ExtAuthzHeaderValueOption `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty"`
+	Body    string                       `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (x *ExtAuthzDeniedHttpResponse) Reset() {
+	*x = ExtAuthzDeniedHttpResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ext_authz_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtAuthzDeniedHttpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtAuthzDeniedHttpResponse) ProtoMessage() {}
+
+func (x *ExtAuthzDeniedHttpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ext_authz_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtAuthzDeniedHttpResponse.ProtoReflect.Descriptor instead.
+func (*ExtAuthzDeniedHttpResponse) Descriptor() ([]byte, []int) {
+	return file_ext_authz_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExtAuthzDeniedHttpResponse) GetStatus() *ExtAuthzHttpStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ExtAuthzDeniedHttpResponse) GetHeaders() []*ExtAuthzHeaderValueOption {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *ExtAuthzDeniedHttpResponse) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+// 对应 envoy.service.auth.v3.OkHttpResponse
+type ExtAuthzOkHttpResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headers         []*ExtAuthzHeaderValueOption `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty"`
+	HeadersToRemove []string                     `protobuf:"bytes,5,rep,name=headers_to_remove,json=headersToRemove,proto3" json:"headers_to_remove,omitempty"`
+}
+
+func (x *ExtAuthzOkHttpResponse) Reset() {
+	*x = ExtAuthzOkHttpResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ext_authz_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtAuthzOkHttpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtAuthzOkHttpResponse) ProtoMessage() {}
+
+func (x *ExtAuthzOkHttpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ext_authz_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtAuthzOkHttpResponse.ProtoReflect.Descriptor instead.
+func (*ExtAuthzOkHttpResponse) Descriptor() ([]byte, []int) {
+	return file_ext_authz_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ExtAuthzOkHttpResponse) GetHeaders() []*ExtAuthzHeaderValueOption {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *ExtAuthzOkHttpResponse) GetHeadersToRemove() []string {
+	if x != nil {
+		return x.HeadersToRemove
+	}
+	return nil
+}
+
+// 对应 envoy.type.v3.HttpStatus，code 为 HTTP 状态码
+type ExtAuthzHttpStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *ExtAuthzHttpStatus) Reset() {
+	*x = ExtAuthzHttpStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ext_authz_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtAuthzHttpStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtAuthzHttpStatus) ProtoMessage() {}
+
+func (x *ExtAuthzHttpStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_ext_authz_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtAuthzHttpStatus.ProtoReflect.Descriptor instead.
+func (*ExtAuthzHttpStatus) Descriptor() ([]byte, []int) {
+	return file_ext_authz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExtAuthzHttpStatus) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+// 对应 envoy.config.core.v3.HeaderValueOption
+type ExtAuthzHeaderValueOption struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header *ExtAuthzHeaderValue `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+}
+
+func (x *ExtAuthzHeaderValueOption) Reset() {
+	*x = ExtAuthzHeaderValueOption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ext_authz_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtAuthzHeaderValueOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtAuthzHeaderValueOption) ProtoMessage() {}
+
+func (x *ExtAuthzHeaderValueOption) ProtoReflect() protoreflect.Message {
+	mi := &file_ext_authz_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtAuthzHeaderValueOption.ProtoReflect.Descriptor instead.
+func (*ExtAuthzHeaderValueOption) Descriptor() ([]byte, []int) {
+	return file_ext_authz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExtAuthzHeaderValueOption) GetHeader() *ExtAuthzHeaderValue {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+// 对应 envoy.config.core.v3.HeaderValue
+type ExtAuthzHeaderValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ExtAuthzHeaderValue) Reset() {
+	*x = ExtAuthzHeaderValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ext_authz_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtAuthzHeaderValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtAuthzHeaderValue) ProtoMessage() {}
+
+func (x *ExtAuthzHeaderValue) ProtoReflect() protoreflect.Message {
+	mi := &file_ext_authz_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtAuthzHeaderValue.ProtoReflect.Descriptor instead.
+func (*ExtAuthzHeaderValue) Descriptor() ([]byte, []int) {
+	return file_ext_authz_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExtAuthzHeaderValue) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ExtAuthzHeaderValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_ext_authz_proto protoreflect.FileDescriptor
+
+var file_ext_authz_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x65, 0x78, 0x74, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x07, 0x6f, 0x74, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x59, 0x0a, 0x14, 0x45, 0x78, 0x74, 0x41,
+	0x75, 0x74, 0x68, 0x7a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x41, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x74, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x73, 0x22, 0xe5, 0x02, 0x0a, 0x18, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a,
+	0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
+	0x12, 0x2d, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x6f, 0x74, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x41, 0x75,
+	0x74, 0x68, 0x7a, 0x50, 0x65, 0x65, 0x72, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x37, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x74, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x50, 0x65, 0x65, 0x72, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x74, 0x61, 0x63,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x67, 0x0a, 0x12,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6f, 0x74, 0x61, 0x63, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x41, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x44, 0x0a, 0x16, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xde, 0x01, 0x0a, 0x0c,
+	0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x50, 0x65, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x74, 0x61, 0x63, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x50, 0x65, 0x65, 0x72, 0x2e, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x69, 0x6e, 0x63, 0x69, 0x70, 0x61, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x6e, 0x63, 0x69, 0x70, 0x61, 0x6c, 0x12,
+	0x20, 0x0a, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x43, 0x0a, 0x0f,
+	0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x30, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x6f, 0x74, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a,
+	0x48, 0x74, 0x74, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x68, 0x74, 0x74,
+	0x70, 0x22, 0xf4, 0x02, 0x0a, 0x13, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x48, 0x74,
+	0x74, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x12, 0x43, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6f, 0x74, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74,
+	0x41, 0x75, 0x74, 0x68, 0x7a, 0x48, 0x74, 0x74, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08,
+	0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x1a, 0x3a, 0x0a, 0x0c,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9c, 0x02, 0x0a, 0x15, 0x45, 0x78, 0x74,
+	0x41, 0x75, 0x74, 0x68, 0x7a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x74, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74,
+	0x41, 0x75, 0x74, 0x68, 0x7a, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x4c, 0x0a, 0x0f, 0x64, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x5f, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f,
+	0x74, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x44,
+	0x65, 0x6e, 0x69, 0x65, 0x64, 0x48, 0x74, 0x74, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x52, 0x0e, 0x64, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x40, 0x0a, 0x0b, 0x6f, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x74, 0x61, 0x63, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x4f, 0x6b, 0x48, 0x74, 0x74, 0x70, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0a, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x10, 0x64, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x5f, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x0f, 0x64, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x3e, 0x0a, 0x0e, 0x45, 0x78, 0x74, 0x41, 0x75,
+	0x74, 0x68, 0x7a, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xa3, 0x01, 0x0a, 0x1a, 0x45, 0x78, 0x74, 0x41,
+	0x75, 0x74, 0x68, 0x7a, 0x44, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x48, 0x74, 0x74, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6f, 0x74, 0x61, 0x63, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x48, 0x74, 0x74, 0x70, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3c, 0x0a, 0x07, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f,
+	0x74, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0x82, 0x01,
+	0x0a, 0x16, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x4f, 0x6b, 0x48, 0x74, 0x74, 0x70,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x74, 0x61, 0x63,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x5f, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x54, 0x6f, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x22, 0x28, 0x0a, 0x12, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x48, 0x74,
+	0x74, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x51, 0x0a, 0x19,
+	0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a, 0x06, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x74, 0x61, 0x63,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x22,
+	0x3d, 0x0a, 0x13, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x24,
+	0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65,
+	0x6e, 0x2d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x2f, 0x6f, 0x74, 0x2d, 0x61, 0x63, 0x2f, 0x73, 0x72,
+	0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ext_authz_proto_rawDescOnce sync.Once
+	file_ext_authz_proto_rawDescData = file_ext_authz_proto_rawDesc
+)
+
+func file_ext_authz_proto_rawDescGZIP() []byte {
+	file_ext_authz_proto_rawDescOnce.Do(func() {
+		file_ext_authz_proto_rawDescData = protoimpl.X.CompressGZIP(file_ext_authz_proto_rawDescData)
+	})
+	return file_ext_authz_proto_rawDescData
+}
+
+var file_ext_authz_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_ext_authz_proto_goTypes = []interface{}{
+	(*ExtAuthzCheckRequest)(nil),       // 0: otac.v1.ExtAuthzCheckRequest
+	(*ExtAuthzAttributeContext)(nil),   // 1: otac.v1.ExtAuthzAttributeContext
+	(*ExtAuthzPeer)(nil),               // 2: otac.v1.ExtAuthzPeer
+	(*ExtAuthzRequest)(nil),            // 3: otac.v1.ExtAuthzRequest
+	(*ExtAuthzHttpRequest)(nil),        // 4: otac.v1.ExtAuthzHttpRequest
+	(*ExtAuthzCheckResponse)(nil),      // 5: otac.v1.ExtAuthzCheckResponse
+	(*ExtAuthzStatus)(nil),             // 6: otac.v1.ExtAuthzStatus
+	(*ExtAuthzDeniedHttpResponse)(nil), // 7: otac.v1.ExtAuthzDeniedHttpResponse
+	(*ExtAuthzOkHttpResponse)(nil),     // 8: otac.v1.ExtAuthzOkHttpResponse
+	(*ExtAuthzHttpStatus)(nil),         // 9: otac.v1.ExtAuthzHttpStatus
+	(*ExtAuthzHeaderValueOption)(nil),  // 10: otac.v1.ExtAuthzHeaderValueOption
+	(*ExtAuthzHeaderValue)(nil),        // 11: otac.v1.ExtAuthzHeaderValue
+	nil,                                // 12: otac.v1.ExtAuthzAttributeContext.ContextExtensionsEntry
+	nil,                                // 13: otac.v1.ExtAuthzPeer.LabelsEntry
+	nil,                                // 14: otac.v1.ExtAuthzHttpRequest.HeadersEntry
+	(*_struct.Struct)(nil),             // 15: google.protobuf.Struct
+}
+var file_ext_authz_proto_depIdxs = []int32{
+	1,  // 0: otac.v1.ExtAuthzCheckRequest.attributes:type_name -> otac.v1.ExtAuthzAttributeContext
+	2,  // 1: otac.v1.ExtAuthzAttributeContext.source:type_name -> otac.v1.ExtAuthzPeer
+	2,  // 2: otac.v1.ExtAuthzAttributeContext.destination:type_name -> otac.v1.ExtAuthzPeer
+	3,  // 3: otac.v1.ExtAuthzAttributeContext.request:type_name -> otac.v1.ExtAuthzRequest
+	12, // 4: otac.v1.ExtAuthzAttributeContext.context_extensions:type_name -> otac.v1.ExtAuthzAttributeContext.ContextExtensionsEntry
+	13, // 5: otac.v1.ExtAuthzPeer.labels:type_name -> otac.v1.ExtAuthzPeer.LabelsEntry
+	4,  // 6: otac.v1.ExtAuthzRequest.http:type_name -> otac.v1.ExtAuthzHttpRequest
+	14, // 7: otac.v1.ExtAuthzHttpRequest.headers:type_name -> otac.v1.ExtAuthzHttpRequest.HeadersEntry
+	6,  // 8: otac.v1.ExtAuthzCheckResponse.status:type_name -> otac.v1.ExtAuthzStatus
+	7,  // 9: otac.v1.ExtAuthzCheckResponse.denied_response:type_name -> otac.v1.ExtAuthzDeniedHttpResponse
+	8,  // 10: otac.v1.ExtAuthzCheckResponse.ok_response:type_name -> otac.v1.ExtAuthzOkHttpResponse
+	15, // 11: otac.v1.ExtAuthzCheckResponse.dynamic_metadata:type_name -> google.protobuf.Struct
+	9,  // 12: otac.v1.ExtAuthzDeniedHttpResponse.status:type_name -> otac.v1.ExtAuthzHttpStatus
+	10, // 13: otac.v1.ExtAuthzDeniedHttpResponse.headers:type_name -> otac.v1.ExtAuthzHeaderValueOption
+	10, // 14: otac.v1.ExtAuthzOkHttpResponse.headers:type_name -> otac.v1.ExtAuthzHeaderValueOption
+	11, // 15: otac.v1.ExtAuthzHeaderValueOption.header:type_name -> otac.v1.ExtAuthzHeaderValue
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_ext_authz_proto_init() }
+func file_ext_authz_proto_init() {
+	if File_ext_authz_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ext_authz_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtAuthzCheckRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ext_authz_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtAuthzAttributeContext); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ext_authz_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtAuthzPeer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ext_authz_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtAuthzRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ext_authz_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtAuthzHttpRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ext_authz_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtAuthzCheckResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ext_authz_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtAuthzStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ext_authz_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtAuthzDeniedHttpResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ext_authz_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtAuthzOkHttpResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ext_authz_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtAuthzHttpStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ext_authz_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtAuthzHeaderValueOption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ext_authz_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtAuthzHeaderValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ext_authz_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ext_authz_proto_goTypes,
+		DependencyIndexes: file_ext_authz_proto_depIdxs,
+		MessageInfos:      file_ext_authz_proto_msgTypes,
+	}.Build()
+	File_ext_authz_proto = out.File
+	file_ext_authz_proto_rawDesc = nil
+	file_ext_authz_proto_goTypes = nil
+	file_ext_authz_proto_depIdxs = nil
+}
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/pb"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// AC ..
+type AC struct {
+	pb.UnimplementedACServer
+	blls *bll.Blls
+}
+
+// checkInput 权限检查是热点路径，直接转换请求消息，不经过 JSON
+func checkInput(ctx context.Context, req *pb.CheckRequest) (*tpl.Tenant, *tpl.ACCheckPermissionsInput, error) {
+	input := &tpl.ACCheckPermissionsInput{
+		Target: tpl.Target{Type: req.TargetType, ID: req.TargetId},
+	}
+	input.Subject = req.Subject
+	input.Permissions = req.Permissions
+	input.WithOrganization = req.WithOrganization
+	input.IgnoreScope = req.IgnoreScope
+	if err := input.Validate(); err != nil {
+		return nil, nil, toStatus(err)
+	}
+	tenant, err := tenantFromCtx(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tenant, input, nil
+}
+
+// CheckUnit 检查请求主体到指定管理单元有没有指定权限
+func (s *AC) CheckUnit(ctx context.Context, req *pb.CheckRequest) (*pb.Response, error) {
+	tenant, input, err := checkInput(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.AC.CheckUnit(ctx, *tenant, input.Subject, input.Target, input.Permissions, input.WithOrganization))
+}
+
+// CheckScope ...
+func (s *AC) CheckScope(ctx context.Context, req *pb.CheckRequest) (*pb.Response, error) {
+	tenant, input, err := checkInput(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.AC.CheckScope(ctx, *tenant, input.Subject, input.Target, input.Permissions, input.WithOrganization))
+}
+
+// CheckObject ...
+func (s *AC) CheckObject(ctx context.Context, req *pb.CheckRequest) (*pb.Response, error) {
+	tenant, input, err := checkInput(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.AC.CheckObject(ctx, *tenant, input.Subject, input.Target, input.Permissions, input.WithOrganization, input.IgnoreScope))
+}
@@ -0,0 +1,175 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/pb"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// snapshotChunkSize ExportTenant 每个 SnapshotChunk 的最大字节数
+const snapshotChunkSize = 64 * 1024
+
+// Admin ..
+type Admin struct {
+	pb.UnimplementedAdminServer
+	blls *bll.Blls
+}
+
+// AddTenant ...
+func (s *Admin) AddTenant(ctx context.Context, req *pb.TenantRequest) (*pb.Response, error) {
+	input := tpl.TenantAddInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Admin.AddTenant(ctx, input.Tenant))
+}
+
+// UpdateTenantStatus ...
+func (s *Admin) UpdateTenantStatus(ctx context.Context, req *pb.TenantRequest) (*pb.Response, error) {
+	input := tpl.TenantAddInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Admin.UpdateTenantStatus(ctx, input.Tenant, input.Status))
+}
+
+// DeleteTenant ...
+func (s *Admin) DeleteTenant(ctx context.Context, req *pb.TenantRequest) (*pb.Response, error) {
+	input := tpl.TenantAddInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Admin.DeleteTenant(ctx, input.Tenant))
+}
+
+// GetDeleteTenantJob 获取删除租户任务的状态
+func (s *Admin) GetDeleteTenantJob(ctx context.Context, req *pb.TenantRequest) (*pb.Response, error) {
+	input := tpl.TenantAddInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Admin.GetDeleteTenantJob(ctx, input.Tenant))
+}
+
+// CloneTenant 启动复制租户的后台任务
+func (s *Admin) CloneTenant(ctx context.Context, req *pb.TenantCloneRequest) (*pb.Response, error) {
+	input := tpl.TenantCloneInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+
+	var mapper bll.SubjectMapper
+	if len(input.SubjectMapping) > 0 {
+		mapper = bll.MapSubjects(input.SubjectMapping)
+	}
+	return respond(s.blls.Admin.CloneTenant(ctx, input.Source, input.Target, input.WithSubjects, mapper))
+}
+
+// GetCloneTenantJob 获取复制租户任务的状态
+func (s *Admin) GetCloneTenantJob(ctx context.Context, req *pb.TenantRequest) (*pb.Response, error) {
+	input := tpl.TenantAddInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Admin.GetCloneTenantJob(ctx, input.Tenant))
+}
+
+// chunkWriter 将写入的数据作为 SnapshotChunk 发送
+type chunkWriter struct {
+	stream pb.Admin_ExportTenantServer
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&pb.SnapshotChunk{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ExportTenant 以 SnapshotChunk 流的形式导出租户快照，拼接所有 chunk 即为 NDJSON 格式的快照
+func (s *Admin) ExportTenant(req *pb.TenantRequest, stream pb.Admin_ExportTenantServer) error {
+	input := tpl.TenantAddInput{}
+	if err := bind(req, &input); err != nil {
+		return err
+	}
+
+	export, err := s.blls.Admin.ExportTenant(stream.Context(), input.Tenant)
+	if err != nil {
+		return toStatus(err)
+	}
+	w := bufio.NewWriterSize(&chunkWriter{stream: stream}, snapshotChunkSize)
+	if err := export(w); err != nil {
+		return toStatus(err)
+	}
+	if err := w.Flush(); err != nil {
+		return toStatus(err)
+	}
+	return nil
+}
+
+// chunkReader 从 SnapshotChunk 流中读取数据
+type chunkReader struct {
+	stream pb.Admin_ImportTenantServer
+	buf    []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// ImportTenant 导入 SnapshotChunk 流形式的租户快照，内容为 ExportTenant 的输出
+func (s *Admin) ImportTenant(stream pb.Admin_ImportTenantServer) error {
+	res, err := respond(s.blls.Admin.ImportTenant(stream.Context(), &chunkReader{stream: stream}))
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(res)
+}
+
+// ListTenants ...
+func (s *Admin) ListTenants(ctx context.Context, req *pb.Pagination) (*pb.Response, error) {
+	input := tpl.Pagination{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Admin.ListTenants(ctx, input))
+}
+
+// BatchAddSubjects ...
+func (s *Admin) BatchAddSubjects(ctx context.Context, req *pb.SubjectsRequest) (*pb.Response, error) {
+	input := tpl.SubjectsInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Admin.BatchAddSubjects(ctx, input.Subjects))
+}
+
+// UpdateSubjectStatus ...
+func (s *Admin) UpdateSubjectStatus(ctx context.Context, req *pb.SubjectUpdateRequest) (*pb.Response, error) {
+	input := tpl.SubjectUpdateInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Admin.UpdateSubjectStatus(ctx, input.Sub, input.Status))
+}
+
+// ListSubjects ...
+func (s *Admin) ListSubjects(ctx context.Context, req *pb.Pagination) (*pb.Response, error) {
+	input := tpl.Pagination{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Admin.ListSubjects(ctx, input))
+}
@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/pb"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Object ..
+type Object struct {
+	pb.UnimplementedObjectServer
+	blls *bll.Blls
+}
+
+// BatchAdd 批量添加资源对象
+func (s *Object) BatchAdd(ctx context.Context, req *pb.TargetBatchAddRequest) (*pb.Response, error) {
+	input := tpl.TargetBatchAddInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Object.BatchAdd(ctx, *tenant, input.Targets, input.Parent, input.Scope))
+}
+
+// AddPermissions ...
+func (s *Object) AddPermissions(ctx context.Context, req *pb.ObjectAddPermissionsRequest) (*pb.Response, error) {
+	input := tpl.ObjectAddPermissionsInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Object.AddPermissions(ctx, *tenant, input.Target, input.Permissions))
+}
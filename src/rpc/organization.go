@@ -0,0 +1,150 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/pb"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Organization ..
+type Organization struct {
+	pb.UnimplementedOrganizationServer
+	blls *bll.Blls
+}
+
+// AddOrg 添加组织
+func (s *Organization) AddOrg(ctx context.Context, req *pb.OrganizationRequest) (*pb.Response, error) {
+	input := tpl.OrganizationInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.AddOrg(ctx, input.Org))
+}
+
+// UpdateOrgStatus ...
+func (s *Organization) UpdateOrgStatus(ctx context.Context, req *pb.OrganizationStatusRequest) (*pb.Response, error) {
+	input := tpl.OrganizationStatusInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.UpdateOrgStatus(ctx, input.Org, input.Status))
+}
+
+// ListOrgs ...
+func (s *Organization) ListOrgs(ctx context.Context, req *pb.Pagination) (*pb.Response, error) {
+	input := tpl.Pagination{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.ListOrgs(ctx, input))
+}
+
+// ListSubjectOrgs ...
+func (s *Organization) ListSubjectOrgs(ctx context.Context, req *pb.OrganizationListSubjectOrgsRequest) (*pb.Response, error) {
+	input := tpl.OrganizationListSubjectOrgsInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.ListSubjectOrgs(ctx, input.Subject, input.Pagination))
+}
+
+// AddOU ...
+func (s *Organization) AddOU(ctx context.Context, req *pb.OrganizationAddOURequest) (*pb.Response, error) {
+	input := tpl.OrganizationAddOUInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.AddOU(ctx, input.Org, input))
+}
+
+// UpdateOUParent ...
+func (s *Organization) UpdateOUParent(ctx context.Context, req *pb.OrganizationUpdateOUParentRequest) (*pb.Response, error) {
+	input := tpl.OrganizationUpdateOUParentInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.UpdateOUParent(ctx, input.Org, input))
+}
+
+// ListOUs ...
+func (s *Organization) ListOUs(ctx context.Context, req *pb.OrganizationListOUsRequest) (*pb.Response, error) {
+	input := tpl.OrganizationListOUsInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.ListOUs(ctx, input.Org, input.Parent, input.Pagination))
+}
+
+// ListSubjectOUs ...
+func (s *Organization) ListSubjectOUs(ctx context.Context, req *pb.OrganizationListSubjectOUsRequest) (*pb.Response, error) {
+	input := tpl.OrganizationListSubjectOUsInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.ListSubjectOUs(ctx, input.Subject, input.Org, input.Pagination))
+}
+
+// SearchOUs ...
+func (s *Organization) SearchOUs(ctx context.Context, req *pb.OrganizationSearchRequest) (*pb.Response, error) {
+	input := tpl.OrganizationSearchInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.SearchOUs(ctx, input.Org, input.Term, input.Pagination))
+}
+
+// BatchAddMember ...
+func (s *Organization) BatchAddMember(ctx context.Context, req *pb.OrganizationBatchAddMemberRequest) (*pb.Response, error) {
+	input := tpl.OrganizationBatchAddMemberInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.BatchAddMember(ctx, input.Org, input))
+}
+
+// ListMembers ...
+func (s *Organization) ListMembers(ctx context.Context, req *pb.OrganizationListRequest) (*pb.Response, error) {
+	input := tpl.OrganizationListInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.ListMembers(ctx, input.Org, input.Pagination))
+}
+
+// SearchMember ...
+func (s *Organization) SearchMember(ctx context.Context, req *pb.OrganizationSearchRequest) (*pb.Response, error) {
+	input := tpl.OrganizationSearchInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.SearchMember(ctx, input.Org, input.Term, input.Pagination))
+}
+
+// BatchAddOUMember ...
+func (s *Organization) BatchAddOUMember(ctx context.Context, req *pb.OrganizationBatchAddOUMemberRequest) (*pb.Response, error) {
+	input := tpl.OrganizationBatchAddOUMemberInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.BatchAddOUMember(ctx, input.Org, input))
+}
+
+// ListOUMembers ...
+func (s *Organization) ListOUMembers(ctx context.Context, req *pb.OrganizationListOUMembersRequest) (*pb.Response, error) {
+	input := tpl.OrganizationListOUMembersInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.ListOUMembers(ctx, input.Org, input.OU, input.Pagination))
+}
+
+// ListOUDescendantMembers ...
+func (s *Organization) ListOUDescendantMembers(ctx context.Context, req *pb.OrganizationListOUMembersRequest) (*pb.Response, error) {
+	input := tpl.OrganizationListOUMembersInput{}
+	if err := bind(req, &input); err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Organization.ListOUDescendantMembers(ctx, input.Org, input.OU, input.Pagination))
+}
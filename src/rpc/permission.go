@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/pb"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Permission ..
+type Permission struct {
+	pb.UnimplementedPermissionServer
+	blls *bll.Blls
+}
+
+// BatchAdd 批量添加权限
+func (s *Permission) BatchAdd(ctx context.Context, req *pb.PermissionBatchAddRequest) (*pb.Response, error) {
+	input := tpl.PermissionBatchAddInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Permission.BatchAdd(ctx, *tenant, input.Permissions))
+}
+
+// List ...
+func (s *Permission) List(ctx context.Context, req *pb.PermissionListRequest) (*pb.Response, error) {
+	input := tpl.PermissionListInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Permission.List(ctx, *tenant, input.Resources, input.Pagination))
+}
+
+// Delete ...
+func (s *Permission) Delete(ctx context.Context, req *pb.PermissionDeleteRequest) (*pb.Response, error) {
+	input := tpl.PermissionDeleteInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Permission.Delete(ctx, *tenant, input.Permission, input.Force))
+}
+
+// Usage ...
+func (s *Permission) Usage(ctx context.Context, req *pb.PermissionRequest) (*pb.Response, error) {
+	input := tpl.PermissionInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Permission.Usage(ctx, *tenant, input.Permission))
+}
+
+// Rename ...
+func (s *Permission) Rename(ctx context.Context, req *pb.PermissionMigrateRequest) (*pb.Response, error) {
+	input := tpl.PermissionMigrateInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Permission.Rename(ctx, *tenant, input.From, input.To))
+}
+
+// Merge ...
+func (s *Permission) Merge(ctx context.Context, req *pb.PermissionMigrateRequest) (*pb.Response, error) {
+	input := tpl.PermissionMigrateInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Permission.Merge(ctx, *tenant, input.From, input.To))
+}
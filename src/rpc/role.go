@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/pb"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Role ..
+type Role struct {
+	pb.UnimplementedRoleServer
+	blls *bll.Blls
+}
+
+// Add 添加角色
+func (s *Role) Add(ctx context.Context, req *pb.RoleAddRequest) (*pb.Response, error) {
+	input := tpl.RoleAddInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Role.Add(ctx, *tenant, input.Role, input.Permissions))
+}
+
+// Get ...
+func (s *Role) Get(ctx context.Context, req *pb.RoleRequest) (*pb.Response, error) {
+	input := tpl.RoleInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Role.Get(ctx, *tenant, input.Role))
+}
+
+// Update ...
+func (s *Role) Update(ctx context.Context, req *pb.RoleAddRequest) (*pb.Response, error) {
+	input := tpl.RoleAddInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Role.Update(ctx, *tenant, input.Role, input.Permissions))
+}
+
+// Delete ...
+func (s *Role) Delete(ctx context.Context, req *pb.RoleRequest) (*pb.Response, error) {
+	input := tpl.RoleInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Role.Delete(ctx, *tenant, input.Role))
+}
+
+// List ...
+func (s *Role) List(ctx context.Context, req *pb.Pagination) (*pb.Response, error) {
+	input := tpl.RoleListInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Role.List(ctx, *tenant, input.Pagination))
+}
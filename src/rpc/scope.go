@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/pb"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Scope ..
+type Scope struct {
+	pb.UnimplementedScopeServer
+	blls *bll.Blls
+}
+
+// Add 添加范围约束
+func (s *Scope) Add(ctx context.Context, req *pb.Target) (*pb.Response, error) {
+	input := tpl.Target{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Scope.Add(ctx, *tenant, input))
+}
+
+// Delete ...
+func (s *Scope) Delete(ctx context.Context, req *pb.Target) (*pb.Response, error) {
+	input := tpl.Target{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Scope.Delete(ctx, *tenant, input))
+}
+
+// DeleteAll ...
+func (s *Scope) DeleteAll(ctx context.Context, req *pb.Target) (*pb.Response, error) {
+	input := tpl.Target{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Scope.DeleteAll(ctx, *tenant, input))
+}
+
+// UpdateStatus ...
+func (s *Scope) UpdateStatus(ctx context.Context, req *pb.ScopeUpdateRequest) (*pb.Response, error) {
+	input := tpl.ScopeUpdateInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Scope.UpdateStatus(ctx, *tenant, input.Target, input.Status))
+}
+
+// List ...
+func (s *Scope) List(ctx context.Context, req *pb.ScopeListRequest) (*pb.Response, error) {
+	input := tpl.ScopeListInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Scope.List(ctx, *tenant, input.TargetType, input.Pagination))
+}
+
+// ListUnits ...
+func (s *Scope) ListUnits(ctx context.Context, req *pb.ScopeListUnitObjectsRequest) (*pb.Response, error) {
+	input := tpl.ScopeListUnitObjectsInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Scope.ListUnits(ctx, *tenant, input.Scope, input.TargetType, input.Pagination))
+}
+
+// ListObjects ...
+func (s *Scope) ListObjects(ctx context.Context, req *pb.ScopeListUnitObjectsRequest) (*pb.Response, error) {
+	input := tpl.ScopeListUnitObjectsInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Scope.ListObjects(ctx, *tenant, input.Scope, input.TargetType, input.Pagination))
+}
@@ -0,0 +1,291 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/logging"
+	"github.com/open-trust/ot-ac/src/metrics"
+	"github.com/open-trust/ot-ac/src/middleware"
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/pb"
+	"github.com/open-trust/ot-ac/src/tpl"
+	"github.com/open-trust/ot-ac/src/util"
+	otgo "github.com/open-trust/ot-go-lib"
+	"github.com/teambition/gear"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func init() {
+	util.DigProvide(NewServer)
+}
+
+// timeout 未设置 deadline 的 unary 请求的超时时间，与 HTTP 接口一致
+const timeout = time.Second * 5
+
+// adminServices 需要管理员身份的服务，其它服务需要租户身份，与 HTTP 路由的 VerifyAdmin/VerifyTenant 对应
+var adminServices = map[string]bool{
+	"otac.v1.Admin":        true,
+	"otac.v1.Organization": true,
+}
+
+type contextKey int
+
+const (
+	tenantKey contextKey = iota
+)
+
+// NewServer 创建 gRPC 服务，各服务与 HTTP 接口一一对应，调用相同的 bll.Blls
+func NewServer(blls *bll.Blls) (*grpc.Server, error) {
+	a := &authenticator{blls: blls}
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(a.unary),
+		grpc.StreamInterceptor(a.stream),
+	}
+	if conf.Config.CertFile != "" && conf.Config.KeyFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(conf.Config.CertFile, conf.Config.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	srv := grpc.NewServer(opts...)
+	pb.RegisterACServer(srv, &AC{blls: blls})
+	pb.RegisterAdminServer(srv, &Admin{blls: blls})
+	pb.RegisterObjectServer(srv, &Object{blls: blls})
+	pb.RegisterOrganizationServer(srv, &Organization{blls: blls})
+	pb.RegisterPermissionServer(srv, &Permission{blls: blls})
+	pb.RegisterRoleServer(srv, &Role{blls: blls})
+	pb.RegisterScopeServer(srv, &Scope{blls: blls})
+	pb.RegisterUnitServer(srv, &Unit{blls: blls})
+	return srv, nil
+}
+
+// ListenWithContext 在 addr 上启动 gRPC 服务，ctx 结束时优雅关闭
+func ListenWithContext(ctx context.Context, srv *grpc.Server, addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+	return srv.Serve(l)
+}
+
+type authenticator struct {
+	blls *bll.Blls
+}
+
+// authenticate 从 metadata 中的 authorization（Bearer OTVID）验证请求者身份，并根据 prefer 生成新的 context
+func (a *authenticator) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	h := http.Header{}
+	for _, v := range md.Get("authorization") {
+		h.Add(gear.HeaderAuthorization, v)
+	}
+	token := otgo.ExtractTokenFromHeader(h)
+
+	service := strings.SplitN(strings.TrimPrefix(fullMethod, "/"), "/", 2)[0]
+	if adminServices[service] {
+		vid, err := middleware.AuthenticateAdmin(ctx, token)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		ctx = model.ContextWithActor(ctx, vid.ID.String())
+	} else {
+		vid, tenant, err := middleware.AuthenticateTenant(ctx, a.blls, token)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		ctx = context.WithValue(ctx, tenantKey, tenant)
+		ctx = model.ContextWithActor(ctx, vid.ID.String())
+	}
+	return model.PreferContext(ctx, md.Get("prefer")), nil
+}
+
+func (a *authenticator) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		observe(nil, info.FullMethod, start, err)
+		return nil, err
+	}
+	res, err := handler(ctx, req)
+	observe(ctx, info.FullMethod, start, err)
+	return res, err
+}
+
+// observe 按方法和租户等级记录 gRPC 请求数和处理时间，ctx 为 nil 表示未通过身份验证
+func observe(ctx context.Context, method string, start time.Time, err error) {
+	tier := metrics.TenantTier("")
+	if ctx != nil {
+		if tenant, ok := ctx.Value(tenantKey).(*tpl.Tenant); ok {
+			tier = metrics.TenantTier(tenant.Tenant)
+		}
+	}
+	metrics.GRPCRequests.WithLabelValues(method, status.Code(err).String(), tier).Inc()
+	metrics.GRPCRequestDuration.WithLabelValues(method, tier).Observe(time.Since(start).Seconds())
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (a *authenticator) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		observe(nil, info.FullMethod, start, err)
+		return err
+	}
+	err = handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	observe(ctx, info.FullMethod, start, err)
+	return err
+}
+
+func tenantFromCtx(ctx context.Context) (*tpl.Tenant, error) {
+	tenant, ok := ctx.Value(tenantKey).(*tpl.Tenant)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "tenant not exist")
+	}
+	return tenant, nil
+}
+
+// codeFromHTTP 将 HTTP 状态码映射为 gRPC 状态码
+func codeFromHTTP(code int) codes.Code {
+	switch code {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed, http.StatusPreconditionRequired:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	if code >= 500 {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// toStatus 将 bll 返回的错误转换为 gRPC 状态，与 HTTP 接口的错误处理一致
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch err {
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case context.Canceled:
+		return status.Error(codes.Canceled, err.Error())
+	}
+
+	// 冲突等业务错误由 model 和 bll 返回对应状态码的 gear.HTTPError，这里只按状态码映射
+	e := gear.ParseError(err)
+	code := codeFromHTTP(e.Status())
+	if code == codes.Internal {
+		logging.Errf("grpc internal error: %v", err)
+	}
+	return status.Error(code, e.Error())
+}
+
+// bind 将请求消息转换为与 HTTP 接口相同的 tpl 输入并验证，请求消息字段的 JSON 名称与 tpl 输入一致
+func bind(req proto.Message, input gear.BodyTemplate) error {
+	data, err := protojson.Marshal(req)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := json.Unmarshal(data, input); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := input.Validate(); err != nil {
+		return toStatus(err)
+	}
+	return nil
+}
+
+// bindTenant 同 bind，并返回请求者的租户
+func bindTenant(ctx context.Context, req proto.Message, input gear.BodyTemplate) (*tpl.Tenant, error) {
+	if err := bind(req, input); err != nil {
+		return nil, err
+	}
+	return tenantFromCtx(ctx)
+}
+
+func toValue(v interface{}) (*structpb.Value, error) {
+	switch x := v.(type) {
+	case nil:
+		return structpb.NewNullValue(), nil
+	case bool:
+		return structpb.NewBoolValue(x), nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	val := &structpb.Value{}
+	if err := protojson.Unmarshal(data, val); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// respond 将 bll 的返回值转换为 gRPC 响应
+func respond(res *tpl.SuccessResponseType, err error) (*pb.Response, error) {
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if res == nil {
+		res = &tpl.SuccessResponseType{}
+	}
+	val, err := toValue(res.Result)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.Response{
+		TotalCount: int32(res.TotalCount),
+		NextToken:  res.NextToken,
+		Result:     val,
+	}, nil
+}
@@ -0,0 +1,171 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/model"
+	"github.com/open-trust/ot-ac/src/pb"
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/tpl"
+	otgo "github.com/open-trust/ot-go-lib"
+	"github.com/teambition/gear"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testToken 用测试配置的私钥签发 sub 的 OTVID，并将对应的公钥设为信任域公钥
+func testToken(t *testing.T, sub string) string {
+	t.Helper()
+	keys, err := otgo.ParseSet(conf.Config.OpenTrust.PrivateKeys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf.OT.SetDomainKeys(*otgo.LookupPublicKeys(keys))
+	key, err := otgo.LookupSigningKey(keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := otgo.ParseOTID(sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vid := &otgo.OTVID{
+		ID:       id,
+		Issuer:   conf.OT.TrustDomain.OTID(),
+		Audience: conf.OT.OTID,
+		Expiry:   time.Now().Add(time.Minute),
+	}
+	token, err := vid.Sign(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestAuthenticatorUnary(t *testing.T) {
+	dg, err := dgraph.NewDgraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &model.Model{Dgraph: dg}
+	a := &authenticator{blls: &bll.Blls{Models: &model.Models{Model: m, Tenant: &model.Tenant{Model: m}}}}
+
+	admin := testToken(t, conf.OT.OTID.String())
+	tenant := testToken(t, "otid:ot.example.com:app:unregistered")
+	cases := []struct {
+		name, method, token string
+		code                codes.Code
+		msg                 string
+	}{
+		{"admin service with admin", "/otac.v1.Admin/AddTenant", admin, codes.OK, ""},
+		{"organization service with admin", "/otac.v1.Organization/List", admin, codes.OK, ""},
+		{"admin service without token", "/otac.v1.Admin/AddTenant", "", codes.Unauthenticated, "invalid authorization token"},
+		{"admin service with tenant", "/otac.v1.Admin/AddTenant", tenant, codes.PermissionDenied, "is not admin"},
+		{"admin service with invalid token", "/otac.v1.Admin/AddTenant", admin + "x", codes.Unauthenticated, ""},
+		// 租户服务按租户验证，管理员身份不是已注册的租户
+		{"tenant service with admin", "/otac.v1.Unit/BatchAdd", admin, codes.Unauthenticated, "invalid tenant"},
+		{"tenant service with unregistered tenant", "/otac.v1.AC/CheckUnit", tenant, codes.Unauthenticated, "invalid tenant"},
+		{"tenant service without token", "/otac.v1.AC/CheckUnit", "", codes.Unauthenticated, "invalid authorization token"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			md := metadata.Pairs("prefer", "respond-detail")
+			if c.token != "" {
+				md.Append("authorization", "Bearer "+c.token)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			var actor string
+			var detail bool
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				actor = model.ActorFromContext(ctx)
+				detail = model.IsRespondDetail(ctx)
+				return req, nil
+			}
+			_, err := a.unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: c.method}, handler)
+			if code := status.Code(err); code != c.code {
+				t.Fatalf("got %v, want %s", err, c.code)
+			}
+			if c.code != codes.OK {
+				if !strings.Contains(status.Convert(err).Message(), c.msg) || actor != "" {
+					t.Fatalf("got %v, actor %q", err, actor)
+				}
+				return
+			}
+			if actor != conf.OT.OTID.String() || !detail {
+				t.Fatalf("context got actor %q, respond-detail %v", actor, detail)
+			}
+		})
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{gear.ErrConflict.WithMsg("tenant exists"), codes.AlreadyExists},
+		{gear.ErrNotFound.WithMsg("resource not found"), codes.NotFound},
+		{gear.ErrBadRequest.WithMsg("invalid"), codes.InvalidArgument},
+		{gear.ErrNotImplemented, codes.Unimplemented},
+		{context.DeadlineExceeded, codes.DeadlineExceeded},
+		{status.Error(codes.Aborted, "aborted"), codes.Aborted},
+		// 没有类型的错误不按错误消息猜测状态码
+		{errors.New("unit already exists"), codes.Internal},
+	}
+	for _, c := range cases {
+		if code := status.Code(toStatus(c.err)); code != c.code {
+			t.Fatalf("toStatus(%v) got %s, want %s", c.err, code, c.code)
+		}
+	}
+}
+
+func TestBind(t *testing.T) {
+	notAfter := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	input := tpl.UnitAddSubjectsInput{}
+	err := bind(&pb.UnitAddSubjectsRequest{
+		TargetType: "team",
+		TargetId:   "t1",
+		Subjects:   []string{"user:1", "user:2"},
+		NotAfter:   timestamppb.New(notAfter),
+	}, &input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input.Type != "team" || input.ID != "t1" || len(input.Subjects) != 2 || input.Subjects[1] != "user:2" ||
+		input.NotBefore != nil || input.NotAfter == nil || !input.NotAfter.Equal(notAfter) {
+		data, _ := json.Marshal(input)
+		t.Fatalf("bind got %s", data)
+	}
+
+	batch := tpl.TargetBatchAddInput{}
+	err = bind(&pb.TargetBatchAddRequest{
+		Targets: []*pb.Target{{TargetType: "doc", TargetId: "a"}, {TargetType: "doc", TargetId: "b"}},
+		Parent:  &pb.Target{TargetType: "doc", TargetId: "root"},
+	}, &batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch.Targets) != 2 || batch.Targets[1].ID != "b" || batch.Parent == nil || batch.Parent.ID != "root" || batch.Scope != nil {
+		t.Fatalf("bind got %+v", batch)
+	}
+
+	// tpl 输入的验证错误转换为 InvalidArgument
+	err = bind(&pb.TargetBatchAddRequest{}, &tpl.TargetBatchAddInput{})
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(status.Convert(err).Message(), "targets empty") {
+		t.Fatalf("bind empty targets got %v", err)
+	}
+	err = bind(&pb.TenantRequest{Tenant: "invalid"}, &tpl.TenantAddInput{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("bind invalid tenant got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/open-trust/ot-ac/src/bll"
+	"github.com/open-trust/ot-ac/src/pb"
+	"github.com/open-trust/ot-ac/src/tpl"
+)
+
+// Unit ..
+type Unit struct {
+	pb.UnimplementedUnitServer
+	blls *bll.Blls
+}
+
+// BatchAdd 批量添加管理单元
+func (s *Unit) BatchAdd(ctx context.Context, req *pb.TargetBatchAddRequest) (*pb.Response, error) {
+	input := tpl.TargetBatchAddInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Unit.BatchAdd(ctx, *tenant, input.Targets, input.Parent, input.Scope))
+}
+
+// AddFromOrg ...
+func (s *Unit) AddFromOrg(ctx context.Context, req *pb.UnitAddFromOrgRequest) (*pb.Response, error) {
+	input := tpl.UnitAddFromOrgInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Unit.AddFromOrg(ctx, *tenant, input.Target, input.Org, input.Parent, input.Scope))
+}
+
+// AddFromOU ...
+func (s *Unit) AddFromOU(ctx context.Context, req *pb.UnitAddFromOURequest) (*pb.Response, error) {
+	input := tpl.UnitAddFromOUInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Unit.AddFromOU(ctx, *tenant, input.Target, input.Org, input.OU, input.Parent, input.Scope))
+}
+
+// AddFromMembers ...
+func (s *Unit) AddFromMembers(ctx context.Context, req *pb.UnitAddFromMembersRequest) (*pb.Response, error) {
+	input := tpl.UnitAddFromMembersInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Unit.AddFromMembers(ctx, *tenant, input.Target, input.Org, input.Subjects, input.Parent, input.Scope))
+}
+
+// AssignParent ...
+func (s *Unit) AssignParent(ctx context.Context, req *pb.UnitAssignParentRequest) (*pb.Response, error) {
+	input := tpl.UnitAssignParentInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Unit.AssignParent(ctx, *tenant, input.Target, input.Parent))
+}
+
+// AssignScope ...
+func (s *Unit) AssignScope(ctx context.Context, req *pb.UnitAssignScopeRequest) (*pb.Response, error) {
+	input := tpl.UnitAssignScopeInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Unit.AssignScope(ctx, *tenant, input.Target, input.Scope))
+}
+
+// AssignObject ...
+func (s *Unit) AssignObject(ctx context.Context, req *pb.UnitAssignObjectRequest) (*pb.Response, error) {
+	input := tpl.UnitAssignObjectInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Unit.AssignObject(ctx, *tenant, input.Target, input.Object))
+}
+
+// AddSubjects ...
+func (s *Unit) AddSubjects(ctx context.Context, req *pb.UnitAddSubjectsRequest) (*pb.Response, error) {
+	input := tpl.UnitAddSubjectsInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Unit.AddSubjects(ctx, *tenant, input.Target, input.Subjects, input.Validity))
+}
+
+// AddPermissions ...
+func (s *Unit) AddPermissions(ctx context.Context, req *pb.UnitAddPermissionsRequest) (*pb.Response, error) {
+	input := tpl.UnitAddPermissionsInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Unit.AddPermissions(ctx, *tenant, input.Target, input.Permissions))
+}
+
+// AddRoles ...
+func (s *Unit) AddRoles(ctx context.Context, req *pb.UnitRolesRequest) (*pb.Response, error) {
+	input := tpl.UnitRolesInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Unit.AddRoles(ctx, *tenant, input.Target, input.Roles))
+}
+
+// RemoveRoles ...
+func (s *Unit) RemoveRoles(ctx context.Context, req *pb.UnitRolesRequest) (*pb.Response, error) {
+	input := tpl.UnitRolesInput{}
+	tenant, err := bindTenant(ctx, req, &input)
+	if err != nil {
+		return nil, err
+	}
+	return respond(s.blls.Unit.RemoveRoles(ctx, *tenant, input.Target, input.Roles))
+}
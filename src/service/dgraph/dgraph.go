@@ -13,11 +13,13 @@ import (
 
 	"github.com/open-trust/ot-ac/src/conf"
 	"github.com/open-trust/ot-ac/src/logging"
+	"github.com/open-trust/ot-ac/src/metrics"
 	"github.com/open-trust/ot-ac/src/util"
 
 	"github.com/dgraph-io/dgo/v200"
 	"github.com/dgraph-io/dgo/v200/protos/api"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/encoding/gzip"
 )
 
@@ -165,7 +167,8 @@ func writeNquad(w io.Writer, subject, predicate string, object interface{}) erro
 // Dgraph ...
 type Dgraph struct {
 	*dgo.Dgraph
-	dc api.DgraphClient
+	dc   api.DgraphClient
+	conn *grpc.ClientConn
 }
 
 // NewDgraph ...
@@ -185,7 +188,14 @@ func NewDgraph() (*Dgraph, error) {
 
 	dc := api.NewDgraphClient(conn)
 	dg := dgo.NewDgraphClient(dc)
-	return &Dgraph{Dgraph: dg, dc: dc}, nil
+	states := make([]string, 0, connectivity.Shutdown+1)
+	for st := connectivity.Idle; st <= connectivity.Shutdown; st++ {
+		states = append(states, st.String())
+	}
+	metrics.DgraphConnectionState.Watch(states, func() string {
+		return conn.GetState().String()
+	})
+	return &Dgraph{Dgraph: dg, dc: dc, conn: conn}, nil
 }
 
 // CheckHealth ...
@@ -199,6 +209,13 @@ func (dg *Dgraph) CheckHealth(ctx context.Context) (interface{}, error) {
 
 // Query ...
 func (dg *Dgraph) Query(ctx context.Context, query string, vars map[string]string, out interface{}) error {
+	if t := TxnFromCtx(ctx); t != nil {
+		resp, err := t.QueryWithVars(ctx, query, vars)
+		if err == nil && out != nil && len(resp.Json) > 0 {
+			err = json.Unmarshal(resp.Json, out)
+		}
+		return err
+	}
 	txn := dg.NewReadOnlyTxn()
 	resp, err := loggingDgraph(ctx, func() (*api.Response, error) {
 		return txn.QueryWithVars(ctx, query, vars)
@@ -211,6 +228,9 @@ func (dg *Dgraph) Query(ctx context.Context, query string, vars map[string]strin
 
 // QueryBestEffort ...
 func (dg *Dgraph) QueryBestEffort(ctx context.Context, query string, vars map[string]string, out interface{}) error {
+	if TxnFromCtx(ctx) != nil {
+		return dg.Query(ctx, query, vars, out)
+	}
 	txn := dg.NewReadOnlyTxn().BestEffort()
 	resp, err := loggingDgraph(ctx, func() (*api.Response, error) {
 		return txn.QueryWithVars(ctx, query, vars)
@@ -221,11 +241,195 @@ func (dg *Dgraph) QueryBestEffort(ctx context.Context, query string, vars map[st
 	return err
 }
 
+// Txn 读写事务，查询、mutation 与提交都经过 loggingDgraph，与 Do、Query 一样记录耗时与指标
+type Txn struct {
+	txn *dgo.Txn
+	// done 事务已提交或丢弃
+	done bool
+	// pq、pending 等待随下一个请求执行的 Prefetch
+	pq      *Query
+	pbodies []DQL
+	pending []*Prefetch
+}
+
+// Prefetch 随事务中的下一个请求一起执行的查询块，用于在写操作的请求中同时读取事务写入之前的数据，不增加请求
+type Prefetch struct {
+	blocks []string
+	res    map[string]json.RawMessage
+	done   bool
+}
+
+// Result 返回各查询块的结果，查询尚未执行或请求失败时 ok 为 false
+func (p *Prefetch) Result() (res map[string]json.RawMessage, ok bool) {
+	return p.res, p.done
+}
+
+// Prefetch 以 build 生成的查询块注册 Prefetch，build 返回查询块和查询块的名称，这些查询块的结果不会出现在请求的响应中。
+// 查询变量通过 build 的参数注册，与请求自身的变量不冲突
+func (t *Txn) Prefetch(build func(q *Query) (DQL, []string)) *Prefetch {
+	if t.pq == nil {
+		t.pq = &Query{prefix: "$p", vars: make(map[string]string)}
+	}
+	body, blocks := build(t.pq)
+	p := &Prefetch{blocks: blocks}
+	t.pbodies = append(t.pbodies, body)
+	t.pending = append(t.pending, p)
+	return p
+}
+
+// merge 将等待执行的 Prefetch 的查询块合并到请求的查询中，query 为 Query.Build 生成的查询或空字符串
+func (t *Txn) merge(query string, vars map[string]string) (string, map[string]string, []*Prefetch) {
+	ps := t.pending
+	if len(ps) == 0 {
+		return query, vars, nil
+	}
+	params := make([]string, 0, len(t.pq.params)+1)
+	bodies := make([]string, 0, len(t.pbodies)+1)
+	merged := make(map[string]string, len(vars)+len(t.pq.vars))
+	if i := strings.Index(query, "{"); i >= 0 {
+		header := query[:i]
+		if j := strings.Index(header, "("); j >= 0 {
+			params = append(params, header[j+1:strings.LastIndex(header, ")")])
+		}
+		bodies = append(bodies, strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(query[i+1:]), "}")))
+	}
+	for k, v := range vars {
+		merged[k] = v
+	}
+	params = append(params, t.pq.params...)
+	for k, v := range t.pq.vars {
+		merged[k] = v
+	}
+	for _, body := range t.pbodies {
+		bodies = append(bodies, body.s)
+	}
+	t.pq, t.pbodies, t.pending = nil, nil, nil
+	if len(params) == 0 {
+		return fmt.Sprintf("query {\n%s\n}", strings.Join(bodies, "\n")), merged, ps
+	}
+	return fmt.Sprintf("query q(%s) {\n%s\n}", strings.Join(params, ", "), strings.Join(bodies, "\n")), merged, ps
+}
+
+// fetched 从响应中取出 Prefetch 的查询块的结果
+func fetched(resp *api.Response, ps []*Prefetch) error {
+	if len(ps) == 0 {
+		return nil
+	}
+	res := make(map[string]json.RawMessage)
+	if len(resp.Json) > 0 {
+		if err := json.Unmarshal(resp.Json, &res); err != nil {
+			return err
+		}
+	}
+	for _, p := range ps {
+		p.res = make(map[string]json.RawMessage, len(p.blocks))
+		for _, block := range p.blocks {
+			p.res[block] = res[block]
+			delete(res, block)
+		}
+		p.done = true
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	resp.Json = data
+	return nil
+}
+
+// QueryWithVars ...
+func (t *Txn) QueryWithVars(ctx context.Context, query string, vars map[string]string) (*api.Response, error) {
+	query, vars, ps := t.merge(query, vars)
+	resp, err := loggingDgraph(ctx, func() (*api.Response, error) {
+		return t.txn.QueryWithVars(ctx, query, vars)
+	})
+	if err == nil {
+		err = fetched(resp, ps)
+	}
+	return resp, err
+}
+
+// Mutate 执行 mutation，有等待执行的 Prefetch 时与其查询块作为一个 upsert 请求执行
+func (t *Txn) Mutate(ctx context.Context, mu *api.Mutation) (*api.Response, error) {
+	if len(t.pending) > 0 {
+		return t.Do(ctx, "", nil, mu)
+	}
+	return loggingDgraph(ctx, func() (*api.Response, error) {
+		return t.txn.Mutate(ctx, mu)
+	})
+}
+
+// Do 在事务中执行带查询的 upsert 请求，事务由 Txn 提交
+func (t *Txn) Do(ctx context.Context, query string, vars map[string]string, mus ...*api.Mutation) (*api.Response, error) {
+	query, vars, ps := t.merge(query, vars)
+	resp, err := loggingDgraph(ctx, func() (*api.Response, error) {
+		return t.txn.Do(ctx, &api.Request{Query: query, Vars: vars, Mutations: mus})
+	})
+	if err == nil {
+		err = fetched(resp, ps)
+	}
+	return resp, err
+}
+
+// Commit 提交事务，之后 context 中的事务不再生效
+func (t *Txn) Commit(ctx context.Context) error {
+	t.done = true
+	_, err := loggingDgraph(ctx, func() (*api.Response, error) {
+		return nil, t.txn.Commit(ctx)
+	})
+	return err
+}
+
+// Discard 丢弃事务，之后 context 中的事务不再生效
+func (t *Txn) Discard(ctx context.Context) {
+	t.done = true
+	t.txn.Discard(ctx)
+}
+
+type txnKey struct{}
+
+// WithTxn 返回携带新的读写事务的 context，使用该 context 的 Query、QueryBestEffort、Do、DoRaw 和 Txn 都在这个事务中执行，
+// 并能读到事务中尚未提交的写入；它们不会提交事务，调用方需要通过 Commit 或 Discard 结束事务
+func (dg *Dgraph) WithTxn(ctx context.Context) (context.Context, *Txn) {
+	txn := &Txn{txn: dg.NewTxn()}
+	return context.WithValue(ctx, txnKey{}, txn), txn
+}
+
+// TxnFromCtx 返回 WithTxn 开始的尚未结束的事务，没有时返回 nil
+func TxnFromCtx(ctx context.Context) *Txn {
+	if t, ok := ctx.Value(txnKey{}).(*Txn); ok && !t.done {
+		return t
+	}
+	return nil
+}
+
+// Txn 在一个读写事务中执行 fn，fn 返回 nil 时提交事务，否则丢弃事务。
+// context 携带 WithTxn 开始的事务时直接在其中执行 fn，由 WithTxn 的调用方提交
+func (dg *Dgraph) Txn(ctx context.Context, fn func(txn *Txn) error) error {
+	if t := TxnFromCtx(ctx); t != nil {
+		return fn(t)
+	}
+	txn := &Txn{txn: dg.NewTxn()}
+	defer txn.txn.Discard(ctx)
+
+	if err := fn(txn); err != nil {
+		return err
+	}
+	return txn.Commit(ctx)
+}
+
 // Do ...
 func (dg *Dgraph) Do(ctx context.Context, query string, vars map[string]string, out interface{}, mus ...*api.Mutation) error {
 	if len(mus) == 0 {
 		return dg.Query(ctx, query, vars, out)
 	}
+	if t := TxnFromCtx(ctx); t != nil {
+		resp, err := t.Do(ctx, query, vars, mus...)
+		if err == nil && out != nil && len(resp.Json) > 0 {
+			err = json.Unmarshal(resp.Json, out)
+		}
+		return err
+	}
 
 	txn := dg.NewTxn()
 	defer txn.Discard(ctx)
@@ -247,9 +451,17 @@ func (dg *Dgraph) Do(ctx context.Context, query string, vars map[string]string,
 
 // DoRaw ...
 func (dg *Dgraph) DoRaw(ctx context.Context, query string, vars map[string]string, mus ...*api.Mutation) (*api.Response, error) {
+	if t := TxnFromCtx(ctx); t != nil {
+		if len(mus) == 0 {
+			return t.QueryWithVars(ctx, query, vars)
+		}
+		return t.Do(ctx, query, vars, mus...)
+	}
 	if len(mus) == 0 {
 		txn := dg.NewReadOnlyTxn().BestEffort()
-		return txn.QueryWithVars(ctx, query, vars)
+		return loggingDgraph(ctx, func() (*api.Response, error) {
+			return txn.QueryWithVars(ctx, query, vars)
+		})
 	}
 
 	txn := dg.NewTxn()
@@ -267,10 +479,32 @@ func (dg *Dgraph) DoRaw(ctx context.Context, query string, vars map[string]strin
 	})
 }
 
+type methodKey struct{}
+
+// WithMethod 返回标记了发起 Dgraph 请求的 model 方法的 context，如 Unit.AddPermissions，用于 Dgraph 请求的指标
+func WithMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, methodKey{}, method)
+}
+
+// methodFromCtx 返回 WithMethod 标记的 model 方法，没有标记时为 other
+func methodFromCtx(ctx context.Context) string {
+	if method, ok := ctx.Value(methodKey{}).(string); ok {
+		return method
+	}
+	return "other"
+}
+
+// loggingDgraph 执行 Dgraph 请求并按发起请求的 model 方法记录往返时间，超过 10ms 时同时记录到访问日志
 func loggingDgraph(ctx context.Context, fn func() (*api.Response, error)) (*api.Response, error) {
 	startT := time.Now()
 	resp, err := fn()
-	end := time.Now().Sub(startT) / 1000000
+	elapsed := time.Now().Sub(startT)
+	method := methodFromCtx(ctx)
+	metrics.DgraphRequestDuration.WithLabelValues(method).Observe(elapsed.Seconds())
+	if err != nil {
+		metrics.DgraphRequestErrors.WithLabelValues(method).Inc()
+	}
+	end := elapsed / 1000000
 	if end > 10 {
 		logging.SetKV(ctx, "dgraphClientLatency", end)
 		if resp != nil {
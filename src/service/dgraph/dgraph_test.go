@@ -0,0 +1,79 @@
+package dgraph
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/open-trust/ot-ac/src/metrics"
+)
+
+func TestLoggingDgraph(t *testing.T) {
+	ctx := WithMethod(context.Background(), "Unit.AddPermissions")
+	loggingDgraph(ctx, func() (*api.Response, error) {
+		return &api.Response{}, nil
+	})
+	loggingDgraph(ctx, func() (*api.Response, error) {
+		return nil, errors.New("dgraph unavailable")
+	})
+	// 没有标记 model 方法的请求为 other
+	loggingDgraph(context.Background(), func() (*api.Response, error) {
+		return nil, errors.New("dgraph unavailable")
+	})
+
+	if n := testutil.ToFloat64(metrics.DgraphRequestErrors.WithLabelValues("Unit.AddPermissions")); n != 1 {
+		t.Fatalf("errors of Unit.AddPermissions got %v", n)
+	}
+	if n := testutil.ToFloat64(metrics.DgraphRequestErrors.WithLabelValues("other")); n != 1 {
+		t.Fatalf("errors of other got %v", n)
+	}
+	if n := testutil.CollectAndCount(metrics.DgraphRequestDuration); n != 2 {
+		t.Fatalf("duration series got %d", n)
+	}
+	// 嵌套调用时以最内层标记的方法为准
+	if got := methodFromCtx(WithMethod(ctx, "Tenant.Get")); got != "Tenant.Get" {
+		t.Fatalf("method got %s", got)
+	}
+}
+
+func TestTxnPrefetch(t *testing.T) {
+	txn := &Txn{}
+	p := txn.Prefetch(func(q *Query) (DQL, []string) {
+		return Sprintf(`audit_0(func: eq(OTAC.T, %s)) { uid }`, q.Str("t1")), []string{"audit_0"}
+	})
+	q := NewQuery()
+	query, vars := q.Build(Sprintf(`result(func: eq(OTAC.U.UK, %s)) { uid }`, q.Str("u1")))
+	query, vars, ps := txn.merge(query, vars)
+	want := "query q($v0: string, $p0: string) {\nresult(func: eq(OTAC.U.UK, $v0)) { uid }\naudit_0(func: eq(OTAC.T, $p0)) { uid }\n}"
+	if query != want || !reflect.DeepEqual(vars, map[string]string{"$v0": "u1", "$p0": "t1"}) || len(ps) != 1 {
+		t.Fatalf("merged query got %q, %v", query, vars)
+	}
+	// 只合并到一个请求中
+	if query, _, ps := txn.merge("", nil); query != "" || len(ps) != 0 {
+		t.Fatalf("merged again got %q", query)
+	}
+	if _, ok := p.Result(); ok {
+		t.Fatal("result before the request")
+	}
+
+	resp := &api.Response{Json: []byte(`{"result":[{"uid":"0x1"}],"audit_0":[{"uid":"0x2"}]}`)}
+	if err := fetched(resp, ps); err != nil {
+		t.Fatal(err)
+	}
+	res, ok := p.Result()
+	if !ok || string(res["audit_0"]) != `[{"uid":"0x2"}]` || string(resp.Json) != `{"result":[{"uid":"0x1"}]}` {
+		t.Fatalf("result got %s, response %s", res["audit_0"], resp.Json)
+	}
+
+	// 没有查询的 mutation 请求只带有 Prefetch 的查询块
+	txn.Prefetch(func(q *Query) (DQL, []string) {
+		return Sprintf(`audit_t(func: has(OTAC.T)) { uid }`), []string{"audit_t"}
+	})
+	if query, _, _ := txn.merge("", nil); query != "query {\naudit_t(func: has(OTAC.T)) { uid }\n}" {
+		t.Fatalf("mutation query got %q", query)
+	}
+}
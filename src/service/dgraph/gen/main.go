@@ -0,0 +1,193 @@
+// gen 根据 graphql/schema.graphql 生成 Dgraph 的 DQL schema src/service/dgraph/schema_gen.go：
+// 每个 @dgraph(pred: ...) 指定的正向谓词生成一个谓词定义，以 ~ 开头的反向边由正向谓词的 @reverse 提供。
+// 标量映射为对应的 DQL 类型，对象类型映射为 uid（均带 @reverse），@search(by: [...]) 映射为 @index，
+// @id 映射为 @upsert（没有 @search 时使用 hash 索引）。多个类型共用的谓词（如 OTAC.status）的定义必须一致。
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	in  = flag.String("in", "graphql/schema.graphql", "GraphQL schema file")
+	out = flag.String("out", "src/service/dgraph/schema_gen.go", "output Go file")
+)
+
+var (
+	typeReg   = regexp.MustCompile(`^type\s+([A-Za-z_][0-9A-Za-z_]*)\s*\{`)
+	fieldReg  = regexp.MustCompile(`^([A-Za-z_][0-9A-Za-z_]*)\s*:\s*(\[?)\s*([A-Za-z_][0-9A-Za-z_]*)`)
+	predReg   = regexp.MustCompile(`@dgraph\(pred:\s*"([^"]+)"\)`)
+	searchReg = regexp.MustCompile(`@search\(by:\s*\[([^\]]*)\]\)`)
+	idReg     = regexp.MustCompile(`@id\b`)
+)
+
+var scalarTypes = map[string]string{
+	"String":   "string",
+	"Int":      "int",
+	"Boolean":  "bool",
+	"DateTime": "datetime",
+}
+
+type predicate struct {
+	name string
+	def  string
+}
+
+type objectType struct {
+	name  string
+	preds []string
+}
+
+func main() {
+	flag.Parse()
+	types, preds, err := parse(*in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	src, err := generate(types, preds)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// parse 返回类型及其正向谓词，以及按类型分组的谓词定义，共用的谓词只在第一次出现时定义
+func parse(file string) ([]*objectType, [][]*predicate, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var types []*objectType
+	var groups [][]*predicate
+	var cur *objectType
+	defs := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		// 注释中可能提到 @facets 等指令，不参与解析
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		switch {
+		case line == "":
+		case cur == nil:
+			m := typeReg.FindStringSubmatch(line)
+			if m == nil {
+				return nil, nil, fmt.Errorf("%s:%d: type definition expected", file, n)
+			}
+			cur = &objectType{name: m[1]}
+			types = append(types, cur)
+			groups = append(groups, nil)
+		case strings.HasPrefix(line, "}"):
+			cur = nil
+		default:
+			m := fieldReg.FindStringSubmatch(line)
+			if m == nil {
+				return nil, nil, fmt.Errorf("%s:%d: field definition expected", file, n)
+			}
+			p := predReg.FindStringSubmatch(line)
+			if p == nil {
+				if m[1] != "id" {
+					return nil, nil, fmt.Errorf("%s:%d: @dgraph(pred: ...) required", file, n)
+				}
+				continue
+			}
+			if strings.HasPrefix(p[1], "~") {
+				continue
+			}
+			def := definition(m[3], m[2] == "[", line)
+			if old, ok := defs[p[1]]; ok {
+				if old != def {
+					return nil, nil, fmt.Errorf("%s:%d: %s defined as %q, want %q", file, n, p[1], def, old)
+				}
+			} else {
+				defs[p[1]] = def
+				groups[len(groups)-1] = append(groups[len(groups)-1], &predicate{name: p[1], def: def})
+			}
+			cur.preds = append(cur.preds, p[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return types, groups, nil
+}
+
+// definition 返回字段对应的谓词定义，如 [uid] @reverse、string @index(hash) @upsert
+func definition(typ string, list bool, line string) string {
+	dt, scalar := scalarTypes[typ]
+	if !scalar {
+		dt = "uid"
+	}
+	if list {
+		dt = "[" + dt + "]"
+	}
+	directives := []string{dt}
+	if !scalar {
+		directives = append(directives, "@reverse")
+	}
+	index := ""
+	if m := searchReg.FindStringSubmatch(line); m != nil {
+		by := strings.Split(m[1], ",")
+		for i := range by {
+			by[i] = strings.TrimSpace(by[i])
+		}
+		index = strings.Join(by, ", ")
+	}
+	upsert := idReg.MatchString(line)
+	if upsert && index == "" {
+		index = "hash"
+	}
+	if index != "" {
+		directives = append(directives, "@index("+index+")")
+	}
+	if upsert {
+		directives = append(directives, "@upsert")
+	}
+	return strings.Join(directives, " ")
+}
+
+func generate(types []*objectType, groups [][]*predicate) ([]byte, error) {
+	var s bytes.Buffer
+	for _, g := range groups {
+		if len(g) == 0 {
+			continue
+		}
+		for _, p := range g {
+			fmt.Fprintf(&s, "%s: %s .\n", p.name, p.def)
+		}
+		s.WriteString("\n")
+	}
+	for i, t := range types {
+		if i > 0 {
+			s.WriteString("\n")
+		}
+		fmt.Fprintf(&s, "type %s {\n", t.name)
+		for _, p := range t.preds {
+			fmt.Fprintf(&s, "\t%s\n", p)
+		}
+		s.WriteString("}\n")
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by src/service/dgraph/gen from graphql/schema.graphql. DO NOT EDIT.\n\n")
+	b.WriteString("package dgraph\n\n")
+	b.WriteString("// Schema 与 graphql/schema.graphql 对应的 DQL schema\n")
+	fmt.Fprintf(&b, "const Schema = `\n%s`\n", s.String())
+	return format.Source(b.Bytes())
+}
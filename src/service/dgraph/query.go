@@ -0,0 +1,137 @@
+package dgraph
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DQL 是可信的 DQL 文本片段，只能由 Sprintf、Join、Index 或 Query 的变量方法生成，
+// 外部输入无法直接构造 DQL
+type DQL struct {
+	s string
+}
+
+// String ...
+func (d DQL) String() string {
+	return d.s
+}
+
+// IsZero ...
+func (d DQL) IsZero() bool {
+	return d.s == ""
+}
+
+// Format DQL 格式化模板。字符串字面量（无类型常量）可以直接作为 Format 使用，
+// 而 string 类型的变量必须显式转换，从类型上避免把运行时拼接的字符串当作模板
+type Format string
+
+// Sprintf 使用 DQL 片段格式化 DQL，format 应为字面量，参数只能是 DQL 片段
+func Sprintf(format Format, args ...DQL) DQL {
+	vs := make([]interface{}, len(args))
+	for i, a := range args {
+		vs[i] = a.s
+	}
+	return DQL{fmt.Sprintf(string(format), vs...)}
+}
+
+// Join 使用 sep 连接 DQL 片段
+func Join(ds []DQL, sep string) DQL {
+	ss := make([]string, len(ds))
+	for i, d := range ds {
+		ss[i] = d.s
+	}
+	return DQL{strings.Join(ss, sep)}
+}
+
+// Index 返回整数的 DQL 片段，用于生成 DQL 变量名，如 uid_0
+func Index(i int) DQL {
+	return DQL{strconv.Itoa(i)}
+}
+
+var predicateReg = regexp.MustCompile(`^~?[A-Za-z][0-9A-Za-z._-]*$`)
+
+// Predicate 返回谓词名的 DQL 片段，谓词名只能来自代码，非法的谓词名会 panic
+func Predicate(name string) DQL {
+	if !predicateReg.MatchString(name) {
+		panic(fmt.Sprintf("invalid predicate %q", name))
+	}
+	return DQL{name}
+}
+
+// Query 参数化的 DQL 查询构造器，所有外部输入都通过 GraphQL+- 查询变量（$v0、$v1...）传入，
+// 查询文本只由字面量和变量引用组成，从结构上排除 DQL 注入
+type Query struct {
+	prefix string
+	params []string
+	vars   map[string]string
+}
+
+// NewQuery ...
+func NewQuery() *Query {
+	return &Query{prefix: "$v", vars: make(map[string]string)}
+}
+
+func (q *Query) param(typ, val string) DQL {
+	name := fmt.Sprintf("%s%d", q.prefix, len(q.params))
+	q.params = append(q.params, fmt.Sprintf("%s: %s", name, typ))
+	q.vars[name] = val
+	return DQL{name}
+}
+
+// Str 注册 string 类型的查询变量
+func (q *Query) Str(s string) DQL {
+	return q.param("string", s)
+}
+
+// Strs 为每个元素注册 string 类型的查询变量，返回如 [$v0, $v1] 的列表，用于 eq 等函数
+func (q *Query) Strs(ss []string) DQL {
+	ds := make([]DQL, len(ss))
+	for i, s := range ss {
+		ds[i] = q.Str(s)
+	}
+	return Sprintf("[%s]", Join(ds, ", "))
+}
+
+// Int 注册 int 类型的查询变量，用于 first、offset 等参数
+func (q *Query) Int(i int) DQL {
+	return q.param("int", strconv.Itoa(i))
+}
+
+// Int64 注册 int 类型的查询变量，用于比较 revision 等 64 位整数
+func (q *Query) Int64(i int64) DQL {
+	return q.param("int", strconv.FormatInt(i, 10))
+}
+
+// Time 注册 RFC3339 格式的时间查询变量
+func (q *Query) Time(t time.Time) DQL {
+	return q.Str(t.UTC().Format(time.RFC3339))
+}
+
+// UID 注册 uid 查询变量，用于 uid()、uid_in() 和 after 参数，空值会被视为 0x0
+func (q *Query) UID(uid string) DQL {
+	if uid == "" {
+		uid = "0x0"
+	}
+	return q.param("string", uid)
+}
+
+// UIDs 将多个 uid 注册为一个查询变量，如 "[0x1, 0x2]"，用于 uid() 函数
+func (q *Query) UIDs(uids []string) DQL {
+	return q.param("string", fmt.Sprintf("[%s]", strings.Join(uids, ", ")))
+}
+
+// Regexp 注册正则表达式查询变量，用于 regexp() 函数
+func (q *Query) Regexp(re string) DQL {
+	return q.Str(fmt.Sprintf("/%s/", re))
+}
+
+// Build 生成完整的 DQL 查询与查询变量，body 为查询块
+func (q *Query) Build(body DQL) (string, map[string]string) {
+	if len(q.params) == 0 {
+		return fmt.Sprintf("query {\n%s\n}", body.s), nil
+	}
+	return fmt.Sprintf("query q(%s) {\n%s\n}", strings.Join(q.params, ", "), body.s), q.vars
+}
@@ -0,0 +1,203 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+)
+
+func TestQueryBuild(t *testing.T) {
+	q := NewQuery()
+	body := Sprintf(`result(func: eq(OTAC.P, %s), first: %s, after: %s) @filter(uid_in(OTAC.P-T, %s)) { uid }`,
+		q.Strs([]string{"Doc.read", "Doc.*"}), q.Int(10), q.UID(""), q.UID("0x1"))
+	query, vars := q.Build(body)
+
+	want := "query q($v0: string, $v1: string, $v2: int, $v3: string, $v4: string) {\n" +
+		"result(func: eq(OTAC.P, [$v0, $v1]), first: $v2, after: $v3) @filter(uid_in(OTAC.P-T, $v4)) { uid }\n}"
+	if query != want {
+		t.Fatalf("unexpected query:\n%s\nwant:\n%s", query, want)
+	}
+	wantVars := map[string]string{"$v0": "Doc.read", "$v1": "Doc.*", "$v2": "10", "$v3": "0x0", "$v4": "0x1"}
+	if fmt.Sprint(vars) != fmt.Sprint(wantVars) {
+		t.Fatalf("unexpected vars: %v", vars)
+	}
+
+	// 外部输入只出现在变量中
+	q = NewQuery()
+	query, vars = q.Build(Sprintf("result(func: eq(OTAC.Sub, %s)) { uid }", q.Str(`x") { uid } evil(func: has(OTAC.T)`)))
+	if strings.Contains(query, "evil") || vars["$v0"] == "" {
+		t.Fatalf("input leaked into query: %s", query)
+	}
+
+	q = NewQuery()
+	if query, vars = q.Build(Sprintf("result(func: has(OTAC.T)) { uid }")); vars != nil || !strings.HasPrefix(query, "query {") {
+		t.Fatalf("unexpected query without params: %s %v", query, vars)
+	}
+}
+
+func TestPredicate(t *testing.T) {
+	if Predicate("~OTAC.U-Ps").String() != "~OTAC.U-Ps" {
+		t.Fatal("valid predicate rejected")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("invalid predicate should panic")
+		}
+	}()
+	Predicate("OTAC.P) { uid }")
+}
+
+// testDgraph 连接配置中的 Dgraph，Dgraph 不可用时跳过测试
+func testDgraph(t *testing.T) *Dgraph {
+	t.Helper()
+	dg, err := NewDgraph()
+	if err != nil {
+		t.Skipf("Dgraph unavailable: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := dg.CheckHealth(ctx); err != nil {
+		t.Skipf("Dgraph unavailable: %v", err)
+	}
+	return dg
+}
+
+const testSchema = `
+OTACTest.name: string @index(exact, trigram) .
+OTACTest.T: uid @reverse .
+OTACTest.links: [uid] .
+`
+
+// TestQueryVariables 在真实的 Dgraph 上验证 uid_in、after、regexp 与 @facets 中的 $vN 变量
+func TestQueryVariables(t *testing.T) {
+	dg := testDgraph(t)
+	ctx := context.Background()
+	if err := dg.Alter(ctx, &api.Operation{Schema: testSchema}); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	past := now.Add(-time.Hour).Format(time.RFC3339)
+	future := now.Add(time.Hour).Format(time.RFC3339)
+	prefix := fmt.Sprintf("t%d-", now.UnixNano())
+	nquads := fmt.Sprintf(`
+_:t1 <OTACTest.name> "%[1]stenant1" .
+_:t2 <OTACTest.name> "%[1]stenant2" .
+_:a <OTACTest.name> "%[1]sa" .
+_:a <OTACTest.T> _:t1 .
+_:b <OTACTest.name> "%[1]sb" .
+_:b <OTACTest.T> _:t1 .
+_:c <OTACTest.name> "%[1]sc" .
+_:c <OTACTest.T> _:t1 .
+_:d <OTACTest.name> "%[1]sd" .
+_:d <OTACTest.T> _:t2 .
+_:a <OTACTest.links> _:b (notAfter=%[3]s) .
+_:a <OTACTest.links> _:c (notBefore=%[3]s) .
+_:a <OTACTest.links> _:d (notBefore=%[2]s, notAfter=%[3]s) .
+`, prefix, past, future)
+	resp, err := dg.DoRaw(ctx, "", nil, &api.Mutation{SetNquads: []byte(nquads)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	uids := resp.Uids
+	defer func() {
+		del := make([]string, 0, len(uids))
+		for _, uid := range uids {
+			del = append(del, fmt.Sprintf("<%s> * * .", uid))
+		}
+		dg.DoRaw(ctx, "", nil, &api.Mutation{DelNquads: []byte(strings.Join(del, "\n"))})
+	}()
+
+	type node struct {
+		UID  string `json:"uid"`
+		Name string `json:"name"`
+	}
+	names := func(ns []node) string {
+		ss := make([]string, 0, len(ns))
+		for _, n := range ns {
+			ss = append(ss, strings.TrimPrefix(n.Name, prefix))
+		}
+		sort.Strings(ss)
+		return strings.Join(ss, ",")
+	}
+	query := func(q *Query, body DQL) []node {
+		t.Helper()
+		query, vars := q.Build(body)
+		out := struct {
+			Result []node `json:"result"`
+		}{}
+		if err := dg.Query(ctx, query, vars, &out); err != nil {
+			t.Fatalf("%v\n%s", err, query)
+		}
+		return out.Result
+	}
+
+	t.Run("uid_in", func(t *testing.T) {
+		q := NewQuery()
+		res := query(q, Sprintf(`
+			result(func: has(OTACTest.T)) @filter(uid_in(OTACTest.T, %s) AND eq(OTACTest.name, %s)) {
+				uid
+				name: OTACTest.name
+			}`, q.UID(uids["t1"]), q.Strs([]string{prefix + "a", prefix + "b", prefix + "c", prefix + "d"})))
+		if got := names(res); got != "a,b,c" {
+			t.Fatalf("uid_in got %s", got)
+		}
+	})
+
+	t.Run("after", func(t *testing.T) {
+		ordered := []string{uids["a"], uids["b"], uids["c"]}
+		sort.Slice(ordered, func(i, j int) bool {
+			return len(ordered[i]) < len(ordered[j]) || (len(ordered[i]) == len(ordered[j]) && ordered[i] < ordered[j])
+		})
+		q := NewQuery()
+		res := query(q, Sprintf(`
+			result(func: uid(%s), first: %s, after: %s) {
+				uid
+				name: OTACTest.name
+			}`, q.UIDs(ordered), q.Int(10), q.UID(ordered[0])))
+		if len(res) != 2 || res[0].UID != ordered[1] || res[1].UID != ordered[2] {
+			t.Fatalf("after got %v, want %v", res, ordered[1:])
+		}
+	})
+
+	t.Run("regexp", func(t *testing.T) {
+		q := NewQuery()
+		res := query(q, Sprintf(`
+			result(func: has(OTACTest.T)) @filter(regexp(OTACTest.name, %s)) {
+				uid
+				name: OTACTest.name
+			}`, q.Regexp("^"+prefix+"[ab]$")))
+		if got := names(res); got != "a,b" {
+			t.Fatalf("regexp got %s", got)
+		}
+	})
+
+	t.Run("facets", func(t *testing.T) {
+		q := NewQuery()
+		tv := q.Time(now)
+		query, vars := q.Build(Sprintf(`
+			result(func: uid(%s)) {
+				links: OTACTest.links @facets(NOT gt(notBefore, %s) AND NOT lt(notAfter, %s)) {
+					name: OTACTest.name
+				}
+			}`, q.UID(uids["a"]), tv, tv))
+		out := struct {
+			Result []struct {
+				Links []node `json:"links"`
+			} `json:"result"`
+		}{}
+		if err := dg.Query(ctx, query, vars, &out); err != nil {
+			t.Fatalf("%v\n%s", err, query)
+		}
+		data, _ := json.Marshal(out)
+		if len(out.Result) != 1 || names(out.Result[0].Links) != "b,d" {
+			t.Fatalf("facets got %s", data)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package dgraph
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/open-trust/ot-ac/src/logging"
+)
+
+//go:generate go run ./gen -in ../../../graphql/schema.graphql -out schema_gen.go
+
+// Migration 版本化的数据迁移，第 i 个迁移执行后 schema 版本为 i+1
+type Migration struct {
+	Description string
+	Run         func(ctx context.Context, dg *Dgraph) error
+}
+
+// Migrations 数据迁移列表，按顺序追加，已发布的迁移不能修改
+var Migrations = []Migration{
+	{
+		Description: "initial schema",
+		Run:         func(ctx context.Context, dg *Dgraph) error { return nil },
+	},
+}
+
+type jsonSchemaVersion struct {
+	Result []struct {
+		UID     string `json:"uid"`
+		Version int    `json:"version"`
+	} `json:"result"`
+}
+
+// SchemaVersion 返回当前记录的 schema 版本及版本节点的 uid，未初始化时返回 0
+func (dg *Dgraph) SchemaVersion(ctx context.Context) (int, string, error) {
+	out := &jsonSchemaVersion{}
+	err := dg.Query(ctx, `query {
+		result(func: type(OTACSchema), first: 1) {
+			uid
+			version: OTAC.Schema.version
+		}
+	}`, nil, out)
+	if err != nil || len(out.Result) == 0 {
+		return 0, "", err
+	}
+	return out.Result[0].Version, out.Result[0].UID, nil
+}
+
+func (dg *Dgraph) setSchemaVersion(ctx context.Context, uid string, version int) (string, error) {
+	id := "_:schema"
+	if uid != "" {
+		id = uid
+	}
+	nq := &Nquads{
+		ID:   id,
+		Type: "OTACSchema",
+		KV: map[string]interface{}{
+			"OTAC.Schema.version":   version,
+			"OTAC.Schema.updatedAt": time.Now(),
+		},
+	}
+	data, err := nq.Bytes()
+	if err != nil {
+		return "", err
+	}
+	resp, err := dg.DoRaw(ctx, "", nil, &api.Mutation{SetNquads: data})
+	if err != nil {
+		return "", err
+	}
+	if uid == "" {
+		uid = resp.Uids["schema"]
+	}
+	return uid, nil
+}
+
+// Migrate 应用 DQL schema 并执行尚未执行的数据迁移，每个迁移完成后更新 schema 版本节点。
+// 迁移需要是幂等的，中断后重新执行会从最后记录的版本继续
+func (dg *Dgraph) Migrate(ctx context.Context) error {
+	if err := dg.Alter(ctx, &api.Operation{Schema: Schema}); err != nil {
+		return fmt.Errorf("alter schema failed: %v", err)
+	}
+
+	version, uid, err := dg.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if version > len(Migrations) {
+		return fmt.Errorf("schema version %d is newer than %d supported by this binary", version, len(Migrations))
+	}
+	for i := version; i < len(Migrations); i++ {
+		m := Migrations[i]
+		if err := m.Run(ctx, dg); err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %v", i+1, m.Description, err)
+		}
+		if uid, err = dg.setSchemaVersion(ctx, uid, i+1); err != nil {
+			return err
+		}
+		logging.Infof("schema migrated to version %d: %s", i+1, m.Description)
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/util"
+)
+
+// Dgraph 基于 Dgraph 的 Storage 实现
+type Dgraph struct {
+	dg *dgraph.Dgraph
+}
+
+// NewDgraph ...
+func NewDgraph(dg *dgraph.Dgraph) *Dgraph {
+	return &Dgraph{dg: dg}
+}
+
+type jsonResult struct {
+	Result []map[string]json.RawMessage `json:"result"`
+}
+
+func toNode(raw map[string]json.RawMessage) (*Node, error) {
+	n := &Node{Attrs: make(map[string]interface{}, len(raw))}
+	for k, v := range raw {
+		switch k {
+		case "uid":
+			if err := json.Unmarshal(v, &n.UID); err != nil {
+				return nil, err
+			}
+		case "dgraph.type":
+			ts := make([]string, 0, 1)
+			if err := json.Unmarshal(v, &ts); err != nil {
+				return nil, err
+			}
+			if len(ts) > 0 {
+				n.Type = ts[0]
+			}
+		default:
+			var val interface{}
+			if err := json.Unmarshal(v, &val); err != nil {
+				return nil, err
+			}
+			switch val.(type) {
+			case map[string]interface{}, []interface{}:
+				// 忽略边
+			default:
+				n.Attrs[k] = val
+			}
+		}
+	}
+	return n, nil
+}
+
+func (s *Dgraph) nodes(ctx context.Context, query string, vars map[string]string) ([]*Node, error) {
+	out := &jsonResult{}
+	if err := s.dg.Query(ctx, query, vars, out); err != nil {
+		return nil, err
+	}
+	res := make([]*Node, 0, len(out.Result))
+	for _, raw := range out.Result {
+		n, err := toNode(raw)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+	}
+	return res, nil
+}
+
+// Get ...
+func (s *Dgraph) Get(ctx context.Context, uids ...string) ([]*Node, error) {
+	if len(uids) == 0 {
+		return []*Node{}, nil
+	}
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: uid(%s)) @filter(has(dgraph.type)) {
+			uid
+			dgraph.type
+			expand(_all_)
+		}`, q.UIDs(uids)))
+	return s.nodes(ctx, query, vars)
+}
+
+// GetByUK ...
+func (s *Dgraph) GetByUK(ctx context.Context, ukKey, ukVal string) (*Node, error) {
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(%s, %s), first: 1) {
+			uid
+			dgraph.type
+			expand(_all_)
+		}`, dgraph.Predicate(ukKey), q.Str(ukVal)))
+	ns, err := s.nodes(ctx, query, vars)
+	if err != nil || len(ns) == 0 {
+		return nil, err
+	}
+	return ns[0], nil
+}
+
+type jsonUpsert struct {
+	Result []struct {
+		UID string `json:"uid"`
+	} `json:"result"`
+}
+
+// Upsert ...
+func (s *Dgraph) Upsert(ctx context.Context, typ, ukKey, ukVal string, attrs map[string]interface{}) (string, error) {
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: eq(%s, %s), first: 1) {
+			_uid as uid
+		}`, dgraph.Predicate(ukKey), q.Str(ukVal)))
+
+	create := &dgraph.Nquads{
+		ID:   "_:x",
+		Type: typ,
+		KV:   map[string]interface{}{ukKey: ukVal},
+	}
+	for k, v := range attrs {
+		create.KV[k] = v
+	}
+	createData, err := create.Bytes()
+	if err != nil {
+		return "", err
+	}
+	mus := []*api.Mutation{{
+		Cond:      "@if(eq(len(_uid), 0))",
+		SetNquads: createData,
+	}}
+	if len(attrs) > 0 {
+		update := &dgraph.Nquads{ID: "uid(_uid)", KV: attrs}
+		updateData, err := update.Bytes()
+		if err != nil {
+			return "", err
+		}
+		mus = append(mus, &api.Mutation{
+			Cond:      "@if(eq(len(_uid), 1))",
+			SetNquads: updateData,
+		})
+	}
+
+	resp, err := s.dg.DoRaw(ctx, query, vars, mus...)
+	if err != nil {
+		return "", err
+	}
+	if uid, ok := resp.Uids["x"]; ok {
+		return uid, nil
+	}
+	out := &jsonUpsert{}
+	if err := json.Unmarshal(resp.Json, out); err != nil {
+		return "", err
+	}
+	if len(out.Result) == 0 {
+		return "", nil
+	}
+	return out.Result[0].UID, nil
+}
+
+func edgesToNquads(edges []*Edge, del bool) ([]byte, error) {
+	buf := make([]byte, 0)
+	for _, e := range edges {
+		var val interface{} = util.FormatUID(e.To)
+		if e.To == "" && del {
+			val = "*"
+		} else if len(e.Facets) > 0 && !del {
+			val = dgraph.WithFacets{V: val, KV: e.Facets}
+		}
+		nq := &dgraph.Nquads{
+			ID: util.FormatUID(e.From),
+			KV: map[string]interface{}{e.Predicate: val},
+		}
+		data, err := nq.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, data...)
+	}
+	return buf, nil
+}
+
+// SetEdges ...
+func (s *Dgraph) SetEdges(ctx context.Context, edges ...*Edge) error {
+	if len(edges) == 0 {
+		return nil
+	}
+	data, err := edgesToNquads(edges, false)
+	if err != nil {
+		return err
+	}
+	_, err = s.dg.DoRaw(ctx, "", nil, &api.Mutation{SetNquads: data})
+	return err
+}
+
+// DeleteEdges ...
+func (s *Dgraph) DeleteEdges(ctx context.Context, edges ...*Edge) error {
+	if len(edges) == 0 {
+		return nil
+	}
+	data, err := edgesToNquads(edges, true)
+	if err != nil {
+		return err
+	}
+	_, err = s.dg.DoRaw(ctx, "", nil, &api.Mutation{DelNquads: data})
+	return err
+}
+
+// Edges ...
+func (s *Dgraph) Edges(ctx context.Context, from []string, predicate string) ([]*Edge, error) {
+	if len(from) == 0 {
+		return []*Edge{}, nil
+	}
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: uid(%s)) {
+			uid
+			%s @facets {
+				uid
+			}
+		}`, q.UIDs(from), dgraph.Predicate(predicate)))
+	out := &jsonResult{}
+	if err := s.dg.Query(ctx, query, vars, out); err != nil {
+		return nil, err
+	}
+
+	res := make([]*Edge, 0)
+	prefix := predicate + "|"
+	for _, raw := range out.Result {
+		fromUID := ""
+		if err := json.Unmarshal(raw["uid"], &fromUID); err != nil {
+			return nil, err
+		}
+		v, ok := raw[predicate]
+		if !ok {
+			continue
+		}
+		targets := make([]map[string]interface{}, 0)
+		if err := json.Unmarshal(v, &targets); err != nil {
+			// 非 list 类型的 uid 谓词返回单个对象
+			target := make(map[string]interface{})
+			if err := json.Unmarshal(v, &target); err != nil {
+				return nil, err
+			}
+			targets = append(targets, target)
+		}
+		for _, t := range targets {
+			e := &Edge{From: fromUID, Predicate: predicate}
+			e.To, _ = t["uid"].(string)
+			for k, fv := range t {
+				if strings.HasPrefix(k, prefix) {
+					if e.Facets == nil {
+						e.Facets = make(map[string]interface{})
+					}
+					e.Facets[k[len(prefix):]] = fv
+				}
+			}
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+// Traverse ...
+func (s *Dgraph) Traverse(ctx context.Context, start []string, predicate string, depth int) ([]*Edge, error) {
+	return traverse(ctx, s, start, predicate, depth)
+}
+
+// Search ...
+func (s *Dgraph) Search(ctx context.Context, typ, predicate, terms string, first int) ([]*Node, error) {
+	q := dgraph.NewQuery()
+	query, vars := q.Build(dgraph.Sprintf(`
+		result(func: allofterms(%s, %s), first: %s) @filter(type(%s)) {
+			uid
+			dgraph.type
+			expand(_all_)
+		}`, dgraph.Predicate(predicate), q.Str(terms), q.Int(first), dgraph.Predicate(typ)))
+	return s.nodes(ctx, query, vars)
+}
+
+// CheckHealth ...
+func (s *Dgraph) CheckHealth(ctx context.Context) (interface{}, error) {
+	return s.dg.CheckHealth(ctx)
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-trust/ot-ac/src/conf"
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/util"
+)
+
+func init() {
+	util.DigProvide(NewStorage)
+}
+
+// Node 图节点，Attrs 为节点的标量属性，不包含边
+type Node struct {
+	UID   string                 `json:"uid"`
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs"`
+}
+
+// Int 返回整数属性，属性不存在时返回 0
+func (n *Node) Int(key string) int {
+	switch v := n.Attrs[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// Str 返回字符串属性，属性不存在时返回空字符串
+func (n *Node) Str(key string) string {
+	s, _ := n.Attrs[key].(string)
+	return s
+}
+
+// Edge 有向边，Facets 为边上的属性，时间类型的 facet 统一为 RFC3339 字符串
+type Edge struct {
+	From      string                 `json:"from"`
+	Predicate string                 `json:"predicate"`
+	To        string                 `json:"to"`
+	Facets    map[string]interface{} `json:"facets,omitempty"`
+}
+
+// Storage 访问控制引擎需要的图存储操作，Dgraph 为默认实现。
+// 谓词名沿用 Dgraph schema 中的命名，如 OTAC.U-Us，以 ~ 开头表示反向边
+type Storage interface {
+	// Get 按 uid 批量获取节点，不存在的 uid 会被忽略
+	Get(ctx context.Context, uids ...string) ([]*Node, error)
+	// GetByUK 按唯一键获取节点，不存在时返回 nil
+	GetByUK(ctx context.Context, ukKey, ukVal string) (*Node, error)
+	// Upsert 按唯一键创建节点或更新节点属性，返回节点 uid
+	Upsert(ctx context.Context, typ, ukKey, ukVal string, attrs map[string]interface{}) (string, error)
+	// SetEdges 添加边，已存在的边会覆盖其 facets
+	SetEdges(ctx context.Context, edges ...*Edge) error
+	// DeleteEdges 删除边，To 为空时删除 From 在 Predicate 上的所有边
+	DeleteEdges(ctx context.Context, edges ...*Edge) error
+	// Edges 返回 from 节点在 predicate 上的所有边
+	Edges(ctx context.Context, from []string, predicate string) ([]*Edge, error)
+	// Traverse 从 start 出发沿 predicate 递归遍历，返回经过的边，每个节点只访问一次，depth <= 0 表示不限深度
+	Traverse(ctx context.Context, start []string, predicate string, depth int) ([]*Edge, error)
+	// Search 检索 typ 类型中 predicate 包含全部 terms 词项的节点
+	Search(ctx context.Context, typ, predicate, terms string, first int) ([]*Node, error)
+	// CheckHealth 检查存储的健康状态
+	CheckHealth(ctx context.Context) (interface{}, error)
+}
+
+// NewStorage 根据 dgraph.storage.driver 配置创建 Storage，目前只支持 Dgraph
+func NewStorage(dg *dgraph.Dgraph) (Storage, error) {
+	cfg := conf.Config.Dgraph.Storage
+	switch cfg.Driver {
+	case "", "dgraph":
+		return NewDgraph(dg), nil
+	}
+	return nil, fmt.Errorf("unsupported storage driver: %s", cfg.Driver)
+}
+
+func traverse(ctx context.Context, s Storage, start []string, predicate string, depth int) ([]*Edge, error) {
+	visited := make(map[string]struct{}, len(start))
+	for _, uid := range start {
+		visited[uid] = struct{}{}
+	}
+	res := make([]*Edge, 0)
+	for level := 0; len(start) > 0 && (depth <= 0 || level < depth); level++ {
+		edges, err := s.Edges(ctx, start, predicate)
+		if err != nil {
+			return nil, err
+		}
+		next := make([]string, 0)
+		for _, e := range edges {
+			res = append(res, e)
+			if _, ok := visited[e.To]; !ok {
+				visited[e.To] = struct{}{}
+				next = append(next, e.To)
+			}
+		}
+		start = next
+	}
+	return res, nil
+}
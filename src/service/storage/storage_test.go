@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/open-trust/ot-ac/src/service/dgraph"
+	"github.com/open-trust/ot-ac/src/util"
+)
+
+// testStorage 所有 Storage 实现都需要满足的语义，prefix 为唯一键和检索词项的前缀，避免与存储中已有的数据冲突。
+// 创建的节点 uid 会传给 created
+func testStorage(t *testing.T, s Storage, prefix string, created func(uids ...string)) {
+	ctx := context.Background()
+	uids := make(map[string]string)
+	upsert := func(id string) string {
+		uid, err := s.Upsert(ctx, "OTACUnit", "OTAC.U.UK", prefix+id, map[string]interface{}{"OTAC.UId": id, "OTAC.status": 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		uids[id] = uid
+		created(uid)
+		return uid
+	}
+	names := make(map[string]string)
+	for _, id := range []string{"a", "b", "c", "d", "e", "x", "y", "z"} {
+		names[upsert(id)] = id
+	}
+	link := func(pairs ...string) {
+		edges := make([]*Edge, 0, len(pairs)/2)
+		for i := 0; i+1 < len(pairs); i += 2 {
+			edges = append(edges, &Edge{From: uids[pairs[i]], Predicate: "OTAC.U-Us", To: uids[pairs[i+1]]})
+		}
+		if err := s.SetEdges(ctx, edges...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	format := func(es []*Edge) string {
+		ss := make([]string, 0, len(es))
+		for _, e := range es {
+			ss = append(ss, names[e.From]+">"+names[e.To])
+		}
+		return strings.Join(ss, ",")
+	}
+	sorted := func(es []*Edge) string {
+		ss := strings.Split(format(es), ",")
+		sort.Strings(ss)
+		return strings.Join(ss, ",")
+	}
+
+	t.Run("Upsert", func(t *testing.T) {
+		uid, err := s.Upsert(ctx, "OTACUnit", "OTAC.U.UK", prefix+"a", map[string]interface{}{"OTAC.status": -1, "OTAC.UType": "team"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if uid != uids["a"] {
+			t.Fatalf("Upsert created a new node %s, want %s", uid, uids["a"])
+		}
+		n, err := s.GetByUK(ctx, "OTAC.U.UK", prefix+"a")
+		if err != nil || n == nil {
+			t.Fatalf("GetByUK got %v, %v", n, err)
+		}
+		// 未提交的属性保持不变，提交的属性被覆盖
+		if n.UID != uid || n.Str("OTAC.UId") != "a" || n.Str("OTAC.UType") != "team" || n.Int("OTAC.status") != -1 {
+			t.Fatalf("Upsert merged attrs got %v", n.Attrs)
+		}
+		if n.Str("OTAC.U.UK") != prefix+"a" {
+			t.Fatalf("unique key not stored as attr: %v", n.Attrs)
+		}
+		if _, err := s.Upsert(ctx, "OTACUnit", "OTAC.U.UK", prefix+"a", map[string]interface{}{"OTAC.status": 0}); err != nil {
+			t.Fatal(err)
+		}
+		if n, err = s.GetByUK(ctx, "OTAC.U.UK", prefix+"missing"); err != nil || n != nil {
+			t.Fatalf("GetByUK missing got %v, %v", n, err)
+		}
+		ns, err := s.Get(ctx, uids["b"], uids["c"])
+		if err != nil || len(ns) != 2 {
+			t.Fatalf("Get got %v, %v", ns, err)
+		}
+	})
+
+	t.Run("Edges", func(t *testing.T) {
+		notAfter := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+		err := s.SetEdges(ctx,
+			&Edge{From: uids["x"], Predicate: "OTAC.U-Ss", To: uids["z"], Facets: map[string]interface{}{"notAfter": notAfter}},
+			&Edge{From: uids["y"], Predicate: "OTAC.U-Ss", To: uids["z"]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		es, err := s.Edges(ctx, []string{uids["x"]}, "OTAC.U-Ss")
+		if err != nil || format(es) != "x>z" {
+			t.Fatalf("Edges got %s, %v", format(es), err)
+		}
+
+		// 反向谓词：From 为查询的节点，To 为正向边的起点，facets 与正向边一致
+		es, err = s.Edges(ctx, []string{uids["z"]}, "~OTAC.U-Ss")
+		if err != nil || sorted(es) != "z>x,z>y" {
+			t.Fatalf("reverse Edges got %s, %v", sorted(es), err)
+		}
+		for _, e := range es {
+			if e.Predicate != "~OTAC.U-Ss" {
+				t.Fatalf("reverse edge predicate got %s", e.Predicate)
+			}
+			if names[e.To] == "x" {
+				if v, _ := e.Facets["notAfter"].(string); !sameTime(v, notAfter) {
+					t.Fatalf("reverse edge facets got %v", e.Facets)
+				}
+			}
+			if names[e.To] == "y" && len(e.Facets) != 0 {
+				t.Fatalf("reverse edge facets got %v", e.Facets)
+			}
+		}
+
+		if err := s.DeleteEdges(ctx, &Edge{From: uids["x"], Predicate: "OTAC.U-Ss", To: uids["z"]}); err != nil {
+			t.Fatal(err)
+		}
+		if es, _ = s.Edges(ctx, []string{uids["z"]}, "~OTAC.U-Ss"); sorted(es) != "z>y" {
+			t.Fatalf("reverse Edges after delete got %s", sorted(es))
+		}
+		if err := s.DeleteEdges(ctx, &Edge{From: uids["y"], Predicate: "OTAC.U-Ss"}); err != nil {
+			t.Fatal(err)
+		}
+		if es, _ = s.Edges(ctx, []string{uids["z"]}, "~OTAC.U-Ss"); len(es) != 0 {
+			t.Fatalf("reverse Edges after delete all got %s", sorted(es))
+		}
+	})
+
+	t.Run("Traverse", func(t *testing.T) {
+		// a -> b -> d -> e, a -> c -> d，d 经两条路径到达
+		link("a", "b", "a", "c", "b", "d", "c", "d", "d", "e")
+		es, err := s.Traverse(ctx, []string{uids["a"]}, "OTAC.U-Us", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 每条边只返回一次，按层返回
+		if sorted(es) != "a>b,a>c,b>d,c>d,d>e" || len(es) != 5 {
+			t.Fatalf("Traverse got %s", format(es))
+		}
+		level := map[string]int{"a>b": 1, "a>c": 1, "b>d": 2, "c>d": 2, "d>e": 3}
+		for i := 1; i < len(es); i++ {
+			if level[format(es[i-1:i])] > level[format(es[i:i+1])] {
+				t.Fatalf("Traverse not ordered by depth: %s", format(es))
+			}
+		}
+
+		if es, err = s.Traverse(ctx, []string{uids["a"]}, "OTAC.U-Us", 2); err != nil || sorted(es) != "a>b,a>c,b>d,c>d" {
+			t.Fatalf("Traverse with depth got %s, %v", format(es), err)
+		}
+		if es, err = s.Traverse(ctx, []string{uids["e"]}, "~OTAC.U-Us", 0); err != nil || sorted(es) != "b>a,c>a,d>b,d>c,e>d" {
+			t.Fatalf("reverse Traverse got %s, %v", sorted(es), err)
+		}
+
+		// 有环时遍历会结束，并且能到达环上所有节点
+		link("e", "a")
+		if es, err = s.Traverse(ctx, []string{uids["a"]}, "OTAC.U-Us", 0); err != nil {
+			t.Fatal(err)
+		}
+		reached := make(map[string]struct{})
+		for _, e := range es {
+			reached[names[e.To]] = struct{}{}
+		}
+		if len(reached) != 5 || !strings.Contains(format(es), "d>e") {
+			t.Fatalf("cyclic Traverse got %s", format(es))
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		for i, terms := range []string{"Quarterly Report 2020", "annual report", "report draft"} {
+			uid, err := s.Upsert(ctx, "OTACObject", "OTAC.O.UK", fmt.Sprintf("%sdoc%d", prefix, i),
+				map[string]interface{}{"OTAC.terms": prefix + " " + terms})
+			if err != nil {
+				t.Fatal(err)
+			}
+			created(uid)
+		}
+		ns, err := s.Search(ctx, "OTACObject", "OTAC.terms", prefix+" report quarterly", 10)
+		if err != nil || len(ns) != 1 || ns[0].Str("OTAC.O.UK") != prefix+"doc0" {
+			t.Fatalf("Search got %v, %v", ns, err)
+		}
+		if ns, err = s.Search(ctx, "OTACObject", "OTAC.terms", prefix+" report", 2); err != nil || len(ns) != 2 {
+			t.Fatalf("Search with first got %v, %v", ns, err)
+		}
+	})
+}
+
+// sameTime 判断 RFC3339 字符串与 tm 是否为同一时刻
+func sameTime(s string, tm time.Time) bool {
+	v, err := time.Parse(time.RFC3339Nano, s)
+	return err == nil && v.Equal(tm)
+}
+
+// TestDgraph 在 testing 配置中的 Dgraph 上运行，Dgraph 不可用时跳过，测试结束时删除创建的节点
+func TestDgraph(t *testing.T) {
+	dg, err := dgraph.NewDgraph()
+	if err != nil {
+		t.Skipf("Dgraph unavailable: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := dg.CheckHealth(ctx); err != nil {
+		t.Skipf("Dgraph unavailable: %v", err)
+	}
+	if err := dg.Migrate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	created := make([]string, 0)
+	t.Cleanup(func() {
+		buf := new(bytes.Buffer)
+		for _, uid := range created {
+			data, err := (&dgraph.Nquads{ID: util.FormatUID(uid), KV: map[string]interface{}{"*": "*"}}).Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			buf.Write(data)
+		}
+		if _, err := dg.DoRaw(context.Background(), "", nil, &api.Mutation{DelNquads: buf.Bytes()}); err != nil {
+			t.Error(err)
+		}
+	})
+	prefix := fmt.Sprintf("test%d", time.Now().UnixNano())
+	testStorage(t, NewDgraph(dg), prefix, func(uids ...string) { created = append(created, uids...) })
+}
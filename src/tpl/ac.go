@@ -1,8 +1,14 @@
 package tpl
 
+import (
+	"strconv"
+
+	"github.com/teambition/gear"
+)
+
 // ACCheckPermissions ...
 type ACCheckPermissions struct {
-	PermissionBatchAddInput
+	PermissionsInput
 	Subject          string `json:"subject"`
 	WithOrganization bool   `json:"withOrganization"`
 	IgnoreScope      bool   `json:"ignoreScope"` // 仅对 Object 权限检查有效
@@ -13,9 +19,14 @@ func (t *ACCheckPermissions) Validate() error {
 	if err := CheckSubject(t.Subject); err != nil {
 		return err
 	}
-	if err := t.PermissionBatchAddInput.Validate(); err != nil {
+	if err := t.PermissionsInput.Validate(); err != nil {
 		return err
 	}
+	for _, p := range t.Permissions {
+		if IsWildcardPermission(p) {
+			return gear.ErrBadRequest.WithMsgf("wildcard permission %s can not be checked", strconv.Quote(p))
+		}
+	}
 	return nil
 }
 
@@ -40,4 +51,30 @@ func (t *ACCheckPermissionsInput) Validate() error {
 type ACPermissionPayload struct {
 	Target
 	PermissionEx
+	Role string `json:"role,omitempty"` // 权限通过角色获得时为角色名
+}
+
+// ACListPermissionsInput ...
+type ACListPermissionsInput struct {
+	Target
+	ResourcesInput
+	Subject          string `json:"subject"`
+	WithOrganization bool   `json:"withOrganization"`
+	IgnoreScope      bool   `json:"ignoreScope"` // 仅对 Object 权限列表有效
+}
+
+// Validate 实现 gear.BodyTemplate，列出管理单元的权限时可以不指定 Target
+func (t *ACListPermissionsInput) Validate() error {
+	if err := CheckSubject(t.Subject); err != nil {
+		return err
+	}
+	if t.Target.Type != "" || t.Target.ID != "" {
+		if err := t.Target.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := t.ResourcesInput.Validate(); err != nil {
+		return err
+	}
+	return nil
 }
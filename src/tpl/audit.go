@@ -0,0 +1,62 @@
+package tpl
+
+import (
+	"strings"
+	"time"
+
+	"github.com/teambition/gear"
+)
+
+// AuditRef 审计日志中节点的引用，由节点类型和标识组成，如 unit:Dept:d1、permission:Doc.read、role:admin
+func AuditRef(kind string, ids ...string) string {
+	return kind + ":" + strings.Join(ids, ":")
+}
+
+// AuditEdge 写操作增加或删除的边，To 为 * 时表示 From 在 Predicate 上的所有边，Predicate 也为 * 时表示 From 节点及其所有的边
+type AuditEdge struct {
+	From      string `json:"from"`
+	Predicate string `json:"predicate"` // 如 parent、scope、permissions、roles、subjects
+	To        string `json:"to"`
+}
+
+// AuditEntry 一次写操作的审计记录
+type AuditEntry struct {
+	UID       string                 `json:"uid,omitempty"`
+	Tenant    string                 `json:"tenant,omitempty"` // 为空表示不属于租户的操作，如组织和请求主体的变更
+	TenantUID string                 `json:"-"`                // 写入时租户节点的 uid，以相同 OTID 重新创建的租户不能读取此前租户的记录
+	Actor     string                 `json:"actor"`            // 操作者的 OTID，后台任务为空
+	Op        string                 `json:"op"`               // 写操作，如 Unit.AddPermissions
+	Targets   []string               `json:"targets"`          // 涉及的节点引用
+	Added     []AuditEdge            `json:"added,omitempty"`
+	Removed   []AuditEdge            `json:"removed,omitempty"`
+	Before    map[string]interface{} `json:"before,omitempty"` // 更新前的属性
+	After     map[string]interface{} `json:"after,omitempty"`  // 更新后的属性
+	CreatedAt time.Time              `json:"createdAt"`
+}
+
+// AuditListInput ...
+type AuditListInput struct {
+	Pagination
+	// 只列出涉及该节点的记录，如 unit:Dept:d1
+	Target string `json:"target"`
+	// 只列出该写操作的记录，如 Role.Update
+	Op string `json:"op"`
+	// 只列出该操作者的记录
+	Actor string     `json:"actor"`
+	Since *time.Time `json:"since"`
+	Until *time.Time `json:"until"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *AuditListInput) Validate() error {
+	if err := t.Pagination.Validate(); err != nil {
+		return err
+	}
+	if len(t.Target) > 1024 {
+		return gear.ErrBadRequest.WithMsg("target should not be longer than 1024")
+	}
+	if t.Since != nil && t.Until != nil && t.Since.After(*t.Until) {
+		return gear.ErrBadRequest.WithMsg("since should not be after until")
+	}
+	return nil
+}
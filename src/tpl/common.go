@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	otgo "github.com/open-trust/ot-go-lib"
 	"github.com/teambition/gear"
@@ -84,7 +85,10 @@ type Extensions map[string]interface{}
 
 // Validate 实现 gear.BodyTemplate
 func (t Extensions) Validate() error {
-	for _, v := range t {
+	for k, v := range t {
+		if k == "notBefore" || k == "notAfter" {
+			return gear.ErrBadRequest.WithMsgf("reserved extension key: %s", k)
+		}
 		switch v.(type) {
 		case string, bool, int, int32, float64:
 			continue
@@ -95,6 +99,44 @@ func (t Extensions) Validate() error {
 	return nil
 }
 
+// Validity 定义授权关系的有效时间窗口，以 notBefore/notAfter facets 的形式存储在 OTAC.U-Ps 和 OTAC.U-Ss 上，未设置时不限制
+type Validity struct {
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+	NotAfter  *time.Time `json:"notAfter,omitempty"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *Validity) Validate() error {
+	if t.NotBefore != nil && t.NotAfter != nil && !t.NotAfter.After(*t.NotBefore) {
+		return gear.ErrBadRequest.WithMsgf("notAfter %s should be after notBefore %s",
+			t.NotAfter.Format(time.RFC3339), t.NotBefore.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Facets 返回需要写入边的 facets
+func (t Validity) Facets() map[string]interface{} {
+	fs := make(map[string]interface{}, 2)
+	if t.NotBefore != nil {
+		fs["notBefore"] = *t.NotBefore
+	}
+	if t.NotAfter != nil {
+		fs["notAfter"] = *t.NotAfter
+	}
+	return fs
+}
+
+// ValidAt 判断授权关系在 now 时刻是否有效
+func (t Validity) ValidAt(now time.Time) bool {
+	if t.NotBefore != nil && now.Before(*t.NotBefore) {
+		return false
+	}
+	if t.NotAfter != nil && now.After(*t.NotAfter) {
+		return false
+	}
+	return true
+}
+
 // Validate 实现 gear.BodyTemplate
 func (t *ResourcesInput) Validate() error {
 	if t.Resources == nil {
@@ -136,7 +178,7 @@ func CheckSubject(s string) error {
 
 var permissionReg = regexp.MustCompile(`^[0-9A-Za-z]{2,32}$`)
 
-// CheckPermission ...
+// CheckPermission 检查具体的权限，不允许通配符权限
 func CheckPermission(s string) error {
 	if s == "" {
 		return gear.ErrBadRequest.WithMsgf("empty permission")
@@ -154,6 +196,18 @@ func CheckPermission(s string) error {
 	return nil
 }
 
+// CheckWildcardPermission 检查可注册和授予的权限，允许以 ".*" 结尾的通配符权限，如 "Project.*"、"Project.write.*"，
+// "*" 只能作为完整的最后一段出现，"*"、"Project.*.read"、"Project.wr*" 等有歧义的形式都是无效的
+func CheckWildcardPermission(s string) error {
+	if IsWildcardPermission(s) {
+		if err := CheckPermission(s[:len(s)-2]); err != nil {
+			return gear.ErrBadRequest.WithMsgf("invalid permission %s", strconv.Quote(s))
+		}
+		return nil
+	}
+	return CheckPermission(s)
+}
+
 var whitespaceReg = regexp.MustCompile(`\s`)
 
 // CheckTerm ...
@@ -0,0 +1,19 @@
+package tpl
+
+import "time"
+
+// Decision 一次权限检查的决策记录
+type Decision struct {
+	Tenant      string   `json:"tenant"`
+	Check       string   `json:"check"` // CheckUnit、CheckScope 或 CheckObject
+	Subject     string   `json:"subject"`
+	Target      Target   `json:"target"`
+	Permissions []string `json:"permissions"`
+	Allowed     bool     `json:"allowed"`
+	Error       string   `json:"error,omitempty"` // 检查出错时的错误，此时 allowed 为 false
+	// 允许时授予权限的管理单元（或透传权限的资源对象）、权限和角色
+	GrantedBy  []ACPermissionPayload `json:"grantedBy,omitempty"`
+	LatencyMs  float64               `json:"latencyMs"`
+	SampleRate float64               `json:"sampleRate"` // 记录时的采样率，拒绝和出错时为 1
+	CreatedAt  time.Time             `json:"createdAt"`
+}
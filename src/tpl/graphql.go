@@ -0,0 +1,23 @@
+package tpl
+
+import (
+	"github.com/teambition/gear"
+)
+
+// GraphQLInput GraphQL 请求，格式与 GraphQL over HTTP 的 POST 请求一致
+type GraphQLInput struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *GraphQLInput) Validate() error {
+	if t.Query == "" {
+		return gear.ErrBadRequest.WithMsg("query required")
+	}
+	if len(t.Query) > 64*1024 {
+		return gear.ErrBadRequest.WithMsg("query should not be longer than 64KB")
+	}
+	return nil
+}
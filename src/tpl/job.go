@@ -0,0 +1,30 @@
+package tpl
+
+import "time"
+
+// 后台任务的状态
+const (
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// 后台任务的类型
+const (
+	JobDeleteTenant = "deleteTenant" // 删除租户及其名下所有数据
+	JobCloneTenant  = "cloneTenant"  // 复制租户，任务对象为目标租户
+)
+
+// Job 后台任务，任务状态持久化在 Dgraph 中，服务重启后会继续执行未完成的任务
+type Job struct {
+	UID       string    `json:"uid,omitempty"`
+	Kind      string    `json:"kind"`
+	Target    string    `json:"target"`
+	Status    string    `json:"status"`
+	Stage     string    `json:"stage,omitempty"`  // 当前阶段，如删除租户时正在删除的节点类型
+	Deleted   int       `json:"deleted"`          // 已删除的节点数
+	Copied    int       `json:"copied,omitempty"` // 已复制的快照记录数
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
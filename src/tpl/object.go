@@ -32,7 +32,7 @@ func (t *ObjectAddPermissionsInput) Validate() error {
 		if err := cr.Check(p); err != nil {
 			return err
 		}
-		if err := CheckPermission(p); err != nil {
+		if err := CheckWildcardPermission(p); err != nil {
 			return err
 		}
 	}
@@ -1,13 +1,73 @@
 package tpl
 
 import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/teambition/gear"
 )
 
 // Permission ...
 type Permission struct {
-	UID        string `json:"uid,omitempty"`
-	Permission string `json:"permission"`
+	UID         string   `json:"uid,omitempty"`
+	Permission  string   `json:"permission"`
+	Name        string   `json:"name,omitempty"`        // 展示名称
+	Description string   `json:"description,omitempty"` // 描述
+	Deprecated  bool     `json:"deprecated,omitempty"`  // 是否已废弃，废弃的权限仍然有效，仅用于提示
+	Implies     []string `json:"implies,omitempty"`     // 蕴含的权限，如 "Doc.edit" 蕴含 "Doc.read"
+	metadata    bool
+}
+
+// UnmarshalJSON 兼容字符串形式的权限，如 "Doc.read"，对象形式的权限会被标记为带有元数据
+func (t *Permission) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*t = Permission{}
+		return json.Unmarshal(data, &t.Permission)
+	}
+	type permission Permission
+	p := permission{}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*t = Permission(p)
+	t.metadata = true
+	return nil
+}
+
+// HasMetadata 是否以对象形式提交，对象形式的权限会覆盖已存在权限的元数据
+func (t *Permission) HasMetadata() bool {
+	return t.metadata
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *Permission) Validate() error {
+	if err := CheckWildcardPermission(t.Permission); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(t.Name) > 64 {
+		return gear.ErrBadRequest.WithMsgf("name too long: %d", utf8.RuneCountInString(t.Name))
+	}
+	if utf8.RuneCountInString(t.Description) > 1024 {
+		return gear.ErrBadRequest.WithMsgf("description too long: %d", utf8.RuneCountInString(t.Description))
+	}
+	if len(t.Implies) > 100 {
+		return gear.ErrBadRequest.WithMsgf("too many implies: %d", len(t.Implies))
+	}
+	cr := make(checkRepetitive)
+	for _, p := range t.Implies {
+		if err := cr.Check(p); err != nil {
+			return err
+		}
+		if err := CheckWildcardPermission(p); err != nil {
+			return err
+		}
+		if p == t.Permission {
+			return gear.ErrBadRequest.WithMsgf("permission %s can not imply itself", strconv.Quote(p))
+		}
+	}
+	return nil
 }
 
 // GetPermissionUID ...
@@ -20,15 +80,53 @@ func GetPermissionUID(ps []Permission, p string) string {
 	return ""
 }
 
+// IsWildcardPermission 判断是否为通配符权限，如 "Project.*"
+func IsWildcardPermission(p string) bool {
+	return strings.HasSuffix(p, ".*")
+}
+
+// MatchPermission 判断授予的权限（可以是通配符权限）是否覆盖 permission，
+// "Project.*" 覆盖 "Project.read" 和 "Project.write.own"，"Project.write.*" 覆盖 "Project.write.own"，但不覆盖 "Project.write"
+func MatchPermission(granted, permission string) bool {
+	if granted == permission {
+		return true
+	}
+	if IsWildcardPermission(granted) {
+		return strings.HasPrefix(permission, granted[:len(granted)-1])
+	}
+	return false
+}
+
+// WildcardPermissions 返回能覆盖 permissions 的所有权限，包括 permissions 本身及其上级通配符权限，
+// 如 "Project.write.own" 返回 "Project.write.own"、"Project.*"、"Project.write.*"
+func WildcardPermissions(permissions []string) []string {
+	res := make([]string, 0, len(permissions)*3)
+	cr := make(checkRepetitive)
+	for _, p := range permissions {
+		if cr.Check(p) == nil {
+			res = append(res, p)
+		}
+		ss := strings.Split(p, ".")
+		for i := 1; i < len(ss); i++ {
+			w := strings.Join(ss[:i], ".") + ".*"
+			if cr.Check(w) == nil {
+				res = append(res, w)
+			}
+		}
+	}
+	return res
+}
+
 // PermissionEx ...
 type PermissionEx struct {
 	Permission string     `json:"permission"`
 	Extensions Extensions `json:"extensions"`
+	Validity
 }
 
 // Validate 实现 gear.BodyTemplate
 func (t *PermissionEx) Validate() error {
-	if err := CheckPermission(t.Permission); err != nil {
+	if err := CheckWildcardPermission(t.Permission); err != nil {
 		return err
 	}
 	if len(t.Extensions) > 10 {
@@ -37,16 +135,41 @@ func (t *PermissionEx) Validate() error {
 	if err := t.Extensions.Validate(); err != nil {
 		return err
 	}
+	if err := t.Validity.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
 // PermissionBatchAddInput ...
 type PermissionBatchAddInput struct {
-	Permissions []string `json:"permissions"`
+	Permissions []Permission `json:"permissions"`
 }
 
 // Validate 实现 gear.BodyTemplate
 func (t *PermissionBatchAddInput) Validate() error {
+	if len(t.Permissions) == 0 {
+		return gear.ErrBadRequest.WithMsg("empty permissions")
+	}
+	cr := make(checkRepetitive)
+	for i := range t.Permissions {
+		if err := cr.Check(t.Permissions[i].Permission); err != nil {
+			return err
+		}
+		if err := t.Permissions[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PermissionsInput ...
+type PermissionsInput struct {
+	Permissions []string `json:"permissions"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *PermissionsInput) Validate() error {
 	// OTID UnmarshalText method will validate
 	if len(t.Permissions) == 0 {
 		return gear.ErrBadRequest.WithMsg("empty permissions")
@@ -56,7 +179,7 @@ func (t *PermissionBatchAddInput) Validate() error {
 		if err := cr.Check(permission); err != nil {
 			return err
 		}
-		if err := CheckPermission(permission); err != nil {
+		if err := CheckWildcardPermission(permission); err != nil {
 			return err
 		}
 	}
@@ -80,15 +203,74 @@ func (t *PermissionListInput) Validate() error {
 	return nil
 }
 
+// PermissionInput ...
+type PermissionInput struct {
+	Permission string `json:"permission"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *PermissionInput) Validate() error {
+	if err := CheckWildcardPermission(t.Permission); err != nil {
+		return err
+	}
+	return nil
+}
+
 // PermissionDeleteInput ...
 type PermissionDeleteInput struct {
-	Permission string `json:"permission"`
+	PermissionInput
+	Force bool `json:"force"` // 为 true 时解除所有引用关系后删除权限
 }
 
 // Validate 实现 gear.BodyTemplate
 func (t *PermissionDeleteInput) Validate() error {
-	if err := CheckPermission(t.Permission); err != nil {
+	if err := t.PermissionInput.Validate(); err != nil {
 		return err
 	}
 	return nil
 }
+
+// PermissionUsage ...
+type PermissionUsage struct {
+	Permission string `json:"permission"`
+	Units      int    `json:"units"`   // 直接授予该权限的管理单元数量
+	Objects    int    `json:"objects"` // 透传该权限的资源对象数量
+	Roles      int    `json:"roles"`   // 包含该权限的角色数量
+}
+
+// PermissionDeleteOutput ...
+type PermissionDeleteOutput struct {
+	Units   []Target `json:"units"`
+	Objects []Target `json:"objects"`
+	Roles   []string `json:"roles"`
+}
+
+// PermissionMigrateInput ...
+type PermissionMigrateInput struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *PermissionMigrateInput) Validate() error {
+	if err := CheckWildcardPermission(t.From); err != nil {
+		return err
+	}
+	if err := CheckWildcardPermission(t.To); err != nil {
+		return err
+	}
+	if t.From == t.To {
+		return gear.ErrBadRequest.WithMsgf("from and to are the same permission %s", strconv.Quote(t.From))
+	}
+	if IsWildcardPermission(t.From) != IsWildcardPermission(t.To) {
+		return gear.ErrBadRequest.WithMsgf("can not migrate %s to %s", strconv.Quote(t.From), strconv.Quote(t.To))
+	}
+	return nil
+}
+
+// PermissionMigrateOutput ...
+type PermissionMigrateOutput struct {
+	Units   int `json:"units"`   // 受影响的管理单元数量
+	Objects int `json:"objects"` // 受影响的资源对象数量
+	Roles   int `json:"roles"`   // 受影响的角色数量
+}
@@ -0,0 +1,122 @@
+package tpl
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/teambition/gear"
+)
+
+// Role ...
+type Role struct {
+	UID         string   `json:"uid,omitempty"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// GetRoleUID ...
+func GetRoleUID(rs []Role, r string) string {
+	for _, v := range rs {
+		if v.Role == r {
+			return v.UID
+		}
+	}
+	return ""
+}
+
+var roleReg = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z_-]{1,63}$`)
+
+// CheckRole ...
+func CheckRole(s string) error {
+	if s == "" {
+		return gear.ErrBadRequest.WithMsgf("empty role")
+	}
+	if !roleReg.MatchString(s) {
+		return gear.ErrBadRequest.WithMsgf("invalid role %s", strconv.Quote(s))
+	}
+	return nil
+}
+
+// RoleInput ...
+type RoleInput struct {
+	Role string `json:"role"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *RoleInput) Validate() error {
+	if err := CheckRole(t.Role); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RoleAddInput ...
+type RoleAddInput struct {
+	RoleInput
+	Permissions []string `json:"permissions"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *RoleAddInput) Validate() error {
+	if err := t.RoleInput.Validate(); err != nil {
+		return err
+	}
+	if t.Permissions == nil {
+		t.Permissions = make([]string, 0)
+	}
+	if len(t.Permissions) > 1000 {
+		return gear.ErrBadRequest.WithMsgf("too many permissions: %d", len(t.Permissions))
+	}
+	cr := make(checkRepetitive)
+	for _, p := range t.Permissions {
+		if err := cr.Check(p); err != nil {
+			return err
+		}
+		if err := CheckWildcardPermission(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnitRolesInput ...
+type UnitRolesInput struct {
+	Target
+	Roles []string `json:"roles"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *UnitRolesInput) Validate() error {
+	if err := t.Target.Validate(); err != nil {
+		return err
+	}
+	if len(t.Roles) == 0 {
+		return gear.ErrBadRequest.WithMsgf("roles empty")
+	}
+	if len(t.Roles) > 100 {
+		return gear.ErrBadRequest.WithMsgf("too many roles: %d", len(t.Roles))
+	}
+	cr := make(checkRepetitive)
+	for _, r := range t.Roles {
+		if err := cr.Check(r); err != nil {
+			return err
+		}
+		if err := CheckRole(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RoleListInput ...
+type RoleListInput struct {
+	Pagination
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *RoleListInput) Validate() error {
+	if err := t.Pagination.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
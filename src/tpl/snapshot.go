@@ -0,0 +1,167 @@
+package tpl
+
+import (
+	"github.com/teambition/gear"
+)
+
+// SnapshotVersion 租户快照格式的版本
+const SnapshotVersion = 1
+
+// 租户快照的记录类型，节点记录总在引用它的边记录之前
+const (
+	SnapshotTenant           = "tenant"
+	SnapshotPermission       = "permission"
+	SnapshotRole             = "role"
+	SnapshotScope            = "scope"
+	SnapshotUnit             = "unit"
+	SnapshotObject           = "object"
+	SnapshotUnitParent       = "unitParent"
+	SnapshotUnitScope        = "unitScope"
+	SnapshotUnitPermission   = "unitPermission"
+	SnapshotUnitRole         = "unitRole"
+	SnapshotUnitSubject      = "unitSubject"
+	SnapshotUnitOrg          = "unitOrg"
+	SnapshotUnitOU           = "unitOU"
+	SnapshotUnitMember       = "unitMember"
+	SnapshotObjectParent     = "objectParent"
+	SnapshotObjectScope      = "objectScope"
+	SnapshotObjectPermission = "objectPermission"
+	SnapshotObjectUnit       = "objectUnit"
+	SnapshotEnd              = "end"
+)
+
+// SnapshotRecord 租户快照（NDJSON）中的一行记录，以业务标识而非 uid 关联，Kind 决定哪些字段有效
+type SnapshotRecord struct {
+	Kind        string     `json:"kind"`
+	Version     int        `json:"version,omitempty"`     // tenant
+	Count       int        `json:"count,omitempty"`       // end，不含 end 的记录数
+	Tenant      string     `json:"tenant,omitempty"`      // tenant
+	Status      int        `json:"status,omitempty"`      // tenant、scope、unit
+	TargetType  string     `json:"targetType,omitempty"`  // scope、unit、object
+	TargetID    string     `json:"targetId,omitempty"`    // scope、unit、object
+	Terms       string     `json:"terms,omitempty"`       // object
+	Permission  string     `json:"permission,omitempty"`  // permission、unitPermission、objectPermission
+	Name        string     `json:"name,omitempty"`        // permission
+	Description string     `json:"description,omitempty"` // permission
+	Deprecated  bool       `json:"deprecated,omitempty"`  // permission
+	Implies     []string   `json:"implies,omitempty"`     // permission
+	Role        string     `json:"role,omitempty"`        // role、unitRole
+	Permissions []string   `json:"permissions,omitempty"` // role
+	Unit        *Target    `json:"unit,omitempty"`        // unit*、objectUnit
+	Object      *Target    `json:"object,omitempty"`      // object*
+	Parent      *Target    `json:"parent,omitempty"`      // unitParent、objectParent
+	Scope       *Target    `json:"scope,omitempty"`       // unitScope、objectScope
+	Subject     string     `json:"subject,omitempty"`     // unitSubject、unitMember
+	Org         string     `json:"org,omitempty"`         // unitOrg、unitOU、unitMember
+	OU          string     `json:"ou,omitempty"`          // unitOU
+	Extensions  Extensions `json:"extensions,omitempty"`  // unitPermission
+	Validity               // unitPermission、unitSubject
+}
+
+// Target 返回 scope、unit、object 记录的目标
+func (t *SnapshotRecord) Target() Target {
+	return Target{Type: t.TargetType, ID: t.TargetID}
+}
+
+func checkTargets(ts ...*Target) error {
+	for _, t := range ts {
+		if t == nil {
+			return gear.ErrBadRequest.WithMsg("target required")
+		}
+		if err := t.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *SnapshotRecord) Validate() error {
+	switch t.Kind {
+	case SnapshotTenant:
+		if t.Version != SnapshotVersion {
+			return gear.ErrBadRequest.WithMsgf("unsupported snapshot version %d", t.Version)
+		}
+		if t.Status < -1 {
+			return gear.ErrBadRequest.WithMsgf("invalid tenant status %d", t.Status)
+		}
+	case SnapshotPermission:
+		p := Permission{
+			Permission:  t.Permission,
+			Name:        t.Name,
+			Description: t.Description,
+			Implies:     t.Implies,
+		}
+		return p.Validate()
+	case SnapshotRole:
+		if err := CheckRole(t.Role); err != nil {
+			return err
+		}
+		for _, p := range t.Permissions {
+			if err := CheckWildcardPermission(p); err != nil {
+				return err
+			}
+		}
+	case SnapshotScope, SnapshotUnit, SnapshotObject:
+		target := t.Target()
+		return target.Validate()
+	case SnapshotUnitParent:
+		return checkTargets(t.Unit, t.Parent)
+	case SnapshotUnitScope:
+		return checkTargets(t.Unit, t.Scope)
+	case SnapshotUnitPermission:
+		if err := checkTargets(t.Unit); err != nil {
+			return err
+		}
+		p := PermissionEx{Permission: t.Permission, Extensions: t.Extensions, Validity: t.Validity}
+		return p.Validate()
+	case SnapshotUnitRole:
+		if err := checkTargets(t.Unit); err != nil {
+			return err
+		}
+		return CheckRole(t.Role)
+	case SnapshotUnitSubject:
+		if err := checkTargets(t.Unit); err != nil {
+			return err
+		}
+		if err := CheckSubject(t.Subject); err != nil {
+			return err
+		}
+		return t.Validity.Validate()
+	case SnapshotUnitOrg, SnapshotUnitOU, SnapshotUnitMember:
+		if err := checkTargets(t.Unit); err != nil {
+			return err
+		}
+		if t.Org == "" {
+			return gear.ErrBadRequest.WithMsg("organization required")
+		}
+		if t.Kind == SnapshotUnitOU && t.OU == "" {
+			return gear.ErrBadRequest.WithMsg("OU required")
+		}
+		if t.Kind == SnapshotUnitMember {
+			return CheckSubject(t.Subject)
+		}
+	case SnapshotObjectParent:
+		return checkTargets(t.Object, t.Parent)
+	case SnapshotObjectScope:
+		return checkTargets(t.Object, t.Scope)
+	case SnapshotObjectPermission:
+		if err := checkTargets(t.Object); err != nil {
+			return err
+		}
+		return CheckWildcardPermission(t.Permission)
+	case SnapshotObjectUnit:
+		return checkTargets(t.Object, t.Unit)
+	case SnapshotEnd:
+	default:
+		return gear.ErrBadRequest.WithMsgf("unknown snapshot record kind %s", t.Kind)
+	}
+	return nil
+}
+
+// SnapshotImportOutput ...
+type SnapshotImportOutput struct {
+	Tenant  string         `json:"tenant"`
+	Records int            `json:"records"` // 已导入的记录数，不含 end
+	Kinds   map[string]int `json:"kinds"`   // 各类型记录数
+}
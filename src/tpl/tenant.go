@@ -1,11 +1,28 @@
 package tpl
 
 import (
-	"github.com/open-trust/ot-ac/src/conf"
 	otgo "github.com/open-trust/ot-go-lib"
 	"github.com/teambition/gear"
 )
 
+// TrustDomain 租户所属的信任域，服务启动时由 conf 设置。
+// tpl 不依赖 conf，客户端（如 otac）可以直接使用 tpl 的类型而不需要服务的配置文件
+var TrustDomain otgo.TrustDomain
+
+// checkTenant 检查租户是否属于 TrustDomain，TrustDomain 未设置时只检查 OTID 是否有效
+func checkTenant(tenant otgo.OTID) error {
+	if TrustDomain == "" {
+		if err := tenant.Validate(); err != nil {
+			return gear.ErrBadRequest.From(err)
+		}
+		return nil
+	}
+	if !tenant.MemberOf(TrustDomain) {
+		return gear.ErrBadRequest.WithMsgf("tenant %s is not a member of %s", tenant.String(), TrustDomain.String())
+	}
+	return nil
+}
+
 // Tenant ...
 type Tenant struct {
 	UID    string `json:"uid,omitempty"`
@@ -22,11 +39,42 @@ type TenantAddInput struct {
 // Validate 实现 gear.BodyTemplate
 func (t *TenantAddInput) Validate() error {
 	// OTID UnmarshalText method will validate
-	if !t.Tenant.MemberOf(conf.OT.TrustDomain) {
-		return gear.ErrBadRequest.WithMsg("tenant %s is not a member of %s", t.Tenant.String(), conf.OT.TrustDomain.String())
+	if err := checkTenant(t.Tenant); err != nil {
+		return err
 	}
 	if t.Status < -1 {
 		return gear.ErrBadRequest.WithMsgf("invalid tenant status %d", t.Status)
 	}
 	return nil
 }
+
+// TenantCloneInput ...
+type TenantCloneInput struct {
+	Source         otgo.OTID         `json:"source"`
+	Target         otgo.OTID         `json:"target"`
+	WithSubjects   bool              `json:"withSubjects"`   // 是否复制管理单元的请求主体、组织、OU 和组织成员关系
+	SubjectMapping map[string]string `json:"subjectMapping"` // 复制请求主体关系时改写请求主体，映射为空字符串的请求主体不复制
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *TenantCloneInput) Validate() error {
+	for _, tenant := range []otgo.OTID{t.Source, t.Target} {
+		if err := checkTenant(tenant); err != nil {
+			return err
+		}
+	}
+	if t.Source.String() == t.Target.String() {
+		return gear.ErrBadRequest.WithMsg("source and target tenant should be different")
+	}
+	for from, to := range t.SubjectMapping {
+		if err := CheckSubject(from); err != nil {
+			return err
+		}
+		if to != "" {
+			if err := CheckSubject(to); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
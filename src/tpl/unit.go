@@ -1,6 +1,10 @@
 package tpl
 
-import "github.com/teambition/gear"
+import (
+	"time"
+
+	"github.com/teambition/gear"
+)
 
 // Unit ...
 type Unit struct {
@@ -94,6 +98,7 @@ func (t *UnitAddFromMembersInput) Validate() error {
 type UnitAddSubjectsInput struct {
 	Target
 	SubjectsInput
+	Validity
 }
 
 // Validate 实现 gear.BodyTemplate
@@ -104,6 +109,9 @@ func (t *UnitAddSubjectsInput) Validate() error {
 	if err := t.SubjectsInput.Validate(); err != nil {
 		return err
 	}
+	if err := t.Validity.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -187,3 +195,11 @@ func (t *UnitAddPermissionsInput) Validate() error {
 
 	return nil
 }
+
+// ExpiredGrant 已过期并被清理的授权关系，Permission 和 Subject 二者其一有值
+type ExpiredGrant struct {
+	Unit       Target     `json:"unit"`
+	Permission string     `json:"permission,omitempty"`
+	Subject    string     `json:"subject,omitempty"`
+	NotAfter   *time.Time `json:"notAfter"`
+}
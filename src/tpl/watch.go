@@ -0,0 +1,58 @@
+package tpl
+
+import (
+	"time"
+
+	"github.com/teambition/gear"
+)
+
+// WatchEvent 租户的变更事件，记录写操作及其涉及的管理单元、资源对象、范围约束、权限、角色和请求主体，
+// 消费方据此精确地使缓存失效
+type WatchEvent struct {
+	Revision    int64     `json:"revision"`
+	Op          string    `json:"op"` // 产生事件的写操作，如 Unit.AddPermissions
+	Units       []Target  `json:"units,omitempty"`
+	Objects     []Target  `json:"objects,omitempty"`
+	Scopes      []Target  `json:"scopes,omitempty"`
+	Permissions []string  `json:"permissions,omitempty"`
+	Roles       []string  `json:"roles,omitempty"`
+	Subjects    []string  `json:"subjects,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// WatchInput ...
+type WatchInput struct {
+	// 从该 revision 之后的事件开始返回，为 0 时不等待，直接返回当前的 revision
+	Revision int64 `json:"revision"`
+	// 没有新事件时最多等待的秒数，默认 30，最大 60，为 0 时使用默认值
+	Timeout int `json:"timeout"`
+	// 每次最多返回的事件数，默认 100，最大 1000
+	Limit int `json:"limit"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *WatchInput) Validate() error {
+	if t.Revision < 0 {
+		return gear.ErrBadRequest.WithMsgf("invalid revision %d", t.Revision)
+	}
+	if t.Timeout < 0 || t.Timeout > 60 {
+		return gear.ErrBadRequest.WithMsgf("timeout %d should be between 0 and 60", t.Timeout)
+	}
+	if t.Timeout == 0 {
+		t.Timeout = 30
+	}
+	if t.Limit < 0 || t.Limit > 1000 {
+		return gear.ErrBadRequest.WithMsgf("limit %d should be between 0 and 1000", t.Limit)
+	}
+	if t.Limit == 0 {
+		t.Limit = 100
+	}
+	return nil
+}
+
+// WatchOutput ...
+type WatchOutput struct {
+	// 下次请求使用的 revision
+	Revision int64        `json:"revision"`
+	Events   []WatchEvent `json:"events"`
+}
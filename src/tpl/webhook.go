@@ -0,0 +1,293 @@
+package tpl
+
+import (
+	"encoding/json"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/teambition/gear"
+)
+
+// webhookEventsByOp 变更事件的写操作对应的 webhook 事件类型
+var webhookEventsByOp = map[string][]string{
+	"Unit.BatchAdd":         {"unit.added"},
+	"Unit.AddFromOrg":       {"unit.added"},
+	"Unit.AddFromOU":        {"unit.added"},
+	"Unit.AddFromMembers":   {"unit.added", "unit.subjects.added"},
+	"Unit.AddSubjects":      {"unit.subjects.added"},
+	"Unit.AddPermissions":   {"unit.permissions.changed"},
+	"Unit.AddRoles":         {"unit.roles.changed"},
+	"Unit.RemoveRoles":      {"unit.roles.changed"},
+	"Unit.AssignParent":     {"unit.parent.changed"},
+	"Unit.AssignScope":      {"unit.scope.changed"},
+	"Unit.AssignObject":     {"unit.objects.changed"},
+	"Unit.UpdateStatus":     {"unit.status.changed"},
+	"Object.BatchAdd":       {"object.added"},
+	"Object.AddPermissions": {"object.permissions.changed"},
+	"Object.UpdateTerms":    {"object.terms.changed"},
+	"Scope.Add":             {"scope.added"},
+	"Scope.UpdateStatus":    {"scope.status.changed"},
+	"Scope.Delete":          {"scope.deleted"},
+	"Scope.DeleteAll":       {"scope.deleted", "unit.deleted", "object.deleted"},
+	"Permission.BatchAdd":   {"permission.added"},
+	"Permission.Delete":     {"permission.deleted"},
+	"Permission.Rename":     {"permission.renamed"},
+	"Permission.Merge":      {"permission.merged"},
+	"Role.Add":              {"role.added"},
+	"Role.Update":           {"role.permissions.changed"},
+	"Role.Delete":           {"role.deleted"},
+	"Tenant.Update":         {"tenant.status.changed"},
+}
+
+// WebhookEventTypes 可以订阅的 webhook 事件类型
+var WebhookEventTypes = func() []string {
+	set := make(map[string]struct{})
+	for _, types := range webhookEventsByOp {
+		for _, t := range types {
+			set[t] = struct{}{}
+		}
+	}
+	res := make([]string, 0, len(set))
+	for t := range set {
+		res = append(res, t)
+	}
+	sort.Strings(res)
+	return res
+}()
+
+// WebhookEventsOf 返回写操作对应的 webhook 事件类型
+func WebhookEventsOf(op string) []string {
+	return webhookEventsByOp[op]
+}
+
+// CheckWebhookEvent ...
+func CheckWebhookEvent(s string) error {
+	i := sort.SearchStrings(WebhookEventTypes, s)
+	if i < len(WebhookEventTypes) && WebhookEventTypes[i] == s {
+		return nil
+	}
+	return gear.ErrBadRequest.WithMsgf("invalid webhook event %s", strconv.Quote(s))
+}
+
+// privateNetworks 内网地址段，0.0.0.0/8 在 Linux 上会连接到本机，100.64.0.0/10 为运营商级 NAT 共享地址，192.0.0.0/24 为 IETF 协议分配地址
+var privateNetworks = func() []*net.IPNet {
+	res := make([]*net.IPNet, 0)
+	for _, cidr := range []string{"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "172.16.0.0/12", "192.0.0.0/24", "192.168.0.0/16", "fc00::/7"} {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		res = append(res, n)
+	}
+	return res
+}()
+
+// CheckWebhookIP 拒绝回环、链路本地、内网、未指定和组播地址，避免通过 webhook 访问服务所在的内部网络。
+// 注册时只能检查 URL 中的 IP 地址，域名解析后的地址在投递连接时检查
+func CheckWebhookIP(ip net.IP) error {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	ok := !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+	for _, n := range privateNetworks {
+		ok = ok && !n.Contains(ip)
+	}
+	if !ok {
+		return gear.ErrBadRequest.WithMsgf("webhook address %s is not allowed", ip)
+	}
+	return nil
+}
+
+// CheckWebhookURL ...
+func CheckWebhookURL(s string) error {
+	if s == "" {
+		return gear.ErrBadRequest.WithMsg("empty url")
+	}
+	if len(s) > 2048 {
+		return gear.ErrBadRequest.WithMsg("url should not be longer than 2048")
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return gear.ErrBadRequest.WithMsgf("invalid url %s", strconv.Quote(s))
+	}
+	return nil
+}
+
+// checkWebhookHost 注册 webhook 时拒绝 localhost 和 CheckWebhookIP 不允许的 IP 地址。
+// 更新、删除和查询死信时不检查，以便管理此前注册的 webhook
+func checkWebhookHost(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return gear.ErrBadRequest.WithMsgf("invalid url %s", strconv.Quote(s))
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return gear.ErrBadRequest.WithMsgf("webhook host %s is not allowed", strconv.Quote(host))
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return CheckWebhookIP(ip)
+	}
+	return nil
+}
+
+// Webhook 租户注册的 webhook，secret 只在创建时返回
+type Webhook struct {
+	UID       string    `json:"uid,omitempty"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	Status    int       `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WebhookPayload 投递到 webhook 的请求体
+type WebhookPayload struct {
+	ID        string     `json:"id"` // 投递 ID，同一事件重试时不变
+	Type      string     `json:"type"`
+	Tenant    string     `json:"tenant"`
+	Revision  int64      `json:"revision"`
+	CreatedAt time.Time  `json:"createdAt"`
+	Data      WatchEvent `json:"data"`
+}
+
+// WebhookDeadLetter 重试后仍投递失败的事件
+type WebhookDeadLetter struct {
+	UID       string          `json:"uid"`
+	URL       string          `json:"url"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	Error     string          `json:"error"`
+	Attempts  int             `json:"attempts"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// WebhookDelivery 等待重试的 webhook 投递，保存在 Dgraph 中，服务重启后由任一实例继续重试
+type WebhookDelivery struct {
+	UID           string          `json:"uid"`
+	Tenant        string          `json:"tenant"`
+	Webhook       Webhook         `json:"webhook"`
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	Payload       json.RawMessage `json:"payload"`
+	Error         string          `json:"error"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"nextAttemptAt"`
+}
+
+// WebhookInput ...
+type WebhookInput struct {
+	URL string `json:"url"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *WebhookInput) Validate() error {
+	return CheckWebhookURL(t.URL)
+}
+
+func checkWebhookEvents(events []string) error {
+	if len(events) == 0 {
+		return gear.ErrBadRequest.WithMsg("events empty")
+	}
+	if len(events) > 100 {
+		return gear.ErrBadRequest.WithMsgf("too many events: %d", len(events))
+	}
+	cr := make(checkRepetitive)
+	for _, e := range events {
+		if err := cr.Check(e); err != nil {
+			return err
+		}
+		if err := CheckWebhookEvent(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkWebhookSecret(secret string) error {
+	if secret != "" && (len(secret) < 16 || len(secret) > 256) {
+		return gear.ErrBadRequest.WithMsg("secret should be between 16 and 256 bytes")
+	}
+	return nil
+}
+
+// WebhookAddInput ...
+type WebhookAddInput struct {
+	WebhookInput
+	Events []string `json:"events"`
+	// 签名密钥，为空时自动生成
+	Secret string `json:"secret"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *WebhookAddInput) Validate() error {
+	if err := t.WebhookInput.Validate(); err != nil {
+		return err
+	}
+	if err := checkWebhookHost(t.URL); err != nil {
+		return err
+	}
+	if err := checkWebhookEvents(t.Events); err != nil {
+		return err
+	}
+	return checkWebhookSecret(t.Secret)
+}
+
+// WebhookUpdateInput ...
+type WebhookUpdateInput struct {
+	WebhookInput
+	// 为空时不更新
+	Events []string `json:"events"`
+	// 为空时不更新
+	Secret string `json:"secret"`
+	// 为空时不更新，-1 表示停用
+	Status *int `json:"status"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *WebhookUpdateInput) Validate() error {
+	if err := t.WebhookInput.Validate(); err != nil {
+		return err
+	}
+	if len(t.Events) > 0 {
+		if err := checkWebhookEvents(t.Events); err != nil {
+			return err
+		}
+	}
+	if t.Status != nil && *t.Status < -1 {
+		return gear.ErrBadRequest.WithMsgf("invalid webhook status %d", *t.Status)
+	}
+	return checkWebhookSecret(t.Secret)
+}
+
+// WebhookListInput ...
+type WebhookListInput struct {
+	Pagination
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *WebhookListInput) Validate() error {
+	return t.Pagination.Validate()
+}
+
+// WebhookDeadLetterListInput ...
+type WebhookDeadLetterListInput struct {
+	Pagination
+	// 为空时列出所有 webhook 的死信
+	URL string `json:"url"`
+}
+
+// Validate 实现 gear.BodyTemplate
+func (t *WebhookDeadLetterListInput) Validate() error {
+	if err := t.Pagination.Validate(); err != nil {
+		return err
+	}
+	if t.URL != "" {
+		return CheckWebhookURL(t.URL)
+	}
+	return nil
+}